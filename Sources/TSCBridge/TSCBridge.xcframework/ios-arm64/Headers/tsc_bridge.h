@@ -12,6 +12,8 @@
 
 #ifndef GO_CGO_GOSTRING_TYPEDEF
 typedef struct { const char *p; ptrdiff_t n; } _GoString_;
+extern size_t _GoStringLen(_GoString_ s);
+extern const char *_GoStringPtr(_GoString_ s);
 #endif
 
 #endif
@@ -21,6 +23,7 @@ typedef struct { const char *p; ptrdiff_t n; } _GoString_;
 
 #line 3 "c_bridge.go"
 
+#include <stdint.h>
 #include <stdlib.h>
 #include <string.h>
 
@@ -75,6 +78,16 @@ typedef struct {
 // Callback function pointer (like plugin callbacks)
 typedef c_file_resolve_result* (*file_resolve_callback)(c_file_resolve_args*, void*);
 
+// Build progress, reported to the progress callback
+typedef struct {
+    int phase;  // 0 = config parse, 1 = program creation, 2 = check, 3 = emit
+    char* file;  // for check, the file about to be checked
+    int completed;  // for check, the number of files checked so far
+    int total;  // for check, the number of files in the program
+} c_progress_event;
+
+typedef void (*progress_callback)(c_progress_event*, void*);
+
 // Resolver callbacks structure (like plugin system)
 typedef struct {
     file_resolve_callback resolver;
@@ -86,10 +99,15 @@ static inline c_file_resolve_result* call_file_resolve_callback(file_resolve_cal
     return cb(args, data);
 }
 
+static inline void call_progress_callback(progress_callback cb, c_progress_event* event, void* data) {
+    cb(event, data);
+}
+
 #line 1 "cgo-generated-wrapper"
 
 #line 3 "esbuild_c_bridge.go"
 
+#include <stdint.h>
 #include <stdlib.h>
 
 typedef struct {
@@ -509,10 +527,16 @@ typedef size_t GoUintptr;
 typedef float GoFloat32;
 typedef double GoFloat64;
 #ifdef _MSC_VER
+#if !defined(__cplusplus) || _MSVC_LANG <= 201402L
 #include <complex.h>
 typedef _Fcomplex GoComplex64;
 typedef _Dcomplex GoComplex128;
 #else
+#include <complex>
+typedef std::complex<float> GoComplex64;
+typedef std::complex<double> GoComplex128;
+#endif
+#else
 typedef float _Complex GoComplex64;
 typedef double _Complex GoComplex128;
 #endif
@@ -542,200 +566,180 @@ extern "C" {
 extern c_build_result* tsc_build_filesystem(char* projectPath, int printErrors, char* configFile);
 extern c_build_result* tsc_build_with_resolver(char* projectPath, int printErrors, char* configFile, c_file_resolver_data* resolverData);
 extern c_build_result* tsc_build_with_dynamic_resolver(char* projectPath, int printErrors, char* configFile, c_resolver_callbacks* callbacks);
+extern c_build_result* tsc_build_with_dynamic_resolver_cancellable(char* projectPath, int printErrors, char* configFile, c_resolver_callbacks* callbacks, uintptr_t token, progress_callback progress, void* progressData);
+extern uintptr_t tsc_session_create(char* projectPath, char* configFile, c_resolver_callbacks* callbacks);
+extern uintptr_t tsc_session_create_in_memory(char* projectPath, char* configFile);
+extern void tsc_session_set_progress(uintptr_t session, progress_callback progress, void* progressData);
+extern void tsc_session_update_file(uintptr_t session, char* path, char* content);
+extern c_build_result* tsc_session_build(uintptr_t session, int printErrors);
+extern c_build_result* tsc_session_build_cancellable(uintptr_t session, int printErrors, uintptr_t token);
+extern void tsc_session_free(uintptr_t session);
+extern char* tsc_session_completions(uintptr_t session, char* path, int line, int character, char* triggerCharacter);
+extern char* tsc_session_completion_resolve(uintptr_t session, char* item);
+extern char* tsc_session_hover(uintptr_t session, char* path, int line, int character);
+extern char* tsc_session_definition(uintptr_t session, char* path, int line, int character);
+extern char* tsc_session_references(uintptr_t session, char* path, int line, int character, int includeDeclaration);
+extern char* tsc_session_signature_help(uintptr_t session, char* path, int line, int character, char* triggerCharacter);
+extern char* tsc_session_document_symbols(uintptr_t session, char* path);
+extern char* tsc_session_format(uintptr_t session, char* path, char* options);
+extern uintptr_t tsc_cancellation_token_create(void);
+extern void tsc_cancellation_token_cancel(uintptr_t token);
+extern void tsc_cancellation_token_free(uintptr_t token);
 extern char* tsc_validate_simple(char* code);
+extern char* tsc_validate_json(char* request);
 extern void tsc_free_string(char* str);
 extern void tsc_free_result(c_build_result* result);
-extern c_file_resolver_data* tsc_create_resolver_data();
+extern c_file_resolver_data* tsc_create_resolver_data(void);
 extern void tsc_add_file_to_resolver(c_file_resolver_data* data, char* path, char* content);
 extern void tsc_add_directory_to_resolver(c_file_resolver_data* data, char* path);
 extern void tsc_free_resolver_data(c_file_resolver_data* data);
-extern int esbuild_platform_default();
-extern int esbuild_platform_browser();
-extern int esbuild_platform_node();
-extern int esbuild_platform_neutral();
-extern c_int_array* esbuild_get_all_platform_values();
+extern int esbuild_platform_default(void);
+extern int esbuild_platform_browser(void);
+extern int esbuild_platform_node(void);
+extern int esbuild_platform_neutral(void);
+extern c_int_array* esbuild_get_all_platform_values(void);
 extern void esbuild_free_int_array(c_int_array* arr);
-
-// Format enum functions
-//
-extern int esbuild_format_default();
-extern int esbuild_format_iife();
-extern int esbuild_format_commonjs();
-extern int esbuild_format_esmodule();
-extern c_int_array* esbuild_get_all_format_values();
-
-// Target enum functions
-//
-extern int esbuild_target_default();
-extern int esbuild_target_esnext();
-extern int esbuild_target_es5();
-extern int esbuild_target_es2015();
-extern int esbuild_target_es2016();
-extern int esbuild_target_es2017();
-extern int esbuild_target_es2018();
-extern int esbuild_target_es2019();
-extern int esbuild_target_es2020();
-extern int esbuild_target_es2021();
-extern int esbuild_target_es2022();
-extern int esbuild_target_es2023();
-extern int esbuild_target_es2024();
-extern c_int_array* esbuild_get_all_target_values();
-
-// Loader enum functions
-//
-extern int esbuild_loader_none();
-extern int esbuild_loader_base64();
-extern int esbuild_loader_binary();
-extern int esbuild_loader_copy();
-extern int esbuild_loader_css();
-extern int esbuild_loader_dataurl();
-extern int esbuild_loader_default();
-extern int esbuild_loader_empty();
-extern int esbuild_loader_file();
-extern int esbuild_loader_globalcss();
-extern int esbuild_loader_js();
-extern int esbuild_loader_json();
-extern int esbuild_loader_jsx();
-extern int esbuild_loader_localcss();
-extern int esbuild_loader_text();
-extern int esbuild_loader_ts();
-extern int esbuild_loader_tsx();
-extern c_int_array* esbuild_get_all_loader_values();
-
-// SourceMap enum functions
-//
-extern int esbuild_sourcemap_none();
-extern int esbuild_sourcemap_inline();
-extern int esbuild_sourcemap_linked();
-extern int esbuild_sourcemap_external();
-extern int esbuild_sourcemap_inlineandexternal();
-extern c_int_array* esbuild_get_all_sourcemap_values();
-
-// JSX enum functions
-//
-extern int esbuild_jsx_transform();
-extern int esbuild_jsx_preserve();
-extern int esbuild_jsx_automatic();
-extern c_int_array* esbuild_get_all_jsx_values();
-
-// LogLevel enum functions
-//
-extern int esbuild_loglevel_silent();
-extern int esbuild_loglevel_verbose();
-extern int esbuild_loglevel_debug();
-extern int esbuild_loglevel_info();
-extern int esbuild_loglevel_warning();
-extern int esbuild_loglevel_error();
-extern c_int_array* esbuild_get_all_loglevel_values();
-
-// LegalComments enum functions
-//
-extern int esbuild_legalcomments_default();
-extern int esbuild_legalcomments_none();
-extern int esbuild_legalcomments_inline();
-extern int esbuild_legalcomments_endoffile();
-extern int esbuild_legalcomments_linked();
-extern int esbuild_legalcomments_external();
-extern c_int_array* esbuild_get_all_legalcomments_values();
-
-// Charset enum functions
-//
-extern int esbuild_charset_default();
-extern int esbuild_charset_ascii();
-extern int esbuild_charset_utf8();
-extern c_int_array* esbuild_get_all_charset_values();
-
-// TreeShaking enum functions
-//
-extern int esbuild_treeshaking_default();
-extern int esbuild_treeshaking_false();
-extern int esbuild_treeshaking_true();
-extern c_int_array* esbuild_get_all_treeshaking_values();
-
-// StderrColor enum functions
-//
-extern int esbuild_color_ifterminal();
-extern int esbuild_color_never();
-extern int esbuild_color_always();
-extern c_int_array* esbuild_get_all_color_values();
-
-// Remaining enums - continuing the pattern for the rest
-//
-extern int esbuild_packages_default();
-extern int esbuild_packages_bundle();
-extern int esbuild_packages_external();
-extern c_int_array* esbuild_get_all_packages_values();
-extern int esbuild_sourcescontent_include();
-extern int esbuild_sourcescontent_exclude();
-extern c_int_array* esbuild_get_all_sourcescontent_values();
-extern int esbuild_manglequoted_false();
-extern int esbuild_manglequoted_true();
-extern c_int_array* esbuild_get_all_manglequoted_values();
-extern int esbuild_drop_console();
-extern int esbuild_drop_debugger();
-extern c_int_array* esbuild_get_all_drop_values();
-
-// EngineName enum functions
-//
-extern int esbuild_engine_chrome();
-extern int esbuild_engine_deno();
-extern int esbuild_engine_edge();
-extern int esbuild_engine_firefox();
-extern int esbuild_engine_hermes();
-extern int esbuild_engine_ie();
-extern int esbuild_engine_ios();
-extern int esbuild_engine_node();
-extern int esbuild_engine_opera();
-extern int esbuild_engine_rhino();
-extern int esbuild_engine_safari();
-extern c_int_array* esbuild_get_all_engine_values();
-
-// SideEffects enum functions
-//
-extern int esbuild_sideeffects_true();
-extern int esbuild_sideeffects_false();
-extern c_int_array* esbuild_get_all_sideeffects_values();
-
-// ResolveKind enum functions
-//
-extern int esbuild_resolvekind_none();
-extern int esbuild_resolvekind_entrypoint();
-extern int esbuild_resolvekind_jsimportstatement();
-extern int esbuild_resolvekind_jsrequirecall();
-extern int esbuild_resolvekind_jsdynamicimport();
-extern int esbuild_resolvekind_jsrequireresolve();
-extern int esbuild_resolvekind_cssimportrule();
-extern int esbuild_resolvekind_csscomposesfrom();
-extern int esbuild_resolvekind_cssurltoken();
-extern c_int_array* esbuild_get_all_resolvekind_values();
-
-// MessageKind enum functions
-//
-extern int esbuild_messagekind_error();
-extern int esbuild_messagekind_warning();
-extern c_int_array* esbuild_get_all_messagekind_values();
-extern c_transform_options* esbuild_create_transform_options();
+extern int esbuild_format_default(void);
+extern int esbuild_format_iife(void);
+extern int esbuild_format_commonjs(void);
+extern int esbuild_format_esmodule(void);
+extern c_int_array* esbuild_get_all_format_values(void);
+extern int esbuild_target_default(void);
+extern int esbuild_target_esnext(void);
+extern int esbuild_target_es5(void);
+extern int esbuild_target_es2015(void);
+extern int esbuild_target_es2016(void);
+extern int esbuild_target_es2017(void);
+extern int esbuild_target_es2018(void);
+extern int esbuild_target_es2019(void);
+extern int esbuild_target_es2020(void);
+extern int esbuild_target_es2021(void);
+extern int esbuild_target_es2022(void);
+extern int esbuild_target_es2023(void);
+extern int esbuild_target_es2024(void);
+extern c_int_array* esbuild_get_all_target_values(void);
+extern int esbuild_loader_none(void);
+extern int esbuild_loader_base64(void);
+extern int esbuild_loader_binary(void);
+extern int esbuild_loader_copy(void);
+extern int esbuild_loader_css(void);
+extern int esbuild_loader_dataurl(void);
+extern int esbuild_loader_default(void);
+extern int esbuild_loader_empty(void);
+extern int esbuild_loader_file(void);
+extern int esbuild_loader_globalcss(void);
+extern int esbuild_loader_js(void);
+extern int esbuild_loader_json(void);
+extern int esbuild_loader_jsx(void);
+extern int esbuild_loader_localcss(void);
+extern int esbuild_loader_text(void);
+extern int esbuild_loader_ts(void);
+extern int esbuild_loader_tsx(void);
+extern c_int_array* esbuild_get_all_loader_values(void);
+extern int esbuild_sourcemap_none(void);
+extern int esbuild_sourcemap_inline(void);
+extern int esbuild_sourcemap_linked(void);
+extern int esbuild_sourcemap_external(void);
+extern int esbuild_sourcemap_inlineandexternal(void);
+extern c_int_array* esbuild_get_all_sourcemap_values(void);
+extern int esbuild_jsx_transform(void);
+extern int esbuild_jsx_preserve(void);
+extern int esbuild_jsx_automatic(void);
+extern c_int_array* esbuild_get_all_jsx_values(void);
+extern int esbuild_loglevel_silent(void);
+extern int esbuild_loglevel_verbose(void);
+extern int esbuild_loglevel_debug(void);
+extern int esbuild_loglevel_info(void);
+extern int esbuild_loglevel_warning(void);
+extern int esbuild_loglevel_error(void);
+extern c_int_array* esbuild_get_all_loglevel_values(void);
+extern int esbuild_legalcomments_default(void);
+extern int esbuild_legalcomments_none(void);
+extern int esbuild_legalcomments_inline(void);
+extern int esbuild_legalcomments_endoffile(void);
+extern int esbuild_legalcomments_linked(void);
+extern int esbuild_legalcomments_external(void);
+extern c_int_array* esbuild_get_all_legalcomments_values(void);
+extern int esbuild_charset_default(void);
+extern int esbuild_charset_ascii(void);
+extern int esbuild_charset_utf8(void);
+extern c_int_array* esbuild_get_all_charset_values(void);
+extern int esbuild_treeshaking_default(void);
+extern int esbuild_treeshaking_false(void);
+extern int esbuild_treeshaking_true(void);
+extern c_int_array* esbuild_get_all_treeshaking_values(void);
+extern int esbuild_color_ifterminal(void);
+extern int esbuild_color_never(void);
+extern int esbuild_color_always(void);
+extern c_int_array* esbuild_get_all_color_values(void);
+extern int esbuild_packages_default(void);
+extern int esbuild_packages_bundle(void);
+extern int esbuild_packages_external(void);
+extern c_int_array* esbuild_get_all_packages_values(void);
+extern int esbuild_sourcescontent_include(void);
+extern int esbuild_sourcescontent_exclude(void);
+extern c_int_array* esbuild_get_all_sourcescontent_values(void);
+extern int esbuild_manglequoted_false(void);
+extern int esbuild_manglequoted_true(void);
+extern c_int_array* esbuild_get_all_manglequoted_values(void);
+extern int esbuild_drop_console(void);
+extern int esbuild_drop_debugger(void);
+extern c_int_array* esbuild_get_all_drop_values(void);
+extern int esbuild_engine_chrome(void);
+extern int esbuild_engine_deno(void);
+extern int esbuild_engine_edge(void);
+extern int esbuild_engine_firefox(void);
+extern int esbuild_engine_hermes(void);
+extern int esbuild_engine_ie(void);
+extern int esbuild_engine_ios(void);
+extern int esbuild_engine_node(void);
+extern int esbuild_engine_opera(void);
+extern int esbuild_engine_rhino(void);
+extern int esbuild_engine_safari(void);
+extern c_int_array* esbuild_get_all_engine_values(void);
+extern int esbuild_sideeffects_true(void);
+extern int esbuild_sideeffects_false(void);
+extern c_int_array* esbuild_get_all_sideeffects_values(void);
+extern int esbuild_resolvekind_none(void);
+extern int esbuild_resolvekind_entrypoint(void);
+extern int esbuild_resolvekind_jsimportstatement(void);
+extern int esbuild_resolvekind_jsrequirecall(void);
+extern int esbuild_resolvekind_jsdynamicimport(void);
+extern int esbuild_resolvekind_jsrequireresolve(void);
+extern int esbuild_resolvekind_cssimportrule(void);
+extern int esbuild_resolvekind_csscomposesfrom(void);
+extern int esbuild_resolvekind_cssurltoken(void);
+extern c_int_array* esbuild_get_all_resolvekind_values(void);
+extern int esbuild_messagekind_error(void);
+extern int esbuild_messagekind_warning(void);
+extern c_int_array* esbuild_get_all_messagekind_values(void);
+extern c_transform_options* esbuild_create_transform_options(void);
 extern void esbuild_free_transform_options(c_transform_options* opts);
-extern c_transform_result* esbuild_create_transform_result();
-extern c_location* esbuild_create_location();
-extern c_note* esbuild_create_note();
-extern c_message* esbuild_create_message();
+extern c_transform_result* esbuild_create_transform_result(void);
+extern c_location* esbuild_create_location(void);
+extern c_note* esbuild_create_note(void);
+extern c_message* esbuild_create_message(void);
 extern void esbuild_free_location(c_location* loc);
 extern void esbuild_free_note(c_note* note);
 extern void esbuild_free_message(c_message* msg);
 extern void esbuild_free_transform_result(c_transform_result* result);
 extern c_transform_result* esbuild_transform(char* code, c_transform_options* opts);
-extern esbuild_entry_point* esbuild_create_entry_point();
-extern esbuild_stdin_options* esbuild_create_stdin_options();
-extern esbuild_output_file* esbuild_create_output_file();
-extern esbuild_build_options* esbuild_create_build_options();
-extern esbuild_build_result* esbuild_create_build_result();
+extern esbuild_entry_point* esbuild_create_entry_point(void);
+extern esbuild_stdin_options* esbuild_create_stdin_options(void);
+extern esbuild_output_file* esbuild_create_output_file(void);
+extern esbuild_build_options* esbuild_create_build_options(void);
+extern esbuild_build_result* esbuild_create_build_result(void);
 extern void esbuild_free_entry_point(esbuild_entry_point* ep);
 extern void esbuild_free_stdin_options(esbuild_stdin_options* stdin);
 extern void esbuild_free_output_file(esbuild_output_file* file);
 extern void esbuild_free_build_options(esbuild_build_options* opts);
 extern void esbuild_free_build_result(esbuild_build_result* result);
 extern esbuild_build_result* esbuild_build(esbuild_build_options* opts);
+extern uintptr_t esbuild_context_create(esbuild_build_options* opts, esbuild_build_result** errors);
+extern esbuild_build_result* esbuild_context_rebuild(uintptr_t context);
+extern char* esbuild_context_watch(uintptr_t context);
+extern void esbuild_context_cancel(uintptr_t context);
+extern void esbuild_context_dispose(uintptr_t context);
 extern void* register_plugin(c_plugin* plugin);
 extern void unregister_plugin(void* pluginID);
 
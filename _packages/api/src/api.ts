@@ -4,6 +4,7 @@ import { TypeFlags } from "#typeFlags";
 import type {
     Node,
     SourceFile,
+    SyntaxKind,
 } from "@typescript/ast";
 import { Client } from "./client.ts";
 import type { FileSystem } from "./fs.ts";
@@ -11,13 +12,24 @@ import { RemoteSourceFile } from "./node.ts";
 import { ObjectRegistry } from "./objectRegistry.ts";
 import type {
     ConfigResponse,
+    DiagnosticKind,
+    DiagnosticResponse,
+    EmitOutputResponse,
+    NodeResponse,
     ProjectResponse,
+    SignatureResponse,
     SymbolResponse,
+    TextChange,
     TypeResponse,
 } from "./proto.ts";
 
 export { SymbolFlags, TypeFlags };
 
+export enum SignatureKind {
+    Call = 0,
+    Construct = 1,
+}
+
 export interface APIOptions {
     tsserverPath: string;
     cwd?: string;
@@ -42,6 +54,35 @@ export class API {
         return this.objectRegistry.getProject(data);
     }
 
+    /**
+     * Overrides the text of a file, or reads it from disk when `text` is omitted, until it is closed.
+     * Projects containing the file use the new text on their next request. Changing the text of a file
+     * invalidates all outstanding source files, symbols, types and signatures.
+     */
+    openFile(fileName: string, text?: string): void {
+        this.client.request("openFile", { fileName, text });
+    }
+
+    /**
+     * Replaces the text of an open file, or applies `changes` to it in order.
+     * Positions are UTF-8 byte offsets into the text after the previous changes.
+     */
+    updateFile(fileName: string, textOrChanges: string | readonly TextChange[]): void {
+        if (typeof textOrChanges === "string") {
+            this.client.request("updateFile", { fileName, text: textOrChanges });
+        }
+        else {
+            this.client.request("updateFile", { fileName, changes: textOrChanges });
+        }
+    }
+
+    /**
+     * Closes a file opened with `openFile`, reverting it to its text on disk.
+     */
+    closeFile(fileName: string): void {
+        this.client.request("closeFile", { fileName });
+    }
+
     echo(message: string): string {
         return this.client.echo(message);
     }
@@ -112,15 +153,15 @@ export class Project extends DisposableObject {
         return data ? new RemoteSourceFile(data, this.decoder) as unknown as SourceFile : undefined;
     }
 
-    getSymbolAtLocation(node: Node): Symbol | undefined;
-    getSymbolAtLocation(nodes: readonly Node[]): (Symbol | undefined)[];
-    getSymbolAtLocation(nodeOrNodes: Node | readonly Node[]): Symbol | (Symbol | undefined)[] | undefined {
+    getSymbolAtLocation(node: Node | NodeResponse): Symbol | undefined;
+    getSymbolAtLocation(nodes: readonly (Node | NodeResponse)[]): (Symbol | undefined)[];
+    getSymbolAtLocation(nodeOrNodes: Node | NodeResponse | readonly (Node | NodeResponse)[]): Symbol | (Symbol | undefined)[] | undefined {
         this.ensureNotDisposed();
         if (Array.isArray(nodeOrNodes)) {
             const data = this.client.request("getSymbolsAtLocations", { project: this.id, locations: nodeOrNodes.map(node => node.id) });
             return data.map((d: SymbolResponse | null) => d ? this.objectRegistry.getSymbol(d) : undefined);
         }
-        const data = this.client.request("getSymbolAtLocation", { project: this.id, location: (nodeOrNodes as Node).id });
+        const data = this.client.request("getSymbolAtLocation", { project: this.id, location: (nodeOrNodes as Node | NodeResponse).id });
         return data ? this.objectRegistry.getSymbol(data) : undefined;
     }
 
@@ -147,6 +188,95 @@ export class Project extends DisposableObject {
         const data = this.client.request("getTypeOfSymbol", { project: this.id, symbol: (symbolOrSymbols as Symbol).ensureNotDisposed().id });
         return data ? this.objectRegistry.getType(data) : undefined;
     }
+
+    typeToString(type: Type, enclosingDeclaration?: Node, flags?: number): string {
+        this.ensureNotDisposed();
+        return this.client.request("typeToString", { project: this.id, type: type.ensureNotDisposed().id, enclosingDeclaration: enclosingDeclaration?.id, flags });
+    }
+
+    getPropertiesOfType(type: Type): Symbol[] {
+        this.ensureNotDisposed();
+        const data = this.client.request("getPropertiesOfType", { project: this.id, type: type.ensureNotDisposed().id });
+        return data.map((d: SymbolResponse) => this.objectRegistry.getSymbol(d));
+    }
+
+    getSignaturesOfType(type: Type, kind: SignatureKind): Signature[] {
+        this.ensureNotDisposed();
+        const data = this.client.request("getSignaturesOfType", { project: this.id, type: type.ensureNotDisposed().id, kind });
+        return data.map((d: SignatureResponse) => this.objectRegistry.getSignature(d));
+    }
+
+    getReturnTypeOfSignature(signature: Signature): Type {
+        this.ensureNotDisposed();
+        const data = this.client.request("getReturnTypeOfSignature", { project: this.id, signature: signature.ensureNotDisposed().id });
+        return this.objectRegistry.getType(data);
+    }
+
+    getBaseTypes(type: Type): Type[] {
+        this.ensureNotDisposed();
+        const data = this.client.request("getBaseTypes", { project: this.id, type: type.ensureNotDisposed().id });
+        return (data ?? []).map((d: TypeResponse) => this.objectRegistry.getType(d));
+    }
+
+    getTypesOfType(type: Type): Type[] | undefined {
+        this.ensureNotDisposed();
+        const data = this.client.request("getTypesOfType", { project: this.id, type: type.ensureNotDisposed().id });
+        return data?.map((d: TypeResponse) => this.objectRegistry.getType(d));
+    }
+
+    isTypeAssignableTo(source: Type, target: Type): boolean {
+        this.ensureNotDisposed();
+        return this.client.request("isTypeAssignableTo", { project: this.id, source: source.ensureNotDisposed().id, target: target.ensureNotDisposed().id });
+    }
+
+    /**
+     * Returns the diagnostics of a file, or of the whole project when `fileName` is omitted.
+     * Without a `kind`, returns the diagnostics `tsc` would report.
+     */
+    getDiagnostics(fileName?: string, kind?: DiagnosticKind): DiagnosticResponse[] {
+        this.ensureNotDisposed();
+        return this.client.request("getDiagnostics", { project: this.id, fileName, kind });
+    }
+
+    /**
+     * Emits a file, or the whole project when `fileName` is omitted, returning the output files instead of writing them.
+     */
+    emit(fileName?: string, options?: { emitOnlyDts?: boolean; }): EmitOutputResponse {
+        this.ensureNotDisposed();
+        return this.client.request("emit", { project: this.id, fileName, emitOnlyDts: options?.emitOnlyDts });
+    }
+
+    getEmitOutput(fileName: string, options?: { emitOnlyDts?: boolean; forceDtsEmit?: boolean; }): EmitOutputResponse {
+        this.ensureNotDisposed();
+        return this.client.request("getEmitOutput", { project: this.id, fileName, emitOnlyDts: options?.emitOnlyDts, forceDtsEmit: options?.forceDtsEmit });
+    }
+
+    /**
+     * Returns the innermost node whose span, excluding leading trivia, contains `position`.
+     * Unlike `getTokenAtPosition`, punctuation is never returned.
+     */
+    getNodeAtPosition(fileName: string, position: number): NodeResponse {
+        this.ensureNotDisposed();
+        return this.client.request("getNodeAtPosition", { project: this.id, fileName, position });
+    }
+
+    getTokenAtPosition(fileName: string, position: number): NodeResponse {
+        this.ensureNotDisposed();
+        return this.client.request("getTokenAtPosition", { project: this.id, fileName, position });
+    }
+
+    /**
+     * Returns the parents of a node, innermost first, ending with its source file.
+     */
+    getAncestors(node: Node | NodeResponse): NodeResponse[] {
+        this.ensureNotDisposed();
+        return this.client.request("getAncestors", { project: this.id, node: node.id });
+    }
+
+    findNodesOfKind(fileName: string, kind: SyntaxKind, range?: { start?: number; end?: number; }): NodeResponse[] {
+        this.ensureNotDisposed();
+        return this.client.request("findNodesOfKind", { project: this.id, fileName, kind, start: range?.start, end: range?.end });
+    }
 }
 
 export class Symbol extends DisposableObject {
@@ -177,3 +307,15 @@ export class Type extends DisposableObject {
         this.flags = data.flags;
     }
 }
+
+export class Signature extends DisposableObject {
+    private client: Client;
+    id: string;
+    parameters: readonly Symbol[];
+    constructor(client: Client, objectRegistry: ObjectRegistry, data: SignatureResponse) {
+        super(objectRegistry);
+        this.client = client;
+        this.id = data.id;
+        this.parameters = data.parameters.map(d => objectRegistry.getSymbol(d));
+    }
+}
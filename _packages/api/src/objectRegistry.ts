@@ -1,11 +1,13 @@
 import {
     Project,
+    Signature,
     Symbol,
     Type,
 } from "./api.ts";
 import type { Client } from "./client.ts";
 import type {
     ProjectResponse,
+    SignatureResponse,
     SymbolResponse,
     TypeResponse,
 } from "./proto.ts";
@@ -15,6 +17,7 @@ export class ObjectRegistry {
     private projects: Map<string, Project> = new Map();
     private symbols: Map<string, Symbol> = new Map();
     private types: Map<string, Type> = new Map();
+    private signatures: Map<string, Signature> = new Map();
 
     constructor(client: Client) {
         this.client = client;
@@ -53,6 +56,17 @@ export class ObjectRegistry {
         return type;
     }
 
+    getSignature(data: SignatureResponse): Signature {
+        let signature = this.signatures.get(data.id);
+        if (signature) {
+            return signature;
+        }
+
+        signature = new Signature(this.client, this, data);
+        this.signatures.set(data.id, signature);
+        return signature;
+    }
+
     release(object: object): void {
         if (object instanceof Project) {
             this.releaseProject(object);
@@ -63,6 +77,9 @@ export class ObjectRegistry {
         else if (object instanceof Type) {
             this.releaseType(object);
         }
+        else if (object instanceof Signature) {
+            this.releaseSignature(object);
+        }
         else {
             throw new Error("Unknown object type");
         }
@@ -82,4 +99,9 @@ export class ObjectRegistry {
         this.types.delete(type.id);
         this.client.request("release", type.id);
     }
+
+    releaseSignature(signature: Signature): void {
+        this.signatures.delete(signature.id);
+        this.client.request("release", signature.id);
+    }
 }
@@ -1,3 +1,5 @@
+import type { SyntaxKind } from "@typescript/ast";
+
 export interface ConfigResponse {
     options: Record<string, unknown>;
     fileNames: string[];
@@ -21,3 +23,60 @@ export interface TypeResponse {
     id: string;
     flags: number;
 }
+
+export interface SignatureResponse {
+    id: string;
+    parameters: SymbolResponse[];
+}
+
+export interface TextChange {
+    start: number;
+    end: number;
+    newText: string;
+}
+
+export interface NodeResponse {
+    id: string;
+    kind: SyntaxKind;
+    pos: number;
+    end: number;
+}
+
+export type DiagnosticKind = "syntactic" | "semantic" | "declaration";
+
+export interface DiagnosticResponse {
+    fileName?: string;
+    start: number;
+    length: number;
+    code: number;
+    category: number;
+    text: string;
+    relatedInformation?: DiagnosticResponse[];
+}
+
+export interface OutputFileResponse {
+    name: string;
+    text: string;
+    writeByteOrderMark?: boolean;
+}
+
+export interface SourceMapResponse {
+    generatedFile: string;
+    inputSourceFileNames: string[];
+    sourceMap: {
+        version: number;
+        file: string;
+        sourceRoot: string;
+        sources: string[];
+        names: string[];
+        mappings: string;
+        sourcesContent?: (string | null)[];
+    };
+}
+
+export interface EmitOutputResponse {
+    outputFiles: OutputFileResponse[];
+    emitSkipped: boolean;
+    diagnostics: DiagnosticResponse[];
+    sourceMaps?: SourceMapResponse[];
+}
@@ -1,5 +1,6 @@
 import {
     API,
+    SignatureKind,
     SymbolFlags,
     TypeFlags,
 } from "@typescript/api";
@@ -11,6 +12,7 @@ import {
     isTemplateHead,
     isTemplateMiddle,
     isTemplateTail,
+    SyntaxKind,
 } from "@typescript/ast";
 import assert from "node:assert";
 import {
@@ -70,6 +72,125 @@ describe("Project", () => {
         assert.ok(type);
         assert.ok(type.flags & TypeFlags.NumberLiteral);
     });
+
+    test("type queries", () => {
+        const api = spawnAPI({
+            "/tsconfig.json": "{}",
+            "/src/index.ts": `class Base { x = 1; }
+class Derived extends Base { m(a: string): number { return 1; } }
+export const d = new Derived();
+export const u: string | number = 1;`,
+        });
+        const project = api.loadProject("/tsconfig.json");
+        const [d, u] = project.getSymbolAtPosition("/src/index.ts", [101, 133]);
+        assert.ok(d && u);
+        const derivedType = project.getTypeOfSymbol(d);
+        assert.ok(derivedType);
+        assert.equal(project.typeToString(derivedType), "Derived");
+
+        const properties = project.getPropertiesOfType(derivedType);
+        assert.deepEqual(properties.map(p => p.name).sort(), ["m", "x"]);
+        const methodType = project.getTypeOfSymbol(properties.find(p => p.name === "m")!);
+        assert.ok(methodType);
+        const [signature] = project.getSignaturesOfType(methodType, SignatureKind.Call);
+        assert.deepEqual(signature.parameters.map(p => p.name), ["a"]);
+        const returnType = project.getReturnTypeOfSignature(signature);
+        assert.equal(project.typeToString(returnType), "number");
+
+        const [baseType] = project.getBaseTypes(derivedType);
+        assert.equal(project.typeToString(baseType), "Base");
+        assert.ok(project.isTypeAssignableTo(derivedType, baseType));
+        assert.ok(!project.isTypeAssignableTo(returnType, baseType));
+
+        const unionType = project.getTypeOfSymbol(u);
+        assert.ok(unionType);
+        assert.deepEqual(project.getTypesOfType(unionType)?.map(t => project.typeToString(t)), ["string", "number"]);
+        assert.equal(project.getTypesOfType(returnType), undefined);
+    });
+
+    test("getDiagnostics", () => {
+        const api = spawnAPI({
+            "/tsconfig.json": "{}",
+            "/src/index.ts": `export const x: number = "";`,
+            "/src/syntax.ts": `let y = ;`,
+        });
+        const project = api.loadProject("/tsconfig.json");
+        const [semantic] = project.getDiagnostics("/src/index.ts", "semantic");
+        assert.equal(semantic.code, 2322);
+        assert.equal(semantic.fileName, "/src/index.ts");
+        assert.equal(semantic.start, 13);
+        assert.equal(semantic.text, `Type 'string' is not assignable to type 'number'.`);
+        assert.deepEqual(project.getDiagnostics(undefined, "syntactic").map(d => d.fileName), ["/src/syntax.ts"]);
+        // Like tsc, semantic errors are not reported while there are syntax errors.
+        assert.deepEqual(project.getDiagnostics().map(d => d.code), [1109]);
+    });
+
+    test("emit", () => {
+        const api = spawnAPI({
+            "/tsconfig.json": `{ "compilerOptions": { "outDir": "/out", "declaration": true, "sourceMap": true } }`,
+            "/src/index.ts": `export const x = 1;`,
+        });
+        const project = api.loadProject("/tsconfig.json");
+        const result = project.emit();
+        assert.equal(result.emitSkipped, false);
+        assert.deepEqual(result.outputFiles.map(f => f.name), ["/out/index.d.ts", "/out/index.js", "/out/index.js.map"]);
+        assert.equal(result.outputFiles[0].text, "export declare const x = 1;\n");
+
+        const output = project.getEmitOutput("/src/index.ts", { emitOnlyDts: true });
+        assert.deepEqual(output.outputFiles.map(f => f.name), ["/out/index.d.ts"]);
+        const withMaps = project.getEmitOutput("/src/index.ts");
+        assert.deepEqual(withMaps.sourceMaps?.map(m => m.generatedFile), ["/out/index.js"]);
+        assert.deepEqual(withMaps.sourceMaps?.[0].inputSourceFileNames, ["/src/index.ts"]);
+    });
+
+    test("file updates", () => {
+        const api = spawnAPI();
+        const project = api.loadProject("/tsconfig.json");
+        const symbol = project.getSymbolAtPosition("/src/index.ts", 9);
+        assert.ok(symbol);
+        const type = project.getTypeOfSymbol(symbol);
+        assert.ok(type);
+        assert.equal(project.typeToString(type), "42");
+
+        api.openFile("/src/foo.ts", `export const foo = "";`);
+        assert.throws(() => project.typeToString(type), /no longer valid/);
+        const updatedSymbol = project.getSymbolAtPosition("/src/index.ts", 9);
+        assert.ok(updatedSymbol);
+        assert.equal(project.typeToString(project.getTypeOfSymbol(updatedSymbol)!), `""`);
+
+        api.updateFile("/src/foo.ts", [{ start: 19, end: 21, newText: "true" }]);
+        assert.equal(project.typeToString(project.getTypeOfSymbol(project.getSymbolAtPosition("/src/index.ts", 9)!)!), "true");
+
+        api.closeFile("/src/foo.ts");
+        assert.equal(project.typeToString(project.getTypeOfSymbol(project.getSymbolAtPosition("/src/index.ts", 9)!)!), "42");
+        assert.throws(() => api.updateFile("/src/foo.ts", ""), /not open/);
+    });
+
+    test("AST queries", () => {
+        const api = spawnAPI({
+            "/tsconfig.json": "{}",
+            "/src/index.ts": `const x = { a: 1 };
+function f(y: number) { return x.a + y; }`,
+        });
+        const project = api.loadProject("/tsconfig.json");
+        const node = project.getNodeAtPosition("/src/index.ts", 53);
+        assert.equal(node.kind, SyntaxKind.Identifier);
+        assert.equal(project.getSymbolAtLocation(node)?.name, "a");
+        assert.deepEqual(project.getAncestors(node).map(n => n.kind), [
+            SyntaxKind.PropertyAccessExpression,
+            SyntaxKind.BinaryExpression,
+            SyntaxKind.ReturnStatement,
+            SyntaxKind.Block,
+            SyntaxKind.FunctionDeclaration,
+            SyntaxKind.SourceFile,
+        ]);
+
+        assert.equal(project.getTokenAtPosition("/src/index.ts", 10).kind, SyntaxKind.OpenBraceToken);
+        assert.equal(project.getNodeAtPosition("/src/index.ts", 10).kind, SyntaxKind.ObjectLiteralExpression);
+
+        const identifiers = project.findNodesOfKind("/src/index.ts", SyntaxKind.Identifier, { start: 20, end: 50 });
+        assert.deepEqual(identifiers.map(n => n.pos), [28, 31]);
+    });
 });
 
 describe("SourceFile", () => {
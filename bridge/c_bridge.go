@@ -1,6 +1,7 @@
 package main
 
 /*
+#include <stdint.h>
 #include <stdlib.h>
 #include <string.h>
 
@@ -55,6 +56,16 @@ typedef struct {
 // Callback function pointer (like plugin callbacks)
 typedef c_file_resolve_result* (*file_resolve_callback)(c_file_resolve_args*, void*);
 
+// Build progress, reported to the progress callback
+typedef struct {
+    int phase;  // 0 = config parse, 1 = program creation, 2 = check, 3 = emit
+    char* file;  // for check, the file about to be checked
+    int completed;  // for check, the number of files checked so far
+    int total;  // for check, the number of files in the program
+} c_progress_event;
+
+typedef void (*progress_callback)(c_progress_event*, void*);
+
 // Resolver callbacks structure (like plugin system)
 typedef struct {
     file_resolve_callback resolver;
@@ -65,18 +76,23 @@ typedef struct {
 static inline c_file_resolve_result* call_file_resolve_callback(file_resolve_callback cb, c_file_resolve_args* args, void* data) {
     return cb(args, data);
 }
+
+static inline void call_progress_callback(progress_callback cb, c_progress_event* event, void* data) {
+    cb(event, data);
+}
 */
 import "C"
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"runtime/cgo"
 	"slices"
 	"strings"
 	"sync"
@@ -89,6 +105,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs"
@@ -101,6 +118,9 @@ type FileResolver interface {
 	FileExists(path string) bool
 	DirectoryExists(path string) bool
 	WriteFile(path string, content string) bool
+	// GetAllPaths returns the entries of a directory. Entries are either the names of the files
+	// and directories directly in it, or the full paths of files and directories anywhere below it,
+	// which is what resolvers returned before names were supported; both may be mixed.
 	GetAllPaths(directory string) *PathList
 }
 
@@ -151,7 +171,17 @@ func (r *SimpleFileResolver) FileExists(path string) bool {
 func (r *SimpleFileResolver) DirectoryExists(path string) bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.directories[path]
+	if r.directories[path] {
+		return true
+	}
+	// Directories of added files exist without being added themselves
+	prefix := tspath.EnsureTrailingDirectorySeparator(path)
+	for filePath := range r.files {
+		if strings.HasPrefix(filePath, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *SimpleFileResolver) WriteFile(path string, content string) bool {
@@ -161,21 +191,30 @@ func (r *SimpleFileResolver) WriteFile(path string, content string) bool {
 	return true
 }
 
+// GetAllPaths returns the names of the files and directories directly in a directory.
 func (r *SimpleFileResolver) GetAllPaths(directory string) *PathList {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var paths []string
+	prefix := tspath.EnsureTrailingDirectorySeparator(directory)
+	childNames := make(map[string]bool)
 	for path := range r.files {
-		if strings.HasPrefix(path, directory) {
-			paths = append(paths, path)
+		if rest, ok := strings.CutPrefix(path, prefix); ok && rest != "" {
+			name, _, _ := strings.Cut(rest, "/")
+			childNames[name] = true
 		}
 	}
 	for path := range r.directories {
-		if strings.HasPrefix(path, directory) {
-			paths = append(paths, path)
+		if rest, ok := strings.CutPrefix(strings.TrimSuffix(path, "/"), prefix); ok && rest != "" {
+			name, _, _ := strings.Cut(rest, "/")
+			childNames[name] = true
 		}
 	}
+
+	paths := make([]string, 0, len(childNames))
+	for name := range childNames {
+		paths = append(paths, name)
+	}
 	return &PathList{Paths: paths}
 }
 
@@ -302,6 +341,10 @@ type BridgeDiagnostic struct {
 	Line     int
 	Column   int
 	Length   int
+	// RelatedInformation and the tags are only reported in the JSON of the validate functions.
+	RelatedInformation []BridgeDiagnostic
+	ReportsUnnecessary bool
+	ReportsDeprecated  bool
 }
 
 // callbackVFS implements vfs.FS using a FileResolver
@@ -365,19 +408,17 @@ func (c *callbackVFS) GetAccessibleEntries(path string) vfs.Entries {
 	var files []string
 	var directories []string
 
+	seen := make(map[string]bool)
 	pathList := c.resolver.GetAllPaths(path)
 	if pathList != nil {
-		for _, childName := range pathList.Paths {
-			if childName == "" {
+		for _, entry := range pathList.Paths {
+			childName := resolverEntryName(path, entry)
+			if childName == "" || seen[childName] {
 				continue
 			}
+			seen[childName] = true
 
-			childPath := path
-			if !strings.HasSuffix(path, "/") {
-				childPath += "/"
-			}
-			childPath += childName
-
+			childPath := tspath.CombinePaths(path, childName)
 			if c.resolver.FileExists(childPath) {
 				files = append(files, childName)
 			} else if c.resolver.DirectoryExists(childPath) {
@@ -397,7 +438,7 @@ func (c *callbackVFS) Stat(path string) vfs.FileInfo {
 }
 
 func (c *callbackVFS) WalkDir(root string, walkFn vfs.WalkDirFunc) error {
-	for filePath := range c.getAllKnownPaths(root) {
+	for _, filePath := range c.getAllKnownPaths(root) {
 		if strings.HasPrefix(filePath, root) {
 			if c.resolver.FileExists(filePath) {
 				entry := &simpleDirEntry{
@@ -425,23 +466,57 @@ func (c *callbackVFS) Realpath(path string) string {
 	return c.osvfs.Realpath(path)
 }
 
-func (c *callbackVFS) getAllKnownPaths(directory string) map[string]bool {
+// getAllKnownPaths returns the sorted paths of the written files and the files of the resolver
+// in a directory and its subdirectories.
+func (c *callbackVFS) getAllKnownPaths(directory string) []string {
 	paths := make(map[string]bool)
 
+	c.mu.RLock()
 	for path := range c.writtenFiles {
 		if strings.HasPrefix(path, directory) {
 			paths[path] = true
 		}
 	}
+	c.mu.RUnlock()
 
+	c.addResolverPaths(directory, paths)
+	return slices.Sorted(maps.Keys(paths))
+}
+
+func (c *callbackVFS) addResolverPaths(directory string, paths map[string]bool) {
 	pathList := c.resolver.GetAllPaths(directory)
-	if pathList != nil {
-		for _, foundPath := range pathList.Paths {
-			paths[foundPath] = true
+	if pathList == nil {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, entry := range pathList.Paths {
+		childName := resolverEntryName(directory, entry)
+		if childName == "" || seen[childName] {
+			continue
+		}
+		seen[childName] = true
+
+		childPath := tspath.CombinePaths(directory, childName)
+		if c.resolver.FileExists(childPath) {
+			paths[childPath] = true
+		} else if c.resolver.DirectoryExists(childPath) {
+			c.addResolverPaths(childPath, paths)
 		}
 	}
+}
 
-	return paths
+// resolverEntryName returns the name of the entry of directory that an entry returned by
+// FileResolver.GetAllPaths refers to, or "" if it is not in directory.
+func resolverEntryName(directory string, entry string) string {
+	if !tspath.IsRootedDiskPath(entry) {
+		return entry
+	}
+	rest, ok := strings.CutPrefix(entry, tspath.EnsureTrailingDirectorySeparator(directory))
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(rest, "/")
+	return name
 }
 
 type simpleDirEntry struct {
@@ -483,6 +558,7 @@ type bridgeSystem struct {
 	start              time.Time
 	customFS           vfs.FS
 	callbackVFS        *callbackVFS
+	progress           progressFunc
 }
 
 func (s *bridgeSystem) SinceStart() time.Duration { return time.Since(s.start) }
@@ -499,6 +575,12 @@ func (s *bridgeSystem) NewLine() string             { return s.newLine }
 func (s *bridgeSystem) Writer() io.Writer           { return s.writer }
 func (s *bridgeSystem) EndWrite()                   {}
 
+func (s *bridgeSystem) reportProgress(phase buildPhase, fileName string, completed int, total int) {
+	if s.progress != nil {
+		s.progress(phase, fileName, completed, total)
+	}
+}
+
 func newBridgeSystem() *bridgeSystem {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -524,13 +606,14 @@ func newBridgeSystemWithResolver(resolver FileResolver) *bridgeSystem {
 	return sys
 }
 
-func buildWithConfig(projectPath string, printErrors bool, configFile string, resolver FileResolver) (*BridgeResult, error) {
+func buildWithConfig(ctx context.Context, projectPath string, printErrors bool, configFile string, resolver FileResolver, progress progressFunc) (*BridgeResult, error) {
 	var sys *bridgeSystem
 	if resolver != nil {
 		sys = newBridgeSystemWithResolver(resolver)
 	} else {
 		sys = newBridgeSystem()
 	}
+	sys.progress = progress
 
 	if printErrors {
 		sys.writer = os.Stdout
@@ -538,6 +621,32 @@ func buildWithConfig(projectPath string, printErrors bool, configFile string, re
 		sys.writer = io.Discard
 	}
 
+	extendedConfigCache := collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]{}
+	configParseResult, configFileName, failure := loadBridgeConfig(sys, projectPath, configFile, &extendedConfigCache)
+	if failure != nil {
+		return failure, nil
+	}
+
+	if ctx.Err() != nil {
+		return canceledResult(configFileName), nil
+	}
+
+	sys.reportProgress(buildPhaseProgram, "", 0, 0)
+	host := compiler.NewCachedFSCompilerHost(sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), &extendedConfigCache)
+	program := compiler.NewProgram(compiler.ProgramOptions{
+		Config:           configParseResult,
+		Host:             host,
+		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+	})
+
+	return collectBridgeResult(ctx, sys, program, configFileName, printErrors), nil
+}
+
+// loadBridgeConfig finds and parses the tsconfig.json of a project, and returns it with its file
+// name. If the config cannot be loaded, the returned BridgeResult describes why.
+func loadBridgeConfig(sys *bridgeSystem, projectPath string, configFile string, extendedConfigCache *collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]) (*tsoptions.ParsedCommandLine, string, *BridgeResult) {
+	sys.reportProgress(buildPhaseConfig, "", 0, 0)
+
 	configPath := configFile
 	if configPath == "" {
 		configPath = projectPath
@@ -546,10 +655,10 @@ func buildWithConfig(projectPath string, printErrors bool, configFile string, re
 	commandLine := tsoptions.ParseCommandLine([]string{"-p", configPath}, sys)
 
 	if len(commandLine.Errors) > 0 {
-		return &BridgeResult{
+		return nil, "", &BridgeResult{
 			Success:     false,
 			Diagnostics: convertASTDiagnostics(commandLine.Errors),
-		}, nil
+		}
 	}
 
 	var configFileName string
@@ -560,60 +669,62 @@ func buildWithConfig(projectPath string, printErrors bool, configFile string, re
 		if sys.FS().DirectoryExists(fileOrDirectory) {
 			configFileName = tspath.CombinePaths(fileOrDirectory, "tsconfig.json")
 			if !sys.FS().FileExists(configFileName) {
-				return &BridgeResult{
+				return nil, configFileName, &BridgeResult{
 					Success: false,
 					Diagnostics: []BridgeDiagnostic{{
 						Code:     0,
 						Category: "error",
 						Message:  fmt.Sprintf("cannot find a tsconfig.json file at: %s", configFileName),
 					}},
-				}, nil
+				}
 			}
 		} else {
 			configFileName = fileOrDirectory
 			if !sys.FS().FileExists(configFileName) {
-				return &BridgeResult{
+				return nil, configFileName, &BridgeResult{
 					Success: false,
 					Diagnostics: []BridgeDiagnostic{{
 						Code:     0,
 						Category: "error",
 						Message:  fmt.Sprintf("the specified path does not exist: %s", fileOrDirectory),
 					}},
-				}, nil
+				}
 			}
 		}
 	}
 
 	if configFileName == "" {
-		return &BridgeResult{
+		return nil, "", &BridgeResult{
 			Success: false,
 			Diagnostics: []BridgeDiagnostic{{
 				Code:     0,
 				Category: "error",
 				Message:  "no tsconfig.json file found",
 			}},
-		}, nil
+		}
 	}
 
-	extendedConfigCache := collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]{}
-	configParseResult, parseErrors := tsoptions.GetParsedCommandLineOfConfigFile(configFileName, compilerOptions, sys, &extendedConfigCache)
+	configParseResult, parseErrors := tsoptions.GetParsedCommandLineOfConfigFile(configFileName, compilerOptions, sys, extendedConfigCache)
 
 	if len(parseErrors) != 0 {
-		return &BridgeResult{
+		return nil, configFileName, &BridgeResult{
 			Success:     false,
 			Diagnostics: convertASTDiagnostics(parseErrors),
 			ConfigFile:  configFileName,
-		}, nil
+		}
 	}
 
-	host := compiler.NewCachedFSCompilerHost(sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), &extendedConfigCache)
-	program := compiler.NewProgram(compiler.ProgramOptions{
-		Config:           configParseResult,
-		Host:             host,
-		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
-	})
+	return configParseResult, configFileName, nil
+}
+
+// collectBridgeResult reports the diagnostics of a program the way tsc does, emits it, and
+// gathers the files written through the bridge. If ctx is canceled, the build stops at the next
+// file the checker or emitter starts, and a canceled result is returned.
+func collectBridgeResult(ctx context.Context, sys *bridgeSystem, program compiler.ProgramLike, configFileName string, printErrors bool) *BridgeResult {
+	if ctx.Err() != nil {
+		return canceledResult(configFileName)
+	}
 
-	ctx := context.Background()
 	options := program.Options()
 	allDiagnostics := slices.Clip(program.GetConfigFileParsingDiagnostics())
 	configFileParsingDiagnosticsLength := len(allDiagnostics)
@@ -628,6 +739,7 @@ func buildWithConfig(projectPath string, printErrors bool, configFile string, re
 			allDiagnostics = append(allDiagnostics, program.GetGlobalDiagnostics(ctx)...)
 
 			if len(allDiagnostics) == configFileParsingDiagnosticsLength {
+				checkSourceFiles(ctx, sys, program)
 				allDiagnostics = append(allDiagnostics, program.GetSemanticDiagnostics(ctx, nil)...)
 			}
 		}
@@ -637,10 +749,18 @@ func buildWithConfig(projectPath string, printErrors bool, configFile string, re
 		}
 	}
 
+	if ctx.Err() != nil {
+		return canceledResult(configFileName)
+	}
+
 	var emitResult *compiler.EmitResult
 	var emittedFiles []string
 	if !options.ListFilesOnly.IsTrue() {
+		sys.reportProgress(buildPhaseEmit, "", 0, 0)
 		emitResult = program.Emit(ctx, compiler.EmitOptions{})
+		if emitResult == nil || ctx.Err() != nil {
+			return canceledResult(configFileName)
+		}
 		allDiagnostics = append(allDiagnostics, emitResult.Diagnostics...)
 		emittedFiles = emitResult.EmittedFiles
 
@@ -694,16 +814,35 @@ func buildWithConfig(projectPath string, printErrors bool, configFile string, re
 		sys.callbackVFS.mu.RUnlock()
 	}
 
-	return result, nil
+	return result
+}
+
+// checkSourceFiles checks the files of a program one at a time when progress is reported, so each
+// file can be reported as it is checked. Without progress, the files are checked in parallel when
+// their diagnostics are requested.
+func checkSourceFiles(ctx context.Context, sys *bridgeSystem, program compiler.ProgramLike) {
+	if sys.progress == nil {
+		return
+	}
+	files := program.GetSourceFiles()
+	for i, file := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		sys.reportProgress(buildPhaseCheck, file.FileName(), i, len(files))
+		program.GetSemanticDiagnostics(ctx, file)
+	}
 }
 
 func convertASTDiagnostics(diagnostics []*ast.Diagnostic) []BridgeDiagnostic {
 	result := make([]BridgeDiagnostic, len(diagnostics))
 	for i, diag := range diagnostics {
 		result[i] = BridgeDiagnostic{
-			Code:     int(diag.Code()),
-			Category: diag.Category().Name(),
-			Message:  diag.Message(),
+			Code:               int(diag.Code()),
+			Category:           diag.Category().Name(),
+			Message:            diag.Message(),
+			ReportsUnnecessary: diag.ReportsUnnecessary(),
+			ReportsDeprecated:  diag.ReportsDeprecated(),
 		}
 
 		if diag.File() != nil {
@@ -715,6 +854,10 @@ func convertASTDiagnostics(diagnostics []*ast.Diagnostic) []BridgeDiagnostic {
 				result[i].Length = diag.Loc().End() - diag.Loc().Pos()
 			}
 		}
+
+		if len(diag.RelatedInformation()) > 0 {
+			result[i].RelatedInformation = convertASTDiagnostics(diag.RelatedInformation())
+		}
 	}
 	return result
 }
@@ -743,7 +886,7 @@ func tsc_build_filesystem(projectPath *C.char, printErrors C.int, configFile *C.
 	goPrintErrors := printErrors != 0
 	goConfigFile := C.GoString(configFile)
 
-	result, err := buildWithConfig(goProjectPath, goPrintErrors, goConfigFile, nil)
+	result, err := buildWithConfig(context.Background(), goProjectPath, goPrintErrors, goConfigFile, nil, nil)
 	if err != nil {
 		cResult := (*C.c_build_result)(C.malloc(C.sizeof_c_build_result))
 		cResult.success = 0
@@ -769,7 +912,7 @@ func tsc_build_with_resolver(projectPath *C.char, printErrors C.int, configFile
 
 	resolver := &FileResolverC{data: resolverData}
 
-	result, err := buildWithConfig(goProjectPath, goPrintErrors, goConfigFile, resolver)
+	result, err := buildWithConfig(context.Background(), goProjectPath, goPrintErrors, goConfigFile, resolver, nil)
 	if err != nil {
 		cResult := (*C.c_build_result)(C.malloc(C.sizeof_c_build_result))
 		cResult.success = 0
@@ -823,6 +966,29 @@ func (f *FileResolverDynamic) ResolveFile(path string) string {
 	return ""
 }
 
+// cProgressFunc returns a progressFunc that calls a C progress callback with data, or nil when the
+// callback is NULL.
+func cProgressFunc(callback C.progress_callback, data unsafe.Pointer) progressFunc {
+	if callback == nil {
+		return nil
+	}
+	return func(phase buildPhase, fileName string, completed int, total int) {
+		cEvent := (*C.c_progress_event)(C.malloc(C.sizeof_c_progress_event))
+		defer C.free(unsafe.Pointer(cEvent))
+
+		cEvent.phase = C.int(phase)
+		cEvent.file = nil
+		if fileName != "" {
+			cEvent.file = C.CString(fileName)
+			defer C.free(unsafe.Pointer(cEvent.file))
+		}
+		cEvent.completed = C.int(completed)
+		cEvent.total = C.int(total)
+
+		C.call_progress_callback(callback, cEvent, data)
+	}
+}
+
 func (f *FileResolverDynamic) FileExists(path string) bool {
 	if f.callbacks == nil || f.callbacks.resolver == nil {
 		return false
@@ -919,13 +1085,23 @@ func (f *FileResolverDynamic) GetAllPaths(directory string) *PathList {
 
 //export tsc_build_with_dynamic_resolver
 func tsc_build_with_dynamic_resolver(projectPath *C.char, printErrors C.int, configFile *C.char, callbacks *C.c_resolver_callbacks) *C.c_build_result {
+	return tsc_build_with_dynamic_resolver_cancellable(projectPath, printErrors, configFile, callbacks, 0, nil, nil)
+}
+
+// tsc_build_with_dynamic_resolver_cancellable builds like tsc_build_with_dynamic_resolver, and
+// stops early when the token from tsc_cancellation_token_create is canceled. A token of 0 is
+// never canceled. Unless it is NULL, progress is called with progressData as the build moves
+// through its phases.
+//
+//export tsc_build_with_dynamic_resolver_cancellable
+func tsc_build_with_dynamic_resolver_cancellable(projectPath *C.char, printErrors C.int, configFile *C.char, callbacks *C.c_resolver_callbacks, token C.uintptr_t, progress C.progress_callback, progressData unsafe.Pointer) *C.c_build_result {
 	goProjectPath := C.GoString(projectPath)
 	goPrintErrors := printErrors != 0
 	goConfigFile := C.GoString(configFile)
 
 	resolver := &FileResolverDynamic{callbacks: callbacks}
 
-	result, err := buildWithConfig(goProjectPath, goPrintErrors, goConfigFile, resolver)
+	result, err := buildWithConfig(tokenContext(token), goProjectPath, goPrintErrors, goConfigFile, resolver, cProgressFunc(progress, progressData))
 	if err != nil {
 		cResult := (*C.c_build_result)(C.malloc(C.sizeof_c_build_result))
 		cResult.success = 0
@@ -943,6 +1119,171 @@ func tsc_build_with_dynamic_resolver(projectPath *C.char, printErrors C.int, con
 	return convertBridgeResultToC(result)
 }
 
+// tsc_session_create loads a project that can be built repeatedly with tsc_session_build. Files
+// are read through the callbacks, or from the file system when callbacks is NULL, and are then
+// cached for the lifetime of the session: changes must be passed to tsc_session_update_file. The
+// callbacks must stay valid until the session is freed with tsc_session_free.
+//
+//export tsc_session_create
+func tsc_session_create(projectPath *C.char, configFile *C.char, callbacks *C.c_resolver_callbacks) C.uintptr_t {
+	var resolver FileResolver
+	if callbacks != nil {
+		resolver = &FileResolverDynamic{callbacks: callbacks}
+	}
+	session := newCompilerSession(C.GoString(projectPath), C.GoString(configFile), resolver)
+	return C.uintptr_t(cgo.NewHandle(session))
+}
+
+// tsc_session_create_in_memory creates a session for a project that only exists in memory. Its
+// files, including its tsconfig.json, are all passed to tsc_session_update_file.
+//
+//export tsc_session_create_in_memory
+func tsc_session_create_in_memory(projectPath *C.char, configFile *C.char) C.uintptr_t {
+	session := newCompilerSession(C.GoString(projectPath), C.GoString(configFile), NewSimpleFileResolver())
+	return C.uintptr_t(cgo.NewHandle(session))
+}
+
+// tsc_session_set_progress sets the callback that is called with progressData as builds of a
+// session move through their phases, and when a language service request loads the project after
+// an update. A NULL progress removes the callback. It must stay valid until it is replaced or the
+// session is freed.
+//
+//export tsc_session_set_progress
+func tsc_session_set_progress(session C.uintptr_t, progress C.progress_callback, progressData unsafe.Pointer) {
+	cgo.Handle(session).Value().(*compilerSession).setProgress(cProgressFunc(progress, progressData))
+}
+
+// tsc_session_update_file sets the contents of a file for the next builds of a session. Passing
+// NULL as content removes the file.
+//
+//export tsc_session_update_file
+func tsc_session_update_file(session C.uintptr_t, path *C.char, content *C.char) {
+	var goContent *string
+	if content != nil {
+		text := C.GoString(content)
+		goContent = &text
+	}
+	cgo.Handle(session).Value().(*compilerSession).updateFile(C.GoString(path), goContent)
+}
+
+// tsc_session_build builds a session. Only the files affected by updates since the previous build
+// are checked and emitted again, but the result always lists every file the session has written.
+// The result is freed with tsc_free_result.
+//
+//export tsc_session_build
+func tsc_session_build(session C.uintptr_t, printErrors C.int) *C.c_build_result {
+	return tsc_session_build_cancellable(session, printErrors, 0)
+}
+
+// tsc_session_build_cancellable builds like tsc_session_build, and stops early when the token from
+// tsc_cancellation_token_create is canceled. The next build of the session checks and emits
+// what the canceled build did not.
+//
+//export tsc_session_build_cancellable
+func tsc_session_build_cancellable(session C.uintptr_t, printErrors C.int, token C.uintptr_t) *C.c_build_result {
+	result := cgo.Handle(session).Value().(*compilerSession).build(tokenContext(token), printErrors != 0)
+	return convertBridgeResultToC(result)
+}
+
+//export tsc_session_free
+func tsc_session_free(session C.uintptr_t) {
+	cgo.Handle(session).Delete()
+}
+
+// The tsc_session_* language service functions answer requests about a file of a session, using
+// the files as last updated. Positions are zero-based lines and UTF-16 character offsets. Each
+// returns a JSON object freed with tsc_free_string: {"success": true, "result": ...} with the LSP
+// result of the request, or {"success": false, "error": "..."}.
+
+//export tsc_session_completions
+func tsc_session_completions(session C.uintptr_t, path *C.char, line C.int, character C.int, triggerCharacter *C.char) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).completions(C.GoString(path), cPosition(line, character), C.GoString(triggerCharacter))
+	return C.CString(result)
+}
+
+// tsc_session_completion_resolve takes the JSON of an item returned by tsc_session_completions
+// and returns it with its details and documentation.
+//
+//export tsc_session_completion_resolve
+func tsc_session_completion_resolve(session C.uintptr_t, item *C.char) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).resolveCompletion(C.GoString(item))
+	return C.CString(result)
+}
+
+//export tsc_session_hover
+func tsc_session_hover(session C.uintptr_t, path *C.char, line C.int, character C.int) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).hover(C.GoString(path), cPosition(line, character))
+	return C.CString(result)
+}
+
+//export tsc_session_definition
+func tsc_session_definition(session C.uintptr_t, path *C.char, line C.int, character C.int) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).definition(C.GoString(path), cPosition(line, character))
+	return C.CString(result)
+}
+
+//export tsc_session_references
+func tsc_session_references(session C.uintptr_t, path *C.char, line C.int, character C.int, includeDeclaration C.int) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).references(C.GoString(path), cPosition(line, character), includeDeclaration != 0)
+	return C.CString(result)
+}
+
+//export tsc_session_signature_help
+func tsc_session_signature_help(session C.uintptr_t, path *C.char, line C.int, character C.int, triggerCharacter *C.char) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).signatureHelp(C.GoString(path), cPosition(line, character), C.GoString(triggerCharacter))
+	return C.CString(result)
+}
+
+//export tsc_session_document_symbols
+func tsc_session_document_symbols(session C.uintptr_t, path *C.char) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).documentSymbols(C.GoString(path))
+	return C.CString(result)
+}
+
+// tsc_session_format returns the edits that format a file. options is the JSON of LSP
+// FormattingOptions, or NULL to indent with four spaces.
+//
+//export tsc_session_format
+func tsc_session_format(session C.uintptr_t, path *C.char, options *C.char) *C.char {
+	result := cgo.Handle(session).Value().(*compilerSession).format(C.GoString(path), C.GoString(options))
+	return C.CString(result)
+}
+
+func cPosition(line C.int, character C.int) lsproto.Position {
+	return lsproto.Position{Line: uint32(max(line, 0)), Character: uint32(max(character, 0))}
+}
+
+// tsc_cancellation_token_create returns a token that cancels the builds it is passed to when
+// tsc_cancellation_token_cancel is called. A token can be passed to several builds, and is freed
+// with tsc_cancellation_token_free once they have finished.
+//
+//export tsc_cancellation_token_create
+func tsc_cancellation_token_create() C.uintptr_t {
+	return C.uintptr_t(cgo.NewHandle(newCancellationToken()))
+}
+
+// tsc_cancellation_token_cancel cancels the builds using a token. It may be called from any thread
+// while they run.
+//
+//export tsc_cancellation_token_cancel
+func tsc_cancellation_token_cancel(token C.uintptr_t) {
+	cgo.Handle(token).Value().(*cancellationToken).cancel()
+}
+
+//export tsc_cancellation_token_free
+func tsc_cancellation_token_free(token C.uintptr_t) {
+	handle := cgo.Handle(token)
+	handle.Value().(*cancellationToken).cancel()
+	handle.Delete()
+}
+
+func tokenContext(token C.uintptr_t) context.Context {
+	if token == 0 {
+		return context.Background()
+	}
+	return cgo.Handle(token).Value().(*cancellationToken).ctx
+}
+
 //export tsc_validate_simple
 func tsc_validate_simple(code *C.char) *C.char {
 	goCode := C.GoString(code)
@@ -959,34 +1300,27 @@ func tsc_validate_simple(code *C.char) *C.char {
 	}`)
 	resolver.AddDirectory("/project")
 
-	result, err := buildWithConfig("/project", false, "", resolver)
-
-	response := map[string]interface{}{
-		"success":     result != nil && result.Success,
-		"diagnostics": []map[string]interface{}{},
-	}
-
-	if err != nil {
-		response["success"] = false
-		response["error"] = err.Error()
-	} else if result != nil {
-		diagnostics := []map[string]interface{}{}
-		for _, diag := range result.Diagnostics {
-			diagnostics = append(diagnostics, map[string]interface{}{
-				"code":     diag.Code,
-				"category": diag.Category,
-				"message":  diag.Message,
-				"file":     diag.File,
-				"line":     diag.Line,
-				"column":   diag.Column,
-				"length":   diag.Length,
-			})
-		}
-		response["diagnostics"] = diagnostics
-	}
+	result, err := buildWithConfig(context.Background(), "/project", false, "", resolver, nil)
+	return C.CString(validationResponse(result, err))
+}
 
-	jsonBytes, _ := json.Marshal(response)
-	return C.CString(string(jsonBytes))
+// tsc_validate_json type checks the files of a JSON request without emitting them, and returns
+// the same JSON as tsc_validate_simple. The request looks like:
+//
+//	{
+//	  "files": {"main.tsx": "...", "node_modules/lib/index.d.ts": "..."},
+//	  "compilerOptions": {"jsx": "react-jsx", "module": "esnext", "moduleResolution": "bundler"},
+//	  "lib": ["es2022", "dom"]
+//	}
+//
+// Relative file names are in /project, the root of the project. compilerOptions are
+// tsconfig.json options applied over the tsc_validate_simple ones, and lib, when given, replaces
+// the lib option. The diagnostics also include the suggestions for the files of the request, such
+// as deprecations.
+//
+//export tsc_validate_json
+func tsc_validate_json(request *C.char) *C.char {
+	return C.CString(validateJson(C.GoString(request)))
 }
 
 //export tsc_free_string
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+func TestCallbackVFSWalkDir(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewSimpleFileResolver()
+	resolver.AddDirectory("/project")
+	resolver.AddFile("/project/tsconfig.json", "{}")
+	resolver.AddFile("/project/src/index.ts", "export {};")
+	resolver.AddFile("/project/src/lib/util.ts", "export {};")
+	resolver.AddFile("/other/index.ts", "export {};")
+
+	fs := newCallbackVFS(resolver)
+	if err := fs.WriteFile("/project/dist/index.js", "export {};", false); err != nil {
+		t.Fatal(err)
+	}
+
+	var walked []string
+	err := fs.WalkDir("/project", func(path string, entry vfs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			t.Errorf("directory %s was walked as a file", path)
+		}
+		walked = append(walked, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"/project/dist/index.js",
+		"/project/src/index.ts",
+		"/project/src/lib/util.ts",
+		"/project/tsconfig.json",
+	}
+	if !slices.Equal(walked, expected) {
+		t.Errorf("walked %v, expected %v", walked, expected)
+	}
+}
+
+func TestCallbackVFSGetAccessibleEntries(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewSimpleFileResolver()
+	resolver.AddFile("/project/tsconfig.json", "{}")
+	resolver.AddFile("/project/src/index.ts", "export {};")
+
+	entries := newCallbackVFS(resolver).GetAccessibleEntries("/project")
+	if !slices.Equal(entries.Files, []string{"tsconfig.json"}) || !slices.Equal(entries.Directories, []string{"src"}) {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+}
+
+// fullPathResolver returns full paths from GetAllPaths, like resolvers written before entry names
+// were supported.
+type fullPathResolver struct {
+	*SimpleFileResolver
+}
+
+func (r fullPathResolver) GetAllPaths(directory string) *PathList {
+	var paths []string
+	for path := range r.files {
+		if strings.HasPrefix(path, tspath.EnsureTrailingDirectorySeparator(directory)) {
+			paths = append(paths, path)
+		}
+	}
+	return &PathList{Paths: paths}
+}
+
+func TestCallbackVFSFullPaths(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewSimpleFileResolver()
+	resolver.AddFile("/project/tsconfig.json", "{}")
+	resolver.AddFile("/project/src/index.ts", "export {};")
+	resolver.AddFile("/project/src/util.ts", "export {};")
+	resolver.AddFile("/other/index.ts", "export {};")
+
+	fs := newCallbackVFS(fullPathResolver{resolver})
+
+	entries := fs.GetAccessibleEntries("/project")
+	if !slices.Equal(entries.Files, []string{"tsconfig.json"}) || !slices.Equal(entries.Directories, []string{"src"}) {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+
+	var walked []string
+	err := fs.WalkDir("/project", func(path string, entry vfs.DirEntry, err error) error {
+		walked = append(walked, path)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"/project/src/index.ts", "/project/src/util.ts", "/project/tsconfig.json"}
+	if !slices.Equal(walked, expected) {
+		t.Errorf("walked %v, expected %v", walked, expected)
+	}
+}
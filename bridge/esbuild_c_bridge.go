@@ -1,6 +1,7 @@
 package main
 
 /*
+#include <stdint.h>
 #include <stdlib.h>
 
 typedef struct {
@@ -397,6 +398,7 @@ void swift_plugin_on_end_callback(void* callbackData);
 import "C"
 
 import (
+	"runtime/cgo"
 	"unsafe"
 
 	"github.com/evanw/esbuild/pkg/api"
@@ -1947,6 +1949,15 @@ func esbuild_free_build_result(result *C.esbuild_build_result) {
 
 //export esbuild_build
 func esbuild_build(opts *C.esbuild_build_options) *C.esbuild_build_result {
+	// Perform the build
+	result := api.Build(convertBuildOptions(opts))
+	
+	return convertBuildResult(result)
+}
+
+// convertBuildOptions converts C build options to Go BuildOptions. Plugin hooks call back into
+// Swift with the callback data of the registered plugins for as long as esbuild runs them.
+func convertBuildOptions(opts *C.esbuild_build_options) api.BuildOptions {
 	// Convert C options to Go BuildOptions
 	buildOpts := api.BuildOptions{}
 	
@@ -2507,9 +2518,12 @@ func esbuild_build(opts *C.esbuild_build_options) *C.esbuild_build_result {
 		}
 	}
 	
-	// Perform the build
-	result := api.Build(buildOpts)
-	
+	return buildOpts
+}
+
+// convertBuildResult converts a Go BuildResult to a C build result freed with
+// esbuild_free_build_result
+func convertBuildResult(result api.BuildResult) *C.esbuild_build_result {
 	// Convert result to C structure
 	cResult := esbuild_create_build_result()
 	
@@ -2607,6 +2621,68 @@ func esbuild_build(opts *C.esbuild_build_options) *C.esbuild_build_result {
 	return cResult
 }
 
+// Incremental build contexts
+
+// esbuild_context_create creates a build context that keeps esbuild's caches between rebuilds.
+// Plugins are set up once, when the context is created, and their callbacks are called on every
+// rebuild, so the registered plugins must stay alive until the context is disposed. The options
+// can be freed once this returns. On failure, 0 is returned and, if errors is not NULL, it is set
+// to a build result with the errors.
+//
+//export esbuild_context_create
+func esbuild_context_create(opts *C.esbuild_build_options, errors **C.esbuild_build_result) C.uintptr_t {
+	ctx, ctxErr := api.Context(convertBuildOptions(opts))
+	if ctxErr != nil {
+		if errors != nil {
+			*errors = convertBuildResult(api.BuildResult{Errors: ctxErr.Errors})
+		}
+		return 0
+	}
+	return C.uintptr_t(cgo.NewHandle(ctx))
+}
+
+// esbuild_context_rebuild builds again, reusing the results for the files on the file system that
+// have not changed since the previous build of the context. Imports are resolved again, and files
+// loaded by plugins are loaded again, on every rebuild, so the on-resolve and on-load callbacks of
+// the plugins are called for them each time.
+//
+//export esbuild_context_rebuild
+func esbuild_context_rebuild(context C.uintptr_t) *C.esbuild_build_result {
+	ctx := cgo.Handle(context).Value().(api.BuildContext)
+	return convertBuildResult(ctx.Rebuild())
+}
+
+// esbuild_context_watch starts rebuilding the context whenever the files it depends on change. The
+// results are reported to the on-end callbacks of the plugins. It returns NULL, or an error message
+// freed with tsc_free_string.
+//
+//export esbuild_context_watch
+func esbuild_context_watch(context C.uintptr_t) *C.char {
+	ctx := cgo.Handle(context).Value().(api.BuildContext)
+	if err := ctx.Watch(api.WatchOptions{}); err != nil {
+		return C.CString(err.Error())
+	}
+	return nil
+}
+
+// esbuild_context_cancel cancels the build the context is running, if any, and returns once it
+// has stopped. The canceled build returns a result with an error.
+//
+//export esbuild_context_cancel
+func esbuild_context_cancel(context C.uintptr_t) {
+	ctx := cgo.Handle(context).Value().(api.BuildContext)
+	ctx.Cancel()
+}
+
+// esbuild_context_dispose stops watching, waits for the running build and frees the context.
+//
+//export esbuild_context_dispose
+func esbuild_context_dispose(context C.uintptr_t) {
+	handle := cgo.Handle(context)
+	handle.Value().(api.BuildContext).Dispose()
+	handle.Delete()
+}
+
 // Plugin callback bridge functions
 
 // Note: swift_plugin_on_* functions are implemented in Swift
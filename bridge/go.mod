@@ -6,12 +6,14 @@ toolchain go1.24.4
 
 require (
 	github.com/evanw/esbuild v0.25.5
+	github.com/go-json-experiment/json v0.0.0-20250725192818-e39067aee2d2
 	github.com/microsoft/typescript-go v0.0.0
 )
 
 require (
 	github.com/dlclark/regexp2 v1.11.5 // indirect
-	github.com/go-json-experiment/json v0.0.0-20250725192818-e39067aee2d2 // indirect
+	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
+	github.com/zeebo/xxh3 v1.0.2 // indirect
 	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/text v0.27.0 // indirect
 )
@@ -0,0 +1,24 @@
+#include <stddef.h>
+
+// The esbuild plugin callbacks are defined by the Swift package. These weak definitions let the
+// bridge link without it, as it does for the Go tests; the Swift definitions take their place
+// when the archive is linked into the package.
+
+typedef struct c_on_resolve_args c_on_resolve_args;
+typedef struct c_on_resolve_result c_on_resolve_result;
+typedef struct c_on_load_args c_on_load_args;
+typedef struct c_on_load_result c_on_load_result;
+
+__attribute__((weak)) c_on_resolve_result* swift_plugin_on_resolve_callback(c_on_resolve_args* args, void* callbackData) {
+    return NULL;
+}
+
+__attribute__((weak)) c_on_load_result* swift_plugin_on_load_callback(c_on_load_args* args, void* callbackData) {
+    return NULL;
+}
+
+__attribute__((weak)) void swift_plugin_on_start_callback(void* callbackData) {
+}
+
+__attribute__((weak)) void swift_plugin_on_end_callback(void* callbackData) {
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+)
+
+// buildPhase is a step of a build reported to the progress callback. The values are part of the C
+// API.
+type buildPhase int
+
+const (
+	buildPhaseConfig  buildPhase = 0
+	buildPhaseProgram buildPhase = 1
+	buildPhaseCheck   buildPhase = 2
+	buildPhaseEmit    buildPhase = 3
+)
+
+// progressFunc is called as a build moves through its phases. For buildPhaseCheck, it is called
+// before each file is checked, with the file name, the number of files checked so far and the
+// number of files in the program.
+type progressFunc func(phase buildPhase, fileName string, completed int, total int)
+
+// cancellationToken cancels the builds it is passed to.
+type cancellationToken struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newCancellationToken() *cancellationToken {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &cancellationToken{ctx: ctx, cancel: cancel}
+}
+
+// canceledResult is the result of a build that was canceled before it finished.
+func canceledResult(configFileName string) *BridgeResult {
+	return &BridgeResult{
+		Success: false,
+		Diagnostics: []BridgeDiagnostic{{
+			Code:     0,
+			Category: "error",
+			Message:  "build was canceled",
+		}},
+		ConfigFile: configFileName,
+	}
+}
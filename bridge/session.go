@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/incremental"
+	"github.com/microsoft/typescript-go/internal/ls/standalone"
+	"github.com/microsoft/typescript-go/internal/parser"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+	"github.com/microsoft/typescript-go/internal/vfs/cachedvfs"
+	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
+)
+
+// compilerSession keeps a project loaded between builds. Files are read through the resolver once
+// and then only change through updateFile, so a build after an edit reparses the edited files,
+// reuses every other SourceFile, and only rechecks and re-emits the files affected by the edit.
+type compilerSession struct {
+	mu          sync.Mutex
+	projectPath string
+	configFile  string
+
+	sys                 *bridgeSystem
+	fs                  *sessionFS
+	host                *sessionCompilerHost
+	extendedConfigCache collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]
+
+	config         *tsoptions.ParsedCommandLine
+	configFileName string
+	// configStale is set when the config has to be parsed again before the next build, because it
+	// changed or because files were added or removed and its include patterns may match differently.
+	configStale bool
+
+	// current is the program for the current files, or nil when files were updated since it was
+	// created. program is the incremental program of the last build.
+	current *compiler.Program
+	program *incremental.Program
+	// lsHost serves the language service requests for current.
+	lsHost *standalone.Host
+}
+
+func newCompilerSession(projectPath string, configFile string, resolver FileResolver) *compilerSession {
+	var sys *bridgeSystem
+	var base vfs.FS
+	if resolver != nil {
+		sys = newBridgeSystemWithResolver(resolver)
+		base = sys.callbackVFS
+	} else {
+		sys = newBridgeSystem()
+		base = osvfs.FS()
+	}
+
+	session := &compilerSession{
+		projectPath: projectPath,
+		configFile:  configFile,
+		sys:         sys,
+		fs:          newSessionFS(cachedvfs.From(base)),
+		configStale: true,
+	}
+	sys.customFS = bundled.WrapFS(session.fs)
+	session.host = &sessionCompilerHost{
+		CompilerHost: compiler.NewCompilerHost(sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), &session.extendedConfigCache),
+	}
+	return session
+}
+
+// setProgress sets the function that builds and language service requests report progress to.
+func (s *compilerSession) setProgress(progress progressFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sys.progress = progress
+}
+
+// updateFile replaces the contents of a file for the following builds. A nil content removes the
+// file.
+func (s *compilerSession) updateFile(path string, content *string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existed := s.fs.updateFile(path, content)
+	s.current = nil
+	if existed != (content != nil) || path == s.configFileName || slices.Contains(s.config.ExtendedSourceFiles(), path) {
+		s.configStale = true
+	}
+}
+
+// build type checks and emits the project, reusing as much of the previous build as possible.
+func (s *compilerSession) build(ctx context.Context, printErrors bool) *BridgeResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if printErrors {
+		s.sys.writer = os.Stdout
+	} else {
+		s.sys.writer = io.Discard
+	}
+
+	program, failure := s.updateProgram()
+	if failure != nil {
+		return failure
+	}
+	if ctx.Err() != nil {
+		return canceledResult(s.configFileName)
+	}
+
+	previous := s.program
+	if s.program == nil || s.program.GetProgram() != program {
+		s.program = incremental.NewProgram(program, s.program, false)
+	}
+
+	result := collectBridgeResult(ctx, s.sys, s.program, s.configFileName, printErrors)
+	if ctx.Err() != nil {
+		// Checkers cannot be used after they were canceled, so the next build starts from a new
+		// program, and from the state of the last build that was not canceled
+		s.current = nil
+		s.program = previous
+	}
+	return result
+}
+
+// updateProgram returns the program for the current files, creating it if files were updated
+// since the last one was created. The caller must hold s.mu.
+func (s *compilerSession) updateProgram() (*compiler.Program, *BridgeResult) {
+	if s.configStale {
+		s.extendedConfigCache.Clear()
+		config, configFileName, failure := loadBridgeConfig(s.sys, s.projectPath, s.configFile, &s.extendedConfigCache)
+		if failure != nil {
+			return nil, failure
+		}
+		s.config, s.configFileName, s.configStale = config, configFileName, false
+	}
+
+	if s.current == nil {
+		s.sys.reportProgress(buildPhaseProgram, "", 0, 0)
+		// JSDoc is parsed in full, as the language service shows it, so the same program serves
+		// builds and language service requests
+		s.current = compiler.NewProgram(compiler.ProgramOptions{
+			Config:           s.config,
+			Host:             s.host,
+			JSDocParsingMode: ast.JSDocParsingModeParseAll,
+		})
+		s.host.retain(s.current.SourceFiles())
+		s.lsHost = nil
+	}
+	return s.current, nil
+}
+
+// sessionCompilerHost hands out the SourceFile of the previous build for files whose text has
+// not changed, so only edited files are parsed and bound again.
+type sessionCompilerHost struct {
+	compiler.CompilerHost
+	sourceFiles collections.SyncMap[ast.SourceFileParseOptions, *ast.SourceFile]
+}
+
+func (h *sessionCompilerHost) GetSourceFile(opts ast.SourceFileParseOptions) *ast.SourceFile {
+	text, ok := h.FS().ReadFile(opts.FileName)
+	if !ok {
+		return nil
+	}
+	if cached, ok := h.sourceFiles.Load(opts); ok && cached.Text() == text {
+		return cached
+	}
+	sourceFile := parser.ParseSourceFile(opts, text, core.GetScriptKindFromFileName(opts.FileName))
+	h.sourceFiles.Store(opts, sourceFile)
+	return sourceFile
+}
+
+// retain drops the cached files that are no longer part of the program.
+func (h *sessionCompilerHost) retain(sourceFiles []*ast.SourceFile) {
+	keep := make(map[*ast.SourceFile]bool, len(sourceFiles))
+	for _, sourceFile := range sourceFiles {
+		keep[sourceFile] = true
+	}
+	h.sourceFiles.Range(func(opts ast.SourceFileParseOptions, sourceFile *ast.SourceFile) bool {
+		if !keep[sourceFile] {
+			h.sourceFiles.Delete(opts)
+		}
+		return true
+	})
+}
+
+// sessionFile is a file as a session last saw it.
+type sessionFile struct {
+	content string
+	exists  bool
+}
+
+// sessionFS caches the files a session reads, so each file crosses the bridge once per session,
+// and layers the files updated through the session over them.
+type sessionFS struct {
+	vfs.FS
+	mu    sync.RWMutex
+	files map[string]sessionFile
+	// updated holds the paths passed to updateFile, which may not exist in the underlying file
+	// system, or may have been removed from the session but still exist there.
+	updated map[string]bool
+}
+
+func newSessionFS(fs vfs.FS) *sessionFS {
+	return &sessionFS{
+		FS:      fs,
+		files:   make(map[string]sessionFile),
+		updated: make(map[string]bool),
+	}
+}
+
+// updateFile sets or, with a nil content, removes a file, and reports whether it existed before.
+func (fs *sessionFS) updateFile(path string, content *string) bool {
+	existed := fs.FileExists(path)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if content != nil {
+		fs.files[path] = sessionFile{content: *content, exists: true}
+	} else {
+		fs.files[path] = sessionFile{}
+	}
+	fs.updated[path] = true
+	return existed
+}
+
+func (fs *sessionFS) lookup(path string) (sessionFile, bool) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	file, ok := fs.files[path]
+	return file, ok
+}
+
+func (fs *sessionFS) FileExists(path string) bool {
+	if file, ok := fs.lookup(path); ok {
+		return file.exists
+	}
+	return fs.FS.FileExists(path)
+}
+
+func (fs *sessionFS) ReadFile(path string) (string, bool) {
+	if file, ok := fs.lookup(path); ok {
+		return file.content, file.exists
+	}
+	content, ok := fs.FS.ReadFile(path)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if file, ok := fs.files[path]; ok {
+		// Updated while the file was being read
+		return file.content, file.exists
+	}
+	fs.files[path] = sessionFile{content: content, exists: ok}
+	return content, ok
+}
+
+func (fs *sessionFS) WriteFile(path string, data string, writeByteOrderMark bool) error {
+	if err := fs.FS.WriteFile(path, data, writeByteOrderMark); err != nil {
+		return err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.files[path] = sessionFile{content: data, exists: true}
+	return nil
+}
+
+func (fs *sessionFS) Remove(path string) error {
+	fs.mu.Lock()
+	delete(fs.files, path)
+	fs.mu.Unlock()
+	return fs.FS.Remove(path)
+}
+
+func (fs *sessionFS) DirectoryExists(path string) bool {
+	if fs.FS.DirectoryExists(path) {
+		return true
+	}
+	prefix := tspath.EnsureTrailingDirectorySeparator(path)
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	for updatedPath := range fs.updated {
+		if strings.HasPrefix(updatedPath, prefix) && fs.files[updatedPath].exists {
+			return true
+		}
+	}
+	return false
+}
+
+func (fs *sessionFS) GetAccessibleEntries(path string) vfs.Entries {
+	entries := fs.FS.GetAccessibleEntries(path)
+	prefix := tspath.EnsureTrailingDirectorySeparator(path)
+
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	if len(fs.updated) == 0 {
+		return entries
+	}
+
+	files := slices.Clone(entries.Files)
+	directories := slices.Clone(entries.Directories)
+	for updatedPath := range fs.updated {
+		rest, ok := strings.CutPrefix(updatedPath, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		name, _, isNested := strings.Cut(rest, "/")
+		switch {
+		case isNested:
+			if fs.files[updatedPath].exists && !slices.Contains(directories, name) {
+				directories = append(directories, name)
+			}
+		case fs.files[updatedPath].exists:
+			if !slices.Contains(files, name) {
+				files = append(files, name)
+			}
+		default:
+			files = slices.DeleteFunc(files, func(file string) bool { return file == name })
+		}
+	}
+	return vfs.Entries{Files: files, Directories: directories}
+}
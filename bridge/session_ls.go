@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/ls/standalone"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// languageServiceResponse is the JSON returned by the language service exports. Result holds the
+// LSP result of the request when it succeeds.
+type languageServiceResponse[T any] struct {
+	Success bool   `json:"success"`
+	Result  T      `json:"result"`
+	Error   string `json:"error,omitempty"`
+}
+
+// languageServiceHost returns the host for the current files of the session, creating their
+// program if files were updated since the previous request or build. The caller must hold s.mu.
+func (s *compilerSession) languageServiceHost() (*standalone.Host, error) {
+	program, failure := s.updateProgram()
+	if failure != nil {
+		if len(failure.Diagnostics) > 0 {
+			return nil, errors.New(failure.Diagnostics[0].Message)
+		}
+		return nil, errors.New("failed to load the project")
+	}
+	if s.lsHost == nil {
+		s.lsHost = standalone.NewHost(program)
+	}
+	return s.lsHost, nil
+}
+
+// languageServiceRequest answers a language service request about a file of a session with
+// provide, and returns the result as languageServiceResponse JSON.
+func languageServiceRequest[T any](session *compilerSession, fileName string, position *lsproto.Position, provide func(context.Context, *ls.LanguageService, lsproto.DocumentUri) (T, error)) string {
+	result, err := callLanguageService(session, fileName, position, provide)
+	response := languageServiceResponse[T]{Success: err == nil, Result: result}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	jsonBytes, _ := json.Marshal(&response)
+	return string(jsonBytes)
+}
+
+// callLanguageService checks that a request is about a file of the program and a position in
+// it, and calls provide. The session lock is held until provide returns, as the checkers of the
+// program are not safe to use from several requests or a build at once.
+func callLanguageService[T any](session *compilerSession, fileName string, position *lsproto.Position, provide func(context.Context, *ls.LanguageService, lsproto.DocumentUri) (T, error)) (result T, err error) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	host, err := session.languageServiceHost()
+	if err != nil {
+		return result, err
+	}
+	var uri lsproto.DocumentUri
+	if fileName != "" {
+		fileName = tspath.GetNormalizedAbsolutePath(fileName, session.sys.GetCurrentDirectory())
+		if host.GetProgram().GetSourceFile(fileName) == nil {
+			return result, fmt.Errorf("file %q is not part of the program", fileName)
+		}
+		if lines := len(host.GetLineMap(fileName).LineStarts); position != nil && int(position.Line) >= lines {
+			return result, fmt.Errorf("line %d is past the end of %s, which has %d lines", position.Line, fileName, lines)
+		}
+		uri = ls.FileNameToDocumentURI(fileName)
+	}
+	result, err = standalone.Call(func() (T, error) {
+		return provide(context.Background(), ls.NewLanguageService(host), uri)
+	})
+	var panicErr *standalone.PanicError
+	if errors.As(err, &panicErr) {
+		// The checkers may have been left in an inconsistent state, so the next request or build
+		// starts from a new program
+		session.current = nil
+		session.lsHost = nil
+	}
+	return result, err
+}
+
+func (s *compilerSession) completions(fileName string, position lsproto.Position, triggerCharacter string) string {
+	return languageServiceRequest(s, fileName, &position, func(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri) (lsproto.CompletionResponse, error) {
+		return languageService.ProvideCompletion(ctx, uri, position, standalone.CompletionContext(triggerCharacter), nil, &ls.UserPreferences{})
+	})
+}
+
+// resolveCompletion adds the details and documentation to a completion item returned by
+// completions.
+func (s *compilerSession) resolveCompletion(itemJson string) string {
+	var item lsproto.CompletionItem
+	if err := json.Unmarshal([]byte(itemJson), &item); err != nil {
+		jsonBytes, _ := json.Marshal(&languageServiceResponse[*lsproto.CompletionItem]{Error: fmt.Sprintf("invalid completion item: %v", err)})
+		return string(jsonBytes)
+	}
+	return languageServiceRequest(s, "", nil, func(ctx context.Context, languageService *ls.LanguageService, _ lsproto.DocumentUri) (lsproto.CompletionResolveResponse, error) {
+		data, err := ls.GetCompletionItemData(&item)
+		if err != nil {
+			return nil, err
+		}
+		return languageService.ResolveCompletionItem(ctx, &item, data, nil, &ls.UserPreferences{})
+	})
+}
+
+func (s *compilerSession) hover(fileName string, position lsproto.Position) string {
+	return languageServiceRequest(s, fileName, &position, func(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri) (lsproto.HoverResponse, error) {
+		return languageService.ProvideHover(ctx, uri, position)
+	})
+}
+
+func (s *compilerSession) definition(fileName string, position lsproto.Position) string {
+	return languageServiceRequest(s, fileName, &position, func(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri) (lsproto.DefinitionResponse, error) {
+		return languageService.ProvideDefinition(ctx, uri, position)
+	})
+}
+
+func (s *compilerSession) references(fileName string, position lsproto.Position, includeDeclaration bool) string {
+	return languageServiceRequest(s, fileName, &position, func(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri) (lsproto.ReferencesResponse, error) {
+		return standalone.ProvideReferences(ctx, languageService, uri, position, includeDeclaration)
+	})
+}
+
+func (s *compilerSession) signatureHelp(fileName string, position lsproto.Position, triggerCharacter string) string {
+	return languageServiceRequest(s, fileName, &position, func(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri) (lsproto.SignatureHelpResponse, error) {
+		return languageService.ProvideSignatureHelp(ctx, uri, position, standalone.SignatureHelpContext(triggerCharacter), standalone.SignatureHelpCapabilities, &ls.UserPreferences{})
+	})
+}
+
+func (s *compilerSession) documentSymbols(fileName string) string {
+	return languageServiceRequest(s, fileName, nil, func(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri) (lsproto.DocumentSymbolResponse, error) {
+		return languageService.ProvideDocumentSymbols(ctx, uri)
+	})
+}
+
+// format formats a file. optionsJson holds LSP FormattingOptions; when it is empty, four spaces
+// are used for indentation.
+func (s *compilerSession) format(fileName string, optionsJson string) string {
+	options := standalone.DefaultFormattingOptions
+	if optionsJson != "" {
+		options = lsproto.FormattingOptions{}
+		if err := json.Unmarshal([]byte(optionsJson), &options); err != nil {
+			jsonBytes, _ := json.Marshal(&languageServiceResponse[lsproto.DocumentFormattingResponse]{Error: fmt.Sprintf("invalid formatting options: %v", err)})
+			return string(jsonBytes)
+		}
+	}
+	return languageServiceRequest(s, fileName, nil, func(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri) (lsproto.DocumentFormattingResponse, error) {
+		return languageService.ProvideFormatDocument(ctx, uri, &options)
+	})
+}
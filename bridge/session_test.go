@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+func TestSessionProgress(t *testing.T) {
+	t.Parallel()
+
+	session := newCompilerSession("/project", "", NewSimpleFileResolver())
+	tsconfig := `{"compilerOptions": {"lib": ["es5"]}, "files": ["a.ts", "b.ts"]}`
+	a := "export const a = 1;"
+	b := "export const b = 2;"
+	session.updateFile("/project/tsconfig.json", &tsconfig)
+	session.updateFile("/project/a.ts", &a)
+	session.updateFile("/project/b.ts", &b)
+
+	var phases []buildPhase
+	var checked []string
+	checkTotal := 0
+	session.setProgress(func(phase buildPhase, fileName string, completed int, total int) {
+		if len(phases) == 0 || phases[len(phases)-1] != phase {
+			phases = append(phases, phase)
+		}
+		if phase == buildPhaseCheck {
+			checked = append(checked, fileName)
+			checkTotal = total
+			if completed != len(checked)-1 {
+				t.Errorf("checking %s reported %d files checked, expected %d", fileName, completed, len(checked)-1)
+			}
+		}
+	})
+
+	result := session.build(context.Background(), false)
+	if !result.Success {
+		t.Fatalf("build failed: %+v", result.Diagnostics)
+	}
+	expected := []buildPhase{buildPhaseConfig, buildPhaseProgram, buildPhaseCheck, buildPhaseEmit}
+	if !slices.Equal(phases, expected) {
+		t.Errorf("reported phases %v, expected %v", phases, expected)
+	}
+	if len(checked) != checkTotal || !slices.Contains(checked, "/project/a.ts") || !slices.Contains(checked, "/project/b.ts") {
+		t.Errorf("reported checking %v of %d files", checked, checkTotal)
+	}
+
+	session.setProgress(nil)
+	session.updateFile("/project/a.ts", &b)
+	if result := session.build(context.Background(), false); !result.Success {
+		t.Fatalf("build failed: %+v", result.Diagnostics)
+	}
+}
+
+func TestSessionReferences(t *testing.T) {
+	t.Parallel()
+
+	session := newCompilerSession("/project", "", NewSimpleFileResolver())
+	tsconfig := `{"compilerOptions": {"lib": ["es5"]}, "files": ["a.ts", "b.ts"]}`
+	a := "export function greet() {}"
+	b := "import { greet } from \"./a\";\ngreet();"
+	session.updateFile("/project/tsconfig.json", &tsconfig)
+	session.updateFile("/project/a.ts", &a)
+	session.updateFile("/project/b.ts", &b)
+
+	position := lsproto.Position{Line: 1, Character: 1}
+	references := session.references("/project/b.ts", position, false)
+	if strings.Contains(references, "file:///project/a.ts") || strings.Count(references, `"uri"`) != 2 {
+		t.Errorf("expected the references in b.ts, got %s", references)
+	}
+	references = session.references("/project/b.ts", position, true)
+	if !strings.Contains(references, "file:///project/a.ts") || strings.Count(references, `"uri"`) != 3 {
+		t.Errorf("expected the declaration in a.ts to be included, got %s", references)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// validateProjectPath is the root of the projects of the validate functions.
+const validateProjectPath = "/project"
+
+// defaultValidateCompilerOptions are the compiler options of tsc_validate_simple, which
+// tsc_validate_json requests override.
+var defaultValidateCompilerOptions = map[string]any{
+	"target": "es2022",
+	"module": "commonjs",
+	"strict": true,
+}
+
+// validateRequest is the input of tsc_validate_json.
+type validateRequest struct {
+	Files           map[string]string `json:"files"`
+	CompilerOptions map[string]any    `json:"compilerOptions"`
+	Lib             []string          `json:"lib"`
+}
+
+// validateJson type checks the files of a validateRequest and returns the diagnostics as JSON.
+func validateJson(input string) string {
+	var request validateRequest
+	if err := json.Unmarshal([]byte(input), &request); err != nil {
+		return validationResponse(nil, fmt.Errorf("invalid request: %w", err))
+	}
+	if len(request.Files) == 0 {
+		return validationResponse(nil, errors.New("invalid request: no files"))
+	}
+
+	compilerOptions := maps.Clone(defaultValidateCompilerOptions)
+	maps.Copy(compilerOptions, request.CompilerOptions)
+	if request.Lib != nil {
+		compilerOptions["lib"] = request.Lib
+	}
+	compilerOptions["noEmit"] = true
+	config, err := json.Marshal(map[string]any{"compilerOptions": compilerOptions})
+	if err != nil {
+		return validationResponse(nil, fmt.Errorf("invalid request: %w", err))
+	}
+
+	resolver := NewSimpleFileResolver()
+	fileNames := make([]string, 0, len(request.Files))
+	for name, content := range request.Files {
+		fileName := tspath.GetNormalizedAbsolutePath(name, validateProjectPath)
+		resolver.AddFile(fileName, content)
+		fileNames = append(fileNames, fileName)
+	}
+	resolver.AddFile(tspath.CombinePaths(validateProjectPath, "tsconfig.json"), string(config))
+	resolver.AddDirectory(validateProjectPath)
+	slices.Sort(fileNames)
+
+	return validationResponse(validateFiles(resolver, fileNames), nil)
+}
+
+// validateFiles type checks the project in validateProjectPath, and adds the suggestions for the
+// given files to the diagnostics.
+func validateFiles(resolver FileResolver, fileNames []string) *BridgeResult {
+	ctx := context.Background()
+	sys := newBridgeSystemWithResolver(resolver)
+
+	extendedConfigCache := collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]{}
+	config, configFileName, failure := loadBridgeConfig(sys, validateProjectPath, "", &extendedConfigCache)
+	if failure != nil {
+		return failure
+	}
+
+	host := compiler.NewCachedFSCompilerHost(sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), &extendedConfigCache)
+	// JSDoc is parsed in full for the @deprecated tags the deprecation suggestions come from
+	program := compiler.NewProgram(compiler.ProgramOptions{
+		Config:           config,
+		Host:             host,
+		JSDocParsingMode: ast.JSDocParsingModeParseAll,
+	})
+	result := collectBridgeResult(ctx, sys, program, configFileName, false)
+
+	var suggestions []*ast.Diagnostic
+	for _, fileName := range fileNames {
+		if sourceFile := program.GetSourceFile(fileName); sourceFile != nil {
+			suggestions = append(suggestions, program.GetSuggestionDiagnostics(ctx, sourceFile)...)
+		}
+	}
+	result.Diagnostics = append(result.Diagnostics, convertASTDiagnostics(suggestions)...)
+	return result
+}
+
+// validationResponse is the JSON returned by the validate functions.
+func validationResponse(result *BridgeResult, err error) string {
+	response := map[string]interface{}{
+		"success":     result != nil && result.Success,
+		"diagnostics": []map[string]interface{}{},
+	}
+
+	if err != nil {
+		response["success"] = false
+		response["error"] = err.Error()
+	} else if result != nil {
+		response["diagnostics"] = diagnosticsJson(result.Diagnostics)
+	}
+
+	jsonBytes, _ := json.Marshal(response)
+	return string(jsonBytes)
+}
+
+func diagnosticsJson(diagnostics []BridgeDiagnostic) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, diag := range diagnostics {
+		diagnostic := map[string]interface{}{
+			"code":     diag.Code,
+			"category": diag.Category,
+			"message":  diag.Message,
+			"file":     diag.File,
+			"line":     diag.Line,
+			"column":   diag.Column,
+			"length":   diag.Length,
+		}
+		if len(diag.RelatedInformation) > 0 {
+			diagnostic["relatedInformation"] = diagnosticsJson(diag.RelatedInformation)
+		}
+		if diag.ReportsUnnecessary {
+			diagnostic["reportsUnnecessary"] = true
+		}
+		if diag.ReportsDeprecated {
+			diagnostic["reportsDeprecated"] = true
+		}
+		result = append(result, diagnostic)
+	}
+	return result
+}
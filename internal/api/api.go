@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/go-json-experiment/json"
@@ -11,9 +13,11 @@ import (
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/astnav"
 	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
 	"github.com/microsoft/typescript-go/internal/project"
+	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs"
@@ -39,6 +43,16 @@ type API struct {
 	symbols   handleMap[ast.Symbol]
 	typesMu   sync.Mutex
 	types     handleMap[checker.Type]
+
+	signaturesMu sync.Mutex
+	signatures   handleMap[checker.Signature]
+
+	// openFiles are the files whose text was set by the client with OpenFile.
+	openFiles map[tspath.Path]struct{}
+	// invalidatedHandles are handles released because a file changed, so that using one reports why it
+	// no longer resolves instead of claiming it never existed.
+	invalidatedHandlesMu sync.Mutex
+	invalidatedHandles   map[string]struct{}
 }
 
 var _ project.ProjectHost = (*API)(nil)
@@ -51,6 +65,11 @@ func NewAPI(host APIHost, options APIOptions) *API {
 		files:    make(handleMap[ast.SourceFile]),
 		symbols:  make(handleMap[ast.Symbol]),
 		types:    make(handleMap[checker.Type]),
+
+		signatures: make(handleMap[checker.Signature]),
+
+		openFiles:          make(map[tspath.Path]struct{}),
+		invalidatedHandles: make(map[string]struct{}),
 	}
 
 	api.documentStore = project.NewDocumentStore(project.DocumentStoreOptions{
@@ -135,6 +154,9 @@ func (api *API) HandleRequest(ctx context.Context, method string, payload []byte
 	switch Method(method) {
 	case MethodRelease:
 		if id, ok := params.(*string); ok {
+			if api.forgetInvalidatedHandle(*id) {
+				return nil, nil
+			}
 			return nil, api.releaseHandle(*id)
 		} else {
 			return nil, fmt.Errorf("expected string for release handle, got %T", params)
@@ -174,6 +196,74 @@ func (api *API) HandleRequest(ctx context.Context, method string, payload []byte
 		return encodeJSON(core.TryMap(params.Symbols, func(symbol Handle[ast.Symbol]) (any, error) {
 			return api.GetTypeOfSymbol(ctx, params.Project, symbol)
 		}))
+	case MethodTypeToString:
+		params := params.(*TypeToStringParams)
+		return encodeJSON(api.TypeToString(ctx, params.Project, params.Type, params.EnclosingDeclaration, params.Flags))
+	case MethodGetPropertiesOfType:
+		params := params.(*GetPropertiesOfTypeParams)
+		return encodeJSON(api.GetPropertiesOfType(ctx, params.Project, params.Type))
+	case MethodGetSignaturesOfType:
+		params := params.(*GetSignaturesOfTypeParams)
+		return encodeJSON(api.GetSignaturesOfType(ctx, params.Project, params.Type, params.Kind))
+	case MethodGetReturnTypeOfSignature:
+		params := params.(*GetReturnTypeOfSignatureParams)
+		return encodeJSON(api.GetReturnTypeOfSignature(ctx, params.Project, params.Signature))
+	case MethodGetBaseTypes:
+		params := params.(*GetBaseTypesParams)
+		return encodeJSON(api.GetBaseTypes(ctx, params.Project, params.Type))
+	case MethodGetTypesOfType:
+		params := params.(*GetTypesOfTypeParams)
+		return encodeJSON(api.GetTypesOfType(params.Project, params.Type))
+	case MethodIsTypeAssignableTo:
+		params := params.(*IsTypeAssignableToParams)
+		return encodeJSON(api.IsTypeAssignableTo(ctx, params.Project, params.Source, params.Target))
+	case MethodGetDiagnostics:
+		params := params.(*GetDiagnosticsParams)
+		diagnostics, err := api.GetDiagnostics(ctx, params.Project, params.FileName, params.Kind)
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeDiagnostics(diagnostics)
+	case MethodEmit:
+		params := params.(*EmitParams)
+		output, err := api.Emit(ctx, params.Project, params.FileName, params.EmitOnlyDts)
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeEmitOutput(output)
+	case MethodGetEmitOutput:
+		params := params.(*GetEmitOutputParams)
+		output, err := api.GetEmitOutput(ctx, params.Project, params.FileName, params.EmitOnlyDts, params.ForceDtsEmit)
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeEmitOutput(output)
+	case MethodOpenFile:
+		params := params.(*OpenFileParams)
+		return nil, api.OpenFile(params.FileName, params.Text)
+	case MethodUpdateFile:
+		params := params.(*UpdateFileParams)
+		return nil, api.UpdateFile(params.FileName, params.Text, core.Map(params.Changes, func(change *TextChange) core.TextChange {
+			return core.TextChange{TextRange: core.NewTextRange(int(change.Start), int(change.End)), NewText: change.NewText}
+		}))
+	case MethodCloseFile:
+		return nil, api.CloseFile(params.(*CloseFileParams).FileName)
+	case MethodGetNodeAtPosition:
+		params := params.(*GetNodeAtPositionParams)
+		return encodeJSON(api.GetNodeAtPosition(params.Project, params.FileName, int(params.Position)))
+	case MethodGetTokenAtPosition:
+		params := params.(*GetTokenAtPositionParams)
+		return encodeJSON(api.GetTokenAtPosition(params.Project, params.FileName, int(params.Position)))
+	case MethodGetAncestors:
+		params := params.(*GetAncestorsParams)
+		return encodeJSON(api.GetAncestors(params.Project, params.Node))
+	case MethodFindNodesOfKind:
+		params := params.(*FindNodesOfKindParams)
+		end := -1
+		if params.End != nil {
+			end = int(*params.End)
+		}
+		return encodeJSON(api.FindNodesOfKind(params.Project, params.FileName, ast.Kind(params.Kind), int(params.Start), end))
 	default:
 		return nil, fmt.Errorf("unhandled API method %q", method)
 	}
@@ -231,11 +321,7 @@ func (api *API) GetSymbolAtPosition(ctx context.Context, projectId Handle[projec
 	if err != nil || symbol == nil {
 		return nil, err
 	}
-	data := NewSymbolResponse(symbol)
-	api.symbolsMu.Lock()
-	defer api.symbolsMu.Unlock()
-	api.symbols[data.Id] = symbol
-	return data, nil
+	return api.newSymbolResponse(symbol), nil
 }
 
 func (api *API) GetSymbolAtLocation(ctx context.Context, projectId Handle[project.Project], location Handle[ast.Node]) (*SymbolResponse, error) {
@@ -243,35 +329,17 @@ func (api *API) GetSymbolAtLocation(ctx context.Context, projectId Handle[projec
 	if !ok {
 		return nil, errors.New("project not found")
 	}
-	fileHandle, pos, kind, err := parseNodeHandle(location)
+	node, err := api.getNode(location)
 	if err != nil {
 		return nil, err
 	}
-	api.filesMu.Lock()
-	defer api.filesMu.Unlock()
-	sourceFile, ok := api.files[fileHandle]
-	if !ok {
-		return nil, fmt.Errorf("file %q not found", fileHandle)
-	}
-	token := astnav.GetTokenAtPosition(sourceFile, pos)
-	if token == nil {
-		return nil, fmt.Errorf("token not found at position %d in file %q", pos, sourceFile.FileName())
-	}
-	node := ast.FindAncestorKind(token, kind)
-	if node == nil {
-		return nil, fmt.Errorf("node of kind %s not found at position %d in file %q", kind.String(), pos, sourceFile.FileName())
-	}
 	languageService, done := project.GetLanguageServiceForRequest(ctx)
 	defer done()
 	symbol := languageService.GetSymbolAtLocation(ctx, node)
 	if symbol == nil {
 		return nil, nil
 	}
-	data := NewSymbolResponse(symbol)
-	api.symbolsMu.Lock()
-	defer api.symbolsMu.Unlock()
-	api.symbols[data.Id] = symbol
-	return data, nil
+	return api.newSymbolResponse(symbol), nil
 }
 
 func (api *API) GetTypeOfSymbol(ctx context.Context, projectId Handle[project.Project], symbolHandle Handle[ast.Symbol]) (*TypeResponse, error) {
@@ -280,10 +348,10 @@ func (api *API) GetTypeOfSymbol(ctx context.Context, projectId Handle[project.Pr
 		return nil, errors.New("project not found")
 	}
 	api.symbolsMu.Lock()
-	defer api.symbolsMu.Unlock()
 	symbol, ok := api.symbols[symbolHandle]
+	api.symbolsMu.Unlock()
 	if !ok {
-		return nil, fmt.Errorf("symbol %q not found", symbolHandle)
+		return nil, api.handleNotFoundError("symbol", string(symbolHandle))
 	}
 	languageService, done := project.GetLanguageServiceForRequest(ctx)
 	defer done()
@@ -291,7 +359,326 @@ func (api *API) GetTypeOfSymbol(ctx context.Context, projectId Handle[project.Pr
 	if t == nil {
 		return nil, nil
 	}
-	return NewTypeData(t), nil
+	return api.newTypeResponse(t), nil
+}
+
+func (api *API) TypeToString(ctx context.Context, projectId Handle[project.Project], typeHandle Handle[checker.Type], enclosingDeclaration Handle[ast.Node], flags *uint32) (string, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return "", errors.New("project not found")
+	}
+	t, err := api.getType(typeHandle)
+	if err != nil {
+		return "", err
+	}
+	var enclosingNode *ast.Node
+	if enclosingDeclaration != "" {
+		if enclosingNode, err = api.getNode(enclosingDeclaration); err != nil {
+			return "", err
+		}
+	}
+	var formatFlags *checker.TypeFormatFlags
+	if flags != nil {
+		f := checker.TypeFormatFlags(*flags)
+		formatFlags = &f
+	}
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return languageService.TypeToString(ctx, t, enclosingNode, formatFlags), nil
+}
+
+func (api *API) GetPropertiesOfType(ctx context.Context, projectId Handle[project.Project], typeHandle Handle[checker.Type]) ([]*SymbolResponse, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	t, err := api.getType(typeHandle)
+	if err != nil {
+		return nil, err
+	}
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return core.Map(languageService.GetPropertiesOfType(ctx, t), api.newSymbolResponse), nil
+}
+
+func (api *API) GetSignaturesOfType(ctx context.Context, projectId Handle[project.Project], typeHandle Handle[checker.Type], kind uint32) ([]*SignatureResponse, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	if kind != uint32(checker.SignatureKindCall) && kind != uint32(checker.SignatureKindConstruct) {
+		return nil, fmt.Errorf("invalid signature kind %d", kind)
+	}
+	t, err := api.getType(typeHandle)
+	if err != nil {
+		return nil, err
+	}
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return core.Map(languageService.GetSignaturesOfType(ctx, t, checker.SignatureKind(kind)), api.newSignatureResponse), nil
+}
+
+func (api *API) GetReturnTypeOfSignature(ctx context.Context, projectId Handle[project.Project], signatureHandle Handle[checker.Signature]) (*TypeResponse, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	api.signaturesMu.Lock()
+	signature, ok := api.signatures[signatureHandle]
+	api.signaturesMu.Unlock()
+	if !ok {
+		return nil, api.handleNotFoundError("signature", string(signatureHandle))
+	}
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return api.newTypeResponse(languageService.GetReturnTypeOfSignature(ctx, signature)), nil
+}
+
+func (api *API) GetBaseTypes(ctx context.Context, projectId Handle[project.Project], typeHandle Handle[checker.Type]) ([]*TypeResponse, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	t, err := api.getType(typeHandle)
+	if err != nil {
+		return nil, err
+	}
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return core.Map(languageService.GetBaseTypes(ctx, t), api.newTypeResponse), nil
+}
+
+// GetTypesOfType returns the constituents of a union or intersection type, or nil for any other type.
+func (api *API) GetTypesOfType(projectId Handle[project.Project], typeHandle Handle[checker.Type]) ([]*TypeResponse, error) {
+	if _, ok := api.projects[projectId]; !ok {
+		return nil, errors.New("project not found")
+	}
+	t, err := api.getType(typeHandle)
+	if err != nil {
+		return nil, err
+	}
+	if t.Flags()&checker.TypeFlagsUnionOrIntersection == 0 {
+		return nil, nil
+	}
+	return core.Map(t.Types(), api.newTypeResponse), nil
+}
+
+func (api *API) IsTypeAssignableTo(ctx context.Context, projectId Handle[project.Project], sourceHandle Handle[checker.Type], targetHandle Handle[checker.Type]) (bool, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return false, errors.New("project not found")
+	}
+	source, err := api.getType(sourceHandle)
+	if err != nil {
+		return false, err
+	}
+	target, err := api.getType(targetHandle)
+	if err != nil {
+		return false, err
+	}
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return languageService.IsTypeAssignableTo(ctx, source, target), nil
+}
+
+// GetDiagnostics returns the diagnostics of kind for fileName, or for every file in the project when
+// fileName is empty.
+func (api *API) GetDiagnostics(ctx context.Context, projectId Handle[project.Project], fileName string, kind DiagnosticKind) ([]*ast.Diagnostic, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	program := project.GetProgram()
+	var sourceFile *ast.SourceFile
+	if fileName != "" {
+		if sourceFile = program.GetSourceFile(fileName); sourceFile == nil {
+			return nil, fmt.Errorf("source file %q not found", fileName)
+		}
+	}
+	switch kind {
+	case DiagnosticKindAll:
+		return compiler.SortAndDeduplicateDiagnostics(compiler.GetDiagnosticsOfAnyProgram(
+			ctx,
+			program,
+			sourceFile,
+			false, /*skipNoEmitCheckForDtsDiagnostics*/
+			program.GetBindDiagnostics,
+			program.GetSemanticDiagnostics,
+		)), nil
+	case DiagnosticKindSyntactic:
+		return program.GetSyntacticDiagnostics(ctx, sourceFile), nil
+	case DiagnosticKindSemantic:
+		return program.GetSemanticDiagnostics(ctx, sourceFile), nil
+	case DiagnosticKindDeclaration:
+		return program.GetDeclarationDiagnostics(ctx, sourceFile), nil
+	default:
+		return nil, fmt.Errorf("invalid diagnostic kind %q", kind)
+	}
+}
+
+// Emit emits fileName, or the whole project when fileName is empty, and returns the output files
+// instead of writing them to disk.
+func (api *API) Emit(ctx context.Context, projectId Handle[project.Project], fileName string, emitOnlyDts bool) (*encoder.EmitOutput, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	program := project.GetProgram()
+	var sourceFile *ast.SourceFile
+	if fileName != "" {
+		if sourceFile = program.GetSourceFile(fileName); sourceFile == nil {
+			return nil, fmt.Errorf("source file %q not found", fileName)
+		}
+	}
+	output, _, err := emitToMemory(ctx, program, sourceFile, core.IfElse(emitOnlyDts, compiler.EmitOnlyDts, compiler.EmitAll))
+	return output, err
+}
+
+// GetEmitOutput emits a single file like Emit, additionally returning the source maps of its outputs.
+func (api *API) GetEmitOutput(ctx context.Context, projectId Handle[project.Project], fileName string, emitOnlyDts bool, forceDtsEmit bool) (*encoder.EmitOutput, error) {
+	project, ok := api.projects[projectId]
+	if !ok {
+		return nil, errors.New("project not found")
+	}
+	program := project.GetProgram()
+	sourceFile := program.GetSourceFile(fileName)
+	if sourceFile == nil {
+		return nil, fmt.Errorf("source file %q not found", fileName)
+	}
+	emitOnly := compiler.EmitAll
+	if forceDtsEmit {
+		emitOnly = compiler.EmitOnlyForcedDts
+	} else if emitOnlyDts {
+		emitOnly = compiler.EmitOnlyDts
+	}
+	output, result, err := emitToMemory(ctx, program, sourceFile, emitOnly)
+	if err != nil {
+		return nil, err
+	}
+	output.SourceMaps = core.Map(result.SourceMaps, func(sourceMap *compiler.SourceMapEmitResult) *encoder.SourceMap {
+		return &encoder.SourceMap{
+			GeneratedFile:        sourceMap.GeneratedFile,
+			InputSourceFileNames: sourceMap.InputSourceFileNames,
+			SourceMap:            sourceMap.SourceMap,
+		}
+	})
+	return output, nil
+}
+
+// emitToMemory emits sourceFile, or every file of program when it is nil, collecting the output files
+// sorted by name.
+func emitToMemory(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile, emitOnly compiler.EmitOnly) (*encoder.EmitOutput, *compiler.EmitResult, error) {
+	var outputFilesMu sync.Mutex
+	var outputFiles []*encoder.OutputFile
+	result := program.Emit(ctx, compiler.EmitOptions{
+		TargetSourceFile: sourceFile,
+		EmitOnly:         emitOnly,
+		WriteFile: func(fileName string, text string, writeByteOrderMark bool, data *compiler.WriteFileData) error {
+			outputFilesMu.Lock()
+			defer outputFilesMu.Unlock()
+			outputFiles = append(outputFiles, &encoder.OutputFile{
+				Name:               fileName,
+				Text:               text,
+				WriteByteOrderMark: writeByteOrderMark,
+			})
+			return nil
+		},
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	slices.SortFunc(outputFiles, func(a, b *encoder.OutputFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return encoder.NewEmitOutput(outputFiles, result.EmitSkipped, result.Diagnostics), result, nil
+}
+
+// GetNodeAtPosition returns the innermost node of the parse tree whose span, excluding leading trivia,
+// contains position. Punctuation is not part of the parse tree, so its parent is returned instead.
+func (api *API) GetNodeAtPosition(projectId Handle[project.Project], fileName string, position int) (*NodeResponse, error) {
+	sourceFile, err := api.getSourceFileForPosition(projectId, fileName, position)
+	if err != nil {
+		return nil, err
+	}
+	node := astnav.GetTouchingToken(sourceFile, position)
+	if node.Parent != nil && !isChildOf(node, node.Parent) {
+		node = node.Parent
+	}
+	return NewNodeResponse(node), nil
+}
+
+// GetTokenAtPosition returns the token whose span, including leading trivia, contains position. Unlike
+// GetNodeAtPosition, this may be a punctuation token constructed by the scanner.
+func (api *API) GetTokenAtPosition(projectId Handle[project.Project], fileName string, position int) (*NodeResponse, error) {
+	sourceFile, err := api.getSourceFileForPosition(projectId, fileName, position)
+	if err != nil {
+		return nil, err
+	}
+	return NewNodeResponse(astnav.GetTokenAtPosition(sourceFile, position)), nil
+}
+
+// GetAncestors returns the parents of a node, innermost first, ending with its source file.
+func (api *API) GetAncestors(projectId Handle[project.Project], nodeHandle Handle[ast.Node]) ([]*NodeResponse, error) {
+	if _, ok := api.projects[projectId]; !ok {
+		return nil, errors.New("project not found")
+	}
+	node, err := api.getNode(nodeHandle)
+	if err != nil {
+		return nil, err
+	}
+	var ancestors []*NodeResponse
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		ancestors = append(ancestors, NewNodeResponse(parent))
+	}
+	return ancestors, nil
+}
+
+// FindNodesOfKind returns the nodes of kind that lie within [start, end) in source order, ignoring
+// their leading trivia. A negative end stands for the end of the file.
+func (api *API) FindNodesOfKind(projectId Handle[project.Project], fileName string, kind ast.Kind, start int, end int) ([]*NodeResponse, error) {
+	sourceFile, err := api.GetSourceFile(projectId, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if end < 0 {
+		end = sourceFile.End()
+	}
+	if start > end || end > sourceFile.End() {
+		return nil, fmt.Errorf("invalid range [%d, %d) in file %q", start, end, fileName)
+	}
+	nodes := []*NodeResponse{}
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if node.End() <= start && node.Kind != ast.KindEndOfFile || node.Pos() >= end || node.Flags&ast.NodeFlagsReparsed != 0 {
+			return false
+		}
+		if node.Kind == kind && scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/) >= start && node.End() <= end {
+			nodes = append(nodes, NewNodeResponse(node))
+		}
+		node.ForEachChild(visit)
+		return false
+	}
+	visit(sourceFile.AsNode())
+	return nodes, nil
+}
+
+func (api *API) getSourceFileForPosition(projectId Handle[project.Project], fileName string, position int) (*ast.SourceFile, error) {
+	sourceFile, err := api.GetSourceFile(projectId, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 || position > sourceFile.End() {
+		return nil, fmt.Errorf("position %d is outside of file %q", position, fileName)
+	}
+	return sourceFile, nil
+}
+
+// isChildOf reports whether node is one of the children of parent in the parse tree, as opposed to a
+// token constructed by the scanner.
+func isChildOf(node *ast.Node, parent *ast.Node) bool {
+	return parent.ForEachChild(func(child *ast.Node) bool {
+		return child == node
+	})
 }
 
 func (api *API) GetSourceFile(projectId Handle[project.Project], fileName string) (*ast.SourceFile, error) {
@@ -303,12 +690,207 @@ func (api *API) GetSourceFile(projectId Handle[project.Project], fileName string
 	if sourceFile == nil {
 		return nil, fmt.Errorf("source file %q not found", fileName)
 	}
+	fileHandle := FileHandle(sourceFile)
+	api.revalidateHandle(string(fileHandle))
 	api.filesMu.Lock()
 	defer api.filesMu.Unlock()
-	api.files[FileHandle(sourceFile)] = sourceFile
+	api.files[fileHandle] = sourceFile
 	return sourceFile, nil
 }
 
+// OpenFile sets the text of fileName to text, or to its text on disk when text is nil, until it is
+// closed with CloseFile. Projects containing the file see the new text on their next request, reusing
+// the rest of their previous program.
+func (api *API) OpenFile(fileName string, text *string) error {
+	fileName = api.toAbsoluteFileName(fileName)
+	path := api.toPath(fileName)
+	if text == nil {
+		content, ok := api.host.FS().ReadFile(fileName)
+		if !ok {
+			return fmt.Errorf("could not read file %q", fileName)
+		}
+		text = &content
+	}
+	var version int
+	if info := api.documentStore.GetScriptInfoByPath(path); info != nil {
+		version = info.Version()
+	}
+	info := api.documentStore.GetOrCreateOpenScriptInfo(fileName, path, *text, core.GetScriptKindFromFileName(fileName), api.host.FS())
+	api.openFiles[path] = struct{}{}
+	if info.Version() != version && len(info.ContainingProjects()) != 0 {
+		api.invalidateHandles()
+	}
+	return nil
+}
+
+// UpdateFile replaces the text of an open file with text, or applies changes to it in order when text
+// is nil. The positions of each change are UTF-8 byte offsets into the text after the previous changes.
+func (api *API) UpdateFile(fileName string, text *string, changes []core.TextChange) error {
+	info, err := api.getOpenScriptInfo(fileName)
+	if err != nil {
+		return err
+	}
+	if text != nil {
+		changes = []core.TextChange{{TextRange: core.NewTextRange(0, len(info.Text())), NewText: *text}}
+	}
+	length := len(info.Text())
+	for _, change := range changes {
+		if change.Pos() < 0 || change.Pos() > change.End() || change.End() > length {
+			return fmt.Errorf("invalid change [%d, %d) to file %q of length %d", change.Pos(), change.End(), fileName, length)
+		}
+		length += len(change.NewText) - change.Len()
+	}
+	api.documentStore.ChangeScriptInfo(info, changes)
+	if len(info.ContainingProjects()) != 0 {
+		api.invalidateHandles()
+	}
+	return nil
+}
+
+// CloseFile closes a file opened with OpenFile, reverting it to its text on disk.
+func (api *API) CloseFile(fileName string) error {
+	info, err := api.getOpenScriptInfo(fileName)
+	if err != nil {
+		return err
+	}
+	delete(api.openFiles, info.Path())
+	if content, ok := api.host.FS().ReadFile(info.FileName()); ok && content != info.Text() && len(info.ContainingProjects()) != 0 {
+		api.invalidateHandles()
+	}
+	api.documentStore.CloseScriptInfo(info)
+	return nil
+}
+
+func (api *API) getOpenScriptInfo(fileName string) (*project.ScriptInfo, error) {
+	path := api.toPath(api.toAbsoluteFileName(fileName))
+	if _, ok := api.openFiles[path]; !ok {
+		return nil, fmt.Errorf("file %q is not open", fileName)
+	}
+	info := api.documentStore.GetScriptInfoByPath(path)
+	if info == nil {
+		return nil, fmt.Errorf("file %q not found", fileName)
+	}
+	return info, nil
+}
+
+// invalidateHandles releases every file, symbol, type and signature handle, since they may refer to
+// nodes or checker state of programs that are about to be replaced. Node handles are invalidated along
+// with their files.
+func (api *API) invalidateHandles() {
+	var handles []string
+	handles = appendAndClearHandles(&api.filesMu, api.files, handles)
+	handles = appendAndClearHandles(&api.symbolsMu, api.symbols, handles)
+	handles = appendAndClearHandles(&api.typesMu, api.types, handles)
+	handles = appendAndClearHandles(&api.signaturesMu, api.signatures, handles)
+	api.invalidatedHandlesMu.Lock()
+	defer api.invalidatedHandlesMu.Unlock()
+	for _, handle := range handles {
+		api.invalidatedHandles[handle] = struct{}{}
+	}
+}
+
+func appendAndClearHandles[T any](mu *sync.Mutex, handles handleMap[T], result []string) []string {
+	mu.Lock()
+	defer mu.Unlock()
+	for handle := range handles {
+		result = append(result, string(handle))
+	}
+	clear(handles)
+	return result
+}
+
+// revalidateHandle is called when a handle is handed out again, e.g. for a symbol of a file that did
+// not change.
+func (api *API) revalidateHandle(handle string) {
+	api.invalidatedHandlesMu.Lock()
+	defer api.invalidatedHandlesMu.Unlock()
+	delete(api.invalidatedHandles, handle)
+}
+
+// forgetInvalidatedHandle reports whether handle was invalidated, so that releasing it succeeds.
+func (api *API) forgetInvalidatedHandle(handle string) bool {
+	api.invalidatedHandlesMu.Lock()
+	defer api.invalidatedHandlesMu.Unlock()
+	if _, ok := api.invalidatedHandles[handle]; ok {
+		delete(api.invalidatedHandles, handle)
+		return true
+	}
+	return false
+}
+
+func (api *API) handleNotFoundError(kind string, handle string) error {
+	api.invalidatedHandlesMu.Lock()
+	defer api.invalidatedHandlesMu.Unlock()
+	if _, ok := api.invalidatedHandles[handle]; ok {
+		return fmt.Errorf("%s %q is no longer valid because a file was updated", kind, handle)
+	}
+	return fmt.Errorf("%s %q not found", kind, handle)
+}
+
+// getNode returns the node a handle created by NodeHandle refers to. The file containing the node
+// must have been retrieved with GetSourceFile.
+func (api *API) getNode(handle Handle[ast.Node]) (*ast.Node, error) {
+	fileHandle, pos, kind, err := parseNodeHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	api.filesMu.Lock()
+	defer api.filesMu.Unlock()
+	sourceFile, ok := api.files[fileHandle]
+	if !ok {
+		return nil, api.handleNotFoundError("file", string(fileHandle))
+	}
+	token := astnav.GetTokenAtPosition(sourceFile, pos)
+	if token == nil {
+		return nil, fmt.Errorf("token not found at position %d in file %q", pos, sourceFile.FileName())
+	}
+	node := ast.FindAncestorKind(token, kind)
+	if node == nil {
+		return nil, fmt.Errorf("node of kind %s not found at position %d in file %q", kind.String(), pos, sourceFile.FileName())
+	}
+	return node, nil
+}
+
+func (api *API) getType(handle Handle[checker.Type]) (*checker.Type, error) {
+	api.typesMu.Lock()
+	defer api.typesMu.Unlock()
+	t, ok := api.types[handle]
+	if !ok {
+		return nil, api.handleNotFoundError("type", string(handle))
+	}
+	return t, nil
+}
+
+func (api *API) newSymbolResponse(symbol *ast.Symbol) *SymbolResponse {
+	data := NewSymbolResponse(symbol)
+	api.revalidateHandle(string(data.Id))
+	api.symbolsMu.Lock()
+	defer api.symbolsMu.Unlock()
+	api.symbols[data.Id] = symbol
+	return data
+}
+
+func (api *API) newTypeResponse(t *checker.Type) *TypeResponse {
+	data := NewTypeData(t)
+	api.revalidateHandle(string(data.Id))
+	api.typesMu.Lock()
+	defer api.typesMu.Unlock()
+	api.types[data.Id] = t
+	return data
+}
+
+func (api *API) newSignatureResponse(signature *checker.Signature) *SignatureResponse {
+	data := &SignatureResponse{
+		Id:         SignatureHandle(signature),
+		Parameters: core.Map(signature.Parameters(), api.newSymbolResponse),
+	}
+	api.revalidateHandle(string(data.Id))
+	api.signaturesMu.Lock()
+	defer api.signaturesMu.Unlock()
+	api.signatures[data.Id] = signature
+	return data
+}
+
 func (api *API) releaseHandle(handle string) error {
 	switch handle[0] {
 	case handlePrefixProject:
@@ -346,6 +928,15 @@ func (api *API) releaseHandle(handle string) error {
 			return fmt.Errorf("type %q not found", handle)
 		}
 		delete(api.types, typeId)
+	case handlePrefixSignature:
+		signatureId := Handle[checker.Signature](handle)
+		api.signaturesMu.Lock()
+		defer api.signaturesMu.Unlock()
+		_, ok := api.signatures[signatureId]
+		if !ok {
+			return fmt.Errorf("signature %q not found", handle)
+		}
+		delete(api.signatures, signatureId)
 	default:
 		return fmt.Errorf("unhandled handle type %q", handle[0])
 	}
@@ -0,0 +1,51 @@
+package encoder
+
+import (
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
+)
+
+// Diagnostic is the wire format of an ast.Diagnostic. Start and Length are UTF-8 byte offsets into
+// the file, matching the positions accepted by the rest of the API. Category uses the numbering of
+// diagnostics.Category, which matches TypeScript's DiagnosticCategory.
+type Diagnostic struct {
+	FileName           string        `json:"fileName,omitzero"`
+	Start              int           `json:"start"`
+	Length             int           `json:"length"`
+	Code               int32         `json:"code"`
+	Category           int32         `json:"category"`
+	Text               string        `json:"text"`
+	RelatedInformation []*Diagnostic `json:"relatedInformation,omitzero"`
+}
+
+func NewDiagnostic(diagnostic *ast.Diagnostic) *Diagnostic {
+	result := &Diagnostic{
+		Start:    diagnostic.Pos(),
+		Length:   diagnostic.Len(),
+		Code:     diagnostic.Code(),
+		Category: int32(diagnostic.Category()),
+		Text:     diagnosticwriter.FlattenDiagnosticMessage(diagnostic, "\n"),
+	}
+	if file := diagnostic.File(); file != nil {
+		result.FileName = file.FileName()
+	}
+	if related := diagnostic.RelatedInformation(); len(related) != 0 {
+		result.RelatedInformation = NewDiagnostics(related)
+	}
+	return result
+}
+
+func NewDiagnostics(diagnostics []*ast.Diagnostic) []*Diagnostic {
+	result := make([]*Diagnostic, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		result[i] = NewDiagnostic(diagnostic)
+	}
+	return result
+}
+
+// EncodeDiagnostics encodes diagnostics as a JSON array of Diagnostic. Unlike source files, diagnostics
+// are small and carry no tree structure, so they do not use the binary format.
+func EncodeDiagnostics(diagnostics []*ast.Diagnostic) ([]byte, error) {
+	return json.Marshal(NewDiagnostics(diagnostics))
+}
@@ -0,0 +1,44 @@
+package encoder
+
+import (
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/sourcemap"
+)
+
+// OutputFile is a file produced by emit, returned to the client instead of being written to disk.
+type OutputFile struct {
+	Name               string `json:"name"`
+	Text               string `json:"text"`
+	WriteByteOrderMark bool   `json:"writeByteOrderMark,omitzero"`
+}
+
+// SourceMap is the decoded source map of a generated file, with the names of the input files it maps
+// back to in the same order as its sources.
+type SourceMap struct {
+	GeneratedFile        string                  `json:"generatedFile"`
+	InputSourceFileNames []string                `json:"inputSourceFileNames"`
+	SourceMap            *sourcemap.RawSourceMap `json:"sourceMap"`
+}
+
+type EmitOutput struct {
+	OutputFiles []*OutputFile `json:"outputFiles"`
+	EmitSkipped bool          `json:"emitSkipped"`
+	Diagnostics []*Diagnostic `json:"diagnostics"`
+	// SourceMaps is only populated for single file emit, and only when the compiler options enable
+	// source maps or declaration maps.
+	SourceMaps []*SourceMap `json:"sourceMaps,omitzero"`
+}
+
+func NewEmitOutput(outputFiles []*OutputFile, emitSkipped bool, diagnostics []*ast.Diagnostic) *EmitOutput {
+	return &EmitOutput{
+		OutputFiles: outputFiles,
+		EmitSkipped: emitSkipped,
+		Diagnostics: NewDiagnostics(diagnostics),
+	}
+}
+
+// EncodeEmitOutput encodes the result of an emit as a JSON EmitOutput.
+func EncodeEmitOutput(output *EmitOutput) ([]byte, error) {
+	return json.Marshal(output)
+}
@@ -35,6 +35,17 @@ func TestEncodeSourceFile(t *testing.T) {
 	})
 }
 
+func TestEncodeDiagnostics(t *testing.T) {
+	t.Parallel()
+	sourceFile := parser.ParseSourceFile(ast.SourceFileParseOptions{
+		FileName: "/test.ts",
+		Path:     "/test.ts",
+	}, "let x = ;", core.ScriptKindTS)
+	buf, err := encoder.EncodeDiagnostics(sourceFile.Diagnostics())
+	assert.NilError(t, err)
+	assert.Equal(t, string(buf), `[{"fileName":"/test.ts","start":8,"length":1,"code":1109,"category":1,"text":"Expression expected."}]`)
+}
+
 func BenchmarkEncodeSourceFile(b *testing.B) {
 	repo.SkipIfNoTypeScriptSubmodule(b)
 	filePath := filepath.Join(repo.TypeScriptSubmodulePath, "src/compiler/checker.ts")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unsafe"
 
 	"github.com/go-json-experiment/json"
 	"github.com/go-json-experiment/json/jsontext"
@@ -24,11 +25,12 @@ type Method string
 type Handle[T any] string
 
 const (
-	handlePrefixProject = 'p'
-	handlePrefixSymbol  = 's'
-	handlePrefixType    = 't'
-	handlePrefixFile    = 'f'
-	handlePrefixNode    = 'n'
+	handlePrefixProject   = 'p'
+	handlePrefixSymbol    = 's'
+	handlePrefixType      = 't'
+	handlePrefixFile      = 'f'
+	handlePrefixNode      = 'n'
+	handlePrefixSignature = 'g'
 )
 
 func ProjectHandle(p *project.Project) Handle[project.Project] {
@@ -43,6 +45,12 @@ func TypeHandle(t *checker.Type) Handle[checker.Type] {
 	return createHandle[checker.Type](handlePrefixType, t.Id())
 }
 
+// SignatureHandle identifies a signature by its address, since signatures have no id.
+// The address stays valid for as long as the API holds on to the signature.
+func SignatureHandle(signature *checker.Signature) Handle[checker.Signature] {
+	return createHandle[checker.Signature](handlePrefixSignature, uintptr(unsafe.Pointer(signature)))
+}
+
 func FileHandle(file *ast.SourceFile) Handle[ast.SourceFile] {
 	return createHandle[ast.SourceFile](handlePrefixFile, ast.GetNodeId(file.AsNode()))
 }
@@ -87,6 +95,27 @@ const (
 	MethodGetTypeOfSymbol       Method = "getTypeOfSymbol"
 	MethodGetTypesOfSymbols     Method = "getTypesOfSymbols"
 	MethodGetSourceFile         Method = "getSourceFile"
+
+	MethodTypeToString             Method = "typeToString"
+	MethodGetPropertiesOfType      Method = "getPropertiesOfType"
+	MethodGetSignaturesOfType      Method = "getSignaturesOfType"
+	MethodGetReturnTypeOfSignature Method = "getReturnTypeOfSignature"
+	MethodGetBaseTypes             Method = "getBaseTypes"
+	MethodGetTypesOfType           Method = "getTypesOfType"
+	MethodIsTypeAssignableTo       Method = "isTypeAssignableTo"
+
+	MethodGetDiagnostics Method = "getDiagnostics"
+	MethodEmit           Method = "emit"
+	MethodGetEmitOutput  Method = "getEmitOutput"
+
+	MethodGetNodeAtPosition  Method = "getNodeAtPosition"
+	MethodGetTokenAtPosition Method = "getTokenAtPosition"
+	MethodGetAncestors       Method = "getAncestors"
+	MethodFindNodesOfKind    Method = "findNodesOfKind"
+
+	MethodOpenFile   Method = "openFile"
+	MethodUpdateFile Method = "updateFile"
+	MethodCloseFile  Method = "closeFile"
 )
 
 var unmarshalers = map[Method]func([]byte) (any, error){
@@ -100,6 +129,27 @@ var unmarshalers = map[Method]func([]byte) (any, error){
 	MethodGetSymbolsAtLocations: unmarshallerFor[GetSymbolsAtLocationsParams],
 	MethodGetTypeOfSymbol:       unmarshallerFor[GetTypeOfSymbolParams],
 	MethodGetTypesOfSymbols:     unmarshallerFor[GetTypesOfSymbolsParams],
+
+	MethodTypeToString:             unmarshallerFor[TypeToStringParams],
+	MethodGetPropertiesOfType:      unmarshallerFor[GetPropertiesOfTypeParams],
+	MethodGetSignaturesOfType:      unmarshallerFor[GetSignaturesOfTypeParams],
+	MethodGetReturnTypeOfSignature: unmarshallerFor[GetReturnTypeOfSignatureParams],
+	MethodGetBaseTypes:             unmarshallerFor[GetBaseTypesParams],
+	MethodGetTypesOfType:           unmarshallerFor[GetTypesOfTypeParams],
+	MethodIsTypeAssignableTo:       unmarshallerFor[IsTypeAssignableToParams],
+
+	MethodGetDiagnostics: unmarshallerFor[GetDiagnosticsParams],
+	MethodEmit:           unmarshallerFor[EmitParams],
+	MethodGetEmitOutput:  unmarshallerFor[GetEmitOutputParams],
+
+	MethodGetNodeAtPosition:  unmarshallerFor[GetNodeAtPositionParams],
+	MethodGetTokenAtPosition: unmarshallerFor[GetTokenAtPositionParams],
+	MethodGetAncestors:       unmarshallerFor[GetAncestorsParams],
+	MethodFindNodesOfKind:    unmarshallerFor[FindNodesOfKindParams],
+
+	MethodOpenFile:   unmarshallerFor[OpenFileParams],
+	MethodUpdateFile: unmarshallerFor[UpdateFileParams],
+	MethodCloseFile:  unmarshallerFor[CloseFileParams],
 }
 
 type ConfigureParams struct {
@@ -196,6 +246,152 @@ func NewTypeData(t *checker.Type) *TypeResponse {
 	}
 }
 
+type TypeToStringParams struct {
+	Project              Handle[project.Project] `json:"project"`
+	Type                 Handle[checker.Type]    `json:"type"`
+	EnclosingDeclaration Handle[ast.Node]        `json:"enclosingDeclaration"`
+	// Flags are checker.TypeFormatFlags; the checker's defaults are used when omitted.
+	Flags *uint32 `json:"flags"`
+}
+
+type GetPropertiesOfTypeParams struct {
+	Project Handle[project.Project] `json:"project"`
+	Type    Handle[checker.Type]    `json:"type"`
+}
+
+type GetSignaturesOfTypeParams struct {
+	Project Handle[project.Project] `json:"project"`
+	Type    Handle[checker.Type]    `json:"type"`
+	// Kind is 0 for call signatures and 1 for construct signatures.
+	Kind uint32 `json:"kind"`
+}
+
+type GetReturnTypeOfSignatureParams struct {
+	Project   Handle[project.Project]   `json:"project"`
+	Signature Handle[checker.Signature] `json:"signature"`
+}
+
+type GetBaseTypesParams struct {
+	Project Handle[project.Project] `json:"project"`
+	Type    Handle[checker.Type]    `json:"type"`
+}
+
+type GetTypesOfTypeParams struct {
+	Project Handle[project.Project] `json:"project"`
+	Type    Handle[checker.Type]    `json:"type"`
+}
+
+type IsTypeAssignableToParams struct {
+	Project Handle[project.Project] `json:"project"`
+	Source  Handle[checker.Type]    `json:"source"`
+	Target  Handle[checker.Type]    `json:"target"`
+}
+
+type SignatureResponse struct {
+	Id         Handle[checker.Signature] `json:"id"`
+	Parameters []*SymbolResponse         `json:"parameters"`
+}
+
+type DiagnosticKind string
+
+const (
+	// DiagnosticKindAll returns the diagnostics tsc would report, in the same order.
+	DiagnosticKindAll         DiagnosticKind = ""
+	DiagnosticKindSyntactic   DiagnosticKind = "syntactic"
+	DiagnosticKindSemantic    DiagnosticKind = "semantic"
+	DiagnosticKindDeclaration DiagnosticKind = "declaration"
+)
+
+type GetDiagnosticsParams struct {
+	Project Handle[project.Project] `json:"project"`
+	// FileName limits the diagnostics to a single file. Diagnostics for the whole project are returned when omitted.
+	FileName string         `json:"fileName"`
+	Kind     DiagnosticKind `json:"kind"`
+}
+
+type EmitParams struct {
+	Project Handle[project.Project] `json:"project"`
+	// FileName limits the emit to a single file. The whole project is emitted when omitted.
+	FileName    string `json:"fileName"`
+	EmitOnlyDts bool   `json:"emitOnlyDts"`
+}
+
+type GetEmitOutputParams struct {
+	Project     Handle[project.Project] `json:"project"`
+	FileName    string                  `json:"fileName"`
+	EmitOnlyDts bool                    `json:"emitOnlyDts"`
+	// ForceDtsEmit produces declarations even when the compiler options do not enable them.
+	ForceDtsEmit bool `json:"forceDtsEmit"`
+}
+
+type GetNodeAtPositionParams struct {
+	Project  Handle[project.Project] `json:"project"`
+	FileName string                  `json:"fileName"`
+	Position uint32                  `json:"position"`
+}
+
+type GetTokenAtPositionParams struct {
+	Project  Handle[project.Project] `json:"project"`
+	FileName string                  `json:"fileName"`
+	Position uint32                  `json:"position"`
+}
+
+type GetAncestorsParams struct {
+	Project Handle[project.Project] `json:"project"`
+	Node    Handle[ast.Node]        `json:"node"`
+}
+
+type FindNodesOfKindParams struct {
+	Project  Handle[project.Project] `json:"project"`
+	FileName string                  `json:"fileName"`
+	Kind     uint32                  `json:"kind"`
+	Start    uint32                  `json:"start"`
+	// End defaults to the end of the file.
+	End *uint32 `json:"end"`
+}
+
+// NodeResponse describes a node without its children. Its Id can be passed anywhere a node handle is
+// expected. Nodes of the same kind starting at the same position share an Id, which refers to the
+// innermost of them.
+type NodeResponse struct {
+	Id   Handle[ast.Node] `json:"id"`
+	Kind uint32           `json:"kind"`
+	Pos  uint32           `json:"pos"`
+	End  uint32           `json:"end"`
+}
+
+func NewNodeResponse(node *ast.Node) *NodeResponse {
+	return &NodeResponse{
+		Id:   NodeHandle(node),
+		Kind: uint32(node.Kind),
+		Pos:  uint32(node.Pos()),
+		End:  uint32(node.End()),
+	}
+}
+
+type OpenFileParams struct {
+	FileName string `json:"fileName"`
+	// Text defaults to the text of the file on disk.
+	Text *string `json:"text"`
+}
+
+type UpdateFileParams struct {
+	FileName string `json:"fileName"`
+	// Text replaces the whole file. Changes are applied in order when it is omitted.
+	Text    *string       `json:"text"`
+	Changes []*TextChange `json:"changes"`
+}
+
+type TextChange struct {
+	Start   uint32 `json:"start"`
+	End     uint32 `json:"end"`
+	NewText string `json:"newText"`
+}
+
+type CloseFileParams struct {
+	FileName string `json:"fileName"`
+}
+
 type GetSourceFileParams struct {
 	Project  Handle[project.Project] `json:"project"`
 	FileName string                  `json:"fileName"`
@@ -96,7 +96,7 @@ func propagateObjectBindingElementSubtreeFacts(child *BindingElementNode) Subtre
 	facts := propagateSubtreeFacts(child)
 	if facts&SubtreeContainsRest != 0 {
 		facts &= ^SubtreeContainsRest
-		facts |= SubtreeContainsObjectRestOrSpread
+		facts |= SubtreeContainsESObjectRestOrSpread | SubtreeContainsObjectRestOrSpread
 	}
 	return facts
 }
@@ -150,3 +150,19 @@ func (c *Checker) GetIndexSignaturesAtLocation(node *ast.Node) []*ast.Node {
 func (c *Checker) GetResolvedSymbol(node *ast.Node) *ast.Symbol {
 	return c.getResolvedSymbol(node)
 }
+
+func HasContextSensitiveParameters(node *ast.Node) bool {
+	return hasContextSensitiveParameters(node)
+}
+
+func (c *Checker) IsTypeAssignableTo(source *Type, target *Type) bool {
+	return c.isTypeAssignableTo(source, target)
+}
+
+// GetBaseTypes returns the base types of a class or interface type, or nil for any other type.
+func (c *Checker) GetBaseTypes(t *Type) []*Type {
+	if t.objectFlags&ObjectFlagsClassOrInterface == 0 {
+		return nil
+	}
+	return c.getBaseTypes(t)
+}
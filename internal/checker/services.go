@@ -411,6 +411,10 @@ func (c *Checker) GetExportSymbolOfSymbol(symbol *ast.Symbol) *ast.Symbol {
 	return c.getMergedSymbol(core.IfElse(symbol.ExportSymbol != nil, symbol.ExportSymbol, symbol))
 }
 
+func (c *Checker) GetImmediateAliasedSymbol(symbol *ast.Symbol) *ast.Symbol {
+	return c.getImmediateAliasedSymbol(symbol)
+}
+
 func (c *Checker) GetExportSpecifierLocalTargetSymbol(node *ast.Node) *ast.Symbol {
 	// node should be ExportSpecifier | Identifier
 	switch node.Kind {
@@ -426,6 +430,7 @@ func (c *Checker) GetExportSpecifierLocalTargetSymbol(node *ast.Node) *ast.Symbo
 			// Skip for invalid syntax like this: export { "x" }
 			return nil
 		}
+		node = name
 	case ast.KindIdentifier:
 		// do nothing (don't panic)
 	default:
@@ -683,3 +688,74 @@ func (c *Checker) GetFirstTypeArgumentFromKnownType(t *Type) *Type {
 	}
 	return nil
 }
+
+func (c *Checker) GetSuggestedSymbolForNonexistentProperty(name *ast.Node, containingType *Type) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentProperty(name, containingType)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentSymbol(location *ast.Node, name string, meaning ast.SymbolFlags) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentSymbol(location, name, meaning)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentModule(name *ast.Node, targetModule *ast.Symbol) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentModule(name, targetModule)
+}
+
+func (c *Checker) GetBaseTypeOfLiteralType(t *Type) *Type {
+	return c.getBaseTypeOfLiteralType(t)
+}
+
+func (c *Checker) GetWidenedType(t *Type) *Type {
+	return c.getWidenedType(t)
+}
+
+func (c *Checker) GetSignatureFromDeclaration(declaration *ast.Node) *Signature {
+	return c.getSignatureFromDeclaration(declaration)
+}
+
+// GetParameterIdentifierInfoAtPosition returns the name of the parameter the argument at position pos of a call to
+// signature is bound to, and whether that argument starts a rest parameter. The returned name node is nil when the
+// parameter has no identifier name.
+func (c *Checker) GetParameterIdentifierInfoAtPosition(signature *Signature, pos int) (parameter *ast.Node, parameterName string, isRestParameter bool) {
+	paramCount := len(signature.parameters)
+	if signatureHasRestParameter(signature) {
+		paramCount--
+	}
+	if pos < paramCount {
+		param := signature.parameters[pos]
+		if paramIdent := getParameterDeclarationIdentifier(param); paramIdent != nil {
+			return paramIdent, param.Name, false
+		}
+		return nil, "", false
+	}
+	restParameter := c.unknownSymbol
+	if paramCount < len(signature.parameters) {
+		restParameter = signature.parameters[paramCount]
+	}
+	restIdent := getParameterDeclarationIdentifier(restParameter)
+	if restIdent == nil {
+		return nil, "", false
+	}
+	restType := c.getTypeOfSymbol(restParameter)
+	if isTupleType(restType) {
+		elementInfos := restType.TargetTupleType().elementInfos
+		index := pos - paramCount
+		if index < len(elementInfos) {
+			if associatedName := elementInfos[index].labeledDeclaration; associatedName != nil && ast.IsIdentifier(associatedName.Name()) {
+				return associatedName.Name(), associatedName.Name().Text(), hasDotDotDotToken(associatedName)
+			}
+		}
+		return nil, "", false
+	}
+	if pos == paramCount {
+		return restIdent, restParameter.Name, true
+	}
+	return nil, "", false
+}
+
+func getParameterDeclarationIdentifier(symbol *ast.Symbol) *ast.Node {
+	if symbol.ValueDeclaration != nil && ast.IsParameter(symbol.ValueDeclaration) && ast.IsIdentifier(symbol.ValueDeclaration.Name()) {
+		return symbol.ValueDeclaration.Name()
+	}
+	return nil
+}
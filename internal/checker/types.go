@@ -1192,6 +1192,8 @@ type TypePredicate struct {
 	t              *Type
 }
 
+func (p *TypePredicate) Type() *Type { return p.t }
+
 // IndexInfo
 
 type IndexInfo struct {
@@ -72,6 +72,9 @@ func getModuleTransformer(emitContext *printer.EmitContext, options *core.Compil
 		core.ModuleKindCommonJS:
 		return moduletransforms.NewImpliedModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
 
+	case core.ModuleKindSystem:
+		return moduletransforms.NewSystemModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
+
 	default:
 		return moduletransforms.NewCommonJSModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
 	}
@@ -86,7 +89,7 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 
 	var emitResolver printer.EmitResolver
 	var referenceResolver binder.ReferenceResolver
-	if importElisionEnabled || options.GetJSXTransformEnabled() {
+	if importElisionEnabled || options.GetJSXTransformEnabled() || options.ExperimentalDecorators.IsTrue() {
 		emitResolver = host.GetEmitResolver()
 		emitResolver.MarkLinkedReferencesRecursively(sourceFile)
 		referenceResolver = emitResolver
@@ -108,12 +111,16 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 		tx = append(tx, tstransforms.NewRuntimeSyntaxTransformer(emitContext, options, referenceResolver))
 	}
 
-	// !!! transform legacy decorator syntax
+	// transform legacy decorator syntax
+	if options.ExperimentalDecorators.IsTrue() {
+		tx = append(tx, tstransforms.NewLegacyDecoratorsTransformer(emitContext, options, emitResolver))
+	}
+
 	if options.GetJSXTransformEnabled() {
 		tx = append(tx, jsxtransforms.NewJSXTransformer(emitContext, options, emitResolver))
 	}
 
-	downleveler := estransforms.GetESTransformer(options, emitContext)
+	downleveler := estransforms.GetESTransformer(&transformers.TransformOptions{Context: emitContext, CompilerOptions: options})
 	if downleveler != nil {
 		tx = append(tx, downleveler)
 	}
@@ -251,7 +258,12 @@ func (e *emitter) printSourceFile(jsFilePath string, sourceMapFilePath string, s
 		// Write the source map
 		if len(sourceMapFilePath) > 0 {
 			sourceMap := sourceMapGenerator.String()
-			err := e.host.WriteFile(sourceMapFilePath, sourceMap, false /*writeByteOrderMark*/)
+			var err error
+			if e.writeFile == nil {
+				err = e.host.WriteFile(sourceMapFilePath, sourceMap, false /*writeByteOrderMark*/)
+			} else {
+				err = e.writeFile(sourceMapFilePath, sourceMap, false /*writeByteOrderMark*/, &WriteFileData{})
+			}
 			if err != nil {
 				e.emitterDiagnostics.Add(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, jsFilePath, err.Error()))
 			} else if e.emitResult.EmittedFiles != nil {
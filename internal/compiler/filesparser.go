@@ -48,6 +48,9 @@ func (t *parseTask) Path() tspath.Path {
 func (t *parseTask) load(loader *fileLoader) {
 	t.loaded = true
 	t.path = loader.toPath(t.normalizedFilePath)
+	if ctx := loader.opts.Context; ctx != nil && ctx.Err() != nil {
+		return
+	}
 	if t.isForAutomaticTypeDirective {
 		t.loadAutomaticTypeDirectives(loader)
 		return
@@ -35,6 +35,9 @@ type ProgramOptions struct {
 	TypingsLocation             string
 	ProjectName                 string
 	JSDocParsingMode            ast.JSDocParsingMode
+	// Context cancels program creation. Once it is done, no more files are loaded, and the
+	// program is incomplete and should be discarded.
+	Context context.Context
 }
 
 func (p *ProgramOptions) canUseProjectReferenceSource() bool {
@@ -500,16 +503,6 @@ func (p *Program) verifyCompilerOptions() {
 	// 	createRemovedOptionDiagnostic("target", "ES5", "")
 	// }
 
-	if options.Module == core.ModuleKindAMD {
-		createRemovedOptionDiagnostic("module", "AMD", "")
-	}
-	if options.Module == core.ModuleKindSystem {
-		createRemovedOptionDiagnostic("module", "System", "")
-	}
-	if options.Module == core.ModuleKindUMD {
-		createRemovedOptionDiagnostic("module", "UMD", "")
-	}
-
 	if options.StrictPropertyInitialization.IsTrue() && !getStrictOptionValue(options.StrictNullChecks) {
 		createDiagnosticForOptionName(diagnostics.Option_0_cannot_be_specified_without_specifying_option_1, "strictPropertyInitialization", "strictNullChecks")
 	}
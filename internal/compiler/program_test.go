@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"context"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -253,6 +254,34 @@ func TestProgram(t *testing.T) {
 	}
 }
 
+func TestProgramCanceled(t *testing.T) {
+	t.Parallel()
+
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	fs := vfstest.FromMap(map[string]any{
+		"c:/dev/src/index.ts": "import { a } from './a';",
+		"c:/dev/src/a.ts":     "export const a = 1;",
+	}, false /*useCaseSensitiveFileNames*/)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	program := NewProgram(ProgramOptions{
+		Config: &tsoptions.ParsedCommandLine{
+			ParsedConfig: &core.ParsedOptions{
+				FileNames:       []string{"c:/dev/src/index.ts"},
+				CompilerOptions: &core.CompilerOptions{},
+			},
+		},
+		Host:    NewCompilerHost("c:/dev/src", bundled.WrapFS(fs), bundled.LibPath(), nil),
+		Context: ctx,
+	})
+
+	assert.Equal(t, len(program.GetSourceFiles()), 0)
+}
+
 func BenchmarkNewProgram(b *testing.B) {
 	if !bundled.Embedded {
 		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
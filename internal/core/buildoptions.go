@@ -0,0 +1,9 @@
+package core
+
+type BuildOptions struct {
+	Dry               Tristate `json:"dry,omitzero"`
+	Force             Tristate `json:"force,omitzero"`
+	Verbose           Tristate `json:"verbose,omitzero"`
+	Clean             Tristate `json:"clean,omitzero"`
+	StopBuildOnErrors Tristate `json:"stopBuildOnErrors,omitzero"`
+}
@@ -49,12 +49,9 @@ type CommandLineResult struct {
 
 func CommandLine(sys System, commandLineArgs []string, testing bool) CommandLineResult {
 	if len(commandLineArgs) > 0 {
-		// !!! build mode
 		switch strings.ToLower(commandLineArgs[0]) {
 		case "-b", "--b", "-build", "--build":
-			fmt.Fprintln(sys.Writer(), "Build mode is currently unsupported.")
-			sys.EndWrite()
-			return CommandLineResult{Status: ExitStatusNotImplemented}
+			return tscBuildCompilation(sys, tsoptions.ParseBuildCommandLine(commandLineArgs, sys), testing)
 			// case "-f":
 			// 	return fmtMain(sys, commandLineArgs[1], commandLineArgs[1])
 		}
@@ -305,7 +302,7 @@ func emitAndReportStatistics(
 	buildInfoReadTime time.Duration,
 	changesComputeTime time.Duration,
 ) ExitStatus {
-	result := emitFilesAndReportErrors(sys, programLike, reportDiagnostic)
+	result := emitFilesAndReportErrors(sys, programLike, reportDiagnostic, createReportErrorSummary(sys, config.CompilerOptions()))
 	if result.status != ExitStatusSuccess {
 		// compile exited early
 		return result.status
@@ -353,6 +350,7 @@ func emitFilesAndReportErrors(
 	sys System,
 	program compiler.ProgramLike,
 	reportDiagnostic diagnosticReporter,
+	reportErrorSummary func(diagnostics []*ast.Diagnostic),
 ) (result compileAndEmitResult) {
 	ctx := context.Background()
 
@@ -400,7 +398,7 @@ func emitFilesAndReportErrors(
 		listFiles(sys, program)
 	}
 
-	createReportErrorSummary(sys, program.Options())(allDiagnostics)
+	reportErrorSummary(allDiagnostics)
 	result.diagnostics = allDiagnostics
 	result.emitResult = emitResult
 	result.status = ExitStatusSuccess
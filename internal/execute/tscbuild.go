@@ -0,0 +1,535 @@
+package execute
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/incremental"
+	"github.com/microsoft/typescript-go/internal/outputpaths"
+	"github.com/microsoft/typescript-go/internal/pprof"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+func tscBuildCompilation(sys System, buildCommand *tsoptions.ParsedBuildCommandLine, testing bool) CommandLineResult {
+	reportDiagnostic := createDiagnosticReporter(sys, buildCommand.CompilerOptions)
+
+	if len(buildCommand.Errors) > 0 {
+		for _, e := range buildCommand.Errors {
+			reportDiagnostic(e)
+		}
+		return CommandLineResult{Status: ExitStatusDiagnosticsPresent_OutputsSkipped}
+	}
+
+	if pprofDir := buildCommand.CompilerOptions.PprofDir; pprofDir != "" {
+		// !!! stderr?
+		profileSession := pprof.BeginProfiling(pprofDir, sys.Writer())
+		defer profileSession.Stop()
+	}
+
+	if buildCommand.CompilerOptions.Version.IsTrue() {
+		printVersion(sys)
+		return CommandLineResult{Status: ExitStatusSuccess}
+	}
+
+	if buildCommand.CompilerOptions.Help.IsTrue() {
+		printVersion(sys)
+		printEasyHelp(sys, core.Filter(tsoptions.BuildOpts, func(opt *tsoptions.CommandLineOption) bool {
+			return opt.ShowInSimplifiedHelpView
+		}))
+		return CommandLineResult{Status: ExitStatusSuccess}
+	}
+
+	if buildCommand.CompilerOptions.Watch.IsTrue() {
+		// !!! build watch mode
+		fmt.Fprintln(sys.Writer(), "Watch mode is currently unsupported with --build.")
+		sys.EndWrite()
+		return CommandLineResult{Status: ExitStatusNotImplemented}
+	}
+
+	builder := newSolutionBuilder(sys, buildCommand, reportDiagnostic, testing)
+	if buildCommand.BuildOptions.Clean.IsTrue() {
+		return CommandLineResult{Status: builder.clean()}
+	}
+	return CommandLineResult{Status: builder.build()}
+}
+
+type buildResultKind int
+
+const (
+	buildResultNone buildResultKind = iota
+	// The project was up to date and nothing was done.
+	buildResultUpToDate
+	// The project was built (or would be built with --dry).
+	buildResultBuilt
+	// The project was built but reported errors.
+	buildResultBuiltWithErrors
+	// The project could not be built: its config was missing or unreadable,
+	// or a dependency stopped the build.
+	buildResultNotBuilt
+)
+
+// buildTask is a project in the build order along with its build state.
+// Upstream tasks always appear earlier in the build order, so a task may be
+// built as soon as every task it references has closed its done channel.
+type buildTask struct {
+	configName   string
+	config       *tsoptions.ParsedCommandLine
+	configErrors []*ast.Diagnostic
+	upstream     []*buildTask
+
+	done   chan struct{}
+	result buildResultKind
+	// diagnostics reported while building the project, used for the error summary
+	diagnostics []*ast.Diagnostic
+	// set with --dry when the project would have been built
+	wouldBuild bool
+	sys        *bufferedSystem
+}
+
+type solutionBuilder struct {
+	sys              System
+	buildCommand     *tsoptions.ParsedBuildCommandLine
+	reportDiagnostic diagnosticReporter
+	compilerOptions  *core.CompilerOptions
+	testing          bool
+
+	comparePathsOptions tspath.ComparePathsOptions
+	extendedConfigCache collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]
+	tasks               map[tspath.Path]*buildTask
+}
+
+func newSolutionBuilder(sys System, buildCommand *tsoptions.ParsedBuildCommandLine, reportDiagnostic diagnosticReporter, testing bool) *solutionBuilder {
+	compilerOptions := buildCommand.CompilerOptions.Clone()
+	compilerOptions.TscBuild = core.TSTrue
+	return &solutionBuilder{
+		sys:              sys,
+		buildCommand:     buildCommand,
+		reportDiagnostic: reportDiagnostic,
+		compilerOptions:  compilerOptions,
+		testing:          testing,
+		comparePathsOptions: tspath.ComparePathsOptions{
+			CurrentDirectory:          sys.GetCurrentDirectory(),
+			UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
+		},
+		tasks: make(map[tspath.Path]*buildTask),
+	}
+}
+
+func (b *solutionBuilder) build() ExitStatus {
+	buildOrder, ok := b.createBuildOrder()
+	if !ok {
+		return ExitStatusProjectReferenceCycle_OutputsSkipped
+	}
+	if b.buildCommand.BuildOptions.Verbose.IsTrue() {
+		b.reportStatus(b.sys, diagnostics.Projects_in_this_build_Colon_0, strings.Join(core.Map(buildOrder, func(task *buildTask) string {
+			return "\n    * " + b.relativeName(task.configName)
+		}), ""))
+	}
+
+	// Projects are built in parallel, each as soon as its references are done. Output is
+	// buffered per project and flushed in build order so it does not interleave.
+	if b.compilerOptions.SingleThreaded.IsTrue() {
+		for _, task := range buildOrder {
+			b.buildProject(task)
+			task.sys.flush()
+		}
+	} else {
+		// The projects are started on their own goroutines rather than a WorkGroup, whose
+		// queued functions may not run until RunAndWait, so that output can be flushed while
+		// later projects are still building.
+		var wg sync.WaitGroup
+		for _, task := range buildOrder {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, upstream := range task.upstream {
+					<-upstream.done
+				}
+				b.buildProject(task)
+			}()
+		}
+		for _, task := range buildOrder {
+			<-task.done
+			task.sys.flush()
+		}
+		wg.Wait()
+	}
+
+	var allDiagnostics []*ast.Diagnostic
+	successfulProjects := 0
+	errorProjects := 0
+	for _, task := range buildOrder {
+		allDiagnostics = append(allDiagnostics, task.diagnostics...)
+		switch task.result {
+		case buildResultUpToDate, buildResultBuilt:
+			successfulProjects++
+		case buildResultBuiltWithErrors, buildResultNotBuilt:
+			errorProjects++
+		}
+	}
+	if len(allDiagnostics) > 0 {
+		createReportErrorSummary(b.sys, b.compilerOptions)(allDiagnostics)
+	}
+
+	switch {
+	case errorProjects == 0:
+		return ExitStatusSuccess
+	case successfulProjects > 0:
+		return ExitStatusDiagnosticsPresent_OutputsGenerated
+	default:
+		return ExitStatusDiagnosticsPresent_OutputsSkipped
+	}
+}
+
+func (b *solutionBuilder) clean() ExitStatus {
+	buildOrder, ok := b.createBuildOrder()
+	if !ok {
+		return ExitStatusProjectReferenceCycle_OutputsSkipped
+	}
+
+	status := ExitStatusSuccess
+	var filesToDelete []string
+	for _, task := range buildOrder {
+		if task.config == nil {
+			for _, e := range task.configErrors {
+				b.reportDiagnostic(e)
+			}
+			status = ExitStatusDiagnosticsPresent_OutputsSkipped
+			continue
+		}
+		for _, output := range getAllProjectOutputs(task.config) {
+			if !b.sys.FS().FileExists(output) {
+				continue
+			}
+			if b.buildCommand.BuildOptions.Dry.IsTrue() {
+				filesToDelete = append(filesToDelete, output)
+			} else if err := b.sys.FS().Remove(output); err != nil {
+				fmt.Fprintln(b.sys.Writer(), err.Error())
+				b.sys.EndWrite()
+			}
+		}
+	}
+	if len(filesToDelete) > 0 {
+		b.reportStatus(b.sys, diagnostics.A_non_dry_build_would_delete_the_following_files_Colon_0, strings.Join(core.Map(filesToDelete, func(file string) string {
+			return "\n * " + file
+		}), ""))
+	}
+	return status
+}
+
+// createBuildOrder parses the configs of the root projects and everything they reference,
+// and returns the projects so that every project comes after the projects it references.
+func (b *solutionBuilder) createBuildOrder() ([]*buildTask, bool) {
+	var buildOrder []*buildTask
+	var visiting []string
+	visited := make(map[tspath.Path]bool)
+	var cycle []string
+
+	var visit func(configName string, inCircularContext bool) *buildTask
+	visit = func(configName string, inCircularContext bool) *buildTask {
+		path := tspath.ToPath(configName, b.comparePathsOptions.CurrentDirectory, b.comparePathsOptions.UseCaseSensitiveFileNames)
+		if done, ok := visited[path]; ok {
+			if !done && !inCircularContext && cycle == nil {
+				// Still on the stack, so this reference closes a cycle
+				start := core.FindIndex(visiting, func(name string) bool {
+					return tspath.ToPath(name, b.comparePathsOptions.CurrentDirectory, b.comparePathsOptions.UseCaseSensitiveFileNames) == path
+				})
+				cycle = append(slices.Clone(visiting[start:]), configName)
+			}
+			return b.tasks[path]
+		}
+
+		task := b.getOrCreateTask(path, configName)
+		visited[path] = false
+		visiting = append(visiting, configName)
+		if task.config != nil {
+			references := task.config.ProjectReferences()
+			for i, referencePath := range task.config.ResolvedProjectReferencePaths() {
+				if upstream := visit(referencePath, inCircularContext || references[i].Circular); upstream != nil && upstream != task {
+					task.upstream = append(task.upstream, upstream)
+				}
+			}
+		}
+		visiting = visiting[:len(visiting)-1]
+		visited[path] = true
+		buildOrder = append(buildOrder, task)
+		return task
+	}
+
+	for _, root := range b.buildCommand.ResolvedProjectPaths() {
+		visit(root, false)
+	}
+
+	if cycle != nil {
+		b.reportDiagnostic(ast.NewCompilerDiagnostic(
+			diagnostics.Project_references_may_not_form_a_circular_graph_Cycle_detected_Colon_0,
+			strings.Join(core.Map(cycle, b.relativeName), "\n"),
+		))
+		return nil, false
+	}
+	return buildOrder, true
+}
+
+func (b *solutionBuilder) getOrCreateTask(path tspath.Path, configName string) *buildTask {
+	if task, ok := b.tasks[path]; ok {
+		return task
+	}
+	task := &buildTask{
+		configName: configName,
+		done:       make(chan struct{}),
+		sys:        newBufferedSystem(b.sys),
+	}
+	if !b.sys.FS().FileExists(configName) {
+		task.configErrors = []*ast.Diagnostic{ast.NewCompilerDiagnostic(diagnostics.File_0_not_found, configName)}
+	} else {
+		task.config, task.configErrors = tsoptions.GetParsedCommandLineOfConfigFile(configName, b.compilerOptions, b.sys, &b.extendedConfigCache)
+	}
+	b.tasks[path] = task
+	return task
+}
+
+func (b *solutionBuilder) buildProject(task *buildTask) {
+	defer close(task.done)
+	sys := task.sys
+	verbose := b.buildCommand.BuildOptions.Verbose.IsTrue()
+	dry := b.buildCommand.BuildOptions.Dry.IsTrue()
+
+	if task.config == nil {
+		reportDiagnostic := createDiagnosticReporter(sys, b.compilerOptions)
+		for _, e := range task.configErrors {
+			reportDiagnostic(e)
+		}
+		task.diagnostics = task.configErrors
+		task.result = buildResultNotBuilt
+		return
+	}
+
+	for _, upstream := range task.upstream {
+		switch {
+		case upstream.result == buildResultNotBuilt:
+			if verbose {
+				b.reportStatus(sys, diagnostics.Skipping_build_of_project_0_because_its_dependency_1_was_not_built, b.relativeName(task.configName), b.relativeName(upstream.configName))
+			}
+			task.result = buildResultNotBuilt
+			return
+		case upstream.result == buildResultBuiltWithErrors && b.buildCommand.BuildOptions.StopBuildOnErrors.IsTrue():
+			if verbose {
+				b.reportStatus(sys, diagnostics.Skipping_build_of_project_0_because_its_dependency_1_has_errors, b.relativeName(task.configName), b.relativeName(upstream.configName))
+			}
+			task.result = buildResultNotBuilt
+			return
+		}
+	}
+
+	upToDate, message, args := b.getUpToDateStatus(task)
+	if verbose || (dry && upToDate) {
+		b.reportStatus(sys, message, args...)
+	}
+	if upToDate {
+		task.result = buildResultUpToDate
+		return
+	}
+	if dry {
+		b.reportStatus(sys, diagnostics.A_non_dry_build_would_build_project_0, b.relativeName(task.configName))
+		task.wouldBuild = true
+		task.result = buildResultBuilt
+		return
+	}
+	if verbose {
+		b.reportStatus(sys, diagnostics.Building_project_0, b.relativeName(task.configName))
+	}
+
+	buildStart := b.sys.Now()
+	host := compiler.NewCachedFSCompilerHost(b.sys.GetCurrentDirectory(), b.sys.FS(), b.sys.DefaultLibraryPath(), &b.extendedConfigCache)
+	var oldProgram *incremental.Program
+	if !b.buildCommand.BuildOptions.Force.IsTrue() {
+		oldProgram = incremental.ReadBuildInfoProgram(task.config, incremental.NewBuildInfoReader(host))
+	}
+	program := compiler.NewProgram(compiler.ProgramOptions{
+		Config:           task.config,
+		Host:             host,
+		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+	})
+	incrementalProgram := incremental.NewProgram(program, oldProgram, b.testing)
+	result := emitFilesAndReportErrors(sys, incrementalProgram, createDiagnosticReporter(sys, task.config.CompilerOptions()), func([]*ast.Diagnostic) {})
+	task.diagnostics = result.diagnostics
+	if len(result.diagnostics) > 0 {
+		task.result = buildResultBuiltWithErrors
+	} else {
+		task.result = buildResultBuilt
+	}
+
+	// An incremental program with nothing to emit leaves the buildinfo untouched. Rewrite it
+	// so that its timestamp is newer than the inputs and the project is seen as up to date.
+	buildInfoFileName := task.config.GetBuildInfoFileName()
+	if stat := b.sys.FS().Stat(buildInfoFileName); stat != nil && stat.ModTime().Before(buildStart) {
+		if verbose {
+			b.reportStatus(sys, diagnostics.Updating_unchanged_output_timestamps_of_project_0, b.relativeName(task.configName))
+		}
+		if text, ok := b.sys.FS().ReadFile(buildInfoFileName); ok {
+			if err := b.sys.FS().WriteFile(buildInfoFileName, text, false); err != nil {
+				fmt.Fprintln(sys.Writer(), err.Error())
+				sys.EndWrite()
+			}
+		}
+	}
+}
+
+// getUpToDateStatus compares the buildinfo of the project against its inputs and the outputs of
+// its references, returning whether the project is up to date and the message explaining why.
+func (b *solutionBuilder) getUpToDateStatus(task *buildTask) (bool, *diagnostics.Message, []any) {
+	project := b.relativeName(task.configName)
+	if b.buildCommand.BuildOptions.Force.IsTrue() {
+		return false, diagnostics.Project_0_is_being_forcibly_rebuilt, []any{project}
+	}
+
+	for _, upstream := range task.upstream {
+		if upstream.wouldBuild {
+			return false, diagnostics.Project_0_is_out_of_date_because_its_dependency_1_is_out_of_date, []any{project, b.relativeName(upstream.configName)}
+		}
+	}
+
+	buildInfoFileName := task.config.GetBuildInfoFileName()
+	buildInfoStat := b.sys.FS().Stat(buildInfoFileName)
+	if buildInfoStat == nil {
+		return false, diagnostics.Project_0_is_out_of_date_because_output_file_1_does_not_exist, []any{project, b.relativeName(buildInfoFileName)}
+	}
+	buildInfoTime := buildInfoStat.ModTime()
+
+	host := compiler.NewCompilerHost(b.sys.GetCurrentDirectory(), b.sys.FS(), b.sys.DefaultLibraryPath(), &b.extendedConfigCache)
+	buildInfo := incremental.NewBuildInfoReader(host).ReadBuildInfo(buildInfoFileName)
+	if buildInfo == nil {
+		return false, diagnostics.Project_0_is_out_of_date_because_there_was_error_reading_file_1, []any{project, b.relativeName(buildInfoFileName)}
+	}
+	if !buildInfo.IsValidVersion() {
+		return false, diagnostics.Project_0_is_out_of_date_because_output_for_it_was_generated_with_version_1_that_differs_with_current_version_2, []any{project, buildInfo.Version, core.Version()}
+	}
+
+	options := task.config.CompilerOptions()
+	if buildInfo.IsIncremental() {
+		if len(buildInfo.ChangeFileSet) > 0 || !options.NoEmit.IsTrue() && (len(buildInfo.AffectedFilesPendingEmit) > 0 || len(buildInfo.EmitDiagnosticsPerFile) > 0) {
+			return false, diagnostics.Project_0_is_out_of_date_because_buildinfo_file_1_indicates_that_some_of_the_changes_were_not_emitted, []any{project, b.relativeName(buildInfoFileName)}
+		}
+	}
+	if !options.NoCheck.IsTrue() && (buildInfo.Errors || buildInfo.CheckPending || len(buildInfo.SemanticDiagnosticsPerFile) > 0) {
+		return false, diagnostics.Project_0_is_out_of_date_because_buildinfo_file_1_indicates_that_program_needs_to_report_errors, []any{project, b.relativeName(buildInfoFileName)}
+	}
+
+	// Inputs are the config, every config it extends, the root files and, for incremental
+	// projects, every other file the program included when it was built
+	newestInputFileName := task.configName
+	var newestInputTime time.Time
+	inputs := append(append([]string{task.configName}, task.config.ExtendedSourceFiles()...), task.config.FileNames()...)
+	buildInfoDirectory := tspath.GetDirectoryPath(tspath.GetNormalizedAbsolutePath(buildInfoFileName, b.sys.GetCurrentDirectory()))
+	for _, fileName := range buildInfo.FileNames {
+		inputs = append(inputs, tspath.GetNormalizedAbsolutePath(fileName, buildInfoDirectory))
+	}
+	for _, input := range inputs {
+		stat := b.sys.FS().Stat(input)
+		if stat == nil {
+			continue
+		}
+		if stat.ModTime().After(buildInfoTime) {
+			return false, diagnostics.Project_0_is_out_of_date_because_output_1_is_older_than_input_2, []any{project, b.relativeName(buildInfoFileName), b.relativeName(input)}
+		}
+		if stat.ModTime().After(newestInputTime) {
+			newestInputTime = stat.ModTime()
+			newestInputFileName = input
+		}
+	}
+
+	for _, upstream := range task.upstream {
+		if upstream.config == nil {
+			continue
+		}
+		if stat := b.sys.FS().Stat(upstream.config.GetBuildInfoFileName()); stat != nil && stat.ModTime().After(buildInfoTime) {
+			return false, diagnostics.Project_0_is_out_of_date_because_output_1_is_older_than_input_2, []any{project, b.relativeName(buildInfoFileName), b.relativeName(upstream.configName)}
+		}
+	}
+
+	return true, diagnostics.Project_0_is_up_to_date_because_newest_input_1_is_older_than_output_2, []any{project, b.relativeName(newestInputFileName), b.relativeName(buildInfoFileName)}
+}
+
+func (b *solutionBuilder) reportStatus(sys System, message *diagnostics.Message, args ...any) {
+	fmt.Fprintln(sys.Writer(), message.Format(args...))
+	sys.EndWrite()
+}
+
+func (b *solutionBuilder) relativeName(fileName string) string {
+	return tspath.ConvertToRelativePath(fileName, b.comparePathsOptions)
+}
+
+// getAllProjectOutputs returns every file a build of the project can write: the outputs of each
+// root file and the buildinfo.
+func getAllProjectOutputs(config *tsoptions.ParsedCommandLine) []string {
+	var outputs []string
+	options := config.CompilerOptions()
+	for _, fileName := range config.FileNames() {
+		if tspath.IsDeclarationFileName(fileName) {
+			continue
+		}
+		isJsonFile := tspath.FileExtensionIs(fileName, tspath.ExtensionJson)
+		if !options.EmitDeclarationOnly.IsTrue() {
+			jsFileName := outputpaths.GetOutputJSFileNameWorker(fileName, options, config)
+			if !isJsonFile || tspath.ComparePaths(fileName, jsFileName, tspath.ComparePathsOptions{
+				CurrentDirectory:          config.GetCurrentDirectory(),
+				UseCaseSensitiveFileNames: config.UseCaseSensitiveFileNames(),
+			}) != 0 {
+				outputs = append(outputs, jsFileName)
+				if !isJsonFile && options.SourceMap.IsTrue() && !options.InlineSourceMap.IsTrue() {
+					outputs = append(outputs, jsFileName+".map")
+				}
+			}
+		}
+		if options.GetEmitDeclarations() && !isJsonFile {
+			declarationFileName := outputpaths.GetOutputDeclarationFileNameWorker(fileName, options, config)
+			outputs = append(outputs, declarationFileName)
+			if options.GetAreDeclarationMapsEnabled() {
+				outputs = append(outputs, declarationFileName+".map")
+			}
+		}
+	}
+	if buildInfoFileName := config.GetBuildInfoFileName(); buildInfoFileName != "" {
+		outputs = append(outputs, buildInfoFileName)
+	}
+	return outputs
+}
+
+// bufferedSystem collects what a project build writes so that it can be replayed on the
+// underlying System once the project is done.
+type bufferedSystem struct {
+	System
+	currentWrite strings.Builder
+	outputs      []string
+}
+
+func newBufferedSystem(sys System) *bufferedSystem {
+	return &bufferedSystem{System: sys}
+}
+
+func (s *bufferedSystem) Writer() io.Writer {
+	return &s.currentWrite
+}
+
+func (s *bufferedSystem) EndWrite() {
+	s.outputs = append(s.outputs, s.currentWrite.String())
+	s.currentWrite.Reset()
+}
+
+func (s *bufferedSystem) flush() {
+	for _, output := range s.outputs {
+		fmt.Fprint(s.System.Writer(), output)
+		s.System.EndWrite()
+	}
+	s.outputs = nil
+}
@@ -0,0 +1,202 @@
+package execute_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/testutil/stringtestutil"
+)
+
+func getBuildSolutionFileMap() FileMap {
+	return FileMap{
+		"/home/src/workspaces/solution/core/index.ts": stringtestutil.Dedent(`
+			export const someString: string = "HELLO WORLD";
+			export function leftPad(s: string, n: number) { return s + n; }
+			export function multiply(a: number, b: number) { return a * b; }`),
+		"/home/src/workspaces/solution/core/tsconfig.json": stringtestutil.Dedent(`
+		{
+			"compilerOptions": {
+				"composite": true,
+				"declaration": true,
+			},
+		}`),
+		"/home/src/workspaces/solution/logic/index.ts": stringtestutil.Dedent(`
+			import * as c from '../core/index';
+			export function getSecondsInDay() {
+				return c.multiply(10, 15);
+			}`),
+		"/home/src/workspaces/solution/logic/tsconfig.json": stringtestutil.Dedent(`
+		{
+			"compilerOptions": {
+				"composite": true,
+				"declaration": true,
+			},
+			"references": [
+				{ "path": "../core" },
+			],
+		}`),
+		"/home/src/workspaces/solution/tests/index.ts": stringtestutil.Dedent(`
+			import * as c from '../core/index';
+			import * as logic from '../logic/index';
+			c.leftPad("", 10);
+			logic.getSecondsInDay();`),
+		"/home/src/workspaces/solution/tests/tsconfig.json": stringtestutil.Dedent(`
+		{
+			"compilerOptions": {
+				"composite": true,
+				"declaration": true,
+			},
+			"references": [
+				{ "path": "../core" },
+				{ "path": "../logic" },
+			],
+		}`),
+	}
+}
+
+// Verbose output reports why each project is or is not up to date, which depends on
+// the outputs left by the previous build.
+const upToDateStatusDiff = "The up to date status of projects is reported against the outputs of the previous build"
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	cases := []tscInput{
+		{
+			subScenario:     "builds projects in dependency order",
+			files:           getBuildSolutionFileMap(),
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "tests", "--verbose"},
+			edits: []*testTscEdit{
+				{
+					caption:      "no change",
+					expectedDiff: upToDateStatusDiff,
+				},
+				{
+					caption: "change core",
+					edit: func(sys *testSys) {
+						sys.appendFile("/home/src/workspaces/solution/core/index.ts", "\nexport class someClass { }")
+					},
+					expectedDiff: upToDateStatusDiff,
+				},
+				{
+					caption: "change logic",
+					edit: func(sys *testSys) {
+						sys.appendFile("/home/src/workspaces/solution/logic/index.ts", "\nexport const x = 10;")
+					},
+					expectedDiff: upToDateStatusDiff,
+				},
+				{
+					caption:         "force",
+					commandLineArgs: []string{"--b", "tests", "--verbose", "--force"},
+				},
+			},
+		},
+		{
+			subScenario:     "builds the project in the current directory",
+			files:           getBuildSolutionFileMap(),
+			cwd:             "/home/src/workspaces/solution/logic",
+			commandLineArgs: []string{"--b"},
+			edits:           noChangeOnlyEdit,
+		},
+		{
+			subScenario:     "dry",
+			files:           getBuildSolutionFileMap(),
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "tests", "--dry"},
+		},
+		{
+			subScenario:     "clean",
+			files:           getBuildSolutionFileMap(),
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "tests"},
+			edits: []*testTscEdit{
+				{
+					caption:         "clean dry",
+					commandLineArgs: []string{"--b", "tests", "--clean", "--dry"},
+					expectedDiff:    "Clean only lists the outputs that exist, and a clean build has not written any",
+				},
+				{
+					caption:         "clean",
+					commandLineArgs: []string{"--b", "tests", "--clean"},
+				},
+			},
+		},
+		{
+			subScenario:     "reports missing project",
+			files:           getBuildSolutionFileMap(),
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "core", "missing"},
+		},
+		{
+			subScenario: "reports circular references",
+			files: FileMap{
+				"/home/src/workspaces/solution/a/index.ts": "export const a = 1;",
+				"/home/src/workspaces/solution/a/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"compilerOptions": { "composite": true },
+					"references": [{ "path": "../b" }],
+				}`),
+				"/home/src/workspaces/solution/b/index.ts": "export const b = 1;",
+				"/home/src/workspaces/solution/b/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"compilerOptions": { "composite": true },
+					"references": [{ "path": "../a" }],
+				}`),
+			},
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "a"},
+		},
+		{
+			subScenario: "stopBuildOnErrors skips dependents of projects with errors",
+			files: func() FileMap {
+				files := getBuildSolutionFileMap()
+				files["/home/src/workspaces/solution/core/index.ts"] = stringtestutil.Dedent(`
+					export const someString: number = "HELLO WORLD";
+					export function leftPad(s: string, n: number) { return s + n; }
+					export function multiply(a: number, b: number) { return a * b; }`)
+				return files
+			}(),
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "tests", "--verbose", "--stopBuildOnErrors"},
+			edits: []*testTscEdit{
+				{
+					caption:         "without stopBuildOnErrors",
+					commandLineArgs: []string{"--b", "tests", "--verbose"},
+					expectedDiff:    upToDateStatusDiff,
+				},
+			},
+		},
+		{
+			subScenario: "rebuilds when an imported file that is not a root file changes",
+			files: FileMap{
+				"/home/src/workspaces/solution/core/index.ts":  `export { helper } from "./helper";`,
+				"/home/src/workspaces/solution/core/helper.ts": `export function helper() { return 1; }`,
+				"/home/src/workspaces/solution/core/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"compilerOptions": { "incremental": true },
+					"files": ["index.ts"],
+				}`),
+			},
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "core", "--verbose"},
+			edits: []*testTscEdit{
+				{
+					caption: "change helper",
+					edit: func(sys *testSys) {
+						sys.appendFile("/home/src/workspaces/solution/core/helper.ts", "\nexport const x = 10;")
+					},
+					expectedDiff: upToDateStatusDiff,
+				},
+			},
+		},
+		{
+			subScenario:     "reports invalid option combinations",
+			files:           getBuildSolutionFileMap(),
+			cwd:             "/home/src/workspaces/solution",
+			commandLineArgs: []string{"--b", "tests", "--clean", "--force"},
+		},
+	}
+
+	for _, test := range cases {
+		test.run(t, "build")
+	}
+}
@@ -160,7 +160,7 @@ func getDiffForIncremental(incrementalSys *testSys, nonIncrementalSys *testSys)
 func (test *tscInput) getBaselineSubFolder() string {
 	commandName := "tsc"
 	if slices.ContainsFunc(test.commandLineArgs, func(arg string) bool {
-		return arg == "--build" || arg == "-b"
+		return arg == "--build" || arg == "-build" || arg == "--b" || arg == "-b"
 	}) {
 		commandName = "tsbuild"
 	}
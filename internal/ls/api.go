@@ -42,3 +42,48 @@ func (l *LanguageService) GetTypeOfSymbol(ctx context.Context, symbol *ast.Symbo
 	defer done()
 	return checker.GetTypeOfSymbolAtLocation(symbol, nil)
 }
+
+func (l *LanguageService) TypeToString(ctx context.Context, t *checker.Type, enclosingDeclaration *ast.Node, flags *checker.TypeFormatFlags) string {
+	program := l.GetProgram()
+	c, done := program.GetTypeChecker(ctx)
+	defer done()
+	if flags == nil {
+		return c.TypeToString(t)
+	}
+	return c.TypeToStringEx(t, enclosingDeclaration, *flags)
+}
+
+func (l *LanguageService) GetPropertiesOfType(ctx context.Context, t *checker.Type) []*ast.Symbol {
+	program := l.GetProgram()
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	return checker.GetPropertiesOfType(t)
+}
+
+func (l *LanguageService) GetSignaturesOfType(ctx context.Context, t *checker.Type, kind checker.SignatureKind) []*checker.Signature {
+	program := l.GetProgram()
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	return checker.GetSignaturesOfType(t, kind)
+}
+
+func (l *LanguageService) GetReturnTypeOfSignature(ctx context.Context, signature *checker.Signature) *checker.Type {
+	program := l.GetProgram()
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	return checker.GetReturnTypeOfSignature(signature)
+}
+
+func (l *LanguageService) IsTypeAssignableTo(ctx context.Context, source *checker.Type, target *checker.Type) bool {
+	program := l.GetProgram()
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	return checker.IsTypeAssignableTo(source, target)
+}
+
+func (l *LanguageService) GetBaseTypes(ctx context.Context, t *checker.Type) []*checker.Type {
+	program := l.GetProgram()
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	return checker.GetBaseTypes(t)
+}
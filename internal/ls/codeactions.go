@@ -0,0 +1,62 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+func (l *LanguageService) ProvideCodeActions(ctx context.Context, params *lsproto.CodeActionParams) (lsproto.CodeActionResponse, error) {
+	program, sourceFile := l.getProgramAndFile(params.TextDocument.Uri)
+	only := params.Context.Only
+	var actions []lsproto.CommandOrCodeAction
+
+	if codeActionKindRequested(lsproto.CodeActionKindQuickFix, only) {
+		for _, diagnostic := range params.Context.Diagnostics {
+			if diagnostic.Code == nil || diagnostic.Code.Integer == nil {
+				continue
+			}
+			span := core.NewTextRange(
+				int(l.converters.LineAndCharacterToPosition(sourceFile, diagnostic.Range.Start)),
+				int(l.converters.LineAndCharacterToPosition(sourceFile, diagnostic.Range.End)),
+			)
+			for _, fixAction := range l.getCodeFixesAtPosition(ctx, program, sourceFile, *diagnostic.Code.Integer, span) {
+				actions = append(actions, l.createCodeAction(fixAction.description, lsproto.CodeActionKindQuickFix, fixAction.changes, []*lsproto.Diagnostic{diagnostic}))
+			}
+		}
+	}
+	// Source actions apply to the whole file and are only computed when asked for.
+	if only != nil && codeActionKindRequested(lsproto.CodeActionKindSourceOrganizeImports, only) {
+		actions = append(actions, l.createCodeAction("Organize Imports", lsproto.CodeActionKindSourceOrganizeImports, l.organizeImports(ctx, program, sourceFile), nil))
+	}
+	if only != nil && codeActionKindRequested(lsproto.CodeActionKindSourceFixAll, only) {
+		actions = append(actions, l.createCodeAction("Fix All", lsproto.CodeActionKindSourceFixAll, l.getFixAllChanges(ctx, program, sourceFile), nil))
+	}
+	return lsproto.CodeActionResponse{CommandOrCodeActionArray: &actions}, nil
+}
+
+// codeActionKindRequested reports whether kind was asked for, either directly or through one of its parent kinds.
+// Only quick fixes are provided when no kinds are given.
+func codeActionKindRequested(kind lsproto.CodeActionKind, only *[]lsproto.CodeActionKind) bool {
+	if only == nil {
+		return kind == lsproto.CodeActionKindQuickFix
+	}
+	return slices.ContainsFunc(*only, func(requested lsproto.CodeActionKind) bool {
+		return kind == requested || strings.HasPrefix(string(kind), string(requested)+".")
+	})
+}
+
+func (l *LanguageService) createCodeAction(title string, kind lsproto.CodeActionKind, changes *changeTracker, diagnostics []*lsproto.Diagnostic) lsproto.CommandOrCodeAction {
+	action := &lsproto.CodeAction{
+		Title: title,
+		Kind:  &kind,
+		Edit:  l.toWorkspaceEdit(changes),
+	}
+	if len(diagnostics) != 0 {
+		action.Diagnostics = &diagnostics
+	}
+	return lsproto.CommandOrCodeAction{CodeAction: action}
+}
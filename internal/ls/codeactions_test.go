@@ -0,0 +1,365 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestCodeActions(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title       string
+		input       string
+		kind        lsproto.CodeActionKind
+		actionTitle string
+		expected    map[string]string
+	}{
+		{
+			title: "addMissingImport",
+			input: `
+// @filename: /a.ts
+foo();
+// @filename: /b.ts
+export function foo() {}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: `Add import from "./b"`,
+			expected: map[string]string{
+				"/a.ts": `import { foo } from "./b";
+
+foo();`,
+			},
+		},
+		{
+			title: "addToExistingImportWithQuotePreference",
+			input: `
+// @filename: /a.ts
+import { bar } from './b';
+bar();
+foo();
+// @filename: /b.ts
+export function foo() {}
+export function bar() {}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: `Update import from "./b"`,
+			expected: map[string]string{
+				"/a.ts": `import { bar, foo } from './b';
+bar();
+foo();`,
+			},
+		},
+		{
+			title: "addNewImportWithQuotePreference",
+			input: `
+// @filename: /a.ts
+import { bar } from './c';
+bar();
+foo();
+// @filename: /b.ts
+export function foo() {}
+// @filename: /c.ts
+export function bar() {}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: `Add import from "./b"`,
+			expected: map[string]string{
+				"/a.ts": `import { bar } from './c';
+import { foo } from './b';
+bar();
+foo();`,
+			},
+		},
+		{
+			title: "addMissingDefaultImport",
+			input: `
+// @filename: /a.ts
+import { x } from "./c";
+x;
+foo();
+// @filename: /b.ts
+export default function foo() {}
+// @filename: /c.ts
+export const x = 1;`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: `Add import from "./b"`,
+			expected: map[string]string{
+				"/a.ts": `import { x } from "./c";
+import foo from "./b";
+x;
+foo();`,
+			},
+		},
+		{
+			title: "addMissingAwaitToOperand",
+			input: `
+// @filename: /a.ts
+async function f(p: Promise<number>) {
+    return p + 1;
+}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Add 'await'",
+			expected: map[string]string{
+				"/a.ts": `async function f(p: Promise<number>) {
+    return await p + 1;
+}`,
+			},
+		},
+		{
+			title: "addMissingAwaitToPropertyAccess",
+			input: `
+// @filename: /a.ts
+async function f(p: Promise<string>) {
+    return p.length;
+}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Add 'await'",
+			expected: map[string]string{
+				"/a.ts": `async function f(p: Promise<string>) {
+    return (await p).length;
+}`,
+			},
+		},
+		{
+			title: "removeUnusedImportSpecifier",
+			input: `
+// @filename: /tsconfig.json
+{ "compilerOptions": { "noUnusedLocals": true, "noUnusedParameters": true } }
+// @filename: /a.ts
+import { a, b } from "./b";
+a;
+// @filename: /b.ts
+export const a = 1;
+export const b = 2;`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Remove unused declaration for: 'b'",
+			expected: map[string]string{
+				"/a.ts": `import { a } from "./b";
+a;`,
+			},
+		},
+		{
+			title: "removeUnusedVariable",
+			input: `
+// @filename: /tsconfig.json
+{ "compilerOptions": { "noUnusedLocals": true, "noUnusedParameters": true } }
+// @filename: /a.ts
+export function f() {
+    /** Unused. */
+    const x = 1;
+    return 2;
+}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Remove unused declaration for: 'x'",
+			expected: map[string]string{
+				"/a.ts": `export function f() {
+    return 2;
+}`,
+			},
+		},
+		{
+			title: "prefixUnusedParameter",
+			input: `
+// @filename: /tsconfig.json
+{ "compilerOptions": { "noUnusedLocals": true, "noUnusedParameters": true } }
+// @filename: /a.ts
+export function f(x: number) {}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Prefix 'x' with an underscore",
+			expected: map[string]string{
+				"/a.ts": `export function f(_x: number) {}`,
+			},
+		},
+		{
+			title: "implementInterface",
+			input: `
+// @filename: /a.ts
+interface I {
+    readonly x: number;
+    y?: string;
+    m(a: string): void;
+}
+class C implements I {
+}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Implement interface 'I'",
+			expected: map[string]string{
+				"/a.ts": `interface I {
+    readonly x: number;
+    y?: string;
+    m(a: string): void;
+}
+class C implements I {
+    readonly x: number;
+    y?: string;
+    m(a: string): void {
+        throw new Error("Method not implemented.");
+    }
+}`,
+			},
+		},
+		{
+			title: "addMissingProperty",
+			input: `
+// @filename: /a.ts
+class C {
+    a = 1;
+    f() {
+        this.b = "x";
+    }
+}`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Declare property 'b'",
+			expected: map[string]string{
+				"/a.ts": `class C {
+    a = 1;
+    b: string;
+    f() {
+        this.b = "x";
+    }
+}`,
+			},
+		},
+		{
+			title: "addMissingStaticProperty",
+			input: `
+// @filename: /a.ts
+class C {
+    static f() {}
+}
+C.count = 1;`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Declare static property 'count'",
+			expected: map[string]string{
+				"/a.ts": `class C {
+    static count: number;
+    static f() {}
+}
+C.count = 1;`,
+			},
+		},
+		{
+			title: "fixSpelling",
+			input: `
+// @filename: /a.ts
+const hello = 1;
+const o = { value: 1 };
+helo + o.valeu;`,
+			kind:        lsproto.CodeActionKindQuickFix,
+			actionTitle: "Change spelling to 'value'",
+			expected: map[string]string{
+				"/a.ts": `const hello = 1;
+const o = { value: 1 };
+helo + o.value;`,
+			},
+		},
+		{
+			title: "organizeImports",
+			input: `
+// @filename: /a.ts
+import { z, a } from "./c";
+import { unused } from "./b";
+import x from "lib";
+import { y } from "./c";
+
+import { b } from "./b";
+a; z; y; x; b;
+// @filename: /b.ts
+export const unused = 1;
+export const b = 1;
+// @filename: /c.ts
+export const a = 1;
+export const y = 1;
+export const z = 1;`,
+			kind:        lsproto.CodeActionKindSourceOrganizeImports,
+			actionTitle: "Organize Imports",
+			expected: map[string]string{
+				"/a.ts": `import x from "lib";
+import { a, y, z } from "./c";
+
+import { b } from "./b";
+a; z; y; x; b;`,
+			},
+		},
+		{
+			title: "fixAll",
+			input: `
+// @filename: /a.ts
+async function f(p: Promise<number>) {
+    return [p + 1, foo(), bar()];
+}
+// @filename: /b.ts
+export function foo() {}
+export function bar() {}`,
+			kind:        lsproto.CodeActionKindSourceFixAll,
+			actionTitle: "Fix All",
+			expected: map[string]string{
+				"/a.ts": `import { foo, bar } from "./b";
+
+async function f(p: Promise<number>) {
+    return [await p + 1, foo(), bar()];
+}`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			runCodeActionTest(t, testCase.input, testCase.kind, testCase.actionTitle, testCase.expected)
+		})
+	}
+}
+
+func runCodeActionTest(t *testing.T, input string, kind lsproto.CodeActionKind, actionTitle string, expected map[string]string) {
+	testData := fourslash.ParseTestData(t, input, "/a.ts")
+	ctx := projecttestutil.WithRequestID(t.Context())
+	// Like rename, code actions need every file open, or importers are missing from the program
+	languageService, done := createLanguageServiceForRename(ctx, testData)
+	defer done()
+
+	uri := ls.FileNameToDocumentURI("/a.ts")
+	diagnostics, err := languageService.ProvideDiagnostics(ctx, uri)
+	assert.NilError(t, err)
+	params := &lsproto.CodeActionParams{
+		TextDocument: lsproto.TextDocumentIdentifier{Uri: uri},
+		Context: &lsproto.CodeActionContext{
+			Diagnostics: diagnostics.FullDocumentDiagnosticReport.Items,
+		},
+	}
+	if kind != lsproto.CodeActionKindQuickFix {
+		params.Context.Only = &[]lsproto.CodeActionKind{kind}
+	}
+	result, err := languageService.ProvideCodeActions(ctx, params)
+	assert.NilError(t, err)
+
+	var action *lsproto.CodeAction
+	var titles []string
+	for _, item := range *result.CommandOrCodeActionArray {
+		titles = append(titles, item.CodeAction.Title)
+		if item.CodeAction.Title == actionTitle && *item.CodeAction.Kind == kind {
+			action = item.CodeAction
+			break
+		}
+	}
+	assert.Assert(t, action != nil, "no code action %q in %v", actionTitle, titles)
+
+	changes := *action.Edit.Changes
+	for _, file := range testData.Files {
+		expectedContent, ok := expected[file.FileName()]
+		if !ok {
+			expectedContent = file.Content
+		}
+		actual := applyTextEdits(file.Content, changes[ls.FileNameToDocumentURI(file.FileName())])
+		assert.Equal(t, actual, expectedContent, "unexpected code action result in %s", file.FileName())
+	}
+}
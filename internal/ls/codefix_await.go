@@ -0,0 +1,135 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+var addMissingAwaitFix = &codeFix{
+	errorCodes: []int32{
+		diagnostics.The_left_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.The_right_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.Operator_0_cannot_be_applied_to_type_1.Code(),
+		diagnostics.Operator_0_cannot_be_applied_to_types_1_and_2.Code(),
+		diagnostics.This_comparison_appears_to_be_unintentional_because_the_types_0_and_1_have_no_overlap.Code(),
+		diagnostics.This_condition_will_always_return_true_since_this_0_is_always_defined.Code(),
+		diagnostics.Type_0_is_not_an_array_type.Code(),
+		diagnostics.Type_0_is_not_an_array_type_or_a_string_type.Code(),
+		diagnostics.Type_0_can_only_be_iterated_through_when_using_the_downlevelIteration_flag_or_with_a_target_of_es2015_or_higher.Code(),
+		diagnostics.Type_0_is_not_an_array_type_or_a_string_type_or_does_not_have_a_Symbol_iterator_method_that_returns_an_iterator.Code(),
+		diagnostics.Type_0_is_not_an_array_type_or_does_not_have_a_Symbol_iterator_method_that_returns_an_iterator.Code(),
+		diagnostics.Type_0_must_have_a_Symbol_iterator_method_that_returns_an_iterator.Code(),
+		diagnostics.Type_0_must_have_a_Symbol_asyncIterator_method_that_returns_an_async_iterator.Code(),
+		diagnostics.Argument_of_type_0_is_not_assignable_to_parameter_of_type_1.Code(),
+		diagnostics.Property_0_does_not_exist_on_type_1.Code(),
+		diagnostics.This_expression_is_not_callable.Code(),
+		diagnostics.This_expression_is_not_constructable.Code(),
+	},
+	getCodeActions:  getAddMissingAwaitCodeActions,
+	includeInFixAll: true,
+}
+
+func getAddMissingAwaitCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	expression := getAwaitErrorSpanExpression(ctx, fixContext)
+	if expression == nil {
+		return nil
+	}
+	changes := newChangeTracker()
+	addMissingAwait(changes, fixContext, expression)
+	if changes.isEmpty() {
+		return nil
+	}
+	return []*codeFixAction{{description: diagnostics.Add_await.Message(), changes: changes}}
+}
+
+// getAwaitErrorSpanExpression returns the expression reported by the diagnostic, provided the checker
+// suggested a missing await for it and an await would be allowed there.
+func getAwaitErrorSpanExpression(ctx context.Context, fixContext *codeFixContext) *ast.Node {
+	diagnostic := findDiagnosticForSpan(ctx, fixContext)
+	if diagnostic == nil || !slices.ContainsFunc(diagnostic.RelatedInformation(), func(related *ast.Diagnostic) bool {
+		return related.Code() == diagnostics.Did_you_forget_to_use_await.Code()
+	}) {
+		return nil
+	}
+	expression := getFixableErrorSpanExpression(fixContext)
+	if expression == nil || !isInsideAwaitableBody(fixContext.sourceFile, expression) {
+		return nil
+	}
+	return expression
+}
+
+// getFixableErrorSpanExpression returns the expression whose span is exactly the span of the diagnostic.
+func getFixableErrorSpanExpression(fixContext *codeFixContext) *ast.Node {
+	sourceFile := fixContext.sourceFile
+	span := fixContext.span
+	for node := astnav.GetTokenAtPosition(sourceFile, span.Pos()); node != nil && !ast.IsSourceFile(node); node = node.Parent {
+		start := scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/)
+		if start < span.Pos() || node.End() > span.End() {
+			return nil
+		}
+		if ast.IsExpression(node) && start == span.Pos() && node.End() == span.End() {
+			return node
+		}
+	}
+	return nil
+}
+
+func isInsideAwaitableBody(sourceFile *ast.SourceFile, node *ast.Node) bool {
+	if node.Flags&ast.NodeFlagsAwaitContext != 0 {
+		return true
+	}
+	// Top-level await is only allowed in modules.
+	return ast.IsExternalModule(sourceFile) && ast.FindAncestor(node, ast.IsFunctionLikeOrClassStaticBlockDeclaration) == nil
+}
+
+func addMissingAwait(changes *changeTracker, fixContext *codeFixContext, insertionSite *ast.Node) {
+	sourceFile := fixContext.sourceFile
+	checker := fixContext.checker
+	switch {
+	case ast.IsBinaryExpression(insertionSite):
+		binary := insertionSite.AsBinaryExpression()
+		for _, side := range []*ast.Node{binary.Left, binary.Right} {
+			if checker.GetPromisedTypeOfPromise(checker.GetTypeAtLocation(side)) != nil {
+				insertAwait(changes, sourceFile, side)
+			}
+		}
+	case fixContext.errorCode == diagnostics.Property_0_does_not_exist_on_type_1.Code() && ast.IsPropertyAccessExpression(insertionSite.Parent):
+		insertAwait(changes, sourceFile, insertionSite.Parent.Expression())
+	default:
+		insertAwait(changes, sourceFile, insertionSite)
+	}
+}
+
+func insertAwait(changes *changeTracker, sourceFile *ast.SourceFile, expression *ast.Node) {
+	operand := scanner.GetSourceTextOfNodeFromSourceFile(sourceFile, expression, false /*includeTrivia*/)
+	if !ast.IsLeftHandSideExpression(expression) && !ast.IsUnaryExpression(expression) {
+		operand = "(" + operand + ")"
+	}
+	text := "await " + operand
+	if awaitNeedsParentheses(expression) {
+		text = "(" + text + ")"
+	}
+	changes.replaceNode(sourceFile, expression, text)
+}
+
+// awaitNeedsParentheses reports whether an await expression replacing expression must be parenthesized
+// because its parent requires a left-hand-side expression there.
+func awaitNeedsParentheses(expression *ast.Node) bool {
+	parent := expression.Parent
+	switch parent.Kind {
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression, ast.KindCallExpression, ast.KindNewExpression:
+		return parent.Expression() == expression
+	case ast.KindTaggedTemplateExpression:
+		return parent.AsTaggedTemplateExpression().Tag == expression
+	case ast.KindNonNullExpression, ast.KindPostfixUnaryExpression:
+		return true
+	case ast.KindBinaryExpression:
+		return parent.AsBinaryExpression().OperatorToken.Kind == ast.KindAsteriskAsteriskToken && parent.AsBinaryExpression().Left == expression
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+var implementInterfaceFix = &codeFix{
+	errorCodes: []int32{
+		diagnostics.Class_0_incorrectly_implements_interface_1.Code(),
+		diagnostics.Class_0_incorrectly_implements_class_1_Did_you_mean_to_extend_1_and_inherit_its_members_as_a_subclass.Code(),
+	},
+	getCodeActions:  getImplementInterfaceCodeActions,
+	includeInFixAll: true,
+	fixAll:          fixAllImplementInterface,
+}
+
+const codeFixTypeFormatFlags = checker.TypeFormatFlagsNoTruncation | checker.TypeFormatFlagsUseAliasDefinedOutsideCurrentScope
+
+func getImplementInterfaceCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	class := getClassAtSpan(fixContext)
+	if class == nil {
+		return nil
+	}
+	var actions []*codeFixAction
+	for _, implementedTypeNode := range ast.GetImplementsHeritageClauseElements(class) {
+		members := getMissingInterfaceMembers(fixContext, class, implementedTypeNode, map[string]bool{})
+		if len(members) == 0 {
+			continue
+		}
+		changes := newChangeTracker()
+		changes.insertMembersAtEnd(fixContext.sourceFile, class, members)
+		typeName := scanner.GetSourceTextOfNodeFromSourceFile(fixContext.sourceFile, implementedTypeNode, false /*includeTrivia*/)
+		actions = append(actions, &codeFixAction{description: diagnostics.Implement_interface_0.Format(typeName), changes: changes})
+	}
+	return actions
+}
+
+func fixAllImplementInterface(ctx context.Context, fixContext *codeFixContext, diagnostics []*ast.Diagnostic, changes *changeTracker) {
+	var seenClasses []*ast.Node
+	for _, diagnostic := range diagnostics {
+		fixContext.span = diagnostic.Loc()
+		class := getClassAtSpan(fixContext)
+		if class == nil || slices.Contains(seenClasses, class) {
+			continue
+		}
+		seenClasses = append(seenClasses, class)
+		seenNames := map[string]bool{}
+		var members []string
+		for _, implementedTypeNode := range ast.GetImplementsHeritageClauseElements(class) {
+			members = append(members, getMissingInterfaceMembers(fixContext, class, implementedTypeNode, seenNames)...)
+		}
+		if len(members) != 0 {
+			changes.insertMembersAtEnd(fixContext.sourceFile, class, members)
+		}
+	}
+}
+
+func getClassAtSpan(fixContext *codeFixContext) *ast.Node {
+	return ast.GetContainingClass(astnav.GetTokenAtPosition(fixContext.sourceFile, fixContext.span.Pos()))
+}
+
+// getMissingInterfaceMembers returns the text of the members of an implemented type that the class does not declare.
+// Member names in seenNames are skipped, and the names of returned members are added to it.
+func getMissingInterfaceMembers(fixContext *codeFixContext, class *ast.Node, implementedTypeNode *ast.Node, seenNames map[string]bool) []string {
+	c := fixContext.checker
+	classType := c.GetDeclaredTypeOfSymbol(class.Symbol())
+	implementedType := c.GetTypeAtLocation(implementedTypeNode)
+	var members []string
+	for _, property := range c.GetPropertiesOfType(implementedType) {
+		if seenNames[property.Name] ||
+			strings.HasPrefix(property.Name, ast.InternalSymbolNamePrefix) ||
+			checker.GetDeclarationModifierFlagsFromSymbol(property)&ast.ModifierFlagsPrivate != 0 ||
+			c.GetPropertyOfType(classType, property.Name) != nil {
+			continue
+		}
+		seenNames[property.Name] = true
+		members = append(members, createMemberText(fixContext, class, property))
+	}
+	return members
+}
+
+// createMemberText returns the text of a class member implementing property, indented with tabs.
+func createMemberText(fixContext *codeFixContext, class *ast.Node, property *ast.Symbol) string {
+	c := fixContext.checker
+	sourceFile := fixContext.sourceFile
+	semicolon := ""
+	if probablyUsesSemicolons(sourceFile) {
+		semicolon = ";"
+	}
+	name := property.Name
+	if !scanner.IsIdentifierText(name, sourceFile.LanguageVariant) {
+		name = quote(sourceFile, &UserPreferences{}, name)
+	}
+	propertyType := c.GetTypeOfSymbol(property)
+
+	if property.Flags&ast.SymbolFlagsMethod != 0 {
+		signatures := c.GetSignaturesOfType(propertyType, checker.SignatureKindCall)
+		body := " {\n\tthrow new Error(" + quote(sourceFile, &UserPreferences{}, "Method not implemented.") + ")" + semicolon + "\n}"
+		if property.Flags&ast.SymbolFlagsOptional != 0 {
+			name += "?"
+		}
+		if len(signatures) == 1 {
+			return name + c.SignatureToStringEx(signatures[0], class, codeFixTypeFormatFlags|checker.TypeFormatFlagsWriteCallStyleSignature) + body
+		}
+		var b strings.Builder
+		for _, signature := range signatures {
+			b.WriteString(name)
+			b.WriteString(c.SignatureToStringEx(signature, class, codeFixTypeFormatFlags|checker.TypeFormatFlagsWriteCallStyleSignature))
+			b.WriteString(";\n")
+		}
+		b.WriteString(name)
+		b.WriteString("(...args: any[]): any")
+		b.WriteString(body)
+		return b.String()
+	}
+
+	var b strings.Builder
+	if checker.GetDeclarationModifierFlagsFromSymbol(property)&ast.ModifierFlagsReadonly != 0 {
+		b.WriteString("readonly ")
+	}
+	b.WriteString(name)
+	if property.Flags&ast.SymbolFlagsOptional != 0 {
+		b.WriteString("?")
+		propertyType = c.GetNonNullableType(propertyType)
+	}
+	b.WriteString(": ")
+	b.WriteString(c.TypeToStringEx(propertyType, class, codeFixTypeFormatFlags))
+	b.WriteString(semicolon)
+	return b.String()
+}
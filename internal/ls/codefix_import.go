@@ -0,0 +1,290 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+var importFix = &codeFix{
+	errorCodes: []int32{
+		diagnostics.Cannot_find_name_0.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Cannot_find_namespace_0.Code(),
+	},
+	getCodeActions:  getImportCodeActions,
+	includeInFixAll: true,
+	fixAll:          fixAllMissingImports,
+}
+
+// importCandidate is an export of another module that can satisfy an unresolved name.
+type importCandidate struct {
+	moduleSpecifier string
+	isDefault       bool
+}
+
+func getImportCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	name := getUnresolvedNameAtSpan(fixContext)
+	if name == nil {
+		return nil
+	}
+	var actions []*codeFixAction
+	for _, candidate := range getImportCandidates(fixContext, name) {
+		adder := newImportAdder(fixContext.sourceFile)
+		adder.addImport(candidate, name.Text())
+		changes := newChangeTracker()
+		adder.writeChanges(changes)
+		description := diagnostics.Add_import_from_0.Format(candidate.moduleSpecifier)
+		if findExistingImport(fixContext.sourceFile, candidate.moduleSpecifier) != nil {
+			description = diagnostics.Update_import_from_0.Format(candidate.moduleSpecifier)
+		}
+		actions = append(actions, &codeFixAction{description: description, changes: changes})
+	}
+	return actions
+}
+
+func fixAllMissingImports(ctx context.Context, fixContext *codeFixContext, diagnostics []*ast.Diagnostic, changes *changeTracker) {
+	adder := newImportAdder(fixContext.sourceFile)
+	for _, diagnostic := range diagnostics {
+		fixContext.errorCode = diagnostic.Code()
+		fixContext.span = diagnostic.Loc()
+		name := getUnresolvedNameAtSpan(fixContext)
+		if name == nil {
+			continue
+		}
+		if candidates := getImportCandidates(fixContext, name); len(candidates) != 0 {
+			adder.addImport(candidates[0], name.Text())
+		}
+	}
+	adder.writeChanges(changes)
+}
+
+func getUnresolvedNameAtSpan(fixContext *codeFixContext) *ast.Node {
+	node := astnav.GetTokenAtPosition(fixContext.sourceFile, fixContext.span.Pos())
+	if !ast.IsIdentifier(node) {
+		return nil
+	}
+	return node
+}
+
+// getImportCandidates returns the module exports named like name, in program order, that have the meaning
+// required at the location of name.
+func getImportCandidates(fixContext *codeFixContext, name *ast.Node) []importCandidate {
+	program := fixContext.program
+	checker := fixContext.checker
+	meaning := getMeaningFromLocation(name)
+	var result []importCandidate
+	for _, file := range program.GetSourceFiles() {
+		if file == fixContext.sourceFile || !ast.IsExternalModule(file) || program.IsSourceFileDefaultLibrary(file.Path()) {
+			continue
+		}
+		moduleSymbol := file.Symbol
+		if moduleSymbol == nil {
+			continue
+		}
+		for _, exported := range checker.GetExportsOfModule(moduleSymbol) {
+			isDefault := exported.Name == ast.InternalSymbolNameDefault
+			if isDefault {
+				if getDefaultExportName(exported) != name.Text() {
+					continue
+				}
+			} else if exported.Name != name.Text() {
+				continue
+			}
+			target := exported
+			if target.Flags&ast.SymbolFlagsAlias != 0 {
+				target = checker.GetAliasedSymbol(target)
+			}
+			if !symbolHasMeaning(target, meaning) {
+				continue
+			}
+			specifiers := modulespecifiers.GetModuleSpecifiers(
+				moduleSymbol,
+				checker,
+				program.Options(),
+				fixContext.sourceFile,
+				program,
+				modulespecifiers.UserPreferences{},
+				modulespecifiers.ModuleSpecifierOptions{},
+			)
+			if len(specifiers) == 0 {
+				continue
+			}
+			candidate := importCandidate{moduleSpecifier: specifiers[0], isDefault: isDefault}
+			if !slices.Contains(result, candidate) {
+				result = append(result, candidate)
+			}
+		}
+	}
+	return result
+}
+
+// getDefaultExportName returns the local name a default export is conventionally imported as.
+func getDefaultExportName(symbol *ast.Symbol) string {
+	for _, declaration := range symbol.Declarations {
+		if ast.IsExportAssignment(declaration) {
+			if expression := declaration.AsExportAssignment().Expression; ast.IsIdentifier(expression) {
+				return expression.Text()
+			}
+			continue
+		}
+		if name := ast.GetNameOfDeclaration(declaration); name != nil && ast.IsIdentifier(name) {
+			return name.Text()
+		}
+	}
+	return ""
+}
+
+func symbolHasMeaning(symbol *ast.Symbol, meaning ast.SemanticMeaning) bool {
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		// Unresolvable aliases are assumed to have any meaning.
+		return true
+	}
+	return symbol.Flags&semanticMeaningToSymbolFlags(meaning) != 0
+}
+
+// importAdder collects names to import into a file, grouped by module specifier, so that several fixes to
+// the same file produce one import per module.
+type importAdder struct {
+	sourceFile *ast.SourceFile
+	specifiers []string
+	imports    map[string]*pendingImport
+}
+
+type pendingImport struct {
+	defaultName string
+	namedImport []string
+}
+
+func newImportAdder(sourceFile *ast.SourceFile) *importAdder {
+	return &importAdder{sourceFile: sourceFile, imports: map[string]*pendingImport{}}
+}
+
+func (a *importAdder) addImport(candidate importCandidate, name string) {
+	pending := a.imports[candidate.moduleSpecifier]
+	if pending == nil {
+		pending = &pendingImport{}
+		a.imports[candidate.moduleSpecifier] = pending
+		a.specifiers = append(a.specifiers, candidate.moduleSpecifier)
+	}
+	if candidate.isDefault {
+		pending.defaultName = name
+	} else if !slices.Contains(pending.namedImport, name) {
+		pending.namedImport = append(pending.namedImport, name)
+	}
+}
+
+func (a *importAdder) writeChanges(changes *changeTracker) {
+	file := a.sourceFile
+	var newImports []string
+	for _, specifier := range a.specifiers {
+		pending := a.imports[specifier]
+		if existing := findExistingImport(file, specifier); existing != nil {
+			updateExistingImport(changes, file, existing.AsImportDeclaration().ImportClause.AsImportClause(), pending)
+			continue
+		}
+		newImports = append(newImports, getNewImportText(file, specifier, pending))
+	}
+	if len(newImports) == 0 {
+		return
+	}
+
+	newLine := getNewLineCharacter(file)
+	var lastImport *ast.Node
+	for _, statement := range file.Statements.Nodes {
+		if !ast.IsImportDeclaration(statement) && !ast.IsImportEqualsDeclaration(statement) {
+			break
+		}
+		lastImport = statement
+	}
+	if lastImport != nil {
+		changes.insertText(file, lastImport.End(), newLine+strings.Join(newImports, newLine))
+		return
+	}
+	text := strings.Join(newImports, newLine) + newLine
+	if len(file.Statements.Nodes) != 0 {
+		text += newLine
+	}
+	changes.insertText(file, getStartOfFileInsertionPosition(file), text)
+}
+
+// getStartOfFileInsertionPosition skips a shebang line, if any.
+func getStartOfFileInsertionPosition(file *ast.SourceFile) int {
+	text := file.Text()
+	if !strings.HasPrefix(text, "#!") {
+		return 0
+	}
+	if end := strings.IndexByte(text, '\n'); end >= 0 {
+		return end + 1
+	}
+	return len(text)
+}
+
+// findExistingImport returns a top-level import declaration of specifier that new names can be added to.
+func findExistingImport(file *ast.SourceFile, specifier string) *ast.Node {
+	for _, statement := range file.Statements.Nodes {
+		if !ast.IsImportDeclaration(statement) {
+			continue
+		}
+		declaration := statement.AsImportDeclaration()
+		if !ast.IsStringLiteral(declaration.ModuleSpecifier) || declaration.ModuleSpecifier.Text() != specifier {
+			continue
+		}
+		clause := declaration.ImportClause
+		if clause == nil || clause.AsImportClause().IsTypeOnly {
+			continue
+		}
+		if namedBindings := clause.AsImportClause().NamedBindings; namedBindings != nil && ast.IsNamespaceImport(namedBindings) {
+			continue
+		}
+		return statement
+	}
+	return nil
+}
+
+func updateExistingImport(changes *changeTracker, file *ast.SourceFile, clause *ast.ImportClause, pending *pendingImport) {
+	if pending.defaultName != "" && clause.Name() == nil {
+		changes.insertText(file, scanner.GetTokenPosOfNode(clause.AsNode(), file, false /*includeJSDoc*/), pending.defaultName+", ")
+	}
+	if len(pending.namedImport) == 0 {
+		return
+	}
+	names := strings.Join(pending.namedImport, ", ")
+	switch {
+	case clause.NamedBindings == nil:
+		changes.insertText(file, clause.Name().End(), ", { "+names+" }")
+	case len(clause.NamedBindings.AsNamedImports().Elements.Nodes) == 0:
+		changes.replaceNode(file, clause.NamedBindings, "{ "+names+" }")
+	default:
+		elements := clause.NamedBindings.AsNamedImports().Elements.Nodes
+		changes.insertText(file, elements[len(elements)-1].End(), ", "+names)
+	}
+}
+
+func getNewImportText(file *ast.SourceFile, specifier string, pending *pendingImport) string {
+	var b strings.Builder
+	b.WriteString("import ")
+	if pending.defaultName != "" {
+		b.WriteString(pending.defaultName)
+		if len(pending.namedImport) != 0 {
+			b.WriteString(", ")
+		}
+	}
+	if len(pending.namedImport) != 0 {
+		b.WriteString("{ ")
+		b.WriteString(strings.Join(pending.namedImport, ", "))
+		b.WriteString(" }")
+	}
+	b.WriteString(" from ")
+	b.WriteString(quote(file, &UserPreferences{}, specifier))
+	if probablyUsesSemicolons(file) {
+		b.WriteString(";")
+	}
+	return b.String()
+}
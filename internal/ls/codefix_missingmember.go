@@ -0,0 +1,98 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+)
+
+var addMissingMemberFix = &codeFix{
+	errorCodes: []int32{
+		diagnostics.Property_0_does_not_exist_on_type_1.Code(),
+		diagnostics.Property_0_does_not_exist_on_type_1_Did_you_mean_2.Code(),
+	},
+	getCodeActions: getAddMissingMemberCodeActions,
+}
+
+func getAddMissingMemberCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	sourceFile := fixContext.sourceFile
+	c := fixContext.checker
+	token := astnav.GetTokenAtPosition(sourceFile, fixContext.span.Pos())
+	if !ast.IsIdentifier(token) && !ast.IsPrivateIdentifier(token) {
+		return nil
+	}
+	access := token.Parent
+	if !ast.IsPropertyAccessExpression(access) || access.Name() != token {
+		return nil
+	}
+
+	leftType := c.GetTypeAtLocation(access.Expression())
+	if leftType.Flags()&checker.TypeFlagsTypeParameter != 0 {
+		if constraint := c.GetConstraintOfTypeParameter(leftType); constraint != nil {
+			leftType = constraint
+		}
+	}
+	symbol := leftType.Symbol()
+	if symbol == nil {
+		return nil
+	}
+	declaredType := c.GetDeclaredTypeOfSymbol(symbol)
+	instanceType := leftType
+	if leftType.Flags()&checker.TypeFlagsObject != 0 && leftType.Target() != nil {
+		instanceType = leftType.Target()
+	}
+	isStatic := instanceType != declaredType
+
+	container := core.Find(symbol.Declarations, func(declaration *ast.Node) bool {
+		return ast.IsClassLike(declaration) || !isStatic && ast.IsInterfaceDeclaration(declaration)
+	})
+	if container == nil || ast.GetSourceFileOfNode(container).IsDeclarationFile {
+		return nil
+	}
+	if ast.IsPrivateIdentifier(token) && !ast.IsClassLike(container) {
+		return nil
+	}
+
+	text := token.Text() + ": " + getMissingMemberTypeText(c, access, container)
+	if isStatic {
+		text = "static " + text
+	}
+	containerFile := ast.GetSourceFileOfNode(container)
+	if probablyUsesSemicolons(containerFile) {
+		text += ";"
+	}
+
+	changes := newChangeTracker()
+	if lastProperty := getNodeToInsertPropertyAfter(container); lastProperty != nil {
+		changes.insertMemberAfter(containerFile, container, lastProperty, text)
+	} else {
+		changes.insertMemberAtStart(containerFile, container, text)
+	}
+	description := core.IfElse(isStatic, diagnostics.Declare_static_property_0, diagnostics.Declare_property_0).Format(token.Text())
+	return []*codeFixAction{{description: description, changes: changes}}
+}
+
+// getMissingMemberTypeText returns the widened type of the value assigned to access, or any.
+func getMissingMemberTypeText(c *checker.Checker, access *ast.Node, container *ast.Node) string {
+	if parent := access.Parent; ast.IsBinaryExpression(parent) && parent.AsBinaryExpression().OperatorToken.Kind == ast.KindEqualsToken && parent.AsBinaryExpression().Left == access {
+		t := c.GetWidenedType(c.GetBaseTypeOfLiteralType(c.GetTypeAtLocation(parent.AsBinaryExpression().Right)))
+		return c.TypeToStringEx(t, container, codeFixTypeFormatFlags)
+	}
+	return "any"
+}
+
+// getNodeToInsertPropertyAfter returns the last of the leading property declarations of a class or interface.
+func getNodeToInsertPropertyAfter(container *ast.Node) *ast.Node {
+	var result *ast.Node
+	for _, member := range container.Members() {
+		if !ast.IsPropertyDeclaration(member) && !ast.IsPropertySignatureDeclaration(member) {
+			break
+		}
+		result = member
+	}
+	return result
+}
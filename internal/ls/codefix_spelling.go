@@ -0,0 +1,90 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+var spellingFix = &codeFix{
+	errorCodes: []int32{
+		diagnostics.Property_0_does_not_exist_on_type_1_Did_you_mean_2.Code(),
+		diagnostics.Property_0_may_not_exist_on_type_1_Did_you_mean_2.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Could_not_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Cannot_find_namespace_0_Did_you_mean_1.Code(),
+		diagnostics.X_0_has_no_exported_member_named_1_Did_you_mean_2.Code(),
+	},
+	getCodeActions: getSpellingCodeActions,
+}
+
+func getSpellingCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	sourceFile := fixContext.sourceFile
+	node := astnav.GetTokenAtPosition(sourceFile, fixContext.span.Pos())
+	suggestedSymbol := getSpellingSuggestion(fixContext, node)
+	if suggestedSymbol == nil {
+		return nil
+	}
+	suggestion := ast.SymbolName(suggestedSymbol)
+	changes := newChangeTracker()
+	if !scanner.IsIdentifierText(suggestion, sourceFile.LanguageVariant) && ast.IsPropertyAccessExpression(node.Parent) {
+		// o.fooBar -> o["foo-bar"]
+		access := node.Parent
+		expression := scanner.GetSourceTextOfNodeFromSourceFile(sourceFile, access.Expression(), false /*includeTrivia*/)
+		changes.replaceNode(sourceFile, access, expression+"["+quote(sourceFile, &UserPreferences{}, suggestion)+"]")
+	} else {
+		changes.replaceNode(sourceFile, node, suggestion)
+	}
+	return []*codeFixAction{{description: diagnostics.Change_spelling_to_0.Format(suggestion), changes: changes}}
+}
+
+// getSpellingSuggestion returns the symbol the checker suggests in place of the misspelled name node.
+func getSpellingSuggestion(fixContext *codeFixContext, node *ast.Node) *ast.Symbol {
+	if !ast.IsIdentifier(node) && !ast.IsPrivateIdentifier(node) {
+		return nil
+	}
+	c := fixContext.checker
+	parent := node.Parent
+	switch {
+	case ast.IsPropertyAccessExpression(parent) && parent.Name() == node:
+		containingType := c.GetTypeAtLocation(parent.Expression())
+		if parent.Flags&ast.NodeFlagsOptionalChain != 0 {
+			containingType = c.GetNonNullableType(containingType)
+		}
+		return c.GetSuggestedSymbolForNonexistentProperty(node, containingType)
+	case ast.IsQualifiedName(parent) && parent.AsQualifiedName().Right == node:
+		symbol := c.GetSymbolAtLocation(parent.AsQualifiedName().Left)
+		if symbol != nil && symbol.Flags&ast.SymbolFlagsModule != 0 {
+			return c.GetSuggestedSymbolForNonexistentModule(node, symbol)
+		}
+		return nil
+	case ast.IsImportSpecifier(parent) && parent.Name() == node:
+		importDeclaration := ast.FindAncestor(node, ast.IsImportDeclaration)
+		if importDeclaration == nil {
+			return nil
+		}
+		moduleSymbol := c.GetSymbolAtLocation(importDeclaration.AsImportDeclaration().ModuleSpecifier)
+		if moduleSymbol == nil {
+			return nil
+		}
+		return c.GetSuggestedSymbolForNonexistentModule(node, moduleSymbol)
+	}
+	return c.GetSuggestedSymbolForNonexistentSymbol(node, node.Text(), semanticMeaningToSymbolFlags(getMeaningFromLocation(node)))
+}
+
+func semanticMeaningToSymbolFlags(meaning ast.SemanticMeaning) ast.SymbolFlags {
+	var flags ast.SymbolFlags
+	if meaning&ast.SemanticMeaningNamespace != 0 {
+		flags |= ast.SymbolFlagsNamespace
+	}
+	if meaning&ast.SemanticMeaningType != 0 {
+		flags |= ast.SymbolFlagsType
+	}
+	if meaning&ast.SemanticMeaningValue != 0 {
+		flags |= ast.SymbolFlagsValue
+	}
+	return flags
+}
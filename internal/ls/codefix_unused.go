@@ -0,0 +1,166 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+var unusedIdentifierFix = &codeFix{
+	errorCodes: []int32{
+		diagnostics.X_0_is_declared_but_its_value_is_never_read.Code(),
+		diagnostics.X_0_is_declared_but_never_used.Code(),
+		diagnostics.Property_0_is_declared_but_its_value_is_never_read.Code(),
+		diagnostics.All_imports_in_import_declaration_are_unused.Code(),
+		diagnostics.All_destructured_elements_are_unused.Code(),
+		diagnostics.All_variables_are_unused.Code(),
+		diagnostics.All_type_parameters_are_unused.Code(),
+	},
+	getCodeActions: getUnusedIdentifierCodeActions,
+}
+
+func getUnusedIdentifierCodeActions(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	sourceFile := fixContext.sourceFile
+	token := astnav.GetTokenAtPosition(sourceFile, fixContext.span.Pos())
+	changes := newChangeTracker()
+	var description string
+
+	switch fixContext.errorCode {
+	case diagnostics.All_imports_in_import_declaration_are_unused.Code():
+		if !ast.IsImportDeclaration(token.Parent) {
+			return nil
+		}
+		changes.deleteNode(sourceFile, token.Parent)
+		description = diagnostics.Remove_import_from_0.Format(token.Parent.AsImportDeclaration().ModuleSpecifier.Text())
+	case diagnostics.All_destructured_elements_are_unused.Code():
+		pattern := token.Parent
+		if !ast.IsBindingPattern(pattern) || !ast.IsVariableDeclaration(pattern.Parent) || !deleteVariableDeclaration(changes, sourceFile, pattern.Parent) {
+			return nil
+		}
+		description = diagnostics.Remove_unused_destructuring_declaration.Message()
+	case diagnostics.All_variables_are_unused.Code():
+		list := token.Parent
+		if !ast.IsVariableDeclarationList(list) || !ast.IsVariableStatement(list.Parent) {
+			return nil
+		}
+		changes.deleteNode(sourceFile, list.Parent)
+		description = diagnostics.Remove_variable_statement.Message()
+	case diagnostics.All_type_parameters_are_unused.Code():
+		changes.deleteRange(sourceFile, fixContext.span)
+		description = diagnostics.Remove_type_parameters.Message()
+	default:
+		if !ast.IsIdentifier(token) && !ast.IsPrivateIdentifier(token) {
+			return nil
+		}
+		if ast.IsParameter(token.Parent) {
+			return getPrefixWithUnderscoreAction(sourceFile, token)
+		}
+		if !deleteUnusedDeclaration(changes, sourceFile, token) {
+			return nil
+		}
+		description = diagnostics.Remove_unused_declaration_for_Colon_0.Format(token.Text())
+	}
+	return []*codeFixAction{{description: description, changes: changes}}
+}
+
+func getPrefixWithUnderscoreAction(sourceFile *ast.SourceFile, name *ast.Node) []*codeFixAction {
+	parameter := name.Parent
+	if parameter.Name() != name || ast.IsParameterPropertyDeclaration(parameter, parameter.Parent) || isIdentifierThatStartsWithUnderscore(name) {
+		return nil
+	}
+	changes := newChangeTracker()
+	changes.insertText(sourceFile, scanner.GetTokenPosOfNode(name, sourceFile, false /*includeJSDoc*/), "_")
+	return []*codeFixAction{{description: diagnostics.Prefix_0_with_an_underscore.Format(name.Text()), changes: changes}}
+}
+
+func isIdentifierThatStartsWithUnderscore(node *ast.Node) bool {
+	return ast.IsIdentifier(node) && node.Text() != "" && node.Text()[0] == '_'
+}
+
+// deleteUnusedDeclaration deletes the declaration named by name, reporting whether it knew how to.
+func deleteUnusedDeclaration(changes *changeTracker, sourceFile *ast.SourceFile, name *ast.Node) bool {
+	declaration := name.Parent
+	if declaration.Name() != name {
+		return false
+	}
+	switch declaration.Kind {
+	case ast.KindImportClause:
+		clause := declaration.AsImportClause()
+		if clause.NamedBindings == nil {
+			changes.deleteNode(sourceFile, declaration.Parent)
+		} else {
+			// import d, { a } from "m";
+			changes.deleteRange(sourceFile, core.NewTextRange(scanner.GetTokenPosOfNode(name, sourceFile, false /*includeJSDoc*/), scanner.GetTokenPosOfNode(clause.NamedBindings, sourceFile, false /*includeJSDoc*/)))
+		}
+	case ast.KindNamespaceImport:
+		deleteNamedBindings(changes, sourceFile, declaration.Parent)
+	case ast.KindImportSpecifier:
+		elements := declaration.Parent.AsNamedImports().Elements.Nodes
+		if len(elements) == 1 {
+			deleteNamedBindings(changes, sourceFile, declaration.Parent.Parent)
+		} else {
+			changes.deleteNodeInList(sourceFile, declaration, elements)
+		}
+	case ast.KindImportEqualsDeclaration:
+		changes.deleteNode(sourceFile, declaration)
+	case ast.KindVariableDeclaration:
+		return deleteVariableDeclaration(changes, sourceFile, declaration)
+	case ast.KindBindingElement:
+		pattern := declaration.Parent
+		elements := pattern.AsBindingPattern().Elements.Nodes
+		if !ast.IsObjectBindingPattern(pattern) {
+			return false
+		}
+		if len(elements) == 1 {
+			return ast.IsVariableDeclaration(pattern.Parent) && deleteVariableDeclaration(changes, sourceFile, pattern.Parent)
+		}
+		changes.deleteNodeInList(sourceFile, declaration, elements)
+	case ast.KindTypeParameter:
+		typeParameters := declaration.Parent.TypeParameterList()
+		if len(typeParameters.Nodes) == 1 {
+			changes.deleteRange(sourceFile, core.NewTextRange(typeParameters.Pos()-1, scanner.SkipTrivia(sourceFile.Text(), typeParameters.End())+1))
+		} else {
+			changes.deleteNodeInList(sourceFile, declaration, typeParameters.Nodes)
+		}
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration,
+		ast.KindEnumDeclaration, ast.KindModuleDeclaration, ast.KindPropertyDeclaration, ast.KindMethodDeclaration,
+		ast.KindGetAccessor, ast.KindSetAccessor:
+		changes.deleteNode(sourceFile, declaration)
+	default:
+		return false
+	}
+	return true
+}
+
+// deleteNamedBindings deletes the named bindings of an import clause, or the whole import if it has no default import.
+func deleteNamedBindings(changes *changeTracker, sourceFile *ast.SourceFile, importClause *ast.Node) {
+	clause := importClause.AsImportClause()
+	if clause.Name() == nil {
+		changes.deleteNode(sourceFile, importClause.Parent)
+		return
+	}
+	// import d, { a } from "m";
+	changes.deleteRange(sourceFile, core.NewTextRange(clause.Name().End(), clause.NamedBindings.End()))
+}
+
+func deleteVariableDeclaration(changes *changeTracker, sourceFile *ast.SourceFile, declaration *ast.Node) bool {
+	list := declaration.Parent
+	if !ast.IsVariableDeclarationList(list) {
+		return false
+	}
+	declarations := list.AsVariableDeclarationList().Declarations.Nodes
+	if len(declarations) > 1 {
+		changes.deleteNodeInList(sourceFile, declaration, declarations)
+		return true
+	}
+	if !ast.IsVariableStatement(list.Parent) {
+		// The declaration of a for-in or for-of statement cannot be removed.
+		return false
+	}
+	changes.deleteNode(sourceFile, list.Parent)
+	return true
+}
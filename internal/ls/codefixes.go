@@ -0,0 +1,340 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// codeFix provides quick fixes for one or more diagnostic codes.
+type codeFix struct {
+	errorCodes     []int32
+	getCodeActions func(ctx context.Context, fixContext *codeFixContext) []*codeFixAction
+	// includeInFixAll reports whether the fix is safe to apply to every matching diagnostic as part of source.fixAll.
+	includeInFixAll bool
+	// fixAll, if set, replaces the default source.fixAll behavior of applying the first action for each diagnostic.
+	fixAll func(ctx context.Context, fixContext *codeFixContext, diagnostics []*ast.Diagnostic, changes *changeTracker)
+}
+
+type codeFixContext struct {
+	ls         *LanguageService
+	program    *compiler.Program
+	checker    *checker.Checker
+	sourceFile *ast.SourceFile
+	errorCode  int32
+	span       core.TextRange
+}
+
+type codeFixAction struct {
+	description string
+	changes     *changeTracker
+}
+
+var codeFixes = []*codeFix{
+	importFix,
+	addMissingAwaitFix,
+	unusedIdentifierFix,
+	implementInterfaceFix,
+	addMissingMemberFix,
+	spellingFix,
+}
+
+var codeFixesByErrorCode = sync.OnceValue(func() map[int32][]*codeFix {
+	result := map[int32][]*codeFix{}
+	for _, fix := range codeFixes {
+		for _, code := range fix.errorCodes {
+			result[code] = append(result[code], fix)
+		}
+	}
+	return result
+})
+
+func (l *LanguageService) getCodeFixesAtPosition(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile, errorCode int32, span core.TextRange) []*codeFixAction {
+	fixes := codeFixesByErrorCode()[errorCode]
+	if len(fixes) == 0 {
+		return nil
+	}
+	checker, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+	fixContext := &codeFixContext{
+		ls:         l,
+		program:    program,
+		checker:    checker,
+		sourceFile: sourceFile,
+		errorCode:  errorCode,
+		span:       span,
+	}
+	var actions []*codeFixAction
+	for _, fix := range fixes {
+		actions = append(actions, fix.getCodeActions(ctx, fixContext)...)
+	}
+	return actions
+}
+
+// getFixAllChanges applies every fix that is safe to apply unprompted to the diagnostics of sourceFile.
+func (l *LanguageService) getFixAllChanges(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile) *changeTracker {
+	checker, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+
+	diagnostics := slices.Concat(program.GetSemanticDiagnostics(ctx, sourceFile), program.GetSuggestionDiagnostics(ctx, sourceFile))
+	changes := newChangeTracker()
+	for _, fix := range codeFixes {
+		if !fix.includeInFixAll {
+			continue
+		}
+		fixDiagnostics := core.Filter(diagnostics, func(d *ast.Diagnostic) bool {
+			return d.File() == sourceFile && slices.Contains(fix.errorCodes, d.Code())
+		})
+		if len(fixDiagnostics) == 0 {
+			continue
+		}
+		fixContext := &codeFixContext{
+			ls:         l,
+			program:    program,
+			checker:    checker,
+			sourceFile: sourceFile,
+		}
+		if fix.fixAll != nil {
+			fix.fixAll(ctx, fixContext, fixDiagnostics, changes)
+			continue
+		}
+		for _, diagnostic := range fixDiagnostics {
+			fixContext.errorCode = diagnostic.Code()
+			fixContext.span = diagnostic.Loc()
+			if actions := fix.getCodeActions(ctx, fixContext); len(actions) != 0 {
+				changes.mergeIfNoConflict(actions[0].changes)
+			}
+		}
+	}
+	return changes
+}
+
+// findDiagnosticForSpan returns the program diagnostic with the given code and span, as the diagnostics
+// sent by the client do not carry everything the checker reported.
+func findDiagnosticForSpan(ctx context.Context, fixContext *codeFixContext) *ast.Diagnostic {
+	for _, diagnostic := range fixContext.program.GetSemanticDiagnostics(ctx, fixContext.sourceFile) {
+		if diagnostic.Code() == fixContext.errorCode && diagnostic.Loc() == fixContext.span {
+			return diagnostic
+		}
+	}
+	return nil
+}
+
+// changeTracker collects the text changes of a code action across files.
+type changeTracker struct {
+	files   []*ast.SourceFile
+	changes map[*ast.SourceFile][]core.TextChange
+}
+
+func newChangeTracker() *changeTracker {
+	return &changeTracker{changes: map[*ast.SourceFile][]core.TextChange{}}
+}
+
+func (t *changeTracker) isEmpty() bool {
+	return len(t.files) == 0
+}
+
+func (t *changeTracker) replaceRange(sourceFile *ast.SourceFile, textRange core.TextRange, newText string) {
+	if _, ok := t.changes[sourceFile]; !ok {
+		t.files = append(t.files, sourceFile)
+	}
+	t.changes[sourceFile] = append(t.changes[sourceFile], core.TextChange{TextRange: textRange, NewText: newText})
+}
+
+func (t *changeTracker) insertText(sourceFile *ast.SourceFile, pos int, text string) {
+	t.replaceRange(sourceFile, core.NewTextRange(pos, pos), text)
+}
+
+func (t *changeTracker) deleteRange(sourceFile *ast.SourceFile, textRange core.TextRange) {
+	t.replaceRange(sourceFile, textRange, "")
+}
+
+func (t *changeTracker) replaceNode(sourceFile *ast.SourceFile, node *ast.Node, newText string) {
+	t.replaceRange(sourceFile, core.NewTextRange(scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/), node.End()), newText)
+}
+
+// deleteNode deletes node, along with the rest of its line if nothing else is on it.
+func (t *changeTracker) deleteNode(sourceFile *ast.SourceFile, node *ast.Node) {
+	t.deleteRange(sourceFile, getLineRangeOfNode(sourceFile, node))
+}
+
+// deleteNodeInList deletes node from a comma-separated list, along with the comma that separates it from its neighbor.
+func (t *changeTracker) deleteNodeInList(sourceFile *ast.SourceFile, node *ast.Node, list []*ast.Node) {
+	index := slices.Index(list, node)
+	switch {
+	case len(list) == 1:
+		t.deleteRange(sourceFile, core.NewTextRange(scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/), node.End()))
+	case index < len(list)-1:
+		t.deleteRange(sourceFile, core.NewTextRange(scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/), scanner.GetTokenPosOfNode(list[index+1], sourceFile, false /*includeJSDoc*/)))
+	default:
+		t.deleteRange(sourceFile, core.NewTextRange(list[index-1].End(), node.End()))
+	}
+}
+
+// insertMembersAtEnd inserts members before the closing brace of a class or interface. Lines of member text are
+// indented to match the other members, with leading tabs standing for one level of indentation.
+func (t *changeTracker) insertMembersAtEnd(sourceFile *ast.SourceFile, container *ast.Node, members []string) {
+	text := sourceFile.Text()
+	newLine := getNewLineCharacter(sourceFile)
+	indent, indentUnit := getMemberIndentation(sourceFile, container)
+	closeBrace := container.End() - 1
+	lineStart := closeBrace
+	for lineStart > 0 && isWhiteSpaceSingleLine(text[lineStart-1]) {
+		lineStart--
+	}
+	var b strings.Builder
+	if lineStart > 0 && text[lineStart-1] == '\n' && lineStart > container.MemberList().Pos() {
+		for _, member := range members {
+			b.WriteString(formatMember(member, indent, indentUnit, newLine))
+			b.WriteString(newLine)
+		}
+		t.insertText(sourceFile, lineStart, b.String())
+		return
+	}
+	for _, member := range members {
+		b.WriteString(newLine)
+		b.WriteString(formatMember(member, indent, indentUnit, newLine))
+	}
+	b.WriteString(newLine)
+	b.WriteString(getIndentationOfLine(sourceFile, scanner.GetTokenPosOfNode(container, sourceFile, false /*includeJSDoc*/)))
+	t.insertText(sourceFile, closeBrace, b.String())
+}
+
+// insertMemberAfter inserts a member on the line after an existing member.
+func (t *changeTracker) insertMemberAfter(sourceFile *ast.SourceFile, container *ast.Node, after *ast.Node, member string) {
+	newLine := getNewLineCharacter(sourceFile)
+	indent, indentUnit := getMemberIndentation(sourceFile, container)
+	t.insertText(sourceFile, after.End(), newLine+formatMember(member, indent, indentUnit, newLine))
+}
+
+// insertMemberAtStart inserts a member before the first member of a class or interface.
+func (t *changeTracker) insertMemberAtStart(sourceFile *ast.SourceFile, container *ast.Node, member string) {
+	members := container.Members()
+	if len(members) == 0 {
+		t.insertMembersAtEnd(sourceFile, container, []string{member})
+		return
+	}
+	newLine := getNewLineCharacter(sourceFile)
+	indent, indentUnit := getMemberIndentation(sourceFile, container)
+	t.insertText(sourceFile, scanner.GetTokenPosOfNode(members[0], sourceFile, true /*includeJSDoc*/), strings.TrimPrefix(formatMember(member, indent, indentUnit, newLine), indent)+newLine+indent)
+}
+
+// mergeIfNoConflict adds the changes of other unless any of them overlap a change already made to the same file.
+func (t *changeTracker) mergeIfNoConflict(other *changeTracker) bool {
+	for _, file := range other.files {
+		for _, change := range other.changes[file] {
+			for _, existing := range t.changes[file] {
+				if change.Pos() < existing.End() && existing.Pos() < change.End() || change.Pos() == existing.Pos() && change.End() == existing.End() {
+					return false
+				}
+			}
+		}
+	}
+	for _, file := range other.files {
+		for _, change := range other.changes[file] {
+			t.replaceRange(file, change.TextRange, change.NewText)
+		}
+	}
+	return true
+}
+
+func (l *LanguageService) toWorkspaceEdit(changes *changeTracker) *lsproto.WorkspaceEdit {
+	result := make(map[lsproto.DocumentUri][]*lsproto.TextEdit, len(changes.files))
+	for _, file := range changes.files {
+		fileChanges := slices.Clone(changes.changes[file])
+		slices.SortStableFunc(fileChanges, func(a, b core.TextChange) int {
+			return a.Pos() - b.Pos()
+		})
+		result[FileNameToDocumentURI(file.FileName())] = l.toLSProtoTextEdits(file, fileChanges)
+	}
+	return &lsproto.WorkspaceEdit{Changes: &result}
+}
+
+// getLineRangeOfNode returns the range of node and its JSDoc, extended to cover whole lines when nothing else is on them.
+func getLineRangeOfNode(sourceFile *ast.SourceFile, node *ast.Node) core.TextRange {
+	return getLineRange(sourceFile, scanner.GetTokenPosOfNode(node, sourceFile, true /*includeJSDoc*/), node.End())
+}
+
+// getLineRange returns the range from start to end, extended to cover whole lines when nothing else is on them.
+func getLineRange(sourceFile *ast.SourceFile, start int, end int) core.TextRange {
+	text := sourceFile.Text()
+	lineStart := start
+	for lineStart > 0 && isWhiteSpaceSingleLine(text[lineStart-1]) {
+		lineStart--
+	}
+	lineEnd := end
+	for lineEnd < len(text) && isWhiteSpaceSingleLine(text[lineEnd]) {
+		lineEnd++
+	}
+	if lineStart != 0 && text[lineStart-1] != '\n' {
+		return core.NewTextRange(start, end)
+	}
+	switch {
+	case lineEnd == len(text):
+		return core.NewTextRange(lineStart, lineEnd)
+	case text[lineEnd] == '\n':
+		return core.NewTextRange(lineStart, lineEnd+1)
+	case text[lineEnd] == '\r' && lineEnd+1 < len(text) && text[lineEnd+1] == '\n':
+		return core.NewTextRange(lineStart, lineEnd+2)
+	}
+	return core.NewTextRange(start, end)
+}
+
+// getIndentationOfLine returns the leading whitespace of the line containing pos.
+func getIndentationOfLine(sourceFile *ast.SourceFile, pos int) string {
+	text := sourceFile.Text()
+	lineStarts := scanner.GetLineStarts(sourceFile)
+	lineStart := int(lineStarts[scanner.ComputeLineOfPosition(lineStarts, pos)])
+	end := lineStart
+	for end < len(text) && isWhiteSpaceSingleLine(text[end]) {
+		end++
+	}
+	return text[lineStart:end]
+}
+
+// getMemberIndentation returns the indentation of the members of a class or interface, and the unit of indentation.
+func getMemberIndentation(sourceFile *ast.SourceFile, container *ast.Node) (indent string, indentUnit string) {
+	containerIndent := getIndentationOfLine(sourceFile, scanner.GetTokenPosOfNode(container, sourceFile, false /*includeJSDoc*/))
+	indentUnit = "    "
+	if strings.HasPrefix(containerIndent, "\t") {
+		indentUnit = "\t"
+	}
+	if members := container.Members(); len(members) != 0 {
+		memberIndent := getIndentationOfLine(sourceFile, scanner.GetTokenPosOfNode(members[0], sourceFile, false /*includeJSDoc*/))
+		if len(memberIndent) > len(containerIndent) {
+			return memberIndent, indentUnit
+		}
+	}
+	return containerIndent + indentUnit, indentUnit
+}
+
+// formatMember indents each line of member text, replacing the leading tabs of a line with indentUnit.
+func formatMember(member string, indent string, indentUnit string, newLine string) string {
+	lines := strings.Split(member, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, "\t")
+		lines[i] = indent + strings.Repeat(indentUnit, len(line)-len(trimmed)) + trimmed
+	}
+	return strings.Join(lines, newLine)
+}
+
+func isWhiteSpaceSingleLine(ch byte) bool {
+	return ch == ' ' || ch == '\t'
+}
+
+func getNewLineCharacter(sourceFile *ast.SourceFile) string {
+	if lineStarts := scanner.GetLineStarts(sourceFile); len(lineStarts) > 1 {
+		if end := int(lineStarts[1]); end >= 2 && sourceFile.Text()[end-2] == '\r' {
+			return "\r\n"
+		}
+	}
+	return "\n"
+}
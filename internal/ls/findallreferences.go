@@ -967,14 +967,12 @@ func getReferencedSymbolsForSymbol(originalSymbol *ast.Symbol, node *ast.Node, s
 	state := newState(sourceFiles, sourceFilesSet, node, checker /*, cancellationToken*/, searchMeaning, options)
 
 	var exportSpecifier *ast.Node
-	if !isForRenameWithPrefixAndSuffixText(options) || len(symbol.Declarations) == 0 {
+	if isForRenameWithPrefixAndSuffixText(options) && len(symbol.Declarations) != 0 {
 		exportSpecifier = core.Find(symbol.Declarations, ast.IsExportSpecifier)
 	}
 	if exportSpecifier != nil {
-		// !!! not implemented
-
 		// When renaming at an export specifier, rename the export and not the thing being exported.
-		// state.getReferencesAtExportSpecifier(exportSpecifier.Name(), symbol, exportSpecifier.AsExportSpecifier(), state.createSearch(node, originalSymbol, comingFromUnknown /*comingFrom*/, "", nil), true /*addReferencesHere*/, true /*alwaysGetReferences*/)
+		state.getReferencesAtExportSpecifier(exportSpecifier.Name(), symbol, exportSpecifier.AsExportSpecifier(), state.createSearch(node, originalSymbol, comingFromExport /*comingFrom*/, "", nil), true /*addReferencesHere*/, true /*alwaysGetReferences*/)
 	} else if node != nil && node.Kind == ast.KindDefaultKeyword && symbol.Name == ast.InternalSymbolNameDefault && symbol.Parent != nil {
 		state.addReference(node, symbol, entryKindNone)
 		// !!! not implemented
@@ -1056,7 +1054,7 @@ type refState struct {
 
 	inheritsFromCache            map[inheritKey]bool
 	seenContainingTypeReferences *collections.Set[*ast.Node] // node seen tracker
-	// seenReExportRHS           *collections.Set[*ast.Node] // node seen tracker
+	seenReExportRHS              *collections.Set[*ast.Node] // node seen tracker
 	// importTracker             ImportTracker
 	symbolIdToReferences    map[ast.SymbolId]*SymbolAndEntries
 	sourceFileToSeenSymbols map[ast.NodeId]*collections.Set[ast.SymbolId]
@@ -1073,9 +1071,9 @@ func newState(sourceFiles []*ast.SourceFile, sourceFilesSet *collections.Set[str
 		result:                       []*SymbolAndEntries{},
 		inheritsFromCache:            map[inheritKey]bool{},
 		seenContainingTypeReferences: &collections.Set[*ast.Node]{},
-		// seenReExportRHS:           &collections.Set[*ast.Node]{},
-		symbolIdToReferences:    map[ast.SymbolId]*SymbolAndEntries{},
-		sourceFileToSeenSymbols: map[ast.NodeId]*collections.Set[ast.SymbolId]{},
+		seenReExportRHS:              &collections.Set[*ast.Node]{},
+		symbolIdToReferences:         map[ast.SymbolId]*SymbolAndEntries{},
+		sourceFileToSeenSymbols:      map[ast.NodeId]*collections.Set[ast.SymbolId]{},
 	}
 }
 
@@ -1296,9 +1294,8 @@ func (state *refState) getReferencesAtLocation(sourceFile *ast.SourceFile, posit
 	}
 
 	if parent.Kind == ast.KindExportSpecifier {
-		// !!! not implemented
 		// debug.Assert(referenceLocation.Kind == ast.KindIdentifier || referenceLocation.Kind == ast.KindStringLiteral)
-		// state.getReferencesAtExportSpecifier(referenceLocation /* Identifier | StringLiteral*/, referenceSymbol, parent.AsExportSpecifier(), search, addReferencesHere, false /*alwaysGetReferences*/)
+		state.getReferencesAtExportSpecifier(referenceLocation /* Identifier | StringLiteral*/, referenceSymbol, parent.AsExportSpecifier(), search, addReferencesHere, false /*alwaysGetReferences*/)
 		return
 	}
 
@@ -1332,8 +1329,173 @@ func (state *refState) getReferencesAtLocation(sourceFile *ast.SourceFile, posit
 		}
 	}
 
-	// !!! not implemented
-	// state.getImportOrExportReferences(referenceLocation, referenceSymbol, search)
+	state.getImportOrExportReferences(referenceLocation, referenceSymbol, search)
+}
+
+func (state *refState) getReferencesAtExportSpecifier(referenceLocation *ast.Node, referenceSymbol *ast.Symbol, exportSpecifier *ast.ExportSpecifier, search *refSearch, addReferencesHere bool, alwaysGetReferences bool) {
+	// Debug.assert(!alwaysGetReferences || isForRenameWithPrefixAndSuffixText(state.options), "If alwaysGetReferences is true, then prefix/suffix text must be enabled");
+
+	propertyName := exportSpecifier.PropertyName
+	name := exportSpecifier.Name()
+	exportDeclaration := exportSpecifier.Parent.Parent.AsExportDeclaration()
+	localSymbol := referenceSymbol
+	if referenceLocation.Kind == ast.KindIdentifier {
+		localSymbol = getLocalSymbolForExportSpecifier(referenceLocation.AsIdentifier(), referenceSymbol, exportSpecifier, state.checker)
+	}
+	if !alwaysGetReferences && !search.includes(localSymbol) {
+		return
+	}
+
+	addRef := func() {
+		if addReferencesHere {
+			state.addReference(referenceLocation, localSymbol, entryKindNone)
+		}
+	}
+
+	if propertyName == nil {
+		// Don't rename at `export { default } from "m";`. (but do continue to search for imports of the re-export)
+		if !(state.options.use == referenceUseRename && ast.ModuleExportNameIsDefault(name)) {
+			addRef()
+		}
+	} else if referenceLocation == propertyName {
+		// For `export { foo as bar } from "baz"`, "`foo`" will be added from the singleReferences for import searches of the original export.
+		// For `export { foo as bar };`, where `foo` is a local, so add it now.
+		if exportDeclaration.ModuleSpecifier == nil {
+			addRef()
+		}
+
+		if addReferencesHere && state.options.use != referenceUseRename && state.seenReExportRHS.AddIfAbsent(name) {
+			state.addReference(name, exportSpecifier.AsNode().Symbol(), entryKindNone)
+		}
+	} else {
+		if state.seenReExportRHS.AddIfAbsent(referenceLocation) {
+			addRef()
+		}
+	}
+
+	// For `export { foo as bar }`, rename `foo`, but not `bar`.
+	if !isForRenameWithPrefixAndSuffixText(state.options) || alwaysGetReferences {
+		isDefaultExport := ast.ModuleExportNameIsDefault(referenceLocation) || ast.ModuleExportNameIsDefault(name)
+		exportKind := core.IfElse(isDefaultExport, ExportKindDefault, ExportKindNamed)
+		state.searchForImportsOfExport(referenceLocation, exportSpecifier.AsNode().Symbol(), &ExportInfo{exportingModuleSymbol: exportSpecifier.AsNode().Symbol().Parent, exportKind: exportKind})
+	}
+
+	// At `export { x } from "foo"`, also search for the imported symbol `"foo".x`.
+	if search.comingFrom != comingFromExport && exportDeclaration.ModuleSpecifier != nil && propertyName == nil && !isForRenameWithPrefixAndSuffixText(state.options) {
+		if imported := state.checker.GetExportSpecifierLocalTargetSymbol(exportSpecifier.AsNode()); imported != nil {
+			state.searchForImportedSymbol(imported)
+		}
+	}
+}
+
+// getImportOrExportReferences continues the search across module boundaries when a reference is
+// the local name of an import, or the name of an exported declaration.
+func (state *refState) getImportOrExportReferences(referenceLocation *ast.Node, referenceSymbol *ast.Symbol, search *refSearch) {
+	parent := referenceLocation.Parent
+	if referenceSymbol.Flags&ast.SymbolFlagsAlias != 0 && isImportLocalName(referenceLocation) {
+		if search.comingFrom == comingFromExport || isForRenameWithPrefixAndSuffixText(state.options) {
+			return
+		}
+		if imported := state.checker.GetImmediateAliasedSymbol(referenceSymbol); imported != nil {
+			state.searchForImportedSymbol(imported)
+		}
+		return
+	}
+
+	// !!! `export =`, `export default <expression>` and CommonJS exports are not tracked.
+	if parent.Name() != referenceLocation || ast.GetCombinedModifierFlags(parent)&ast.ModifierFlagsExport == 0 {
+		return
+	}
+	exportSymbol := core.IfElse(referenceSymbol.ExportSymbol != nil, referenceSymbol.ExportSymbol, referenceSymbol)
+	if exportSymbol.Parent == nil || !checker.IsExternalModuleSymbol(exportSymbol.Parent) {
+		return
+	}
+	exportKind := core.IfElse(exportSymbol.Name == ast.InternalSymbolNameDefault, ExportKindDefault, ExportKindNamed)
+	state.searchForImportsOfExport(referenceLocation, exportSymbol, &ExportInfo{exportingModuleSymbol: exportSymbol.Parent, exportKind: exportKind})
+}
+
+// isImportLocalName reports whether node is the local name introduced by an import declaration.
+func isImportLocalName(node *ast.Node) bool {
+	switch node.Parent.Kind {
+	case ast.KindImportSpecifier, ast.KindImportClause, ast.KindNamespaceImport, ast.KindImportEqualsDeclaration:
+		return node.Parent.Name() == node
+	}
+	return false
+}
+
+func (state *refState) searchForImportedSymbol(symbol *ast.Symbol) {
+	for _, declaration := range symbol.Declarations {
+		exportingFile := ast.GetSourceFileOfNode(declaration)
+		// Need to search in the file even if it's not in the search-file set, because it might export the symbol.
+		state.getReferencesInSourceFile(exportingFile, state.createSearch(declaration, symbol, comingFromImport, "", nil), state.sourceFilesSet.Has(exportingFile.FileName()))
+	}
+}
+
+// searchForImportsOfExport finds the imports and re-exports of exportSymbol in the files being searched.
+// Unlike the full import tracker, this only follows named imports, default imports and named re-exports.
+func (state *refState) searchForImportsOfExport(exportLocation *ast.Node, exportSymbol *ast.Symbol, exportInfo *ExportInfo) {
+	if exportSymbol == nil {
+		return
+	}
+	exportName := ast.SymbolName(exportSymbol)
+	isImportOf := func(element *ast.Node) bool {
+		symbol := element.Symbol()
+		return symbol != nil && symbol.Flags&ast.SymbolFlagsAlias != 0 && state.checker.GetImmediateAliasedSymbol(symbol) == exportSymbol
+	}
+
+	for _, sourceFile := range state.sourceFiles {
+		for _, statement := range sourceFile.Statements.Nodes {
+			var namedBindings *ast.Node
+			switch statement.Kind {
+			case ast.KindImportDeclaration:
+				importClause := statement.AsImportDeclaration().ImportClause
+				if importClause == nil {
+					continue
+				}
+				if exportInfo.exportKind == ExportKindDefault && importClause.Name() != nil && isImportOf(importClause) {
+					state.getReferencesInSourceFile(sourceFile, state.createSearch(importClause.Name(), importClause.Symbol(), comingFromExport, "", nil), true /*addReferencesHere*/)
+				}
+				namedBindings = importClause.AsImportClause().NamedBindings
+			case ast.KindExportDeclaration:
+				if statement.AsExportDeclaration().ModuleSpecifier != nil {
+					namedBindings = statement.AsExportDeclaration().ExportClause
+				}
+			}
+			if namedBindings == nil || !(namedBindings.Kind == ast.KindNamedImports || namedBindings.Kind == ast.KindNamedExports) {
+				continue
+			}
+			for _, element := range namedBindings.Elements() {
+				name := element.Name()
+				propertyName := element.PropertyName()
+				if core.OrElse(propertyName, name).Text() != exportName || !isImportOf(element) {
+					continue
+				}
+				if propertyName != nil {
+					// This is `import { foo as bar } from "./a"` or `export { foo as bar } from "./a"`. `foo` isn't a local in the file, so just add it as a single reference.
+					if state.shouldAddSingleReference(propertyName) {
+						state.referenceAdder(exportSymbol)(propertyName, entryKindNode)
+					}
+					// If renaming `{ foo as bar }`, don't touch `bar`, just `foo`.
+					// But do rename `foo` in ` { default as foo }` if that's the original export name.
+					if state.options.use == referenceUseRename && name.Text() != exportName {
+						continue
+					}
+				}
+				state.getReferencesInSourceFile(sourceFile, state.createSearch(name, element.Symbol(), comingFromExport, "", nil), true /*addReferencesHere*/)
+			}
+		}
+	}
+}
+
+func (state *refState) shouldAddSingleReference(singleRef *ast.Node) bool {
+	if getMeaningFromLocation(singleRef)&state.searchMeaning == 0 {
+		return false
+	}
+	if state.options.use != referenceUseRename {
+		return true
+	}
+	// At `default` in `import { default as x }` or `export { default as x }`, do add a reference, but do not rename.
+	return !(ast.IsImportOrExportSpecifier(singleRef.Parent) && ast.ModuleExportNameIsDefault(singleRef))
 }
 
 func (state *refState) getReferenceForShorthandProperty(referenceSymbol *ast.Symbol, search *refSearch) {
@@ -1448,9 +1610,41 @@ func (state *refState) forEachRelatedSymbol(
 			return nil
 		}), returnKind
 	}
-	// !!! not yet implemented
-	// const containingObjectLiteralElement = getContainingObjectLiteralElement(location);
-	// if (containingObjectLiteralElement) {}
+	if containingObjectLiteralElement := getContainingObjectLiteralElement(location); containingObjectLiteralElement != nil {
+		// Because in short-hand property assignment, location has two meaning : property name and as value of the property
+		// When we do findAllReference at the position of the short-hand property assignment, we would want to have references to position of
+		// property name and variable declaration of the identifier.
+		// Like in below example, when querying for all references for an identifier 'name', of the property assignment, the language service
+		// should show both 'name' in 'obj' and 'name' in variable declaration
+		//      const name = "Foo";
+		//      const obj = { name };
+		// In order to do that, we will populate the search set with the value symbol of the identifier as a value of the property assignment
+		// so that when matching with potential reference symbol, both symbols from property declaration and variable declaration
+		// will be included correctly.
+		shorthandValueSymbol := state.checker.GetShorthandAssignmentValueSymbol(location.Parent) // gets the local symbol
+		if shorthandValueSymbol != nil && isForRenamePopulateSearchSymbolSet {
+			// When renaming 'x' in `const o = { x }`, just rename the local variable, not the property.
+			return cbSymbol(shorthandValueSymbol, nil /*rootSymbol*/, nil /*baseSymbol*/, entryKindSearchedLocalFoundProperty)
+		}
+
+		// If the location is in a context sensitive location (i.e. in an object literal) try
+		// to get a contextual type for it, and add the property symbol from the contextual
+		// type to the search set
+		if contextualType := state.checker.GetContextualType(containingObjectLiteralElement.Parent, checker.ContextFlagsNone); contextualType != nil {
+			for _, sym := range getPropertySymbolsFromContextualType(containingObjectLiteralElement, state.checker, contextualType, true /*unionSymbolOk*/) {
+				if res, kind := fromRoot(sym, entryKindSearchedPropertyFoundLocal); res != nil {
+					return res, kind
+				}
+			}
+		}
+
+		// !!! destructuring assignment property symbols are not yet searched
+		if shorthandValueSymbol != nil {
+			if res, kind := cbSymbol(shorthandValueSymbol, nil /*rootSymbol*/, nil /*baseSymbol*/, entryKindSearchedLocalFoundProperty); res != nil {
+				return res, kind
+			}
+		}
+	}
 
 	if aliasedSymbol := getMergedAliasedSymbolOfNamespaceExportDeclaration(location, symbol, state.checker); aliasedSymbol != nil {
 		// In case of UMD module and global merging, search for global as well
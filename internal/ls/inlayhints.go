@@ -0,0 +1,343 @@
+package ls
+
+import (
+	"context"
+	"iter"
+	"regexp"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/jsnum"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const inlayHintTypeFormatFlags = checker.TypeFormatFlagsAllowUniqueESSymbolType | checker.TypeFormatFlagsUseAliasDefinedOutsideCurrentScope
+
+func (l *LanguageService) ProvideInlayHint(ctx context.Context, params *lsproto.InlayHintParams, preferences *UserPreferences) (lsproto.InlayHintResponse, error) {
+	program, file := l.getProgramAndFile(params.TextDocument.Uri)
+	checker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	state := &inlayHintState{
+		ctx:         ctx,
+		ls:          l,
+		file:        file,
+		checker:     checker,
+		preferences: preferences,
+		span: core.NewTextRange(
+			int(l.converters.LineAndCharacterToPosition(file, params.Range.Start)),
+			int(l.converters.LineAndCharacterToPosition(file, params.Range.End)),
+		),
+	}
+	state.visit(file.AsNode())
+	if ctx.Err() != nil {
+		return lsproto.InlayHintsOrNull{}, ctx.Err()
+	}
+	return lsproto.InlayHintsOrNull{InlayHints: &state.result}, nil
+}
+
+type inlayHintState struct {
+	ctx         context.Context
+	ls          *LanguageService
+	file        *ast.SourceFile
+	checker     *checker.Checker
+	preferences *UserPreferences
+	span        core.TextRange
+	result      []*lsproto.InlayHint
+}
+
+func (s *inlayHintState) visit(node *ast.Node) bool {
+	if node == nil || node.End()-node.Pos() == 0 {
+		return false
+	}
+	switch node.Kind {
+	case ast.KindModuleDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindFunctionDeclaration,
+		ast.KindClassExpression, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindArrowFunction:
+		if s.ctx.Err() != nil {
+			return true
+		}
+	}
+	if node.Pos() > s.span.End() || node.End() < s.span.Pos() {
+		return false
+	}
+	if ast.IsTypeNode(node) && !ast.IsExpressionWithTypeArguments(node) {
+		return false
+	}
+
+	preferences := s.preferences
+	switch {
+	case ptrIsTrue(preferences.IncludeInlayVariableTypeHints) && ast.IsVariableDeclaration(node),
+		ptrIsTrue(preferences.IncludeInlayPropertyDeclarationTypeHints) && ast.IsPropertyDeclaration(node):
+		s.visitVariableLikeDeclaration(node)
+	case ptrIsTrue(preferences.IncludeInlayEnumMemberValueHints) && ast.IsEnumMember(node):
+		s.visitEnumMember(node)
+	case s.shouldShowParameterNameHints() && (ast.IsCallExpression(node) || ast.IsNewExpression(node)):
+		s.visitCallOrNewExpression(node)
+	default:
+		if ptrIsTrue(preferences.IncludeInlayFunctionParameterTypeHints) && ast.IsFunctionLikeDeclaration(node) && checker.HasContextSensitiveParameters(node) {
+			s.visitFunctionLikeForParameterType(node)
+		}
+		if ptrIsTrue(preferences.IncludeInlayFunctionLikeReturnTypeHints) && isSignatureSupportingReturnAnnotation(node) {
+			s.visitFunctionDeclarationLikeForReturnType(node)
+		}
+	}
+	return node.ForEachChild(s.visit)
+}
+
+func (s *inlayHintState) shouldShowParameterNameHints() bool {
+	hints := s.preferences.IncludeInlayParameterNameHints
+	return hints != nil && (*hints == IncludeInlayParameterNameHintsLiterals || *hints == IncludeInlayParameterNameHintsAll)
+}
+
+func (s *inlayHintState) shouldShowLiteralParameterNameHintsOnly() bool {
+	hints := s.preferences.IncludeInlayParameterNameHints
+	return hints != nil && *hints == IncludeInlayParameterNameHintsLiterals
+}
+
+func isSignatureSupportingReturnAnnotation(node *ast.Node) bool {
+	return ast.IsArrowFunction(node) || ast.IsFunctionExpression(node) || ast.IsFunctionDeclaration(node) || ast.IsMethodDeclaration(node) || ast.IsGetAccessorDeclaration(node)
+}
+
+func (s *inlayHintState) addParameterHint(text string, position int, isFirstVariadicArgument bool) {
+	if isFirstVariadicArgument {
+		text = "..." + text
+	}
+	s.result = append(s.result, &lsproto.InlayHint{
+		Position:     s.ls.createLspPosition(position, s.file),
+		Label:        lsproto.StringOrInlayHintLabelParts{String: ptrTo(text + ":")},
+		Kind:         ptrTo(lsproto.InlayHintKindParameter),
+		PaddingRight: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) addTypeHint(text string, position int) {
+	s.result = append(s.result, &lsproto.InlayHint{
+		Position:    s.ls.createLspPosition(position, s.file),
+		Label:       lsproto.StringOrInlayHintLabelParts{String: ptrTo(": " + text)},
+		Kind:        ptrTo(lsproto.InlayHintKindType),
+		PaddingLeft: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) addEnumMemberValueHint(text string, position int) {
+	// LSP has no kind for enum member values.
+	s.result = append(s.result, &lsproto.InlayHint{
+		Position:    s.ls.createLspPosition(position, s.file),
+		Label:       lsproto.StringOrInlayHintLabelParts{String: ptrTo("= " + text)},
+		PaddingLeft: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) visitEnumMember(member *ast.Node) {
+	if member.Initializer() != nil {
+		return
+	}
+	switch value := s.checker.GetConstantValue(member).(type) {
+	case jsnum.Number:
+		s.addEnumMemberValueHint(value.String(), member.End())
+	case string:
+		s.addEnumMemberValueHint(value, member.End())
+	}
+}
+
+func isModuleReferenceType(t *checker.Type) bool {
+	return t.Symbol() != nil && t.Symbol().Flags&ast.SymbolFlagsModule != 0
+}
+
+func (s *inlayHintState) visitVariableLikeDeclaration(decl *ast.Node) {
+	if decl.Initializer() == nil && !(ast.IsPropertyDeclaration(decl) && s.checker.GetTypeAtLocation(decl).Flags()&checker.TypeFlagsAny == 0) ||
+		ast.IsBindingPattern(decl.Name()) ||
+		ast.IsVariableDeclaration(decl) && !isHintableDeclaration(decl) {
+		return
+	}
+	if decl.Type() != nil {
+		return
+	}
+	declarationType := s.checker.GetTypeAtLocation(decl)
+	if isModuleReferenceType(declarationType) {
+		return
+	}
+	hintText := s.checker.TypeToStringEx(declarationType, nil, inlayHintTypeFormatFlags)
+	if ptrIsFalse(s.preferences.IncludeInlayVariableTypeHintsWhenTypeMatchesName) &&
+		strings.EqualFold(scanner.GetSourceTextOfNodeFromSourceFile(s.file, decl.Name(), false /*includeTrivia*/), hintText) {
+		return
+	}
+	s.addTypeHint(hintText, decl.Name().End())
+}
+
+func (s *inlayHintState) visitCallOrNewExpression(expr *ast.Node) {
+	args := expr.Arguments()
+	if len(args) == 0 {
+		return
+	}
+	signature := s.checker.GetResolvedSignature(expr)
+	if signature == nil {
+		return
+	}
+	signatureParamPos := 0
+	for _, originalArg := range args {
+		arg := ast.SkipParentheses(originalArg)
+		if s.shouldShowLiteralParameterNameHintsOnly() && !isHintableLiteral(arg) {
+			signatureParamPos++
+			continue
+		}
+		spreadArgs := 0
+		if ast.IsSpreadElement(arg) {
+			spreadType := s.checker.GetTypeAtLocation(arg.Expression())
+			if checker.IsTupleType(spreadType) {
+				tupleType := spreadType.TargetTupleType()
+				if tupleType.FixedLength() == 0 {
+					continue
+				}
+				firstOptionalIndex := core.FindIndex(tupleType.ElementFlags(), func(flags checker.ElementFlags) bool {
+					return flags&checker.ElementFlagsRequired == 0
+				})
+				spreadArgs = core.IfElse(firstOptionalIndex < 0, tupleType.FixedLength(), firstOptionalIndex)
+			}
+		}
+		parameter, parameterName, isFirstVariadicArgument := s.checker.GetParameterIdentifierInfoAtPosition(signature, signatureParamPos)
+		signatureParamPos += core.IfElse(spreadArgs > 0, spreadArgs, 1)
+		if parameter == nil {
+			continue
+		}
+		if !ptrIsTrue(s.preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName) &&
+			identifierOrAccessExpressionPostfixMatchesParameterName(arg, parameterName) && !isFirstVariadicArgument {
+			continue
+		}
+		if s.leadingCommentsContainsParameterName(arg, parameterName) {
+			continue
+		}
+		s.addParameterHint(parameterName, scanner.GetTokenPosOfNode(originalArg, s.file, false /*includeJSDoc*/), isFirstVariadicArgument)
+	}
+}
+
+func identifierOrAccessExpressionPostfixMatchesParameterName(expr *ast.Node, parameterName string) bool {
+	switch {
+	case ast.IsIdentifier(expr):
+		return expr.Text() == parameterName
+	case ast.IsPropertyAccessExpression(expr):
+		return expr.Name().Text() == parameterName
+	}
+	return false
+}
+
+// leadingCommentsContainsParameterName reports whether node is preceded by a comment like `/* name */` or `/** name */`.
+func (s *inlayHintState) leadingCommentsContainsParameterName(node *ast.Node, name string) bool {
+	if !scanner.IsIdentifierText(name, s.file.LanguageVariant) {
+		return false
+	}
+	regex := regexp.MustCompile(`^\s?/\*\*?\s?` + regexp.QuoteMeta(name) + `\s?\*/\s?$`)
+	text := s.file.Text()
+	// Comments on the same line as the preceding token are scanned as trailing comments.
+	factory := &ast.NodeFactory{}
+	for _, commentRanges := range []iter.Seq[ast.CommentRange]{
+		scanner.GetTrailingCommentRanges(factory, text, node.Pos()),
+		scanner.GetLeadingCommentRanges(factory, text, node.Pos()),
+	} {
+		for commentRange := range commentRanges {
+			if regex.MatchString(text[commentRange.Pos():commentRange.End()]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isHintableLiteral(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindPrefixUnaryExpression:
+		operand := node.AsPrefixUnaryExpression().Operand
+		return ast.IsLiteralExpression(operand) || ast.IsIdentifier(operand) && isInfinityOrNaNString(operand.Text())
+	case ast.KindTrueKeyword, ast.KindFalseKeyword, ast.KindNullKeyword, ast.KindNoSubstitutionTemplateLiteral, ast.KindTemplateExpression:
+		return true
+	case ast.KindIdentifier:
+		name := node.Text()
+		return name == "undefined" || isInfinityOrNaNString(name)
+	}
+	return ast.IsLiteralExpression(node)
+}
+
+func isInfinityOrNaNString(name string) bool {
+	return name == "Infinity" || name == "-Infinity" || name == "NaN"
+}
+
+func (s *inlayHintState) visitFunctionDeclarationLikeForReturnType(decl *ast.Node) {
+	if ast.IsArrowFunction(decl) && findChildOfKind(decl, ast.KindOpenParenToken, s.file) == nil {
+		return
+	}
+	if decl.Type() != nil || decl.Body() == nil {
+		return
+	}
+	signature := s.checker.GetSignatureFromDeclaration(decl)
+	if signature == nil {
+		return
+	}
+	if typePredicate := s.checker.GetTypePredicateOfSignature(signature); typePredicate != nil && typePredicate.Type() != nil {
+		s.addTypeHint(s.checker.TypePredicateToString(typePredicate), s.getTypeAnnotationPosition(decl))
+		return
+	}
+	returnType := s.checker.GetReturnTypeOfSignature(signature)
+	if isModuleReferenceType(returnType) {
+		return
+	}
+	s.addTypeHint(s.checker.TypeToStringEx(returnType, nil, inlayHintTypeFormatFlags), s.getTypeAnnotationPosition(decl))
+}
+
+func (s *inlayHintState) getTypeAnnotationPosition(decl *ast.Node) int {
+	if closeParenToken := findChildOfKind(decl, ast.KindCloseParenToken, s.file); closeParenToken != nil {
+		return closeParenToken.End()
+	}
+	return decl.ParameterList().End()
+}
+
+func (s *inlayHintState) visitFunctionLikeForParameterType(node *ast.Node) {
+	signature := s.checker.GetSignatureFromDeclaration(node)
+	if signature == nil {
+		return
+	}
+	pos := 0
+	for _, param := range node.Parameters() {
+		if isHintableDeclaration(param) {
+			if ast.IsThisParameter(param) {
+				s.addParameterTypeHint(param, signature.ThisParameter())
+			} else if pos < len(signature.Parameters()) {
+				s.addParameterTypeHint(param, signature.Parameters()[pos])
+			}
+		}
+		if ast.IsThisParameter(param) {
+			continue
+		}
+		pos++
+	}
+}
+
+func (s *inlayHintState) addParameterTypeHint(node *ast.Node, symbol *ast.Symbol) {
+	if node.Type() != nil || symbol == nil {
+		return
+	}
+	valueDeclaration := symbol.ValueDeclaration
+	if valueDeclaration == nil || !ast.IsParameter(valueDeclaration) {
+		return
+	}
+	signatureParamType := s.checker.GetTypeOfSymbolAtLocation(symbol, valueDeclaration)
+	if isModuleReferenceType(signatureParamType) {
+		return
+	}
+	position := node.Name().End()
+	if questionToken := node.AsParameterDeclaration().QuestionToken; questionToken != nil {
+		position = questionToken.End()
+	}
+	s.addTypeHint(s.checker.TypeToStringEx(signatureParamType, nil, inlayHintTypeFormatFlags), position)
+}
+
+// isHintableDeclaration reports whether the type of a declaration is not already evident from its initializer.
+func isHintableDeclaration(node *ast.Node) bool {
+	if (ast.IsPartOfParameterDeclaration(node) || ast.IsVariableDeclaration(node) && ast.IsVarConst(node)) && node.Initializer() != nil {
+		initializer := ast.SkipParentheses(node.Initializer())
+		return !(isHintableLiteral(initializer) || ast.IsNewExpression(initializer) || ast.IsObjectLiteralExpression(initializer) || ast.IsAssertionExpression(initializer))
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package ls_test
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestInlayHints(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title       string
+		input       string
+		preferences *ls.UserPreferences
+		expected    string
+	}{
+		{
+			title: "parameterNamesAll",
+			input: `function f(a: number, b: string, ...rest: boolean[]) {}
+const b = "";
+f(1, b, true, false);
+f(/* a */ 2, String(3));`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayParameterNameHints: ptrTo(ls.IncludeInlayParameterNameHintsAll),
+			},
+			expected: `function f(a: number, b: string, ...rest: boolean[]) {}
+const b = "";
+f(a: 1, b, ...rest: true, false);
+f(/* a */ 2, b: String(value: 3));`,
+		},
+		{
+			title: "parameterNamesLiterals",
+			input: `function f(a: number, b: string, c: boolean) {}
+const x = 1;
+f(x, "", -1);
+new Date(2024);`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayParameterNameHints: ptrTo(ls.IncludeInlayParameterNameHintsLiterals),
+			},
+			expected: `function f(a: number, b: string, c: boolean) {}
+const x = 1;
+f(x, b: "", c: -1);
+new Date(value: 2024);`,
+		},
+		{
+			title: "parameterNamesWhenArgumentMatchesName",
+			input: `function f(a: number) {}
+const o = { a: 1 };
+f(o.a);`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayParameterNameHints:                        ptrTo(ls.IncludeInlayParameterNameHintsAll),
+				IncludeInlayParameterNameHintsWhenArgumentMatchesName: ptrTo(true),
+			},
+			expected: `function f(a: number) {}
+const o = { a: 1 };
+f(a: o.a);`,
+		},
+		{
+			title: "functionParameterTypes",
+			input: `const f: (x: number, y?: string) => void = (x, y?) => {};
+[1].forEach(n => n);`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayFunctionParameterTypeHints: ptrTo(true),
+			},
+			expected: `const f: (x: number, y?: string) => void = (x : number, y? : string | undefined) => {};
+[1].forEach(n : number => n);`,
+		},
+		{
+			title: "variableTypes",
+			input: `const a = [1];
+let b = "";
+const c = 1;
+const d = { x: 1 };
+const { e } = d;
+const f: number = 2;`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayVariableTypeHints: ptrTo(true),
+			},
+			expected: `const a : number[] = [1];
+let b : string = "";
+const c = 1;
+const d = { x: 1 };
+const { e } = d;
+const f: number = 2;`,
+		},
+		{
+			title: "variableTypesWhenTypeMatchesName",
+			input: `class Foo {}
+const foo = (() => new Foo())();
+const bar = foo;`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayVariableTypeHints:                    ptrTo(true),
+				IncludeInlayVariableTypeHintsWhenTypeMatchesName: ptrTo(false),
+			},
+			expected: `class Foo {}
+const foo = (() => new Foo())();
+const bar : Foo = foo;`,
+		},
+		{
+			title: "propertyDeclarationTypes",
+			input: `class C {
+    a = 1;
+    b;
+    c: string = "";
+    constructor() {
+        this.b = true;
+    }
+}`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayPropertyDeclarationTypeHints: ptrTo(true),
+			},
+			expected: `class C {
+    a : number = 1;
+    b;
+    c: string = "";
+    constructor() {
+        this.b = true;
+    }
+}`,
+		},
+		{
+			title: "returnTypes",
+			input: `function f() { return 1; }
+const g = () => "";
+const h = x => x;
+class C {
+    get p() { return true; }
+    m(): void {}
+}
+function isString(x: unknown) { return typeof x === "string"; }`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayFunctionLikeReturnTypeHints: ptrTo(true),
+			},
+			expected: `function f() : number { return 1; }
+const g = () : string => "";
+const h = x => x;
+class C {
+    get p() : boolean { return true; }
+    m(): void {}
+}
+function isString(x: unknown) : x is string { return typeof x === "string"; }`,
+		},
+		{
+			title: "enumMemberValues",
+			input: `enum E {
+    A,
+    B = 5,
+    C,
+    D = "d",
+}`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayEnumMemberValueHints: ptrTo(true),
+			},
+			expected: `enum E {
+    A = 0,
+    B = 5,
+    C = 6,
+    D = "d",
+}`,
+		},
+		{
+			title: "noPreferences",
+			input: `function f(a: number) { return a; }
+const x = f(1);`,
+			preferences: &ls.UserPreferences{},
+			expected: `function f(a: number) { return a; }
+const x = f(1);`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			runInlayHintTest(t, testCase.input, testCase.preferences, testCase.expected)
+		})
+	}
+}
+
+// runInlayHintTest renders the inlay hints for /a.ts into its text and compares the result to expected.
+func runInlayHintTest(t *testing.T, input string, preferences *ls.UserPreferences, expected string) {
+	testData := fourslash.ParseTestData(t, input, "/a.ts")
+	ctx := projecttestutil.WithRequestID(t.Context())
+	languageService, done := createLanguageServiceForRename(ctx, testData)
+	defer done()
+
+	content := testData.Files[0].Content
+	lines := strings.Split(content, "\n")
+	result, err := languageService.ProvideInlayHint(ctx, &lsproto.InlayHintParams{
+		TextDocument: lsproto.TextDocumentIdentifier{Uri: ls.FileNameToDocumentURI("/a.ts")},
+		Range: lsproto.Range{
+			End: lsproto.Position{Line: uint32(len(lines) - 1), Character: uint32(len(lines[len(lines)-1]))},
+		},
+	}, preferences)
+	assert.NilError(t, err)
+
+	var edits []*lsproto.TextEdit
+	for _, hint := range *result.InlayHints {
+		text := *hint.Label.String
+		if hint.PaddingLeft != nil && *hint.PaddingLeft {
+			text = " " + text
+		}
+		if hint.PaddingRight != nil && *hint.PaddingRight {
+			text += " "
+		}
+		edits = append(edits, &lsproto.TextEdit{Range: lsproto.Range{Start: hint.Position, End: hint.Position}, NewText: text})
+	}
+	slices.SortStableFunc(edits, func(a, b *lsproto.TextEdit) int {
+		return cmp.Or(cmp.Compare(a.Range.Start.Line, b.Range.Start.Line), cmp.Compare(a.Range.Start.Character, b.Range.Start.Character))
+	})
+	assert.Equal(t, applyTextEdits(content, edits), expected)
+}
@@ -0,0 +1,317 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// organizeImports removes unused imports, merges imports of the same module and sorts the imports of sourceFile.
+// Each run of top-level import declarations not separated by a blank line is organized on its own.
+func (l *LanguageService) organizeImports(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile) *changeTracker {
+	checker, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+
+	organizer := &importOrganizer{
+		sourceFile:   sourceFile,
+		checker:      checker,
+		jsxNamespace: getJsxNamespaceRequiringImport(sourceFile, program.Options()),
+		semicolons:   probablyUsesSemicolons(sourceFile),
+	}
+	changes := newChangeTracker()
+	for _, group := range getImportGroups(sourceFile) {
+		organizer.organizeGroup(changes, group)
+	}
+	return changes
+}
+
+// getImportGroups returns the runs of top-level import declarations of sourceFile, split on blank lines.
+func getImportGroups(sourceFile *ast.SourceFile) [][]*ast.Node {
+	text := sourceFile.Text()
+	var groups [][]*ast.Node
+	var group []*ast.Node
+	for _, statement := range sourceFile.Statements.Nodes {
+		if !ast.IsImportDeclaration(statement) {
+			if len(group) != 0 {
+				groups = append(groups, group)
+				group = nil
+			}
+			continue
+		}
+		if len(group) != 0 && strings.Count(text[statement.Pos():scanner.GetTokenPosOfNode(statement, sourceFile, false /*includeJSDoc*/)], "\n") >= 2 {
+			groups = append(groups, group)
+			group = nil
+		}
+		group = append(group, statement)
+	}
+	if len(group) != 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// getJsxNamespaceRequiringImport returns the name JSX elements in sourceFile implicitly reference, if any.
+func getJsxNamespaceRequiringImport(sourceFile *ast.SourceFile, options *core.CompilerOptions) string {
+	if sourceFile.LanguageVariant != core.LanguageVariantJSX || options.Jsx != core.JsxEmitReact && options.Jsx != core.JsxEmitReactNative {
+		return ""
+	}
+	if options.JsxFactory != "" {
+		return strings.Split(options.JsxFactory, ".")[0]
+	}
+	return "React"
+}
+
+type importOrganizer struct {
+	sourceFile   *ast.SourceFile
+	checker      *checker.Checker
+	jsxNamespace string
+	semicolons   bool
+}
+
+// organizedImport is an import declaration after removing unused bindings and merging.
+type organizedImport struct {
+	original         *ast.Node
+	moduleSpecifier  string
+	isTypeOnly       bool
+	defaultName      *ast.Node
+	namespaceImport  *ast.Node
+	namedImports     []*ast.Node
+	hasNamedBindings bool
+	leadingComments  string
+	changed          bool
+}
+
+func (o *importOrganizer) organizeGroup(changes *changeTracker, group []*ast.Node) {
+	file := o.sourceFile
+	text := file.Text()
+
+	var imports []*organizedImport
+	for i, declaration := range group {
+		if organized := o.removeUnusedBindings(declaration); organized != nil {
+			if i == 0 {
+				// Comments before the group stay where they are.
+				organized.leadingComments = ""
+			}
+			imports = append(imports, organized)
+		}
+	}
+	imports = coalesceImports(imports)
+	slices.SortStableFunc(imports, func(a, b *organizedImport) int {
+		return compareModuleSpecifiers(a.moduleSpecifier, b.moduleSpecifier)
+	})
+
+	newLine := getNewLineCharacter(file)
+	texts := make([]string, 0, len(imports))
+	for _, organized := range imports {
+		declarationText := o.getImportText(organized)
+		if organized.leadingComments != "" {
+			declarationText = organized.leadingComments + newLine + declarationText
+		}
+		texts = append(texts, declarationText)
+	}
+	newText := strings.Join(texts, newLine)
+
+	start := scanner.GetTokenPosOfNode(group[0], file, false /*includeJSDoc*/)
+	end := group[len(group)-1].End()
+	if newText == text[start:end] {
+		return
+	}
+	if newText == "" {
+		changes.deleteRange(file, getLineRange(file, start, end))
+		return
+	}
+	changes.replaceRange(file, core.NewTextRange(start, end), newText)
+}
+
+// removeUnusedBindings returns declaration without its unused bindings, or nil if none of its bindings are used.
+func (o *importOrganizer) removeUnusedBindings(declaration *ast.Node) *organizedImport {
+	file := o.sourceFile
+	importDeclaration := declaration.AsImportDeclaration()
+	result := &organizedImport{
+		original:        declaration,
+		moduleSpecifier: importDeclaration.ModuleSpecifier.Text(),
+		leadingComments: strings.TrimSpace(file.Text()[declaration.Pos():scanner.GetTokenPosOfNode(declaration, file, false /*includeJSDoc*/)]),
+	}
+	if importDeclaration.ImportClause == nil {
+		// Side-effect imports are always kept.
+		return result
+	}
+	clause := importDeclaration.ImportClause.AsImportClause()
+	result.isTypeOnly = clause.IsTypeOnly
+	if name := clause.Name(); name != nil {
+		if o.isUsed(name) {
+			result.defaultName = name
+		} else {
+			result.changed = true
+		}
+	}
+	if namedBindings := clause.NamedBindings; namedBindings != nil {
+		if ast.IsNamespaceImport(namedBindings) {
+			if o.isUsed(namedBindings.Name()) {
+				result.namespaceImport = namedBindings
+			} else {
+				result.changed = true
+			}
+		} else {
+			elements := namedBindings.AsNamedImports().Elements.Nodes
+			result.namedImports = core.Filter(elements, func(element *ast.Node) bool {
+				return o.isUsed(element.Name())
+			})
+			result.hasNamedBindings = len(result.namedImports) != 0
+			if len(result.namedImports) != len(elements) {
+				result.changed = true
+			}
+		}
+	}
+	if result.defaultName == nil && result.namespaceImport == nil && len(result.namedImports) == 0 {
+		return nil
+	}
+	return result
+}
+
+// isUsed reports whether the name declared by an import is referenced anywhere else in the file.
+func (o *importOrganizer) isUsed(name *ast.Node) bool {
+	if name.Text() == o.jsxNamespace {
+		return true
+	}
+	symbol := o.checker.GetSymbolAtLocation(name)
+	if symbol == nil {
+		return true
+	}
+	for _, position := range getPossibleSymbolReferencePositions(o.sourceFile, name.Text(), o.sourceFile.AsNode()) {
+		token := astnav.GetTouchingPropertyName(o.sourceFile, position)
+		if token == name || !ast.IsIdentifier(token) || token.Text() != name.Text() {
+			continue
+		}
+		switch parent := token.Parent; {
+		case ast.IsShorthandPropertyAssignment(parent) && parent.Name() == token:
+			if o.checker.GetShorthandAssignmentValueSymbol(parent) == symbol {
+				return true
+			}
+		case ast.IsExportSpecifier(parent) && (parent.PropertyName() == nil || parent.PropertyName() == token):
+			if o.checker.GetExportSpecifierLocalTargetSymbol(parent) == symbol {
+				return true
+			}
+		default:
+			if o.checker.GetSymbolAtLocation(token) == symbol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coalesceImports merges the default and named imports of the same module into one declaration.
+func coalesceImports(imports []*organizedImport) []*organizedImport {
+	var result []*organizedImport
+	for _, organized := range imports {
+		if organized.original.AsImportDeclaration().ImportClause == nil || organized.namespaceImport != nil || organized.original.AsImportDeclaration().Attributes != nil {
+			result = append(result, organized)
+			continue
+		}
+		index := slices.IndexFunc(result, func(existing *organizedImport) bool {
+			return existing.moduleSpecifier == organized.moduleSpecifier &&
+				existing.isTypeOnly == organized.isTypeOnly &&
+				existing.namespaceImport == nil &&
+				existing.original.AsImportDeclaration().ImportClause != nil &&
+				existing.original.AsImportDeclaration().Attributes == nil &&
+				(existing.defaultName == nil || organized.defaultName == nil) &&
+				!(organized.isTypeOnly && organized.defaultName != nil && existing.hasNamedBindings) &&
+				!(organized.isTypeOnly && existing.defaultName != nil && organized.hasNamedBindings)
+		})
+		if index < 0 {
+			result = append(result, organized)
+			continue
+		}
+		existing := result[index]
+		if organized.defaultName != nil {
+			existing.defaultName = organized.defaultName
+		}
+		existing.namedImports = append(existing.namedImports, organized.namedImports...)
+		existing.hasNamedBindings = existing.hasNamedBindings || organized.hasNamedBindings
+		if existing.leadingComments == "" {
+			existing.leadingComments = organized.leadingComments
+		} else if organized.leadingComments != "" {
+			existing.leadingComments += "\n" + organized.leadingComments
+		}
+		existing.changed = true
+	}
+	for _, organized := range result {
+		sorted := slices.IsSortedFunc(organized.namedImports, compareImportSpecifiers)
+		if !sorted {
+			slices.SortStableFunc(organized.namedImports, compareImportSpecifiers)
+			organized.changed = true
+		}
+	}
+	return result
+}
+
+func compareModuleSpecifiers(a string, b string) int {
+	aIsRelative := tspath.IsExternalModuleNameRelative(a)
+	bIsRelative := tspath.IsExternalModuleNameRelative(b)
+	if aIsRelative != bIsRelative {
+		return core.IfElse(aIsRelative, 1, -1)
+	}
+	return stringutil.CompareStringsCaseInsensitive(a, b)
+}
+
+func compareImportSpecifiers(a *ast.Node, b *ast.Node) int {
+	aIsTypeOnly := a.AsImportSpecifier().IsTypeOnly
+	bIsTypeOnly := b.AsImportSpecifier().IsTypeOnly
+	if aIsTypeOnly != bIsTypeOnly {
+		return core.IfElse(aIsTypeOnly, 1, -1)
+	}
+	return stringutil.CompareStringsCaseInsensitive(a.Name().Text(), b.Name().Text())
+}
+
+func (o *importOrganizer) getImportText(organized *organizedImport) string {
+	file := o.sourceFile
+	if !organized.changed {
+		return scanner.GetSourceTextOfNodeFromSourceFile(file, organized.original, false /*includeTrivia*/)
+	}
+	importDeclaration := organized.original.AsImportDeclaration()
+	var b strings.Builder
+	b.WriteString("import ")
+	if organized.isTypeOnly {
+		b.WriteString("type ")
+	}
+	if organized.defaultName != nil {
+		b.WriteString(organized.defaultName.Text())
+	}
+	if organized.namespaceImport != nil || len(organized.namedImports) != 0 {
+		if organized.defaultName != nil {
+			b.WriteString(", ")
+		}
+		if organized.namespaceImport != nil {
+			b.WriteString(scanner.GetSourceTextOfNodeFromSourceFile(file, organized.namespaceImport, false /*includeTrivia*/))
+		} else {
+			b.WriteString("{ ")
+			for i, specifier := range organized.namedImports {
+				if i != 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(scanner.GetSourceTextOfNodeFromSourceFile(file, specifier, false /*includeTrivia*/))
+			}
+			b.WriteString(" }")
+		}
+	}
+	b.WriteString(" from ")
+	b.WriteString(scanner.GetSourceTextOfNodeFromSourceFile(file, importDeclaration.ModuleSpecifier, false /*includeTrivia*/))
+	if importDeclaration.Attributes != nil {
+		b.WriteString(" ")
+		b.WriteString(scanner.GetSourceTextOfNodeFromSourceFile(file, importDeclaration.Attributes, false /*includeTrivia*/))
+	}
+	if o.semicolons {
+		b.WriteString(";")
+	}
+	return b.String()
+}
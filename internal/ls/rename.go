@@ -0,0 +1,228 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+func (l *LanguageService) ProvidePrepareRename(ctx context.Context, params *lsproto.PrepareRenameParams) (lsproto.PrepareRenameResponse, error) {
+	program, sourceFile := l.getProgramAndFile(params.TextDocument.Uri)
+	position := int(l.converters.LineAndCharacterToPosition(sourceFile, params.Position))
+	node, message := l.getRenameNode(ctx, program, sourceFile, position)
+	if message != nil {
+		return lsproto.PrepareRenameResponse{}, newRenameError(message)
+	}
+	return lsproto.PrepareRenameResponse{Range: l.createTriggerRangeForNode(node, sourceFile)}, nil
+}
+
+func (l *LanguageService) ProvideRename(ctx context.Context, params *lsproto.RenameParams) (lsproto.RenameResponse, error) {
+	program, sourceFile := l.getProgramAndFile(params.TextDocument.Uri)
+	position := int(l.converters.LineAndCharacterToPosition(sourceFile, params.Position))
+	node, message := l.getRenameNode(ctx, program, sourceFile, position)
+	if message != nil {
+		return lsproto.RenameResponse{}, newRenameError(message)
+	}
+
+	options := refOptions{use: referenceUseRename, useAliasesForRename: true}
+	symbolsAndEntries := l.getReferencedSymbolsForNode(ctx, position, node, program, program.GetSourceFiles(), options, nil)
+
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+
+	quotePreference := getQuotePreference(sourceFile, &UserPreferences{})
+	changes := map[lsproto.DocumentUri][]*lsproto.TextEdit{}
+	seen := map[lsproto.Location]struct{}{}
+	for _, symbolAndEntries := range symbolsAndEntries {
+		for _, entry := range symbolAndEntries.references {
+			location := l.convertEntriesToLocations([]*referenceEntry{entry})[0]
+			if _, ok := seen[location]; ok {
+				continue
+			}
+			seen[location] = struct{}{}
+			prefixText, suffixText := getPrefixAndSuffixText(entry, node, checker, quotePreference)
+			changes[location.Uri] = append(changes[location.Uri], &lsproto.TextEdit{
+				Range:   location.Range,
+				NewText: prefixText + params.NewName + suffixText,
+			})
+		}
+	}
+	for _, edits := range changes {
+		slices.SortFunc(edits, func(a, b *lsproto.TextEdit) int {
+			return CompareRanges(&a.Range, &b.Range)
+		})
+	}
+	return lsproto.RenameResponse{WorkspaceEdit: &lsproto.WorkspaceEdit{Changes: &changes}}, nil
+}
+
+func newRenameError(message *diagnostics.Message) error {
+	return fmt.Errorf("%w: %s", lsproto.ErrRequestFailed, message.Message())
+}
+
+// getRenameNode returns the node to rename at position, or the reason the element there cannot be renamed.
+func (l *LanguageService) getRenameNode(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile, position int) (*ast.Node, *diagnostics.Message) {
+	node := getAdjustedLocation(astnav.GetTouchingPropertyName(sourceFile, position), true /*forRename*/, sourceFile)
+	if !nodeIsEligibleForRename(node) {
+		return nil, diagnostics.You_cannot_rename_this_element
+	}
+
+	checker, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+
+	symbol := checker.GetSymbolAtLocation(node)
+	if symbol == nil {
+		// !!! string literal types are not yet renameable, since their references are not found
+		if ast.IsLabelName(node) {
+			return node, nil
+		}
+		return nil, diagnostics.You_cannot_rename_this_element
+	}
+	// Only allow a symbol to be renamed if it actually has at least one declaration.
+	if len(symbol.Declarations) == 0 {
+		return nil, diagnostics.You_cannot_rename_this_element
+	}
+
+	// Disallow rename for elements that are defined in the standard TypeScript library.
+	if slices.ContainsFunc(symbol.Declarations, func(declaration *ast.Node) bool {
+		return isDefinedInLibraryFile(program, declaration)
+	}) {
+		return nil, diagnostics.You_cannot_rename_elements_that_are_defined_in_the_standard_TypeScript_library
+	}
+
+	// Cannot rename `default` as in `import { default as foo } from "./someModule";
+	if ast.IsIdentifier(node) && node.Text() == ast.InternalSymbolNameDefault && symbol.Parent != nil && symbol.Parent.Flags&ast.SymbolFlagsModule != 0 {
+		return nil, diagnostics.You_cannot_rename_this_element
+	}
+
+	// !!! renaming of import paths is not supported
+	if ast.IsStringLiteralLike(node) && tryGetImportFromModuleSpecifier(node) != nil {
+		return nil, diagnostics.You_cannot_rename_this_element
+	}
+
+	// Disallow rename for elements that would rename across `*/node_modules/*` packages.
+	if message := wouldRenameInOtherNodeModules(sourceFile, symbol); message != nil {
+		return nil, message
+	}
+	return node, nil
+}
+
+func nodeIsEligibleForRename(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindIdentifier, ast.KindPrivateIdentifier, ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral, ast.KindThisKeyword:
+		return true
+	case ast.KindNumericLiteral:
+		return isLiteralNameOfPropertyDeclarationOrIndexAccess(node)
+	}
+	return false
+}
+
+func isDefinedInLibraryFile(program *compiler.Program, declaration *ast.Node) bool {
+	sourceFile := ast.GetSourceFileOfNode(declaration)
+	return program.IsSourceFileDefaultLibrary(sourceFile.Path()) && tspath.FileExtensionIs(sourceFile.FileName(), tspath.ExtensionDts)
+}
+
+func wouldRenameInOtherNodeModules(originalFile *ast.SourceFile, symbol *ast.Symbol) *diagnostics.Message {
+	originalPackage := getPackagePathComponents(string(originalFile.Path()))
+	if originalPackage == nil {
+		// original source file is not in node_modules
+		if slices.ContainsFunc(symbol.Declarations, func(declaration *ast.Node) bool {
+			return getPackagePathComponents(string(ast.GetSourceFileOfNode(declaration).Path())) != nil
+		}) {
+			return diagnostics.You_cannot_rename_elements_that_are_defined_in_a_node_modules_folder
+		}
+		return nil
+	}
+	// original source file is in node_modules
+	for _, declaration := range symbol.Declarations {
+		declPackage := getPackagePathComponents(string(ast.GetSourceFileOfNode(declaration).Path()))
+		if declPackage != nil && !slices.Equal(originalPackage, declPackage) {
+			return diagnostics.You_cannot_rename_elements_that_are_defined_in_another_node_modules_folder
+		}
+	}
+	return nil
+}
+
+// getPackagePathComponents returns the path components up to and including the package directory
+// of the innermost node_modules folder containing filePath, or nil if it is not in node_modules.
+func getPackagePathComponents(filePath string) []string {
+	components := tspath.GetPathComponents(filePath, "")
+	for i := len(components) - 2; i >= 0; i-- {
+		if components[i] == "node_modules" {
+			return components[:i+2]
+		}
+	}
+	return nil
+}
+
+func (l *LanguageService) createTriggerRangeForNode(node *ast.Node, sourceFile *ast.SourceFile) *lsproto.Range {
+	start := scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/)
+	end := node.End()
+	if ast.IsStringLiteralLike(node) {
+		// Exclude the quotes
+		start++
+		end--
+	}
+	return l.createLspRangeFromBounds(start, end, sourceFile)
+}
+
+func getPrefixAndSuffixText(entry *referenceEntry, originalNode *ast.Node, checker *checker.Checker, quotePreference quotePreference) (prefixText string, suffixText string) {
+	if entry.kind != entryKindRange && (ast.IsIdentifier(originalNode) || ast.IsStringLiteralLike(originalNode)) {
+		node := entry.node
+		parent := node.Parent
+		name := originalNode.Text()
+		isShorthandAssignment := ast.IsShorthandPropertyAssignment(parent)
+		if isShorthandAssignment || (isObjectBindingElementWithoutPropertyName(parent) && parent.Name() == node && parent.AsBindingElement().DotDotDotToken == nil) {
+			switch entry.kind {
+			case entryKindSearchedLocalFoundProperty:
+				return name + ": ", ""
+			case entryKindSearchedPropertyFoundLocal:
+				return "", ": " + name
+			}
+			// In `const o = { x }; o.x`, symbolAtLocation at `x` in `{ x }` is the property symbol.
+			// For a binding element `const { x } = o;`, symbolAtLocation at `x` is the property symbol.
+			if isShorthandAssignment {
+				grandParent := parent.Parent
+				if ast.IsObjectLiteralExpression(grandParent) && ast.IsBinaryExpression(grandParent.Parent) && ast.IsModuleExportsAccessExpression(grandParent.Parent.AsBinaryExpression().Left) {
+					return name + ": ", ""
+				}
+				return "", ": " + name
+			}
+			return name + ": ", ""
+		} else if ast.IsImportSpecifier(parent) && parent.PropertyName() == nil {
+			// If the original symbol was using this alias, just rename the alias.
+			var originalSymbol *ast.Symbol
+			if ast.IsExportSpecifier(originalNode.Parent) {
+				originalSymbol = checker.GetExportSpecifierLocalTargetSymbol(originalNode.Parent)
+			} else {
+				originalSymbol = checker.GetSymbolAtLocation(originalNode)
+			}
+			if originalSymbol != nil && slices.Contains(originalSymbol.Declarations, parent) {
+				return name + " as ", ""
+			}
+			return "", ""
+		} else if ast.IsExportSpecifier(parent) && parent.PropertyName() == nil {
+			// If the symbol for the node is same as declared node symbol use prefix text
+			if originalNode == entry.node || checker.GetSymbolAtLocation(originalNode) == checker.GetSymbolAtLocation(entry.node) {
+				return name + " as ", ""
+			}
+			return "", " as " + name
+		}
+	}
+
+	// If the node is a numerical indexing literal, then add quotes around the property access.
+	if entry.kind != entryKindRange && ast.IsNumericLiteral(entry.node) && ast.IsAccessExpression(entry.node.Parent) {
+		quote := core.IfElse(quotePreference == quotePreferenceSingle, "'", "\"")
+		return quote, quote
+	}
+	return "", ""
+}
@@ -0,0 +1,251 @@
+package ls_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestRename(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title    string
+		input    string
+		marker   string
+		newName  string
+		expected map[string]string
+	}{
+		{
+			title: "localRenamesShorthandPropertyValue",
+			input: `
+// @filename: /a.ts
+const /*1*/x = 1;
+const o = { x };
+o.x;`,
+			marker:  "1",
+			newName: "y",
+			expected: map[string]string{
+				"/a.ts": `const y = 1;
+const o = { x: y };
+o.x;`,
+			},
+		},
+		{
+			title: "propertyRenamesShorthandPropertyName",
+			input: `
+// @filename: /a.ts
+const x = 1;
+const o = { x };
+o./*1*/x;`,
+			marker:  "1",
+			newName: "y",
+			expected: map[string]string{
+				"/a.ts": `const x = 1;
+const o = { y: x };
+o.y;`,
+			},
+		},
+		{
+			title: "stringLiteralPropertyName",
+			input: `
+// @filename: /a.ts
+const o = { "foo-bar": 1 };
+o["/*1*/foo-bar"];`,
+			marker:  "1",
+			newName: "baz",
+			expected: map[string]string{
+				"/a.ts": `const o = { "baz": 1 };
+o["baz"];`,
+			},
+		},
+		{
+			title: "exportRenamesImportsAndKeepsReExportName",
+			input: `
+// @filename: /a.ts
+export function /*1*/foo() {}
+// @filename: /b.ts
+import { foo } from "./a";
+foo();
+export { foo };
+// @filename: /c.ts
+import { foo as baz } from "./a";
+baz();`,
+			marker:  "1",
+			newName: "bar",
+			expected: map[string]string{
+				"/a.ts": `export function bar() {}`,
+				"/b.ts": `import { bar } from "./a";
+bar();
+export { bar as foo };`,
+				"/c.ts": `import { bar as baz } from "./a";
+baz();`,
+			},
+		},
+		{
+			title: "importRenamesOnlyTheLocalAlias",
+			input: `
+// @filename: /b.ts
+import { foo } from "./a";
+/*1*/foo();
+// @filename: /a.ts
+export function foo() {}`,
+			marker:  "1",
+			newName: "bar",
+			expected: map[string]string{
+				"/b.ts": `import { foo as bar } from "./a";
+bar();`,
+				"/a.ts": `export function foo() {}`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			runRenameTest(t, testCase.input, testCase.marker, testCase.newName, testCase.expected)
+		})
+	}
+}
+
+func TestPrepareRename(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title         string
+		input         string
+		expectedError string
+	}{
+		{
+			title: "identifier",
+			input: `
+// @filename: /a.ts
+const [|/*1*/x|] = 1;`,
+		},
+		{
+			title: "stringLiteralPropertyNameExcludesQuotes",
+			input: `
+// @filename: /a.ts
+const o = { "[|/*1*/foo|]": 1 };`,
+		},
+		{
+			title: "numericLiteral",
+			input: `
+// @filename: /a.ts
+const x = /*1*/1;`,
+			expectedError: "RequestFailed: You cannot rename this element.",
+		},
+		{
+			title: "libSymbol",
+			input: `
+// @filename: /a.ts
+[1].m/*1*/ap(x => x);`,
+			expectedError: "RequestFailed: You cannot rename elements that are defined in the standard TypeScript library.",
+		},
+		{
+			title: "nodeModulesSymbol",
+			input: `
+// @filename: /a.ts
+import * as pkg from "pkg";
+pkg./*1*/f();
+// @filename: /node_modules/pkg/index.d.ts
+export declare function f(): void;`,
+			expectedError: "RequestFailed: You cannot rename elements that are defined in a 'node_modules' folder.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, "/a.ts")
+			ctx := projecttestutil.WithRequestID(t.Context())
+			languageService, done := createLanguageServiceForRename(ctx, testData)
+			defer done()
+
+			marker := testData.MarkerPositions["1"]
+			result, err := languageService.ProvidePrepareRename(ctx, &lsproto.PrepareRenameParams{
+				TextDocument: lsproto.TextDocumentIdentifier{Uri: ls.FileNameToDocumentURI(marker.FileName())},
+				Position:     marker.LSPosition,
+			})
+			if testCase.expectedError != "" {
+				assert.Error(t, err, testCase.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result.Range, &testData.Ranges[0].LSRange)
+		})
+	}
+}
+
+func runRenameTest(t *testing.T, input string, markerName string, newName string, expected map[string]string) {
+	testData := fourslash.ParseTestData(t, input, "/a.ts")
+	ctx := projecttestutil.WithRequestID(t.Context())
+	languageService, done := createLanguageServiceForRename(ctx, testData)
+	defer done()
+
+	marker, ok := testData.MarkerPositions[markerName]
+	if !ok {
+		t.Fatalf("No marker found for '%s'", markerName)
+	}
+	result, err := languageService.ProvideRename(ctx, &lsproto.RenameParams{
+		TextDocument: lsproto.TextDocumentIdentifier{Uri: ls.FileNameToDocumentURI(marker.FileName())},
+		Position:     marker.LSPosition,
+		NewName:      newName,
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, result.WorkspaceEdit != nil)
+
+	changes := *result.WorkspaceEdit.Changes
+	for _, file := range testData.Files {
+		actual := applyTextEdits(file.Content, changes[ls.FileNameToDocumentURI(file.FileName())])
+		assert.Equal(t, actual, expected[file.FileName()], "unexpected rename result in %s", file.FileName())
+	}
+}
+
+func createLanguageServiceForRename(ctx context.Context, testData fourslash.TestData) (*ls.LanguageService, func()) {
+	files := map[string]string{}
+	for _, file := range testData.Files {
+		files[file.FileName()] = file.Content
+	}
+	projectService, _ := projecttestutil.Setup(files, nil)
+	for _, file := range testData.Files {
+		projectService.OpenFile(file.FileName(), file.Content, core.GetScriptKindFromFileName(file.FileName()), "")
+	}
+	project := projectService.Projects()[0]
+	return project.GetLanguageServiceForRequest(ctx)
+}
+
+// applyTextEdits applies sorted, non-overlapping edits whose positions are in ASCII text.
+func applyTextEdits(text string, edits []*lsproto.TextEdit) string {
+	lineStarts := core.ComputeLineStarts(text)
+	offsetOf := func(position lsproto.Position) int {
+		return int(lineStarts[position.Line]) + int(position.Character)
+	}
+	var b strings.Builder
+	lastEnd := 0
+	for _, edit := range edits {
+		b.WriteString(text[lastEnd:offsetOf(edit.Range.Start)])
+		b.WriteString(edit.NewText)
+		lastEnd = offsetOf(edit.Range.End)
+	}
+	b.WriteString(text[lastEnd:])
+	return b.String()
+}
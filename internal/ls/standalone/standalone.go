@@ -0,0 +1,132 @@
+// Package standalone runs language service requests against a single program, for embedders
+// such as the HTTP server and the C bridge that create their own programs instead of using a
+// project service.
+package standalone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+// DefaultFormattingOptions are used to format a file when no options are given.
+var DefaultFormattingOptions = lsproto.FormattingOptions{
+	TabSize:      4,
+	InsertSpaces: true,
+}
+
+// SignatureHelpCapabilities are the client capabilities reported for signature help.
+var SignatureHelpCapabilities = &lsproto.SignatureHelpClientCapabilities{
+	SignatureInformation: &lsproto.ClientSignatureInformationOptions{},
+}
+
+// Host serves language service requests from one program, with UTF-16 positions.
+type Host struct {
+	program  *compiler.Program
+	lineMaps collections.SyncMap[string, *ls.LineMap]
+}
+
+var _ ls.Host = (*Host)(nil)
+
+func NewHost(program *compiler.Program) *Host {
+	return &Host{program: program}
+}
+
+func (h *Host) GetProgram() *compiler.Program {
+	return h.program
+}
+
+func (h *Host) GetPositionEncoding() lsproto.PositionEncodingKind {
+	return lsproto.PositionEncodingKindUTF16
+}
+
+func (h *Host) GetLineMap(fileName string) *ls.LineMap {
+	if lineMap, ok := h.lineMaps.Load(fileName); ok {
+		return lineMap
+	}
+	lineMap, _ := h.lineMaps.LoadOrStore(fileName, ls.ComputeLineStarts(h.program.GetSourceFile(fileName).Text()))
+	return lineMap
+}
+
+// CompletionContext returns the context of a completion request, which was triggered by typing
+// triggerCharacter, or invoked explicitly if it is empty.
+func CompletionContext(triggerCharacter string) *lsproto.CompletionContext {
+	if triggerCharacter == "" {
+		return &lsproto.CompletionContext{TriggerKind: lsproto.CompletionTriggerKindInvoked}
+	}
+	return &lsproto.CompletionContext{
+		TriggerKind:      lsproto.CompletionTriggerKindTriggerCharacter,
+		TriggerCharacter: &triggerCharacter,
+	}
+}
+
+// SignatureHelpContext returns the context of a signature help request, which was triggered by
+// typing triggerCharacter, or invoked explicitly if it is empty.
+func SignatureHelpContext(triggerCharacter string) *lsproto.SignatureHelpContext {
+	if triggerCharacter == "" {
+		return &lsproto.SignatureHelpContext{TriggerKind: lsproto.SignatureHelpTriggerKindInvoked}
+	}
+	return &lsproto.SignatureHelpContext{
+		TriggerKind:      lsproto.SignatureHelpTriggerKindTriggerCharacter,
+		TriggerCharacter: &triggerCharacter,
+	}
+}
+
+// ProvideReferences returns the references to the symbol at position. The language service
+// always includes the declarations of the symbol, so unless includeDeclaration is set, the
+// locations that ProvideDefinition returns for the position are left out.
+func ProvideReferences(ctx context.Context, languageService *ls.LanguageService, uri lsproto.DocumentUri, position lsproto.Position, includeDeclaration bool) (lsproto.ReferencesResponse, error) {
+	references, err := languageService.ProvideReferences(ctx, &lsproto.ReferenceParams{
+		TextDocument: lsproto.TextDocumentIdentifier{Uri: uri},
+		Position:     position,
+		Context:      &lsproto.ReferenceContext{IncludeDeclaration: includeDeclaration},
+	})
+	if err != nil || includeDeclaration || references.Locations == nil {
+		return references, err
+	}
+
+	definition, err := languageService.ProvideDefinition(ctx, uri, position)
+	if err != nil {
+		return lsproto.ReferencesResponse{}, err
+	}
+	var declarations []lsproto.Location
+	switch {
+	case definition.Location != nil:
+		declarations = append(declarations, *definition.Location)
+	case definition.Locations != nil:
+		declarations = append(declarations, *definition.Locations...)
+	case definition.DefinitionLinks != nil:
+		for _, link := range *definition.DefinitionLinks {
+			declarations = append(declarations, lsproto.Location{Uri: link.TargetUri, Range: link.TargetSelectionRange})
+		}
+	}
+	locations := slices.DeleteFunc(*references.Locations, func(location lsproto.Location) bool {
+		return slices.Contains(declarations, location)
+	})
+	return lsproto.LocationsOrNull{Locations: &locations}, nil
+}
+
+// PanicError is returned by Call when the language service panics.
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("language service request failed: %v", e.Value)
+}
+
+// Call calls provide, turning a panic in the language service into a *PanicError, so a single
+// unsupported request does not take the embedding process down with it.
+func Call[T any](provide func() (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r}
+		}
+	}()
+	return provide()
+}
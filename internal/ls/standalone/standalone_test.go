@@ -0,0 +1,33 @@
+package standalone_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ls/standalone"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"gotest.tools/v3/assert"
+)
+
+func TestCall(t *testing.T) {
+	t.Parallel()
+
+	result, err := standalone.Call(func() (int, error) { return 1, nil })
+	assert.NilError(t, err)
+	assert.Equal(t, result, 1)
+
+	_, err = standalone.Call(func() (int, error) { panic("unsupported") })
+	var panicErr *standalone.PanicError
+	assert.Assert(t, errors.As(err, &panicErr))
+	assert.Equal(t, panicErr.Value, "unsupported")
+	assert.Error(t, err, "language service request failed: unsupported")
+}
+
+func TestCompletionContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, standalone.CompletionContext("").TriggerKind, lsproto.CompletionTriggerKindInvoked)
+	triggered := standalone.CompletionContext(".")
+	assert.Equal(t, triggered.TriggerKind, lsproto.CompletionTriggerKindTriggerCharacter)
+	assert.Equal(t, *triggered.TriggerCharacter, ".")
+}
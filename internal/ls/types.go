@@ -17,27 +17,62 @@ const (
 	JsxAttributeCompletionStyleNone   JsxAttributeCompletionStyle = "none"
 )
 
+type IncludeInlayParameterNameHints string
+
+const (
+	IncludeInlayParameterNameHintsNone     IncludeInlayParameterNameHints = "none"
+	IncludeInlayParameterNameHintsLiterals IncludeInlayParameterNameHints = "literals"
+	IncludeInlayParameterNameHintsAll      IncludeInlayParameterNameHints = "all"
+)
+
 type UserPreferences struct {
 	// Enables auto-import-style completions on partially-typed import statements. E.g., allows
 	// `import write|` to be completed to `import { writeFile } from "fs"`.
-	IncludeCompletionsForImportStatements *bool
+	IncludeCompletionsForImportStatements *bool `json:"includeCompletionsForImportStatements,omitempty"`
 
 	// Unless this option is `false`,  member completion lists triggered with `.` will include entries
 	// on potentially-null and potentially-undefined values, with insertion text to replace
 	// preceding `.` tokens with `?.`.
-	IncludeAutomaticOptionalChainCompletions *bool
+	IncludeAutomaticOptionalChainCompletions *bool `json:"includeAutomaticOptionalChainCompletions,omitempty"`
 
 	// If enabled, completions for class members (e.g. methods and properties) will include
 	// a whole declaration for the member.
 	// E.g., `class A { f| }` could be completed to `class A { foo(): number {} }`, instead of
 	// `class A { foo }`.
-	IncludeCompletionsWithClassMemberSnippets *bool
+	IncludeCompletionsWithClassMemberSnippets *bool `json:"includeCompletionsWithClassMemberSnippets,omitempty"`
 
 	// If enabled, object literal methods will have a method declaration completion entry in addition
 	// to the regular completion entry containing just the method name.
 	// E.g., `const objectLiteral: T = { f| }` could be completed to `const objectLiteral: T = { foo(): void {} }`,
 	// in addition to `const objectLiteral: T = { foo }`.
-	IncludeCompletionsWithObjectLiteralMethodSnippets *bool
+	IncludeCompletionsWithObjectLiteralMethodSnippets *bool `json:"includeCompletionsWithObjectLiteralMethodSnippets,omitempty"`
+
+	JsxAttributeCompletionStyle *JsxAttributeCompletionStyle `json:"jsxAttributeCompletionStyle,omitempty"`
+
+	// Shows the names of parameters before the arguments of calls. With `literals`, names are only
+	// shown before literal arguments.
+	IncludeInlayParameterNameHints *IncludeInlayParameterNameHints `json:"includeInlayParameterNameHints,omitempty"`
+
+	// Unless this option is `false`, parameter name hints are also shown for arguments that are
+	// identifiers or property accesses with the same name as the parameter.
+	IncludeInlayParameterNameHintsWhenArgumentMatchesName *bool `json:"includeInlayParameterNameHintsWhenArgumentMatchesName,omitempty"`
+
+	// Shows the inferred types of parameters of contextually typed functions, e.g. `x` in `[1].map(x => x)`.
+	IncludeInlayFunctionParameterTypeHints *bool `json:"includeInlayFunctionParameterTypeHints,omitempty"`
+
+	// Shows the inferred types of variables without type annotations.
+	IncludeInlayVariableTypeHints *bool `json:"includeInlayVariableTypeHints,omitempty"`
+
+	// Unless this option is `false`, variable type hints are also shown when the type has the same
+	// name as the variable.
+	IncludeInlayVariableTypeHintsWhenTypeMatchesName *bool `json:"includeInlayVariableTypeHintsWhenTypeMatchesName,omitempty"`
+
+	// Shows the inferred types of class property declarations without type annotations.
+	IncludeInlayPropertyDeclarationTypeHints *bool `json:"includeInlayPropertyDeclarationTypeHints,omitempty"`
+
+	// Shows the inferred return types of functions, methods and get accessors without return type annotations.
+	IncludeInlayFunctionLikeReturnTypeHints *bool `json:"includeInlayFunctionLikeReturnTypeHints,omitempty"`
 
-	JsxAttributeCompletionStyle *JsxAttributeCompletionStyle
+	// Shows the values of enum members without initializers.
+	IncludeInlayEnumMemberValueHints *bool `json:"includeInlayEnumMemberValueHints,omitempty"`
 }
@@ -25,7 +25,7 @@ func ComparePositions(pos, other lsproto.Position) int {
 	if lineComp := cmp.Compare(pos.Line, other.Line); lineComp != 0 {
 		return lineComp
 	}
-	return cmp.Compare(pos.Line, other.Line)
+	return cmp.Compare(pos.Character, other.Character)
 }
 
 // Implements a cmp.Compare like function for two *lsproto.Range
@@ -410,7 +410,7 @@ func quote(file *ast.SourceFile, preferences *UserPreferences, text string) stri
 	quotePreference := getQuotePreference(file, preferences)
 	quoted, _ := core.StringifyJson(text, "" /*prefix*/, "" /*indent*/)
 	if quotePreference == quotePreferenceSingle {
-		quoted = quoteReplacer.Replace(stringutil.StripQuotes(quoted))
+		quoted = "'" + quoteReplacer.Replace(stringutil.StripQuotes(quoted)) + "'"
 	}
 	return quoted
 }
@@ -422,8 +422,20 @@ const (
 	quotePreferenceDouble
 )
 
-// !!!
 func getQuotePreference(file *ast.SourceFile, preferences *UserPreferences) quotePreference {
+	// !!! quotePreference user preference
+	for _, moduleSpecifier := range file.Imports() {
+		if ast.IsStringLiteral(moduleSpecifier) && !ast.NodeIsSynthesized(moduleSpecifier.Parent) {
+			return quotePreferenceFromString(moduleSpecifier, file)
+		}
+	}
+	return quotePreferenceDouble
+}
+
+func quotePreferenceFromString(str *ast.StringLiteralLike, file *ast.SourceFile) quotePreference {
+	if file.Text()[scanner.GetTokenPosOfNode(str, file, false /*includeJSDoc*/)] == '\'' {
+		return quotePreferenceSingle
+	}
 	return quotePreferenceDouble
 }
 
@@ -578,6 +590,72 @@ func isLiteralNameOfPropertyDeclarationOrIndexAccess(node *ast.Node) bool {
 	}
 }
 
+// Returns the containing object literal property declaration given a possible name node, e.g. "a" in x = { "a": 1 }
+func getContainingObjectLiteralElement(node *ast.Node) *ast.Node {
+	element := getContainingObjectLiteralElementWorker(node)
+	if element != nil && (ast.IsObjectLiteralExpression(element.Parent) || ast.IsJsxAttributes(element.Parent)) {
+		return element
+	}
+	return nil
+}
+
+func getContainingObjectLiteralElementWorker(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral, ast.KindNumericLiteral:
+		if node.Parent.Kind == ast.KindComputedPropertyName {
+			if ast.IsObjectLiteralElement(node.Parent.Parent) {
+				return node.Parent.Parent
+			}
+			return nil
+		}
+		fallthrough
+	case ast.KindIdentifier, ast.KindJsxNamespacedName:
+		if ast.IsObjectLiteralElement(node.Parent) &&
+			(node.Parent.Parent.Kind == ast.KindObjectLiteralExpression || node.Parent.Parent.Kind == ast.KindJsxAttributes) &&
+			node.Parent.Name() == node {
+			return node.Parent
+		}
+	}
+	return nil
+}
+
+func getPropertySymbolsFromContextualType(node *ast.Node, typeChecker *checker.Checker, contextualType *checker.Type, unionSymbolOk bool) []*ast.Symbol {
+	name := ast.GetPropertyNameForPropertyNameNode(node.Name())
+	if name == "" {
+		return nil
+	}
+	if !contextualType.IsUnion() {
+		if symbol := typeChecker.GetPropertyOfType(contextualType, name); symbol != nil {
+			return []*ast.Symbol{symbol}
+		}
+		return nil
+	}
+	filteredTypes := contextualType.Types()
+	if ast.IsObjectLiteralExpression(node.Parent) || ast.IsJsxAttributes(node.Parent) {
+		filteredTypes = core.Filter(filteredTypes, func(t *checker.Type) bool {
+			return !typeChecker.IsTypeInvalidDueToUnionDiscriminant(t, node.Parent)
+		})
+	}
+	discriminatedPropertySymbols := core.MapNonNil(filteredTypes, func(t *checker.Type) *ast.Symbol {
+		return typeChecker.GetPropertyOfType(t, name)
+	})
+	if unionSymbolOk && (len(discriminatedPropertySymbols) == 0 || len(discriminatedPropertySymbols) == len(contextualType.Types())) {
+		if symbol := typeChecker.GetPropertyOfType(contextualType, name); symbol != nil {
+			return []*ast.Symbol{symbol}
+		}
+	}
+	if len(filteredTypes) == 0 && len(discriminatedPropertySymbols) == 0 {
+		return core.MapNonNil(contextualType.Types(), func(t *checker.Type) *ast.Symbol {
+			return typeChecker.GetPropertyOfType(t, name)
+		})
+	}
+	var result []*ast.Symbol
+	for _, symbol := range discriminatedPropertySymbols {
+		result = core.AppendIfUnique(result, symbol)
+	}
+	return result
+}
+
 func isObjectBindingElementWithoutPropertyName(bindingElement *ast.Node) bool {
 	return bindingElement.Kind == ast.KindBindingElement &&
 		bindingElement.Parent.Kind == ast.KindObjectBindingPattern &&
@@ -140,6 +140,8 @@ type Server struct {
 	initializeParams *lsproto.InitializeParams
 	positionEncoding lsproto.PositionEncodingKind
 	locale           language.Tag
+	// updated by workspace/didChangeConfiguration while requests are being handled
+	userPreferences atomic.Pointer[ls.UserPreferences]
 
 	watchEnabled bool
 	watcherID    atomic.Uint32
@@ -492,6 +494,7 @@ var handlers = sync.OnceValue(func() handlerMap {
 	registerNotificationHandler(handlers, lsproto.TextDocumentDidSaveInfo, (*Server).handleDidSave)
 	registerNotificationHandler(handlers, lsproto.TextDocumentDidCloseInfo, (*Server).handleDidClose)
 	registerNotificationHandler(handlers, lsproto.WorkspaceDidChangeWatchedFilesInfo, (*Server).handleDidChangeWatchedFiles)
+	registerNotificationHandler(handlers, lsproto.WorkspaceDidChangeConfigurationInfo, (*Server).handleDidChangeConfiguration)
 
 	registerRequestHandler(handlers, lsproto.TextDocumentDiagnosticInfo, (*Server).handleDocumentDiagnostic)
 	registerRequestHandler(handlers, lsproto.TextDocumentHoverInfo, (*Server).handleHover)
@@ -500,6 +503,10 @@ var handlers = sync.OnceValue(func() handlerMap {
 	registerRequestHandler(handlers, lsproto.TextDocumentCompletionInfo, (*Server).handleCompletion)
 	registerRequestHandler(handlers, lsproto.TextDocumentReferencesInfo, (*Server).handleReferences)
 	registerRequestHandler(handlers, lsproto.TextDocumentImplementationInfo, (*Server).handleImplementations)
+	registerRequestHandler(handlers, lsproto.TextDocumentRenameInfo, (*Server).handleRename)
+	registerRequestHandler(handlers, lsproto.TextDocumentPrepareRenameInfo, (*Server).handlePrepareRename)
+	registerRequestHandler(handlers, lsproto.TextDocumentCodeActionInfo, (*Server).handleCodeAction)
+	registerRequestHandler(handlers, lsproto.TextDocumentInlayHintInfo, (*Server).handleInlayHint)
 	registerRequestHandler(handlers, lsproto.TextDocumentSignatureHelpInfo, (*Server).handleSignatureHelp)
 	registerRequestHandler(handlers, lsproto.TextDocumentFormattingInfo, (*Server).handleDocumentFormat)
 	registerRequestHandler(handlers, lsproto.TextDocumentRangeFormattingInfo, (*Server).handleDocumentRangeFormat)
@@ -566,6 +573,8 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 		s.locale = locale
 	}
 
+	s.userPreferences.Store(getUserPreferences(s.initializeParams))
+
 	response := &lsproto.InitializeResult{
 		ServerInfo: &lsproto.ServerInfo{
 			Name:    "typescript-go",
@@ -599,6 +608,23 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 			ImplementationProvider: &lsproto.BooleanOrImplementationOptionsOrImplementationRegistrationOptions{
 				Boolean: ptrTo(true),
 			},
+			RenameProvider: &lsproto.BooleanOrRenameOptions{
+				RenameOptions: &lsproto.RenameOptions{
+					PrepareProvider: ptrTo(true),
+				},
+			},
+			CodeActionProvider: &lsproto.BooleanOrCodeActionOptions{
+				CodeActionOptions: &lsproto.CodeActionOptions{
+					CodeActionKinds: &[]lsproto.CodeActionKind{
+						lsproto.CodeActionKindQuickFix,
+						lsproto.CodeActionKindSourceOrganizeImports,
+						lsproto.CodeActionKindSourceFixAll,
+					},
+				},
+			},
+			InlayHintProvider: &lsproto.BooleanOrInlayHintOptionsOrInlayHintRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
 			DiagnosticProvider: &lsproto.DiagnosticOptionsOrRegistrationOptions{
 				Options: &lsproto.DiagnosticOptions{
 					InterFileDependencies: true,
@@ -655,6 +681,14 @@ func (s *Server) handleInitialized(ctx context.Context, params *lsproto.Initiali
 		s.projectService.SetCompilerOptionsForInferredProjects(s.compilerOptionsForInferredProjects)
 	}
 
+	if supportsConfiguration(s.initializeParams) {
+		go func() {
+			if err := s.updateUserPreferences(ctx, nil); err != nil {
+				s.Log(err.Error())
+			}
+		}()
+	}
+
 	return nil
 }
 
@@ -690,6 +724,52 @@ func (s *Server) handleDidChangeWatchedFiles(ctx context.Context, params *lsprot
 	return s.projectService.OnWatchedFilesChanged(ctx, params.Changes)
 }
 
+func (s *Server) handleDidChangeConfiguration(ctx context.Context, params *lsproto.DidChangeConfigurationParams) error {
+	var settings any
+	if params != nil {
+		settings = params.Settings
+	}
+	if err := s.updateUserPreferences(ctx, settings); err != nil {
+		s.Log(err.Error())
+	}
+	return nil
+}
+
+// updateUserPreferences applies the `typescript` section of the client's settings on top of the
+// preferences passed at initialization, and asks the client to refresh the inlay hints that were
+// computed with the old preferences. Clients that support workspace/configuration are asked for
+// the section; otherwise it is read from the settings sent with workspace/didChangeConfiguration.
+func (s *Server) updateUserPreferences(ctx context.Context, settings any) error {
+	var section any
+	if supportsConfiguration(s.initializeParams) {
+		result, err := s.sendRequest(ctx, lsproto.MethodWorkspaceConfiguration, &lsproto.ConfigurationParams{
+			Items: []*lsproto.ConfigurationItem{{Section: ptrTo("typescript")}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get configuration: %w", err)
+		}
+		if items, ok := result.([]any); ok && len(items) > 0 {
+			section = items[0]
+		}
+	} else if settings, ok := settings.(map[string]any); ok {
+		section = settings["typescript"]
+	}
+
+	typescript, ok := section.(map[string]any)
+	if !ok {
+		return nil
+	}
+	s.userPreferences.Store(applyTypeScriptSettings(getUserPreferences(s.initializeParams), typescript))
+
+	if !supportsInlayHintRefresh(s.initializeParams) {
+		return nil
+	}
+	if _, err := s.sendRequest(ctx, lsproto.MethodWorkspaceInlayHintRefresh, nil); err != nil {
+		return fmt.Errorf("failed to refresh inlay hints: %w", err)
+	}
+	return nil
+}
+
 func (s *Server) handleDocumentDiagnostic(ctx context.Context, params *lsproto.DocumentDiagnosticParams) (lsproto.DocumentDiagnosticResponse, error) {
 	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
 	languageService, done := project.GetLanguageServiceForRequest(ctx)
@@ -748,6 +828,34 @@ func (s *Server) handleImplementations(ctx context.Context, params *lsproto.Impl
 	return languageService.ProvideImplementations(ctx, params)
 }
 
+func (s *Server) handleRename(ctx context.Context, params *lsproto.RenameParams) (lsproto.RenameResponse, error) {
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return languageService.ProvideRename(ctx, params)
+}
+
+func (s *Server) handlePrepareRename(ctx context.Context, params *lsproto.PrepareRenameParams) (lsproto.PrepareRenameResponse, error) {
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return languageService.ProvidePrepareRename(ctx, params)
+}
+
+func (s *Server) handleCodeAction(ctx context.Context, params *lsproto.CodeActionParams) (lsproto.CodeActionResponse, error) {
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return languageService.ProvideCodeActions(ctx, params)
+}
+
+func (s *Server) handleInlayHint(ctx context.Context, params *lsproto.InlayHintParams) (lsproto.InlayHintResponse, error) {
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	return languageService.ProvideInlayHint(ctx, params, s.userPreferences.Load())
+}
+
 func (s *Server) handleCompletion(ctx context.Context, params *lsproto.CompletionParams) (lsproto.CompletionResponse, error) {
 	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
 	languageService, done := project.GetLanguageServiceForRequest(ctx)
@@ -872,6 +980,78 @@ func shouldEnableWatch(params *lsproto.InitializeParams) bool {
 		ptrIsTrue(params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration)
 }
 
+// getUserPreferences reads the preferences a client passes as `initializationOptions.preferences`,
+// using the same names as the preferences of tsserver. The `typescript` settings of the client are
+// applied on top of them by applyTypeScriptSettings.
+func getUserPreferences(params *lsproto.InitializeParams) *ls.UserPreferences {
+	preferences := &ls.UserPreferences{}
+	if params == nil || params.InitializationOptions == nil {
+		return preferences
+	}
+	options, ok := (*params.InitializationOptions).(map[string]any)
+	if !ok || options["preferences"] == nil {
+		return preferences
+	}
+	data, err := json.Marshal(options["preferences"])
+	if err != nil {
+		return preferences
+	}
+	var result ls.UserPreferences
+	if err := json.Unmarshal(data, &result); err != nil {
+		return preferences
+	}
+	return &result
+}
+
+// applyTypeScriptSettings returns preferences with the `typescript.inlayHints.*` settings of an
+// editor applied, using the names and values of the VS Code settings.
+func applyTypeScriptSettings(preferences *ls.UserPreferences, typescript map[string]any) *ls.UserPreferences {
+	result := *preferences
+	inlayHints, _ := typescript["inlayHints"].(map[string]any)
+	if value, ok := getInlayHintSetting[string](inlayHints, "parameterNames", "enabled"); ok {
+		result.IncludeInlayParameterNameHints = ptrTo(ls.IncludeInlayParameterNameHints(value))
+	}
+	if value, ok := getInlayHintSetting[bool](inlayHints, "parameterNames", "suppressWhenArgumentMatchesName"); ok {
+		result.IncludeInlayParameterNameHintsWhenArgumentMatchesName = ptrTo(!value)
+	}
+	if value, ok := getInlayHintSetting[bool](inlayHints, "parameterTypes", "enabled"); ok {
+		result.IncludeInlayFunctionParameterTypeHints = ptrTo(value)
+	}
+	if value, ok := getInlayHintSetting[bool](inlayHints, "variableTypes", "enabled"); ok {
+		result.IncludeInlayVariableTypeHints = ptrTo(value)
+	}
+	if value, ok := getInlayHintSetting[bool](inlayHints, "variableTypes", "suppressWhenTypeMatchesName"); ok {
+		result.IncludeInlayVariableTypeHintsWhenTypeMatchesName = ptrTo(!value)
+	}
+	if value, ok := getInlayHintSetting[bool](inlayHints, "propertyDeclarationTypes", "enabled"); ok {
+		result.IncludeInlayPropertyDeclarationTypeHints = ptrTo(value)
+	}
+	if value, ok := getInlayHintSetting[bool](inlayHints, "functionLikeReturnTypes", "enabled"); ok {
+		result.IncludeInlayFunctionLikeReturnTypeHints = ptrTo(value)
+	}
+	if value, ok := getInlayHintSetting[bool](inlayHints, "enumMemberValues", "enabled"); ok {
+		result.IncludeInlayEnumMemberValueHints = ptrTo(value)
+	}
+	return &result
+}
+
+func getInlayHintSetting[T any](inlayHints map[string]any, hint string, name string) (T, bool) {
+	settings, _ := inlayHints[hint].(map[string]any)
+	value, ok := settings[name].(T)
+	return value, ok
+}
+
+func supportsConfiguration(params *lsproto.InitializeParams) bool {
+	return params != nil && params.Capabilities != nil && params.Capabilities.Workspace != nil &&
+		ptrIsTrue(params.Capabilities.Workspace.Configuration)
+}
+
+func supportsInlayHintRefresh(params *lsproto.InitializeParams) bool {
+	return params != nil && params.Capabilities != nil && params.Capabilities.Workspace != nil &&
+		params.Capabilities.Workspace.InlayHint != nil &&
+		ptrIsTrue(params.Capabilities.Workspace.InlayHint.RefreshSupport)
+}
+
 func getCompletionClientCapabilities(params *lsproto.InitializeParams) *lsproto.CompletionClientCapabilities {
 	if params == nil || params.Capabilities == nil || params.Capabilities.TextDocument == nil {
 		return nil
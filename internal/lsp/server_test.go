@@ -0,0 +1,40 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ls"
+	"gotest.tools/v3/assert"
+)
+
+func TestApplyTypeScriptSettings(t *testing.T) {
+	t.Parallel()
+
+	initial := &ls.UserPreferences{
+		IncludeInlayEnumMemberValueHints: ptrTo(true),
+		IncludeInlayVariableTypeHints:    ptrTo(true),
+	}
+	preferences := applyTypeScriptSettings(initial, map[string]any{
+		"inlayHints": map[string]any{
+			"parameterNames": map[string]any{
+				"enabled":                         "literals",
+				"suppressWhenArgumentMatchesName": true,
+			},
+			"variableTypes": map[string]any{
+				"enabled": false,
+			},
+			"functionLikeReturnTypes": map[string]any{
+				"enabled": true,
+			},
+		},
+	})
+
+	assert.Equal(t, *preferences.IncludeInlayParameterNameHints, ls.IncludeInlayParameterNameHintsLiterals)
+	assert.Equal(t, *preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName, false)
+	assert.Equal(t, *preferences.IncludeInlayVariableTypeHints, false)
+	assert.Equal(t, *preferences.IncludeInlayFunctionLikeReturnTypeHints, true)
+	// Preferences without a setting keep their initial value
+	assert.Equal(t, *preferences.IncludeInlayEnumMemberValueHints, true)
+	assert.Assert(t, preferences.IncludeInlayFunctionParameterTypeHints == nil)
+	assert.Equal(t, *initial.IncludeInlayVariableTypeHints, true)
+}
@@ -215,7 +215,10 @@ func (f *NodeFactory) NewLogicalORExpression(left *ast.Expression, right *ast.Ex
 	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindBarBarToken), right)
 }
 
-// func (f *NodeFactory) NewLogicalANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
+func (f *NodeFactory) NewLogicalANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression {
+	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindAmpersandAmpersandToken), right)
+}
+
 // func (f *NodeFactory) NewBitwiseORExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
 // func (f *NodeFactory) NewBitwiseXORExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
 // func (f *NodeFactory) NewBitwiseANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
@@ -316,6 +319,36 @@ func (f *NodeFactory) NewFunctionCallCall(target *ast.Expression, thisArg *ast.E
 	return f.NewMethodCall(target, f.NewIdentifier("call"), args)
 }
 
+func (f *NodeFactory) NewFunctionBindCall(target *ast.Expression, thisArg *ast.Expression, argumentsList []*ast.Node) *ast.Node {
+	if thisArg == nil {
+		panic("Attempted to construct function bind call without this argument expression")
+	}
+	args := append([]*ast.Expression{thisArg}, argumentsList...)
+	return f.NewMethodCall(target, f.NewIdentifier("bind"), args)
+}
+
+// Creates an arrow function with the provided statements as its body that is immediately invoked:
+//
+//	(() => { ... })()
+func (f *NodeFactory) NewImmediatelyInvokedArrowFunction(statements []*ast.Statement) *ast.Expression {
+	arrow := f.NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		f.NewNodeList(nil),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewToken(ast.KindEqualsGreaterThanToken),
+		f.NewBlock(f.NewNodeList(statements), true /*multiLine*/),
+	)
+	return f.NewCallExpression(
+		f.NewParenthesizedExpression(arrow),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(nil),
+		ast.NodeFlagsNone,
+	)
+}
+
 // Determines whether a node is a parenthesized expression that can be ignored when recreating outer expressions.
 //
 // A parenthesized expression can be ignored when all of the following are true:
@@ -508,7 +541,57 @@ func (f *NodeFactory) NewUnscopedHelperName(name string) *ast.IdentifierNode {
 	return node
 }
 
-// !!! TypeScript Helpers
+// TypeScript Helpers
+
+// Creates a call to the `__decorate` helper, which applies legacy decorators to a class or class member. For a class,
+// memberName and descriptor are nil; for a member, descriptor is either `null` (methods and accessors) or `void 0`
+// (properties).
+func (f *NodeFactory) NewDecorateHelper(decoratorExpressions []*ast.Expression, target *ast.Expression, memberName *ast.Expression, descriptor *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(decorateHelper)
+	arguments := []*ast.Expression{
+		f.NewArrayLiteralExpression(f.NewNodeList(decoratorExpressions), true /*multiLine*/),
+		target,
+	}
+	if memberName != nil {
+		arguments = append(arguments, memberName)
+		if descriptor != nil {
+			arguments = append(arguments, descriptor)
+		}
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__decorate"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Creates a call to the `__metadata` helper, which records design-time type information for a decorated declaration.
+func (f *NodeFactory) NewMetadataHelper(metadataKey string, metadataValue *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(metadataHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__metadata"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewStringLiteral(metadataKey), metadataValue}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Creates a call to the `__param` helper, which adapts a legacy parameter decorator to a method decorator.
+func (f *NodeFactory) NewParamHelper(expression *ast.Expression, parameterOffset int, location core.TextRange) *ast.Expression {
+	f.emitContext.RequestEmitHelper(paramHelper)
+	call := f.NewCallExpression(
+		f.NewUnscopedHelperName("__param"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewNumericLiteral(fmt.Sprint(parameterOffset)), expression}),
+		ast.NodeFlagsNone,
+	)
+	call.Loc = location
+	return call
+}
 
 // ESNext Helpers
 
@@ -534,8 +617,235 @@ func (f *NodeFactory) NewDisposeResourcesHelper(envBinding *ast.Expression) *ast
 	)
 }
 
-// !!! Class Fields Helpers
-// !!! ES2018 Helpers
+// Class Fields Helpers
+
+// Creates a call to the `__classPrivateFieldGet` helper. The kind is one of "f" (field), "m" (method), or "a"
+// (accessor), and `f` is the static field descriptor, method, or getter, if any.
+func (f *NodeFactory) NewClassPrivateFieldGetHelper(receiver *ast.Expression, state *ast.IdentifierNode, kind string, fn *ast.IdentifierNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldGetHelper)
+	arguments := []*ast.Expression{receiver, state, f.NewStringLiteral(kind)}
+	if fn != nil {
+		arguments = append(arguments, fn)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldGet"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Creates a call to the `__classPrivateFieldSet` helper. The kind is one of "f" (field), "m" (method), or "a"
+// (accessor), and `f` is the static field descriptor or setter, if any.
+func (f *NodeFactory) NewClassPrivateFieldSetHelper(receiver *ast.Expression, state *ast.IdentifierNode, value *ast.Expression, kind string, fn *ast.IdentifierNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldSetHelper)
+	arguments := []*ast.Expression{receiver, state, value, f.NewStringLiteral(kind)}
+	if fn != nil {
+		arguments = append(arguments, fn)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldSet"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Creates a call to the `__classPrivateFieldIn` helper, used to lower `#x in obj`.
+func (f *NodeFactory) NewClassPrivateFieldInHelper(state *ast.IdentifierNode, receiver *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldInHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldIn"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{state, receiver}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES Decorators Helpers
+
+// The name of a decorated class element, as exposed through the `name` and `access` properties of its decorator
+// context. When Computed is false, Name is the Identifier or PrivateIdentifier of the element; otherwise it is an
+// expression that evaluates to the property key.
+type ESDecorateName struct {
+	Computed bool
+	Name     *ast.Node
+}
+
+// The `access` object of a class element decorator context. Get and Set indicate whether the `get` and `set`
+// functions should be defined.
+type ESDecorateClassElementAccess struct {
+	Get bool
+	Set bool
+}
+
+// The decorator context for a class element. Kind is one of "method", "getter", "setter", "accessor", or "field".
+type ESDecorateClassElementContext struct {
+	Kind     string
+	Name     ESDecorateName
+	Static   bool
+	Private  bool
+	Access   ESDecorateClassElementAccess
+	Metadata *ast.Expression
+}
+
+// The decorator context for a class.
+type ESDecorateClassContext struct {
+	Name     *ast.Expression
+	Metadata *ast.Expression
+}
+
+func (f *NodeFactory) newESDecorateNameReference(name ESDecorateName) *ast.Node {
+	return name.Name.Clone(f)
+}
+
+func (f *NodeFactory) newESDecorateObjectParameter() *ast.Node {
+	return f.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, f.NewIdentifier("obj"), nil /*questionToken*/, nil /*type*/, nil /*initializer*/)
+}
+
+func (f *NodeFactory) newESDecorateAccessExpression(name ESDecorateName) *ast.Expression {
+	if name.Computed {
+		return f.NewElementAccessExpression(f.NewIdentifier("obj"), nil /*questionDotToken*/, f.newESDecorateNameReference(name), ast.NodeFlagsNone)
+	}
+	return f.NewPropertyAccessExpression(f.NewIdentifier("obj"), nil /*questionDotToken*/, f.newESDecorateNameReference(name), ast.NodeFlagsNone)
+}
+
+func (f *NodeFactory) newESDecorateAccessFunction(name string, parameters []*ast.Node, body *ast.Node) *ast.Node {
+	return f.NewPropertyAssignment(
+		nil, /*modifiers*/
+		f.NewIdentifier(name),
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		f.NewArrowFunction(
+			nil, /*modifiers*/
+			nil, /*typeParameters*/
+			f.NewNodeList(parameters),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			f.NewToken(ast.KindEqualsGreaterThanToken),
+			body,
+		),
+	)
+}
+
+// Creates the `access` object of a class element decorator context:
+//
+//	{ has: obj => "x" in obj, get: obj => obj.x, set: (obj, value) => { obj.x = value; } }
+func (f *NodeFactory) newESDecorateClassElementAccessObject(name ESDecorateName, access ESDecorateClassElementAccess) *ast.Expression {
+	var propertyName *ast.Expression
+	switch {
+	case name.Computed:
+		propertyName = f.newESDecorateNameReference(name)
+	case ast.IsIdentifier(name.Name):
+		propertyName = f.NewStringLiteralFromNode(name.Name)
+	default:
+		propertyName = f.newESDecorateNameReference(name)
+	}
+
+	properties := []*ast.Node{
+		f.newESDecorateAccessFunction(
+			"has",
+			[]*ast.Node{f.newESDecorateObjectParameter()},
+			f.NewBinaryExpression(nil /*modifiers*/, propertyName, nil /*typeNode*/, f.NewToken(ast.KindInKeyword), f.NewIdentifier("obj")),
+		),
+	}
+	if access.Get {
+		properties = append(properties, f.newESDecorateAccessFunction(
+			"get",
+			[]*ast.Node{f.newESDecorateObjectParameter()},
+			f.newESDecorateAccessExpression(name),
+		))
+	}
+	if access.Set {
+		properties = append(properties, f.newESDecorateAccessFunction(
+			"set",
+			[]*ast.Node{
+				f.newESDecorateObjectParameter(),
+				f.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, f.NewIdentifier("value"), nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+			},
+			f.NewBlock(f.NewNodeList([]*ast.Statement{
+				f.NewExpressionStatement(f.NewAssignmentExpression(f.newESDecorateAccessExpression(name), f.NewIdentifier("value"))),
+			}), false /*multiLine*/),
+		))
+	}
+	return f.NewObjectLiteralExpression(f.NewNodeList(properties), false /*multiLine*/)
+}
+
+func (f *NodeFactory) newESDecorateContextProperty(name string, value *ast.Expression) *ast.Node {
+	return f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, value)
+}
+
+// Creates the context object passed to the decorators of a class element:
+//
+//	{ kind: "method", name: "m", static: false, private: false, access: { ... }, metadata: _metadata }
+func (f *NodeFactory) NewESDecorateClassElementContextObject(context ESDecorateClassElementContext) *ast.Expression {
+	var name *ast.Expression
+	if context.Name.Computed {
+		name = f.newESDecorateNameReference(context.Name)
+	} else {
+		name = f.NewStringLiteralFromNode(context.Name.Name)
+	}
+	properties := []*ast.Node{
+		f.newESDecorateContextProperty("kind", f.NewStringLiteral(context.Kind)),
+		f.newESDecorateContextProperty("name", name),
+		f.newESDecorateContextProperty("static", core.IfElse(context.Static, f.NewTrueExpression(), f.NewFalseExpression())),
+		f.newESDecorateContextProperty("private", core.IfElse(context.Private, f.NewTrueExpression(), f.NewFalseExpression())),
+		f.newESDecorateContextProperty("access", f.newESDecorateClassElementAccessObject(context.Name, context.Access)),
+		f.newESDecorateContextProperty("metadata", context.Metadata),
+	}
+	return f.NewObjectLiteralExpression(f.NewNodeList(properties), false /*multiLine*/)
+}
+
+// Creates the context object passed to the decorators of a class:
+//
+//	{ kind: "class", name: _classThis.name, metadata: _metadata }
+func (f *NodeFactory) NewESDecorateClassContextObject(context ESDecorateClassContext) *ast.Expression {
+	properties := []*ast.Node{
+		f.newESDecorateContextProperty("kind", f.NewStringLiteral("class")),
+		f.newESDecorateContextProperty("name", context.Name),
+		f.newESDecorateContextProperty("metadata", context.Metadata),
+	}
+	return f.NewObjectLiteralExpression(f.NewNodeList(properties), false /*multiLine*/)
+}
+
+// Creates a call to the `__esDecorate` helper. A nil ctor, descriptorIn, or initializers is emitted as `null`.
+func (f *NodeFactory) NewESDecorateHelper(ctor *ast.Expression, descriptorIn *ast.Expression, decorators *ast.Expression, contextIn *ast.Expression, initializers *ast.Expression, extraInitializers *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(esDecorateHelper)
+	orNull := func(expression *ast.Expression) *ast.Expression {
+		if expression == nil {
+			return f.NewKeywordExpression(ast.KindNullKeyword)
+		}
+		return expression
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__esDecorate"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{orNull(ctor), orNull(descriptorIn), decorators, contextIn, orNull(initializers), orNull(extraInitializers)}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Creates a call to the `__runInitializers` helper. If value is nil, it is omitted from the call.
+func (f *NodeFactory) NewRunInitializersHelper(thisArg *ast.Expression, initializers *ast.Expression, value *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(runInitializersHelper)
+	arguments := []*ast.Expression{thisArg, initializers}
+	if value != nil {
+		arguments = append(arguments, value)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__runInitializers"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2018 Helpers
 // Chains a sequence of expressions using the __assign helper or Object.assign if available in the target
 func (f *NodeFactory) NewAssignHelper(attributesSegments []*ast.Expression, scriptTarget core.ScriptTarget) *ast.Expression {
 	if scriptTarget >= core.ScriptTargetES2015 {
@@ -551,8 +861,140 @@ func (f *NodeFactory) NewAssignHelper(attributesSegments []*ast.Expression, scri
 	)
 }
 
-// !!! ES2018 Destructuring Helpers
-// !!! ES2017 Helpers
+// Allocates a new Call expression to the `__await` helper.
+func (f *NodeFactory) NewAwaitHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaitHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__await"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncGenerator` helper, which drives `generatorFunc` as an async iterator.
+func (f *NodeFactory) NewAsyncGeneratorHelper(generatorFunc *ast.Expression, hasLexicalThis bool) *ast.Expression {
+	f.emitContext.RequestEmitHelper(asyncGeneratorHelper)
+	f.emitContext.AddEmitFlags(generatorFunc, EFReuseTempVariableScope)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncGenerator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{
+			core.IfElse(hasLexicalThis, f.NewThisExpression(), f.NewVoidZeroExpression()),
+			f.NewIdentifier("arguments"),
+			generatorFunc,
+		}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncDelegator` helper.
+func (f *NodeFactory) NewAsyncDelegatorHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(asyncDelegatorHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncDelegator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncValues` helper.
+func (f *NodeFactory) NewAsyncValuesHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(asyncValuesHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncValues"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2018 Destructuring Helpers
+
+// Allocates a new Call expression to the `__rest` helper, which copies the own enumerable properties of `value` that
+// are not named by `propertyNames`. Each computed property name consumes the next entry of `computedTempVariables`,
+// which holds the already-evaluated property key.
+func (f *NodeFactory) NewRestHelper(value *ast.Expression, propertyNames []*ast.Node, computedTempVariables []*ast.Node, location core.TextRange) *ast.Expression {
+	f.emitContext.RequestEmitHelper(restHelper)
+	var names []*ast.Expression
+	computedIndex := 0
+	for _, propertyName := range propertyNames {
+		if propertyName == nil {
+			continue
+		}
+		if ast.IsComputedPropertyName(propertyName) {
+			temp := computedTempVariables[computedIndex]
+			computedIndex++
+			// typeof _a === "symbol" ? _a : _a + ""
+			names = append(names, f.NewConditionalExpression(
+				f.NewStrictEqualityExpression(f.NewTypeOfExpression(temp), f.NewStringLiteral("symbol")),
+				f.NewToken(ast.KindQuestionToken),
+				temp,
+				f.NewToken(ast.KindColonToken),
+				f.NewBinaryExpression(nil /*modifiers*/, temp, nil /*typeNode*/, f.NewToken(ast.KindPlusToken), f.NewStringLiteral("")),
+			))
+		} else if ast.IsStringLiteral(propertyName) {
+			names = append(names, f.NewStringLiteral(propertyName.Text()))
+		} else {
+			names = append(names, f.NewStringLiteralFromNode(propertyName))
+		}
+	}
+	array := f.NewArrayLiteralExpression(f.NewNodeList(names), false /*multiLine*/)
+	array.Loc = location
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__rest"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{value, array}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2017 Helpers
+
+// Allocates a new Call expression to the `__awaiter` helper, wrapping `body` in a generator function.
+//
+// When `parameters` is non-nil, the generator function receives the original parameter list and
+// `argumentsExpression` supplies the values to forward to it.
+func (f *NodeFactory) NewAwaiterHelper(hasLexicalThis bool, argumentsExpression *ast.Expression, parameters *ast.ParameterList, body *ast.BlockNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaiterHelper)
+	if parameters == nil {
+		parameters = f.NewNodeList([]*ast.Node{})
+	}
+	generatorFunc := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		f.NewToken(ast.KindAsteriskToken),
+		nil, /*name*/
+		nil, /*typeParameters*/
+		parameters,
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		body,
+	)
+	f.emitContext.AddEmitFlags(generatorFunc, EFReuseTempVariableScope)
+
+	thisArg := core.IfElse(hasLexicalThis, f.NewThisExpression(), f.NewVoidZeroExpression())
+	if argumentsExpression == nil {
+		argumentsExpression = f.NewVoidZeroExpression()
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__awaiter"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{
+			thisArg,
+			argumentsExpression,
+			f.NewVoidZeroExpression(), /*promiseConstructor*/
+			generatorFunc,
+		}),
+		ast.NodeFlagsNone,
+	)
+}
 
 // ES2015 Helpers
 
@@ -610,6 +1052,12 @@ func (f *NodeFactory) NewImportStarHelper(expression *ast.Expression) *ast.Expre
 	)
 }
 
+// Allocates a reference to the `__importStar` helper for use as a callback, e.g. `promise.then(__importStar)`.
+func (f *NodeFactory) NewImportStarCallbackHelper() *ast.Expression {
+	f.emitContext.RequestEmitHelper(importStarHelper)
+	return f.NewUnscopedHelperName("__importStar")
+}
+
 // Allocates a new Call expression to the `__exportStar` helper.
 func (f *NodeFactory) NewExportStarHelper(moduleExpression *ast.Expression, exportsExpression *ast.Expression) *ast.Expression {
 	f.emitContext.RequestEmitHelper(exportStarHelper)
@@ -30,7 +30,37 @@ func compareEmitHelpers(x *EmitHelper, y *EmitHelper) int {
 	return x.Priority.Value - y.Priority.Value
 }
 
-// !!! TypeScript Helpers
+// TypeScript Helpers
+
+var decorateHelper = &EmitHelper{
+	Name:       "typescript:decorate",
+	ImportName: "__decorate",
+	Priority:   &Priority{2},
+	Text: `var __decorate = (this && this.__decorate) || function (decorators, target, key, desc) {
+    var c = arguments.length, r = c < 3 ? target : desc === null ? desc = Object.getOwnPropertyDescriptor(target, key) : desc, d;
+    if (typeof Reflect === "object" && typeof Reflect.decorate === "function") r = Reflect.decorate(decorators, target, key, desc);
+    else for (var i = decorators.length - 1; i >= 0; i--) if (d = decorators[i]) r = (c < 3 ? d(r) : c > 3 ? d(target, key, r) : d(target, key)) || r;
+    return c > 3 && r && Object.defineProperty(target, key, r), r;
+};`,
+}
+
+var metadataHelper = &EmitHelper{
+	Name:       "typescript:metadata",
+	ImportName: "__metadata",
+	Priority:   &Priority{3},
+	Text: `var __metadata = (this && this.__metadata) || function (k, v) {
+    if (typeof Reflect === "object" && typeof Reflect.metadata === "function") return Reflect.metadata(k, v);
+};`,
+}
+
+var paramHelper = &EmitHelper{
+	Name:       "typescript:param",
+	ImportName: "__param",
+	Priority:   &Priority{4},
+	Text: `var __param = (this && this.__param) || function (paramIndex, decorator) {
+    return function (target, key) { decorator(target, key, paramIndex); }
+};`,
+}
 
 // ESNext Helpers
 
@@ -98,8 +128,93 @@ var disposeResourcesHelper = &EmitHelper{
 });`,
 }
 
-// !!! Class Fields Helpers
-// !!! ES2018 Helpers
+// Class Fields Helpers
+
+var classPrivateFieldGetHelper = &EmitHelper{
+	Name:       "typescript:classPrivateFieldGet",
+	ImportName: "__classPrivateFieldGet",
+	Scoped:     false,
+	Text: `var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};`,
+}
+
+var classPrivateFieldSetHelper = &EmitHelper{
+	Name:       "typescript:classPrivateFieldSet",
+	ImportName: "__classPrivateFieldSet",
+	Scoped:     false,
+	Text: `var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};`,
+}
+
+var classPrivateFieldInHelper = &EmitHelper{
+	Name:       "typescript:classPrivateFieldIn",
+	ImportName: "__classPrivateFieldIn",
+	Scoped:     false,
+	Text: `var __classPrivateFieldIn = (this && this.__classPrivateFieldIn) || function(state, receiver) {
+    if (receiver === null || (typeof receiver !== "object" && typeof receiver !== "function")) throw new TypeError("Cannot use 'in' operator on non-object");
+    return typeof state === "function" ? receiver === state : state.has(receiver);
+};`,
+}
+
+// ES Decorators Helpers
+
+var esDecorateHelper = &EmitHelper{
+	Name:       "typescript:esDecorate",
+	ImportName: "__esDecorate",
+	Scoped:     false,
+	Priority:   &Priority{2},
+	Text: `var __esDecorate = (this && this.__esDecorate) || function (ctor, descriptorIn, decorators, contextIn, initializers, extraInitializers) {
+    function accept(f) { if (f !== void 0 && typeof f !== "function") throw new TypeError("Function expected"); return f; }
+    var kind = contextIn.kind, key = kind === "getter" ? "get" : kind === "setter" ? "set" : "value";
+    var target = !descriptorIn && ctor ? contextIn["static"] ? ctor : ctor.prototype : null;
+    var descriptor = descriptorIn || (target ? Object.getOwnPropertyDescriptor(target, contextIn.name) : {});
+    var _, done = false;
+    for (var i = decorators.length - 1; i >= 0; i--) {
+        var context = {};
+        for (var p in contextIn) context[p] = p === "access" ? {} : contextIn[p];
+        for (var p in contextIn.access) context.access[p] = contextIn.access[p];
+        context.addInitializer = function (f) { if (done) throw new TypeError("Cannot add initializers after decoration has completed"); extraInitializers.push(accept(f || null)); };
+        var result = (0, decorators[i])(kind === "accessor" ? { get: descriptor.get, set: descriptor.set } : descriptor[key], context);
+        if (kind === "accessor") {
+            if (result === void 0) continue;
+            if (result === null || typeof result !== "object") throw new TypeError("Object expected");
+            if (_ = accept(result.get)) descriptor.get = _;
+            if (_ = accept(result.set)) descriptor.set = _;
+            if (_ = accept(result.init)) initializers.unshift(_);
+        }
+        else if (_ = accept(result)) {
+            if (kind === "field") initializers.unshift(_);
+            else descriptor[key] = _;
+        }
+    }
+    if (target) Object.defineProperty(target, contextIn.name, descriptor);
+    done = true;
+};`,
+}
+
+var runInitializersHelper = &EmitHelper{
+	Name:       "typescript:runInitializers",
+	ImportName: "__runInitializers",
+	Scoped:     false,
+	Priority:   &Priority{2},
+	Text: `var __runInitializers = (this && this.__runInitializers) || function (thisArg, initializers, value) {
+    var useValue = arguments.length > 2;
+    for (var i = 0; i < initializers.length; i++) {
+        value = useValue ? initializers[i].call(thisArg, value) : initializers[i].call(thisArg);
+    }
+    return useValue ? value : void 0;
+};`,
+}
+
+// ES2018 Helpers
+
 var assignHelper = &EmitHelper{
 	Name:       "typescript:assign",
 	ImportName: "__assign",
@@ -118,8 +233,114 @@ var assignHelper = &EmitHelper{
 };`,
 }
 
-// !!! ES2018 Destructuring Helpers
-// !!! ES2017 Helpers
+var awaitHelper = &EmitHelper{
+	Name:       "typescript:await",
+	ImportName: "__await",
+	Scoped:     false,
+	Text:       `var __await = (this && this.__await) || function (v) { return this instanceof __await ? (this.v = v, this) : new __await(v); }`,
+}
+
+var asyncGeneratorHelper = &EmitHelper{
+	Name:         "typescript:asyncGenerator",
+	ImportName:   "__asyncGenerator",
+	Scoped:       false,
+	Dependencies: []*EmitHelper{awaitHelper},
+	Text: `var __asyncGenerator = (this && this.__asyncGenerator) || function (thisArg, _arguments, generator) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var g = generator.apply(thisArg, _arguments || []), i, q = [];
+    return i = Object.create((typeof AsyncIterator === "function" ? AsyncIterator : Object).prototype), verb("next"), verb("throw"), verb("return", awaitReturn), i[Symbol.asyncIterator] = function () { return this; }, i;
+    function awaitReturn(f) { return function (v) { return Promise.resolve(v).then(f, reject); }; }
+    function verb(n, f) { if (g[n]) { i[n] = function (v) { return new Promise(function (a, b) { q.push([n, v, a, b]) > 1 || resume(n, v); }); }; if (f) i[n] = f(i[n]); } }
+    function resume(n, v) { try { step(g[n](v)); } catch (e) { settle(q[0][3], e); } }
+    function step(r) { r.value instanceof __await ? Promise.resolve(r.value.v).then(fulfill, reject) : settle(q[0][2], r); }
+    function fulfill(value) { resume("next", value); }
+    function reject(value) { resume("throw", value); }
+    function settle(f, v) { if (f(v), q.shift(), q.length) resume(q[0][0], q[0][1]); }
+};`,
+}
+
+var asyncDelegatorHelper = &EmitHelper{
+	Name:         "typescript:asyncDelegator",
+	ImportName:   "__asyncDelegator",
+	Scoped:       false,
+	Dependencies: []*EmitHelper{awaitHelper},
+	Text: `var __asyncDelegator = (this && this.__asyncDelegator) || function (o) {
+    var i, p;
+    return i = {}, verb("next"), verb("throw", function (e) { throw e; }), verb("return"), i[Symbol.iterator] = function () { return this; }, i;
+    function verb(n, f) { i[n] = o[n] ? function (v) { return (p = !p) ? { value: __await(o[n](v)), done: false } : f ? f(v) : v; } : f; }
+};`,
+}
+
+var asyncValuesHelper = &EmitHelper{
+	Name:       "typescript:asyncValues",
+	ImportName: "__asyncValues",
+	Scoped:     false,
+	Text: `var __asyncValues = (this && this.__asyncValues) || function (o) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var m = o[Symbol.asyncIterator], i;
+    return m ? m.call(o) : (o = typeof __values === "function" ? __values(o) : o[Symbol.iterator](), i = {}, verb("next"), verb("throw"), verb("return"), i[Symbol.asyncIterator] = function () { return this; }, i);
+    function verb(n) { i[n] = o[n] && function (v) { return new Promise(function (resolve, reject) { v = o[n](v), settle(resolve, reject, v.done, v.value); }); }; }
+    function settle(resolve, reject, d, v) { Promise.resolve(v).then(function(v) { resolve({ value: v, done: d }); }, reject); }
+};`,
+}
+
+// ES2018 Destructuring Helpers
+
+var restHelper = &EmitHelper{
+	Name:       "typescript:rest",
+	ImportName: "__rest",
+	Scoped:     false,
+	Text: `var __rest = (this && this.__rest) || function (s, e) {
+    var t = {};
+    for (var p in s) if (Object.prototype.hasOwnProperty.call(s, p) && e.indexOf(p) < 0)
+        t[p] = s[p];
+    if (s != null && typeof Object.getOwnPropertySymbols === "function")
+        for (var i = 0, p = Object.getOwnPropertySymbols(s); i < p.length; i++) {
+            if (e.indexOf(p[i]) < 0 && Object.prototype.propertyIsEnumerable.call(s, p[i]))
+                t[p[i]] = s[p[i]];
+        }
+    return t;
+};`,
+}
+
+// ES2017 Helpers
+
+var awaiterHelper = &EmitHelper{
+	Name:       "typescript:awaiter",
+	ImportName: "__awaiter",
+	Scoped:     false,
+	Priority:   &Priority{5},
+	Text: `var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};`,
+}
+
+// Scoped helper used to read `super[x]` from within the generator body of a lowered async method.
+var AsyncSuperHelper = &EmitHelper{
+	Name:   "typescript:async-super",
+	Scoped: true,
+	TextCallback: func(makeUniqueName func(string) string) string {
+		return "const " + makeUniqueName("_superIndex") + " = name => super[name];"
+	},
+}
+
+// Scoped helper used to read and write `super[x]` from within the generator body of a lowered async method.
+var AdvancedAsyncSuperHelper = &EmitHelper{
+	Name:   "typescript:advanced-async-super",
+	Scoped: true,
+	TextCallback: func(makeUniqueName func(string) string) string {
+		return "const " + makeUniqueName("_superIndex") + ` = (function (geti, seti) {
+    const cache = Object.create(null);
+    return name => cache[name] || (cache[name] = { get value() { return geti(name); }, set value(v) { seti(name, v); } });
+})(name => super[name], (name, value) => super[name] = value);`
+	},
+}
 
 // ES2015 Helpers
 
@@ -220,6 +441,13 @@ var exportStarHelper = &EmitHelper{
 };`,
 }
 
+// Allows a UMD module to synchronously `require` its dynamic imports when it is loaded as a CommonJS module.
+var DynamicImportUMDHelper = &EmitHelper{
+	Name:   "typescript:dynamicimport-sync-require",
+	Scoped: true,
+	Text:   `var __syncRequire = typeof module === "object" && typeof module.exports === "object";`,
+}
+
 var rewriteRelativeImportExtensionsHelper = &EmitHelper{
 	Name:       "typescript:rewriteRelativeImportExtensions",
 	ImportName: "__rewriteRelativeImportExtension",
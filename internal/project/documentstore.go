@@ -114,6 +114,19 @@ func (ds *DocumentStore) getOrCreateScriptInfoWorker(fileName string, path tspat
 	return info
 }
 
+// ChangeScriptInfo applies changes to the text of an open ScriptInfo and marks the projects containing it as dirty
+func (ds *DocumentStore) ChangeScriptInfo(info *ScriptInfo, changes []core.TextChange) {
+	for _, change := range changes {
+		info.editContent(change)
+	}
+}
+
+// CloseScriptInfo closes a ScriptInfo opened with GetOrCreateOpenScriptInfo. If the file exists on disk,
+// its text is reloaded from disk the next time it is read.
+func (ds *DocumentStore) CloseScriptInfo(info *ScriptInfo) {
+	info.close(!info.isDynamic && info.fs.FileExists(info.fileName))
+}
+
 // DeleteScriptInfo removes a ScriptInfo from the store
 func (ds *DocumentStore) DeleteScriptInfo(info *ScriptInfo) {
 	ds.scriptInfosMu.Lock()
@@ -182,9 +182,12 @@ func (r *CompilerBaselineRunner) runSingleConfigTest(t *testing.T, testName stri
 	payload := makeUnitsFromTest(test.content, test.filename)
 	compilerTest := newCompilerTest(t, testName, test.filename, &payload, config)
 
+	// Submodule reference baselines are not yet maintained for these module kinds
 	switch compilerTest.options.GetEmitModuleKind() {
 	case core.ModuleKindAMD, core.ModuleKindUMD, core.ModuleKindSystem:
-		t.Skipf("Skipping test %s with unsupported module kind %s", testName, compilerTest.options.GetEmitModuleKind())
+		if r.isSubmodule {
+			t.Skipf("Skipping test %s with unsupported module kind %s", testName, compilerTest.options.GetEmitModuleKind())
+		}
 	}
 
 	compilerTest.verifyDiagnostics(t, r.testSuitName, r.isSubmodule)
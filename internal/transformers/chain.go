@@ -2,7 +2,6 @@ package transformers
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
-	"github.com/microsoft/typescript-go/internal/printer"
 )
 
 type chainedTransformer struct {
@@ -21,7 +20,7 @@ func (ch *chainedTransformer) visit(node *ast.Node) *ast.Node {
 	return result.AsNode()
 }
 
-type TransformerFactory = func(emitContext *printer.EmitContext) *Transformer
+type TransformerFactory = func(opt *TransformOptions) *Transformer
 
 // Chains transforms in left-to-right order, running them one at a time in order (as opposed to interleaved at each node)
 // - the resulting combined transform only operates on SourceFile nodes
@@ -32,13 +31,13 @@ func Chain(transforms ...TransformerFactory) TransformerFactory {
 		}
 		return transforms[0]
 	}
-	return func(emitContext *printer.EmitContext) *Transformer {
+	return func(opt *TransformOptions) *Transformer {
 		constructed := make([]*Transformer, 0, len(transforms))
 		for _, t := range transforms {
 			// TODO: flatten nested chains?
-			constructed = append(constructed, t(emitContext))
+			constructed = append(constructed, t(opt))
 		}
 		ch := &chainedTransformer{components: constructed}
-		return ch.NewTransformer(ch.visit, emitContext)
+		return ch.NewTransformer(ch.visit, opt.Context)
 	}
 }
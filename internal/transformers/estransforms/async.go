@@ -2,19 +2,650 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
 type asyncTransformer struct {
 	transformers.Transformer
+	asyncBodyVisitor *ast.NodeVisitor
+	scope            asyncScope
 }
 
-func (ch *asyncTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+// Tracks the lexical state of the function currently being visited.
+type asyncScope struct {
+	hasLexicalThis   bool                     // whether `this` should be forwarded to `__awaiter` (i.e., we are not at the top level)
+	inAsyncBody      bool                     // whether we are in the body of a function that is being lowered into a generator
+	argumentsBinding *ast.IdentifierNode      // a binding that captures the lexical `arguments` of the outermost async function
+	parameterNames   *collections.Set[string] // the names of the parameters of the nearest async function being lowered
+	superAccess      *superAccessScope        // tracks `super` property accesses for the nearest method, accessor, or constructor
 }
 
-func newAsyncTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+func newAsyncTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
 	tx := &asyncTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	result := tx.NewTransformer(tx.visit, opt.Context)
+	tx.asyncBodyVisitor = opt.Context.NewNodeVisitor(tx.visitAsyncBody)
+	return result
+}
+
+func (tx *asyncTransformer) shouldVisit(node *ast.Node) bool {
+	facts := node.SubtreeFacts()
+	if facts&(ast.SubtreeContainsAnyAwait|ast.SubtreeContainsAwait) != 0 {
+		return true
+	}
+	if tx.scope.argumentsBinding != nil && facts&ast.SubtreeContainsIdentifier != 0 {
+		return true
+	}
+	return tx.isSubstitutingSuper() && facts&ast.SubtreeContainsLexicalSuper != 0
+}
+
+func (tx *asyncTransformer) visit(node *ast.Node) *ast.Node {
+	if !tx.shouldVisit(node) {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindAwaitExpression:
+		return tx.visitAwaitExpression(node.AsAwaitExpression())
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	case ast.KindArrowFunction:
+		return tx.visitArrowFunction(node.AsArrowFunction())
+	case ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor:
+		return tx.visitNonAsyncSuperContainer(node)
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindPropertyDeclaration, ast.KindClassStaticBlockDeclaration:
+		return tx.visitClassScope(node)
+	case ast.KindPropertyAccessExpression:
+		return tx.visitPropertyAccessExpression(node.AsPropertyAccessExpression())
+	case ast.KindElementAccessExpression:
+		return tx.visitElementAccessExpression(node.AsElementAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindIdentifier:
+		return tx.visitIdentifier(node)
+	case ast.KindShorthandPropertyAssignment:
+		return tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+// Visits the statements of an async function body, hoisting any `var` declarations whose names collide with the
+// names of the function's parameters.
+func (tx *asyncTransformer) visitAsyncBody(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindVariableStatement:
+		return tx.visitVariableStatementInAsyncBody(node.AsVariableStatement())
+	case ast.KindForStatement:
+		return tx.visitForStatementInAsyncBody(node.AsForStatement())
+	case ast.KindForInStatement, ast.KindForOfStatement:
+		return tx.visitForInOrOfStatementInAsyncBody(node.AsForInOrOfStatement())
+	case ast.KindCatchClause:
+		return tx.visitCatchClauseInAsyncBody(node.AsCatchClause())
+	case ast.KindBlock,
+		ast.KindSwitchStatement,
+		ast.KindCaseBlock,
+		ast.KindCaseClause,
+		ast.KindDefaultClause,
+		ast.KindTryStatement,
+		ast.KindDoStatement,
+		ast.KindWhileStatement,
+		ast.KindIfStatement,
+		ast.KindWithStatement,
+		ast.KindLabeledStatement:
+		return tx.asyncBodyVisitor.VisitEachChild(node)
+	default:
+		return tx.visit(node)
+	}
+}
+
+func (tx *asyncTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+	tx.scope = asyncScope{}
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+func (tx *asyncTransformer) visitAwaitExpression(node *ast.AwaitExpression) *ast.Node {
+	// Top-level await and `await` in an async generator are not handled by this transform.
+	if !tx.scope.inAsyncBody {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	result := tx.Factory().NewYieldExpression(nil /*asteriskToken*/, tx.Visitor().VisitNode(node.Expression))
+	tx.EmitContext().SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+// Enters a non-arrow function, which has its own `this`, `arguments`, and (for class elements) `super`.
+func (tx *asyncTransformer) enterFunctionScope(node *ast.Node, isSuperContainer bool) asyncScope {
+	saved := tx.scope
+	tx.scope = asyncScope{hasLexicalThis: true}
+	if isSuperContainer {
+		if flags := getSuperAccessFlags(node, isLoweredAsyncFunction); flags != superAccessFlagsNone {
+			tx.scope.superAccess = &superAccessScope{flags: flags}
+		}
+	}
+	return saved
+}
+
+func (tx *asyncTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	saved := tx.enterFunctionScope(node.AsNode(), true /*isSuperContainer*/)
+	defer func() { tx.scope = saved }()
+
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	name := tx.Visitor().VisitNode(node.Name())
+	var parameters *ast.ParameterList
+	var body *ast.Node
+	if isLoweredAsyncFunction(node.AsNode()) {
+		modifiers = transformers.ExtractModifiers(tx.EmitContext(), modifiers, ^ast.ModifierFlagsAsync)
+		parameters, body = tx.transformAsyncFunction(node.AsNode())
+	} else {
+		parameters = tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+		body = tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	}
+	body = tx.addSuperAccessDeclarations(body)
+	return tx.Factory().UpdateMethodDeclaration(node, modifiers, node.AsteriskToken, name, nil /*postfixToken*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *asyncTransformer) visitNonAsyncSuperContainer(node *ast.Node) *ast.Node {
+	saved := tx.enterFunctionScope(node, true /*isSuperContainer*/)
+	defer func() { tx.scope = saved }()
+
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body(), tx.Visitor())
+	body = tx.addSuperAccessDeclarations(body)
+	switch node.Kind {
+	case ast.KindGetAccessor:
+		n := node.AsGetAccessorDeclaration()
+		return tx.Factory().UpdateGetAccessorDeclaration(n, modifiers, tx.Visitor().VisitNode(n.Name()), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	case ast.KindSetAccessor:
+		n := node.AsSetAccessorDeclaration()
+		return tx.Factory().UpdateSetAccessorDeclaration(n, modifiers, tx.Visitor().VisitNode(n.Name()), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	default:
+		return tx.Factory().UpdateConstructorDeclaration(node.AsConstructorDeclaration(), modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	}
+}
+
+func (tx *asyncTransformer) visitFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	saved := tx.enterFunctionScope(node.AsNode(), false /*isSuperContainer*/)
+	defer func() { tx.scope = saved }()
+
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	var parameters *ast.ParameterList
+	var body *ast.Node
+	if isLoweredAsyncFunction(node.AsNode()) {
+		modifiers = transformers.ExtractModifiers(tx.EmitContext(), modifiers, ^ast.ModifierFlagsAsync)
+		parameters, body = tx.transformAsyncFunction(node.AsNode())
+	} else {
+		parameters = tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+		body = tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	}
+	return tx.Factory().UpdateFunctionDeclaration(node, modifiers, node.AsteriskToken, node.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *asyncTransformer) visitFunctionExpression(node *ast.FunctionExpression) *ast.Node {
+	saved := tx.enterFunctionScope(node.AsNode(), false /*isSuperContainer*/)
+	defer func() { tx.scope = saved }()
+
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	var parameters *ast.ParameterList
+	var body *ast.Node
+	if isLoweredAsyncFunction(node.AsNode()) {
+		modifiers = transformers.ExtractModifiers(tx.EmitContext(), modifiers, ^ast.ModifierFlagsAsync)
+		parameters, body = tx.transformAsyncFunction(node.AsNode())
+	} else {
+		parameters = tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+		body = tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	}
+	return tx.Factory().UpdateFunctionExpression(node, modifiers, node.AsteriskToken, node.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *asyncTransformer) visitArrowFunction(node *ast.ArrowFunction) *ast.Node {
+	// Arrow functions inherit `this`, `arguments`, and `super` from their containing function, so we only save the
+	// scope to restore the lowering state once we leave the arrow function.
+	saved := tx.scope
+	defer func() { tx.scope = saved }()
+
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	var parameters *ast.ParameterList
+	var body *ast.Node
+	if isLoweredAsyncFunction(node.AsNode()) {
+		modifiers = transformers.ExtractModifiers(tx.EmitContext(), modifiers, ^ast.ModifierFlagsAsync)
+		parameters, body = tx.transformAsyncFunction(node.AsNode())
+	} else {
+		parameters = tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+		body = tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	}
+	return tx.Factory().UpdateArrowFunction(node, modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, node.EqualsGreaterThanToken, body)
+}
+
+// Visits a class or class element, which introduces a new `this` binding and a new `super` home object. Methods,
+// accessors, and constructors further refine this scope when visited.
+func (tx *asyncTransformer) visitClassScope(node *ast.Node) *ast.Node {
+	saved := tx.scope
+	defer func() { tx.scope = saved }()
+	if !ast.IsClassLike(node) {
+		tx.scope = asyncScope{hasLexicalThis: true}
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Lowers an async function into a function that forwards its arguments to a generator function passed to the
+// `__awaiter` helper. For example:
+//
+//	async function f(a, b = 1) {
+//	  await g(a, b);
+//	}
+//
+// produces:
+//
+//	function f(a_1) {
+//	  return __awaiter(this, arguments, void 0, function* (a, b = 1) {
+//	    yield g(a, b);
+//	  });
+//	}
+func (tx *asyncTransformer) transformAsyncFunction(node *ast.Node) (*ast.ParameterList, *ast.Node) {
+	isArrowFunction := ast.IsArrowFunction(node)
+	originalParameters := node.ParameterList()
+	hasSimpleParameters := isSimpleParameterList(originalParameters.Nodes)
+
+	captureArguments := tx.scope.argumentsBinding == nil && referencesArguments(node)
+	if captureArguments {
+		tx.scope.argumentsBinding = tx.Factory().NewUniqueName("arguments")
+	}
+
+	tx.EmitContext().StartVariableEnvironment()
+
+	// Determine the parameters of the outer function and the arguments forwarded to the generator function. With a
+	// simple parameter list, the generator can refer to the outer parameters directly. Otherwise, the original
+	// parameters are moved into the generator so that their initializers and binding patterns are evaluated
+	// asynchronously, as they would be in an `async` function.
+	var outerParameters *ast.ParameterList
+	var innerParameters *ast.ParameterList
+	var argumentsExpression *ast.Expression
+	if hasSimpleParameters {
+		outerParameters = tx.Visitor().VisitNodes(originalParameters)
+	} else {
+		outerParameters, argumentsExpression = tx.createForwardingParameters(originalParameters, isArrowFunction)
+	}
+
+	savedInAsyncBody := tx.scope.inAsyncBody
+	savedParameterNames := tx.scope.parameterNames
+	tx.scope.inAsyncBody = true
+	tx.scope.parameterNames = &collections.Set[string]{}
+	for _, parameter := range originalParameters.Nodes {
+		recordDeclarationName(parameter.Name(), tx.scope.parameterNames)
+	}
+
+	if !hasSimpleParameters {
+		innerParameters = tx.EmitContext().VisitParameters(originalParameters, tx.Visitor())
+	} else {
+		tx.EmitContext().StartVariableEnvironment()
+	}
+
+	var statements []*ast.Statement
+	body := node.Body()
+	if ast.IsBlock(body) {
+		prologue, rest := tx.Factory().SplitStandardPrologue(body.AsBlock().Statements.Nodes)
+		statements = append(statements, prologue...)
+		statements = append(statements, core.FirstResult(tx.asyncBodyVisitor.VisitSlice(rest))...)
+	} else {
+		expression := tx.asyncBodyVisitor.VisitNode(body)
+		statement := tx.Factory().NewReturnStatement(expression)
+		statement.Loc = body.Loc
+		statements = append(statements, statement)
+	}
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+	generatorBody := tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+	generatorBody.Loc = body.Loc
+
+	tx.scope.inAsyncBody = savedInAsyncBody
+	tx.scope.parameterNames = savedParameterNames
+
+	awaiter := tx.Factory().NewAwaiterHelper(tx.scope.hasLexicalThis, argumentsExpression, innerParameters, generatorBody)
+
+	var outerStatements []*ast.Statement
+	if captureArguments {
+		outerStatements = append(outerStatements, tx.createCaptureArgumentsStatement())
+	}
+	outerStatements = tx.EmitContext().EndAndMergeVariableEnvironment(outerStatements)
+
+	if isArrowFunction && len(outerStatements) == 0 {
+		return outerParameters, awaiter
+	}
+
+	returnStatement := tx.Factory().NewReturnStatement(awaiter)
+	outerStatements = append(outerStatements, returnStatement)
+	outerBody := tx.Factory().NewBlock(tx.Factory().NewNodeList(outerStatements), true /*multiLine*/)
+	outerBody.Loc = body.Loc
+	return outerParameters, outerBody
+}
+
+// Creates the parameters for the outer function of a lowered async function with a non-simple parameter list, along
+// with the expression used to forward the arguments of the outer function to the generator.
+//
+// Non-arrow functions keep a parameter for each leading simple parameter to preserve the function's `length` and
+// forward the `arguments` object. Arrow functions have no `arguments` object, so the outer parameters are forwarded
+// explicitly and any remaining arguments are captured with a rest parameter.
+func (tx *asyncTransformer) createForwardingParameters(parameters *ast.ParameterList, isArrowFunction bool) (*ast.ParameterList, *ast.Expression) {
+	var newParameters []*ast.Node
+	var parameterBindings []*ast.Expression
+	for _, parameter := range parameters.Nodes {
+		p := parameter.AsParameterDeclaration()
+		if p.Initializer != nil || p.DotDotDotToken != nil {
+			if isArrowFunction {
+				restName := tx.Factory().NewUniqueNameEx("args", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+				newParameters = append(newParameters, tx.Factory().NewParameterDeclaration(nil /*modifiers*/, tx.Factory().NewToken(ast.KindDotDotDotToken), restName, nil /*questionToken*/, nil /*type*/, nil /*initializer*/))
+				parameterBindings = append(parameterBindings, tx.Factory().NewSpreadElement(restName.Clone(tx.Factory())))
+			}
+			break
+		}
+		name := tx.Factory().NewGeneratedNameForNodeEx(p.Name(), printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		newParameters = append(newParameters, tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, name, nil /*questionToken*/, nil /*type*/, nil /*initializer*/))
+		parameterBindings = append(parameterBindings, name.Clone(tx.Factory()))
+	}
+
+	parameterList := tx.Factory().NewNodeList(newParameters)
+	parameterList.Loc = parameters.Loc
+
+	var argumentsExpression *ast.Expression
+	if isArrowFunction {
+		argumentsExpression = tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(parameterBindings), false /*multiLine*/)
+	} else {
+		argumentsExpression = tx.Factory().NewIdentifier("arguments")
+	}
+	return parameterList, argumentsExpression
+}
+
+func (tx *asyncTransformer) createCaptureArgumentsStatement() *ast.Statement {
+	statement := tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsConst,
+			tx.Factory().NewNodeList([]*ast.Node{
+				tx.Factory().NewVariableDeclaration(tx.scope.argumentsBinding, nil /*exclamationToken*/, nil /*type*/, tx.Factory().NewIdentifier("arguments")),
+			}),
+		),
+	)
+	tx.EmitContext().AddEmitFlags(statement, printer.EFCustomPrologue|printer.EFStartOnNewLine)
+	return statement
+}
+
+func (tx *asyncTransformer) visitVariableStatementInAsyncBody(node *ast.VariableStatement) *ast.Node {
+	if tx.isVariableDeclarationListWithCollidingName(node.DeclarationList) {
+		expression := tx.visitVariableDeclarationListWithCollidingNames(node.DeclarationList.AsVariableDeclarationList(), false /*hasReceiver*/)
+		if expression == nil {
+			return nil
+		}
+		statement := tx.Factory().NewExpressionStatement(expression)
+		tx.EmitContext().SetOriginal(statement, node.AsNode())
+		statement.Loc = node.Loc
+		return statement
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) visitForStatementInAsyncBody(node *ast.ForStatement) *ast.Node {
+	var initializer *ast.ForInitializer
+	if tx.isVariableDeclarationListWithCollidingName(node.Initializer) {
+		initializer = tx.visitVariableDeclarationListWithCollidingNames(node.Initializer.AsVariableDeclarationList(), false /*hasReceiver*/)
+	} else {
+		initializer = tx.Visitor().VisitNode(node.Initializer)
+	}
+	return tx.Factory().UpdateForStatement(
+		node,
+		initializer,
+		tx.Visitor().VisitNode(node.Condition),
+		tx.Visitor().VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.asyncBodyVisitor),
+	)
+}
+
+func (tx *asyncTransformer) visitForInOrOfStatementInAsyncBody(node *ast.ForInOrOfStatement) *ast.Node {
+	var initializer *ast.ForInitializer
+	if tx.isVariableDeclarationListWithCollidingName(node.Initializer) {
+		initializer = tx.visitVariableDeclarationListWithCollidingNames(node.Initializer.AsVariableDeclarationList(), true /*hasReceiver*/)
+	} else {
+		initializer = tx.Visitor().VisitNode(node.Initializer)
+	}
+	return tx.Factory().UpdateForInOrOfStatement(
+		node,
+		node.AwaitModifier,
+		initializer,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.asyncBodyVisitor),
+	)
+}
+
+func (tx *asyncTransformer) visitCatchClauseInAsyncBody(node *ast.CatchClause) *ast.Node {
+	// Names declared by a catch clause variable are block scoped and shadow the parameters of the enclosing function
+	// for the duration of the catch block.
+	if node.VariableDeclaration != nil {
+		catchClauseNames := &collections.Set[string]{}
+		recordDeclarationName(node.VariableDeclaration.Name(), catchClauseNames)
+		var unshadowedNames *collections.Set[string]
+		for name := range catchClauseNames.Keys() {
+			if tx.scope.parameterNames.Has(name) {
+				if unshadowedNames == nil {
+					unshadowedNames = tx.scope.parameterNames.Clone()
+				}
+				unshadowedNames.Delete(name)
+			}
+		}
+		if unshadowedNames != nil {
+			savedParameterNames := tx.scope.parameterNames
+			tx.scope.parameterNames = unshadowedNames
+			defer func() { tx.scope.parameterNames = savedParameterNames }()
+		}
+	}
+	return tx.asyncBodyVisitor.VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) isVariableDeclarationListWithCollidingName(node *ast.Node) bool {
+	if node == nil || !ast.IsVariableDeclarationList(node) || node.Flags&ast.NodeFlagsBlockScoped != 0 {
+		return false
+	}
+	for _, declaration := range node.AsVariableDeclarationList().Declarations.Nodes {
+		if tx.collidesWithParameterName(declaration.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *asyncTransformer) collidesWithParameterName(name *ast.Node) bool {
+	if ast.IsIdentifier(name) {
+		return tx.scope.parameterNames.Has(name.Text())
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if element.Name() != nil && tx.collidesWithParameterName(element.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Converts a `var` declaration list whose names collide with the parameters of the enclosing async function into an
+// assignment expression. Colliding names already refer to the parameters, which the generator can access, while
+// any other names are hoisted to the top of the generator body.
+func (tx *asyncTransformer) visitVariableDeclarationListWithCollidingNames(node *ast.VariableDeclarationList, hasReceiver bool) *ast.Expression {
+	for _, declaration := range node.Declarations.Nodes {
+		tx.hoistVariable(declaration.Name())
+	}
+
+	var expressions []*ast.Expression
+	for _, declaration := range node.Declarations.Nodes {
+		if assignment := transformers.ConvertVariableDeclarationToAssignmentExpression(tx.EmitContext(), declaration.AsVariableDeclaration()); assignment != nil {
+			expressions = append(expressions, tx.Visitor().VisitNode(assignment))
+		}
+	}
+
+	if len(expressions) == 0 {
+		if hasReceiver {
+			name := node.Declarations.Nodes[0].Name()
+			if ast.IsBindingPattern(name) {
+				name = transformers.ConvertBindingPatternToAssignmentPattern(tx.EmitContext(), name.AsBindingPattern())
+			}
+			return tx.Visitor().VisitNode(name)
+		}
+		return nil
+	}
+	return tx.Factory().InlineExpressions(expressions)
+}
+
+func (tx *asyncTransformer) hoistVariable(name *ast.Node) {
+	if ast.IsIdentifier(name) {
+		if !tx.scope.parameterNames.Has(name.Text()) {
+			tx.EmitContext().AddVariableDeclaration(name.Clone(tx.Factory()))
+		}
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if element.Name() != nil {
+			tx.hoistVariable(element.Name())
+		}
+	}
+}
+
+func (tx *asyncTransformer) isSubstitutingSuper() bool {
+	return tx.scope.inAsyncBody && tx.scope.superAccess != nil
+}
+
+// Replaces `super.x` with `_super.x` in the body of a generator function, where `super` is not available.
+func (tx *asyncTransformer) visitPropertyAccessExpression(node *ast.PropertyAccessExpression) *ast.Node {
+	if tx.isSubstitutingSuper() && node.Expression.Kind == ast.KindSuperKeyword {
+		return tx.scope.superAccess.createSuperPropertyAccess(tx.EmitContext(), node)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Replaces `super[x]` with `_superIndex(x)` in the body of a generator function, where `super` is not available.
+func (tx *asyncTransformer) visitElementAccessExpression(node *ast.ElementAccessExpression) *ast.Node {
+	if tx.isSubstitutingSuper() && node.Expression.Kind == ast.KindSuperKeyword {
+		return tx.scope.superAccess.createSuperElementAccess(tx.EmitContext(), node, tx.Visitor().VisitNode(node.ArgumentExpression))
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Replaces `super.x(...)` with `_super.x.call(this, ...)` in the body of a generator function, where `super` is not
+// available.
+func (tx *asyncTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if tx.isSubstitutingSuper() && isSuperProperty(node.Expression) && node.QuestionDotToken == nil {
+		target := tx.Visitor().VisitNode(node.Expression)
+		arguments := tx.Visitor().VisitNodes(node.Arguments)
+		result := tx.Factory().NewFunctionCallCall(target, tx.Factory().NewThisExpression(), arguments.Nodes)
+		tx.EmitContext().SetOriginal(result, node.AsNode())
+		result.Loc = node.Loc
+		return result
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if tx.scope.argumentsBinding != nil && node.Text() == "arguments" && node.Parent != nil && transformers.IsIdentifierReference(node, node.Parent) {
+		result := tx.scope.argumentsBinding.Clone(tx.Factory())
+		result.Loc = node.Loc
+		return result
+	}
+	return node
+}
+
+func (tx *asyncTransformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	if tx.scope.argumentsBinding != nil && node.Name().Text() == "arguments" && node.ObjectAssignmentInitializer == nil {
+		result := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, node.Name(), nil /*postfixToken*/, nil /*typeNode*/, tx.scope.argumentsBinding.Clone(tx.Factory()))
+		tx.EmitContext().SetOriginal(result, node.AsNode())
+		result.Loc = node.Loc
+		return result
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Adds the `_super` accessor object and any `_superIndex` helper to the body of the current super container.
+func (tx *asyncTransformer) addSuperAccessDeclarations(body *ast.Node) *ast.Node {
+	if tx.scope.superAccess == nil || body == nil {
+		return body
+	}
+	return tx.scope.superAccess.addDeclarations(tx.EmitContext(), body)
+}
+
+// Indicates whether a function is an async function that is lowered by this transform. Async generators are lowered
+// by the ES2018 transforms instead.
+func isLoweredAsyncFunction(node *ast.Node) bool {
+	return ast.IsFunctionLike(node) && ast.HasSyntacticModifier(node, ast.ModifierFlagsAsync) && !isGeneratorFunction(node)
+}
+
+func isGeneratorFunction(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindFunctionDeclaration:
+		return node.AsFunctionDeclaration().AsteriskToken != nil
+	case ast.KindFunctionExpression:
+		return node.AsFunctionExpression().AsteriskToken != nil
+	case ast.KindMethodDeclaration:
+		return node.AsMethodDeclaration().AsteriskToken != nil
+	}
+	return false
+}
+
+func isSimpleParameterList(parameters []*ast.ParameterDeclarationNode) bool {
+	for _, parameter := range parameters {
+		p := parameter.AsParameterDeclaration()
+		if p.Initializer != nil || p.DotDotDotToken != nil || !ast.IsIdentifier(p.Name()) {
+			return false
+		}
+	}
+	return true
+}
+
+func recordDeclarationName(name *ast.Node, names *collections.Set[string]) {
+	if ast.IsIdentifier(name) {
+		names.Add(name.Text())
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if element.Name() != nil {
+			recordDeclarationName(element.Name(), names)
+		}
+	}
+}
+
+// Determines whether the parameters or body of a function reference its `arguments` object, including from within
+// nested arrow functions.
+func referencesArguments(node *ast.Node) bool {
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		switch node.Kind {
+		case ast.KindIdentifier:
+			return node.Text() == "arguments" && node.Parent != nil && transformers.IsIdentifierReference(node, node.Parent)
+		case ast.KindShorthandPropertyAssignment:
+			return node.Name().Text() == "arguments"
+		case ast.KindFunctionDeclaration,
+			ast.KindFunctionExpression,
+			ast.KindMethodDeclaration,
+			ast.KindGetAccessor,
+			ast.KindSetAccessor,
+			ast.KindConstructor,
+			ast.KindClassDeclaration,
+			ast.KindClassExpression:
+			return false
+		}
+		return node.ForEachChild(visit)
+	}
+	for _, parameter := range node.Parameters() {
+		if visit(parameter) {
+			return true
+		}
+	}
+	return node.Body() != nil && visit(node.Body())
 }
@@ -1,20 +1,899 @@
 package estransforms
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
 type classFieldsTransformer struct {
 	transformers.Transformer
+	useDefineForClassFields        bool                // whether fields are defined (rather than assigned) on the instance or class
+	shouldTransformPrivateElements bool                // whether `#private` members, static fields, and static blocks are lowered
+	privateEnvironment             *privateEnvironment // the private names in scope for the current class
+	classThis                      *ast.IdentifierNode // replaces `this` in static initializers moved out of the class body
+}
+
+type privateIdentifierKind int
+
+const (
+	privateIdentifierKindField privateIdentifierKind = iota
+	privateIdentifierKindMethod
+	privateIdentifierKindAccessor
+)
+
+type privateIdentifierInfo struct {
+	kind     privateIdentifierKind
+	isStatic bool
+	// The WeakMap (instance fields), WeakSet (instance methods and accessors), or class constructor (static members)
+	// used to check that a receiver has the private member.
+	brandCheckIdentifier *ast.IdentifierNode
+	// The descriptor of a static field, or the function that implements a method.
+	variableName *ast.IdentifierNode
+	getterName   *ast.IdentifierNode
+	setterName   *ast.IdentifierNode
+}
+
+type privateEnvironment struct {
+	parent      *privateEnvironment
+	className   string
+	weakSetName *ast.IdentifierNode // the WeakSet used as the brand for instance methods and accessors
+	members     map[string]*privateIdentifierInfo
+}
+
+// Gets the key under which a private name is stored in a privateEnvironment. Generated private names, such as the
+// backing fields of decorated auto-accessors, are keyed by their generated identity rather than their text, since
+// their text is not known until they are printed.
+func (tx *classFieldsTransformer) getPrivateNameKey(name *ast.PrivateIdentifierNode) string {
+	if info := tx.EmitContext().GetAutoGenerateInfo(name); info != nil {
+		return "@" + strconv.FormatUint(uint64(info.Id), 10)
+	}
+	return name.Text()
+}
+
+// Gets the text from which to name the variables hoisted for a private name.
+func (tx *classFieldsTransformer) getPrivateNameText(name *ast.PrivateIdentifierNode) string {
+	if info := tx.EmitContext().GetAutoGenerateInfo(name); info != nil {
+		if node := tx.EmitContext().GetNodeForGeneratedName(name); node != nil && node != name && (ast.IsIdentifier(node) || ast.IsPrivateIdentifier(node)) {
+			return info.Prefix + node.Text() + info.Suffix
+		}
+		return info.Prefix + info.Suffix
+	}
+	return name.Text()
+}
+
+func (env *privateEnvironment) lookup(name string) *privateIdentifierInfo {
+	for ; env != nil; env = env.parent {
+		if info, ok := env.members[name]; ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// The transformed members of a class, along with the expressions that must be evaluated once the class is defined.
+type classMembersResult struct {
+	members            []*ast.ClassElement
+	pendingExpressions []*ast.Expression // WeakMap and WeakSet initializers, private methods, and computed property names
+	staticInitializers []*ast.Expression // static field initializers and static blocks, in declaration order
+}
+
+func newClassFieldsTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
+	tx := &classFieldsTransformer{
+		useDefineForClassFields:        opt.CompilerOptions.GetUseDefineForClassFields(),
+		shouldTransformPrivateElements: opt.CompilerOptions.GetEmitScriptTarget() < core.ScriptTargetES2022,
+	}
+	return tx.NewTransformer(tx.visit, opt.Context)
+}
+
+func (tx *classFieldsTransformer) visit(node *ast.Node) *ast.Node {
+	facts := node.SubtreeFacts()
+	if facts&ast.SubtreeContainsClassFields == 0 && (tx.classThis == nil || facts&ast.SubtreeContainsLexicalThis == 0) {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindFunctionDeclaration,
+		ast.KindFunctionExpression,
+		ast.KindConstructor,
+		ast.KindMethodDeclaration,
+		ast.KindGetAccessor,
+		ast.KindSetAccessor:
+		return tx.visitFunctionLikeDeclaration(node)
+	case ast.KindThisKeyword:
+		return tx.visitThisKeyword(node)
+	case ast.KindPropertyAccessExpression:
+		return tx.visitPropertyAccessExpression(node.AsPropertyAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression())
+	case ast.KindPrefixUnaryExpression, ast.KindPostfixUnaryExpression:
+		return tx.visitPreOrPostfixUnaryExpression(node, false /*resultIsDiscarded*/)
+	case ast.KindExpressionStatement:
+		return tx.visitExpressionStatement(node.AsExpressionStatement())
+	case ast.KindVariableDeclaration,
+		ast.KindPropertyAssignment,
+		ast.KindShorthandPropertyAssignment,
+		ast.KindParameter,
+		ast.KindBindingElement,
+		ast.KindPropertyDeclaration,
+		ast.KindExportAssignment:
+		return tx.visitNamedEvaluationSource(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+// An anonymous class whose static members are evaluated after the class is defined is assigned to a temporary
+// variable, which prevents it from receiving the name it would otherwise get from its container. Such a class is
+// given its assigned name explicitly instead.
+func (tx *classFieldsTransformer) visitNamedEvaluationSource(node *ast.Node) *ast.Node {
+	if isNamedEvaluationAnd(tx.EmitContext(), node, tx.isAnonymousClassNeedingAssignedName) {
+		node = transformNamedEvaluation(tx.EmitContext(), node, false /*ignoreEmptyStringLiteral*/, "" /*assignedName*/)
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *classFieldsTransformer) isAnonymousClassNeedingAssignedName(node *ast.Node) bool {
+	return ast.IsClassExpression(node) && node.Name() == nil && tx.needsClassReference(node)
+}
+
+func (tx *classFieldsTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Non-arrow functions have their own `this`, so `this` is no longer substituted within them.
+func (tx *classFieldsTransformer) visitFunctionLikeDeclaration(node *ast.Node) *ast.Node {
+	savedClassThis := tx.classThis
+	tx.classThis = nil
+	defer func() { tx.classThis = savedClassThis }()
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *classFieldsTransformer) visitThisKeyword(node *ast.Node) *ast.Node {
+	if tx.classThis != nil {
+		result := tx.classThis.Clone(tx.Factory())
+		result.Loc = node.Loc
+		return result
+	}
+	return node
+}
+
+// Indicates whether a class has any members that this transform moves out of the class body.
+func (tx *classFieldsTransformer) shouldTransformClass(node *ast.Node) bool {
+	for _, member := range node.Members() {
+		if tx.shouldTransformProperty(member) || tx.shouldTransformPrivateMethodOrAccessor(member) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *classFieldsTransformer) shouldTransformProperty(member *ast.Node) bool {
+	if !ast.IsPropertyDeclaration(member) || ast.HasAccessorModifier(member) {
+		// !!! auto-accessors (`accessor x`) are not yet lowered
+		return false
+	}
+	return tx.shouldTransformPrivateElements || !ast.IsPrivateIdentifier(member.Name())
+}
+
+func (tx *classFieldsTransformer) shouldTransformPrivateMethodOrAccessor(member *ast.Node) bool {
+	return tx.shouldTransformPrivateElements &&
+		(ast.IsMethodDeclaration(member) || ast.IsAccessor(member)) &&
+		ast.IsPrivateIdentifier(member.Name())
+}
+
+// Indicates whether transforming the class requires a reference to the class constructor after it is defined.
+func (tx *classFieldsTransformer) needsClassReference(node *ast.Node) bool {
+	if !tx.shouldTransformPrivateElements {
+		return false
+	}
+	for _, member := range node.Members() {
+		if ast.IsStatic(member) && (tx.shouldTransformProperty(member) || tx.shouldTransformPrivateMethodOrAccessor(member)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lowers the fields of a class declaration. For example:
+//
+//	class C {
+//	  #x = 1;
+//	  static y = 2;
+//	}
+//
+// produces:
+//
+//	var _C_x;
+//	class C {
+//	  constructor() {
+//	    _C_x.set(this, 1);
+//	  }
+//	}
+//	_C_x = new WeakMap();
+//	C.y = 2;
+func (tx *classFieldsTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !tx.shouldTransformClass(node.AsNode()) {
+		return tx.visitClassWithoutTransform(node.AsNode())
+	}
+
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+
+	name := node.Name()
+	var classReference *ast.IdentifierNode
+	if tx.needsClassReference(node.AsNode()) {
+		if name == nil {
+			name = tx.Factory().NewGeneratedNameForNode(node.AsNode())
+		}
+		classReference = name
+	}
+
+	result := tx.transformClassMembers(node.AsNode(), classReference)
+	members := tx.Factory().NewNodeList(result.members)
+	members.Loc = node.Members.Loc
+	updated := tx.Factory().UpdateClassDeclaration(node, modifiers, name, nil /*typeParameters*/, heritageClauses, members)
+
+	statements := []*ast.Statement{updated}
+	if len(result.pendingExpressions) > 0 {
+		statement := tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(result.pendingExpressions))
+		tx.EmitContext().AddEmitFlags(statement, printer.EFStartOnNewLine)
+		statements = append(statements, statement)
+	}
+	for _, initializer := range result.staticInitializers {
+		statement := tx.Factory().NewExpressionStatement(initializer)
+		tx.EmitContext().AddEmitFlags(statement, printer.EFStartOnNewLine)
+		statements = append(statements, statement)
+	}
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+// Lowers the fields of a class expression, evaluating any pending initializers after the class is defined:
+//
+//	(_a = class { ... }, _C_x = new WeakMap(), _a.y = 2, _a)
+func (tx *classFieldsTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	if !tx.shouldTransformClass(node.AsNode()) {
+		return tx.visitClassWithoutTransform(node.AsNode())
+	}
+
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+
+	var classReference *ast.IdentifierNode
+	if tx.needsClassReference(node.AsNode()) {
+		classReference = tx.Factory().NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		tx.EmitContext().AddVariableDeclaration(classReference)
+	}
+
+	result := tx.transformClassMembers(node.AsNode(), classReference)
+	members := tx.Factory().NewNodeList(result.members)
+	members.Loc = node.Members.Loc
+	updated := tx.Factory().UpdateClassExpression(node, modifiers, node.Name(), nil /*typeParameters*/, heritageClauses, members)
+	if len(result.pendingExpressions) == 0 && len(result.staticInitializers) == 0 {
+		return updated
+	}
+
+	var expressions []*ast.Expression
+	if classReference != nil {
+		expressions = append(expressions, tx.Factory().NewAssignmentExpression(classReference.Clone(tx.Factory()), updated))
+		expressions = append(expressions, result.pendingExpressions...)
+		expressions = append(expressions, result.staticInitializers...)
+		expressions = append(expressions, classReference.Clone(tx.Factory()))
+	} else {
+		expressions = append(expressions, result.pendingExpressions...)
+		expressions = append(expressions, updated)
+	}
+	return tx.Factory().NewParenthesizedExpression(tx.Factory().InlineExpressions(expressions))
+}
+
+func (tx *classFieldsTransformer) visitClassWithoutTransform(node *ast.Node) *ast.Node {
+	savedClassThis := tx.classThis
+	tx.classThis = nil
+	defer func() { tx.classThis = savedClassThis }()
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *classFieldsTransformer) transformClassMembers(node *ast.Node, classReference *ast.IdentifierNode) *classMembersResult {
+	savedPrivateEnvironment := tx.privateEnvironment
+	savedClassThis := tx.classThis
+	tx.privateEnvironment = tx.createPrivateEnvironment(node, classReference)
+	tx.classThis = nil
+	defer func() {
+		tx.privateEnvironment = savedPrivateEnvironment
+		tx.classThis = savedClassThis
+	}()
+
+	result := &classMembersResult{}
+	var instanceInitializers []*ast.Expression
+	if env := tx.privateEnvironment; env != savedPrivateEnvironment && env.weakSetName != nil {
+		result.pendingExpressions = append(result.pendingExpressions, tx.createNewWeakCollection(env.weakSetName, "WeakSet"))
+		instanceInitializers = append(instanceInitializers, tx.Factory().NewMethodCall(
+			env.weakSetName.Clone(tx.Factory()),
+			tx.Factory().NewIdentifier("add"),
+			[]*ast.Expression{tx.Factory().NewThisExpression()},
+		))
+	}
+
+	var constructor *ast.Node
+	for _, member := range node.Members() {
+		switch {
+		case ast.IsConstructorDeclaration(member):
+			constructor = member
+		case tx.shouldTransformProperty(member):
+			if ast.IsStatic(member) {
+				tx.transformStaticProperty(member.AsPropertyDeclaration(), classReference, result)
+			} else if initializer := tx.transformInstanceProperty(member.AsPropertyDeclaration(), result); initializer != nil {
+				instanceInitializers = append(instanceInitializers, initializer)
+			}
+		case tx.shouldTransformPrivateMethodOrAccessor(member):
+			tx.transformPrivateMethodOrAccessor(member, result)
+		case ast.IsClassStaticBlockDeclaration(member) && classReference != nil:
+			tx.transformStaticBlock(member.AsClassStaticBlockDeclaration(), classReference, result)
+		default:
+			if visited := tx.Visitor().VisitNode(member); visited != nil {
+				result.members = append(result.members, visited)
+			}
+		}
+	}
+
+	// The constructor is emitted first, matching the order in which the class evaluates its instance initializers.
+	if len(instanceInitializers) > 0 {
+		constructor = tx.transformConstructor(node, constructor, instanceInitializers)
+	} else if constructor != nil {
+		constructor = tx.Visitor().VisitNode(constructor)
+	}
+	if constructor != nil {
+		result.members = append([]*ast.ClassElement{constructor}, result.members...)
+	}
+	return result
+}
+
+// Creates the private name environment for a class, hoisting the variables used to store its private members.
+func (tx *classFieldsTransformer) createPrivateEnvironment(node *ast.Node, classReference *ast.IdentifierNode) *privateEnvironment {
+	if !tx.shouldTransformPrivateElements {
+		return tx.privateEnvironment
+	}
+
+	var env *privateEnvironment
+	for _, member := range node.Members() {
+		if !ast.IsPrivateIdentifierClassElementDeclaration(member) || ast.HasAccessorModifier(member) {
+			continue
+		}
+		if env == nil {
+			env = &privateEnvironment{parent: tx.privateEnvironment, members: make(map[string]*privateIdentifierInfo)}
+			if name := node.Name(); name != nil && ast.IsIdentifier(name) {
+				env.className = name.Text()
+			}
+		}
+
+		key := tx.getPrivateNameKey(member.Name())
+		name := tx.getPrivateNameText(member.Name())
+		isStatic := ast.IsStatic(member)
+		info := env.members[key]
+		if info == nil {
+			info = &privateIdentifierInfo{isStatic: isStatic}
+			env.members[key] = info
+		}
+
+		switch member.Kind {
+		case ast.KindPropertyDeclaration:
+			info.kind = privateIdentifierKindField
+			info.variableName = tx.createHoistedVariableForPrivateName(env, name, "")
+			if isStatic {
+				info.brandCheckIdentifier = classReference
+			} else {
+				info.brandCheckIdentifier = info.variableName
+			}
+		case ast.KindMethodDeclaration:
+			info.kind = privateIdentifierKindMethod
+			info.variableName = tx.createHoistedVariableForPrivateName(env, name, "")
+			info.brandCheckIdentifier = tx.getPrivateBrandCheckIdentifier(env, isStatic, classReference)
+		case ast.KindGetAccessor:
+			info.kind = privateIdentifierKindAccessor
+			info.getterName = tx.createHoistedVariableForPrivateName(env, name, "_get")
+			info.brandCheckIdentifier = tx.getPrivateBrandCheckIdentifier(env, isStatic, classReference)
+		case ast.KindSetAccessor:
+			info.kind = privateIdentifierKindAccessor
+			info.setterName = tx.createHoistedVariableForPrivateName(env, name, "_set")
+			info.brandCheckIdentifier = tx.getPrivateBrandCheckIdentifier(env, isStatic, classReference)
+		}
+	}
+	if env == nil {
+		return tx.privateEnvironment
+	}
+	return env
+}
+
+func (tx *classFieldsTransformer) getPrivateBrandCheckIdentifier(env *privateEnvironment, isStatic bool, classReference *ast.IdentifierNode) *ast.IdentifierNode {
+	if isStatic {
+		return classReference
+	}
+	if env.weakSetName == nil {
+		env.weakSetName = tx.createHoistedVariableForPrivateName(env, "#instances", "")
+	}
+	return env.weakSetName
+}
+
+// Creates a hoisted variable named after the class and private name, such as `_C_x` for `#x` in class `C`.
+func (tx *classFieldsTransformer) createHoistedVariableForPrivateName(env *privateEnvironment, name string, suffix string) *ast.IdentifierNode {
+	var b strings.Builder
+	b.WriteString("_")
+	if env.className != "" {
+		b.WriteString(env.className)
+		b.WriteString("_")
+	}
+	b.WriteString(strings.TrimPrefix(name, "#"))
+	b.WriteString(suffix)
+	identifier := tx.Factory().NewUniqueNameEx(b.String(), printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+	tx.EmitContext().AddVariableDeclaration(identifier)
+	return identifier
+}
+
+func (tx *classFieldsTransformer) createNewWeakCollection(name *ast.IdentifierNode, kind string) *ast.Expression {
+	return tx.Factory().NewAssignmentExpression(
+		name.Clone(tx.Factory()),
+		tx.Factory().NewNewExpression(tx.Factory().NewIdentifier(kind), nil /*typeArguments*/, tx.Factory().NewNodeList(nil)),
+	)
+}
+
+// Transforms an instance field into an expression evaluated in the constructor.
+func (tx *classFieldsTransformer) transformInstanceProperty(node *ast.PropertyDeclaration, result *classMembersResult) *ast.Expression {
+	if ast.IsPrivateIdentifier(node.Name()) {
+		info := tx.privateEnvironment.lookup(tx.getPrivateNameKey(node.Name()))
+		result.pendingExpressions = append(result.pendingExpressions, tx.createNewWeakCollection(info.variableName, "WeakMap"))
+		return tx.Factory().NewMethodCall(
+			info.variableName.Clone(tx.Factory()),
+			tx.Factory().NewIdentifier("set"),
+			[]*ast.Expression{tx.Factory().NewThisExpression(), tx.visitPropertyInitializer(node)},
+		)
+	}
+
+	// Parameter properties are already assigned in the constructor, but must first be defined when using
+	// `useDefineForClassFields` semantics.
+	isParameterProperty := ast.IsParameter(tx.EmitContext().MostOriginal(node.AsNode()))
+	if isParameterProperty && !tx.useDefineForClassFields {
+		return nil
+	}
+	if node.Initializer == nil && !tx.useDefineForClassFields {
+		return nil
+	}
+	propertyName := tx.transformPropertyName(node.Name(), result)
+	return tx.createFieldInitializer(tx.Factory().NewThisExpression(), propertyName, tx.visitPropertyInitializer(node))
+}
+
+// Transforms a static field (or a static block converted to a field) into an expression evaluated after the class
+// is defined, or, when static blocks are supported, into a static block in the same position.
+func (tx *classFieldsTransformer) transformStaticProperty(node *ast.PropertyDeclaration, classReference *ast.IdentifierNode, result *classMembersResult) {
+	if !tx.shouldTransformPrivateElements {
+		// `useDefineForClassFields` is disabled, so the static field is assigned in a static block.
+		if node.Initializer == nil {
+			return
+		}
+		propertyName := tx.transformPropertyName(node.Name(), result)
+		expression := tx.createFieldInitializer(tx.Factory().NewThisExpression(), propertyName, tx.Visitor().VisitNode(node.Initializer))
+		statement := tx.Factory().NewExpressionStatement(expression)
+		block := tx.Factory().NewClassStaticBlockDeclaration(nil /*modifiers*/, tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{statement}), true /*multiLine*/))
+		tx.EmitContext().SetOriginal(block, node.AsNode())
+		block.Loc = node.Loc
+		result.members = append(result.members, block)
+		return
+	}
+
+	savedClassThis := tx.classThis
+	tx.classThis = classReference
+	defer func() { tx.classThis = savedClassThis }()
+
+	if ast.IsClassStaticBlockDeclaration(tx.EmitContext().MostOriginal(node.AsNode())) {
+		result.staticInitializers = append(result.staticInitializers, tx.Visitor().VisitNode(node.Initializer))
+		return
+	}
+
+	if ast.IsPrivateIdentifier(node.Name()) {
+		info := tx.privateEnvironment.lookup(tx.getPrivateNameKey(node.Name()))
+		descriptor := tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+			tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, tx.visitPropertyInitializer(node)),
+		}), false /*multiLine*/)
+		result.staticInitializers = append(result.staticInitializers, tx.Factory().NewAssignmentExpression(info.variableName.Clone(tx.Factory()), descriptor))
+		return
+	}
+
+	if node.Initializer == nil && !tx.useDefineForClassFields {
+		return
+	}
+	propertyName := tx.transformPropertyName(node.Name(), result)
+	expression := tx.createFieldInitializer(classReference.Clone(tx.Factory()), propertyName, tx.visitPropertyInitializer(node))
+	tx.EmitContext().SetOriginal(expression, node.AsNode())
+	expression.Loc = node.Loc
+	result.staticInitializers = append(result.staticInitializers, expression)
+}
+
+// Transforms a static block added by this transform, such as one that sets the assigned name of the class, into an
+// expression evaluated after the class is defined. Static blocks in the source have already been converted to static
+// fields at this point.
+func (tx *classFieldsTransformer) transformStaticBlock(node *ast.ClassStaticBlockDeclaration, classReference *ast.IdentifierNode, result *classMembersResult) {
+	savedClassThis := tx.classThis
+	tx.classThis = classReference
+	defer func() { tx.classThis = savedClassThis }()
+
+	statements := node.Body.AsBlock().Statements.Nodes
+	if len(statements) == 1 && ast.IsExpressionStatement(statements[0]) {
+		result.staticInitializers = append(result.staticInitializers, tx.Visitor().VisitNode(statements[0].Expression()))
+		return
+	}
+	tx.EmitContext().StartVariableEnvironment()
+	body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	arrow := tx.Factory().NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList(nil),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Factory().NewToken(ast.KindEqualsGreaterThanToken),
+		body,
+	)
+	iife := tx.Factory().NewCallExpression(tx.Factory().NewParenthesizedExpression(arrow), nil /*questionDotToken*/, nil /*typeArguments*/, tx.Factory().NewNodeList(nil), ast.NodeFlagsNone)
+	result.staticInitializers = append(result.staticInitializers, iife)
+}
+
+func (tx *classFieldsTransformer) visitPropertyInitializer(node *ast.PropertyDeclaration) *ast.Expression {
+	if node.Initializer == nil {
+		return tx.Factory().NewVoidZeroExpression()
+	}
+	return tx.Visitor().VisitNode(node.Initializer)
 }
 
-func (ch *classFieldsTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+// Returns the property name to use outside of the class body, hoisting the value of a computed property name so that
+// it is evaluated only once.
+func (tx *classFieldsTransformer) transformPropertyName(name *ast.PropertyName, result *classMembersResult) *ast.PropertyName {
+	if !ast.IsComputedPropertyName(name) {
+		return name
+	}
+	expression := tx.Visitor().VisitNode(name.Expression())
+	if !ast.IsStringLiteralLike(expression) && !ast.IsNumericLiteral(expression) {
+		temp := tx.Factory().NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		tx.EmitContext().AddVariableDeclaration(temp)
+		result.pendingExpressions = append(result.pendingExpressions, tx.Factory().NewAssignmentExpression(temp, expression))
+		expression = temp.Clone(tx.Factory())
+	}
+	return tx.Factory().NewComputedPropertyName(expression)
+}
+
+// Creates either `Object.defineProperty(receiver, "x", { ... })` or `receiver.x = value`, depending on
+// `useDefineForClassFields`.
+func (tx *classFieldsTransformer) createFieldInitializer(receiver *ast.Expression, name *ast.Node, value *ast.Expression) *ast.Expression {
+	if tx.useDefineForClassFields {
+		var key *ast.Expression
+		switch {
+		case ast.IsIdentifier(name):
+			key = tx.Factory().NewStringLiteral(name.Text())
+		case ast.IsComputedPropertyName(name):
+			key = name.Expression()
+		default:
+			key = name
+		}
+		descriptor := tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+			tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier("enumerable"), nil /*postfixToken*/, nil /*typeNode*/, tx.Factory().NewTrueExpression()),
+			tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier("configurable"), nil /*postfixToken*/, nil /*typeNode*/, tx.Factory().NewTrueExpression()),
+			tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier("writable"), nil /*postfixToken*/, nil /*typeNode*/, tx.Factory().NewTrueExpression()),
+			tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, value),
+		}), false /*multiLine*/)
+		return tx.Factory().NewGlobalMethodCall("Object", "defineProperty", []*ast.Expression{receiver, key, descriptor})
+	}
+
+	var target *ast.Expression
+	if ast.IsIdentifier(name) {
+		target = tx.Factory().NewPropertyAccessExpression(receiver, nil /*questionDotToken*/, name.Clone(tx.Factory()), ast.NodeFlagsNone)
+	} else if ast.IsComputedPropertyName(name) {
+		target = tx.Factory().NewElementAccessExpression(receiver, nil /*questionDotToken*/, name.Expression(), ast.NodeFlagsNone)
+	} else {
+		target = tx.Factory().NewElementAccessExpression(receiver, nil /*questionDotToken*/, name, ast.NodeFlagsNone)
+	}
+	return tx.Factory().NewAssignmentExpression(target, value)
+}
+
+// Moves a private method or accessor out of the class body into a hoisted function:
+//
+//	_C_m = function _C_m() { ... }
+func (tx *classFieldsTransformer) transformPrivateMethodOrAccessor(node *ast.Node, result *classMembersResult) {
+	info := tx.privateEnvironment.lookup(tx.getPrivateNameKey(node.Name()))
+	var name *ast.IdentifierNode
+	var asteriskToken *ast.TokenNode
+	switch node.Kind {
+	case ast.KindMethodDeclaration:
+		name = info.variableName
+		asteriskToken = node.AsMethodDeclaration().AsteriskToken
+	case ast.KindGetAccessor:
+		name = info.getterName
+	case ast.KindSetAccessor:
+		name = info.setterName
+	}
+
+	savedClassThis := tx.classThis
+	tx.classThis = nil
+	defer func() { tx.classThis = savedClassThis }()
+
+	// !!! `super` property accesses in private methods are not yet rewritten
+	modifiers := transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ast.ModifierFlagsAsync)
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body(), tx.Visitor())
+	function := tx.Factory().NewFunctionExpression(modifiers, asteriskToken, name.Clone(tx.Factory()), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	tx.EmitContext().SetOriginal(function, node)
+	function.Loc = node.Loc
+	result.pendingExpressions = append(result.pendingExpressions, tx.Factory().NewAssignmentExpression(name.Clone(tx.Factory()), function))
 }
 
-func newClassFieldsTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &classFieldsTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+// Adds instance initializers to an existing constructor, or synthesizes a constructor to hold them.
+func (tx *classFieldsTransformer) transformConstructor(class *ast.Node, constructor *ast.Node, initializers []*ast.Expression) *ast.Node {
+	isDerivedClass := ast.GetExtendsHeritageClauseElement(class) != nil
+
+	var initializerStatements []*ast.Statement
+	for _, initializer := range initializers {
+		statement := tx.Factory().NewExpressionStatement(initializer)
+		tx.EmitContext().AddEmitFlags(statement, printer.EFStartOnNewLine)
+		initializerStatements = append(initializerStatements, statement)
+	}
+
+	if constructor == nil {
+		var statements []*ast.Statement
+		if isDerivedClass {
+			// constructor(...args) { super(...args); }
+			superCall := tx.Factory().NewCallExpression(
+				tx.Factory().NewKeywordExpression(ast.KindSuperKeyword),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				tx.Factory().NewNodeList([]*ast.Expression{tx.Factory().NewSpreadElement(tx.Factory().NewIdentifier("arguments"))}),
+				ast.NodeFlagsNone,
+			)
+			statements = append(statements, tx.Factory().NewExpressionStatement(superCall))
+		}
+		statements = append(statements, initializerStatements...)
+		body := tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+		return tx.Factory().NewConstructorDeclaration(nil /*modifiers*/, nil /*typeParameters*/, tx.Factory().NewNodeList(nil), nil /*returnType*/, nil /*fullSignature*/, body)
+	}
+
+	savedClassThis := tx.classThis
+	tx.classThis = nil
+	defer func() { tx.classThis = savedClassThis }()
+
+	n := constructor.AsConstructorDeclaration()
+	modifiers := tx.Visitor().VisitModifiers(n.Modifiers())
+	parameters := tx.EmitContext().VisitParameters(n.ParameterList(), tx.Visitor())
+	prologue, rest := tx.Factory().SplitStandardPrologue(n.Body.AsBlock().Statements.Nodes)
+	rest, _ = tx.Visitor().VisitSlice(rest)
+
+	// Field initializers run immediately after `super()` returns. Without `useDefineForClassFields`, they instead run
+	// after the assignments for any parameter properties.
+	insertionIndex := 0
+	if isDerivedClass {
+		// !!! `super()` calls nested within other statements are not yet supported
+		for i, statement := range rest {
+			if ast.IsExpressionStatement(statement) && ast.IsSuperCall(statement.Expression()) {
+				insertionIndex = i + 1
+				break
+			}
+		}
+	}
+	if !tx.useDefineForClassFields {
+		for insertionIndex < len(rest) && ast.IsParameter(tx.EmitContext().MostOriginal(rest[insertionIndex])) {
+			insertionIndex++
+		}
+	}
+
+	statements := make([]*ast.Statement, 0, len(prologue)+len(rest)+len(initializerStatements))
+	statements = append(statements, prologue...)
+	statements = append(statements, rest[:insertionIndex]...)
+	statements = append(statements, initializerStatements...)
+	statements = append(statements, rest[insertionIndex:]...)
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+	statementList := tx.Factory().NewNodeList(statements)
+	statementList.Loc = n.Body.AsBlock().Statements.Loc
+	body := tx.Factory().UpdateBlock(n.Body.AsBlock(), statementList)
+	return tx.Factory().UpdateConstructorDeclaration(n, modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *classFieldsTransformer) lookupPrivateIdentifier(name *ast.Node) *privateIdentifierInfo {
+	if !tx.shouldTransformPrivateElements || !ast.IsPrivateIdentifier(name) {
+		return nil
+	}
+	return tx.privateEnvironment.lookup(tx.getPrivateNameKey(name))
+}
+
+// Creates `__classPrivateFieldGet(receiver, state, kind, f)` to read a private member.
+func (tx *classFieldsTransformer) createPrivateIdentifierGet(info *privateIdentifierInfo, receiver *ast.Expression) *ast.Expression {
+	state := info.brandCheckIdentifier.Clone(tx.Factory())
+	switch info.kind {
+	case privateIdentifierKindMethod:
+		return tx.Factory().NewClassPrivateFieldGetHelper(receiver, state, "m", info.variableName.Clone(tx.Factory()))
+	case privateIdentifierKindAccessor:
+		return tx.Factory().NewClassPrivateFieldGetHelper(receiver, state, "a", cloneIdentifierOrNil(tx.Factory(), info.getterName))
+	default:
+		if info.isStatic {
+			return tx.Factory().NewClassPrivateFieldGetHelper(receiver, state, "f", info.variableName.Clone(tx.Factory()))
+		}
+		return tx.Factory().NewClassPrivateFieldGetHelper(receiver, state, "f", nil)
+	}
+}
+
+// Creates `__classPrivateFieldSet(receiver, state, value, kind, f)` to write a private member.
+func (tx *classFieldsTransformer) createPrivateIdentifierSet(info *privateIdentifierInfo, receiver *ast.Expression, value *ast.Expression) *ast.Expression {
+	state := info.brandCheckIdentifier.Clone(tx.Factory())
+	switch info.kind {
+	case privateIdentifierKindMethod:
+		return tx.Factory().NewClassPrivateFieldSetHelper(receiver, state, value, "m", nil)
+	case privateIdentifierKindAccessor:
+		return tx.Factory().NewClassPrivateFieldSetHelper(receiver, state, value, "a", cloneIdentifierOrNil(tx.Factory(), info.setterName))
+	default:
+		if info.isStatic {
+			return tx.Factory().NewClassPrivateFieldSetHelper(receiver, state, value, "f", info.variableName.Clone(tx.Factory()))
+		}
+		return tx.Factory().NewClassPrivateFieldSetHelper(receiver, state, value, "f", nil)
+	}
+}
+
+func cloneIdentifierOrNil(factory *printer.NodeFactory, node *ast.IdentifierNode) *ast.IdentifierNode {
+	if node == nil {
+		return nil
+	}
+	return node.Clone(factory)
+}
+
+// Visits the receiver of a private member access that is referenced more than once, returning an expression that
+// evaluates the receiver along with a copy that may be reused.
+func (tx *classFieldsTransformer) visitReusableReceiver(receiver *ast.Expression) (*ast.Expression, *ast.Expression) {
+	visited := tx.Visitor().VisitNode(receiver)
+	if transformers.IsSimpleCopiableExpression(visited) {
+		return visited, visited.Clone(tx.Factory())
+	}
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return tx.Factory().NewAssignmentExpression(temp, visited), temp.Clone(tx.Factory())
+}
+
+// Replaces `receiver.#x` with a read of the private member.
+func (tx *classFieldsTransformer) visitPropertyAccessExpression(node *ast.PropertyAccessExpression) *ast.Node {
+	// !!! private names in optional chains (`a?.#x`) are not yet supported
+	if info := tx.lookupPrivateIdentifier(node.Name()); info != nil {
+		result := tx.createPrivateIdentifierGet(info, tx.Visitor().VisitNode(node.Expression))
+		tx.EmitContext().SetOriginal(result, node.AsNode())
+		result.Loc = node.Loc
+		return result
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Replaces `receiver.#m(...)` with `__classPrivateFieldGet(receiver, ...).call(receiver, ...)`.
+func (tx *classFieldsTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if ast.IsPropertyAccessExpression(node.Expression) {
+		if info := tx.lookupPrivateIdentifier(node.Expression.Name()); info != nil {
+			receiver, thisArg := tx.visitReusableReceiver(node.Expression.Expression())
+			target := tx.createPrivateIdentifierGet(info, receiver)
+			arguments := tx.Visitor().VisitNodes(node.Arguments)
+			result := tx.Factory().NewFunctionCallCall(target, thisArg, arguments.Nodes)
+			tx.EmitContext().SetOriginal(result, node.AsNode())
+			result.Loc = node.Loc
+			return result
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Replaces assignments to private members and `#x in obj` brand checks.
+func (tx *classFieldsTransformer) visitBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	operator := node.OperatorToken.Kind
+	if operator == ast.KindInKeyword {
+		if info := tx.lookupPrivateIdentifier(node.Left); info != nil {
+			result := tx.Factory().NewClassPrivateFieldInHelper(info.brandCheckIdentifier.Clone(tx.Factory()), tx.Visitor().VisitNode(node.Right))
+			tx.EmitContext().SetOriginal(result, node.AsNode())
+			result.Loc = node.Loc
+			return result
+		}
+	}
+
+	if ast.IsAssignmentOperator(operator) && ast.IsPropertyAccessExpression(node.Left) {
+		if info := tx.lookupPrivateIdentifier(node.Left.Name()); info != nil {
+			var result *ast.Expression
+			switch {
+			case operator == ast.KindEqualsToken:
+				// receiver.#x = value
+				receiver := tx.Visitor().VisitNode(node.Left.Expression())
+				result = tx.createPrivateIdentifierSet(info, receiver, tx.Visitor().VisitNode(node.Right))
+			case ast.IsLogicalOrCoalescingAssignmentOperator(operator):
+				// receiver.#x ||= value -> get(receiver) || set(receiver, value)
+				receiver, receiverCopy := tx.visitReusableReceiver(node.Left.Expression())
+				result = tx.Factory().NewBinaryExpression(
+					nil, /*modifiers*/
+					tx.createPrivateIdentifierGet(info, receiver),
+					nil, /*typeNode*/
+					tx.Factory().NewToken(getNonAssignmentOperatorForCompoundAssignment(operator)),
+					tx.createPrivateIdentifierSet(info, receiverCopy, tx.Visitor().VisitNode(node.Right)),
+				)
+			default:
+				// receiver.#x += value -> set(receiver, get(receiver) + value)
+				receiver, receiverCopy := tx.visitReusableReceiver(node.Left.Expression())
+				value := tx.Factory().NewBinaryExpression(
+					nil, /*modifiers*/
+					tx.createPrivateIdentifierGet(info, receiverCopy),
+					nil, /*typeNode*/
+					tx.Factory().NewToken(getNonAssignmentOperatorForCompoundAssignment(operator)),
+					tx.Visitor().VisitNode(node.Right),
+				)
+				result = tx.createPrivateIdentifierSet(info, receiver, value)
+			}
+			tx.EmitContext().SetOriginal(result, node.AsNode())
+			result.Loc = node.Loc
+			return result
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *classFieldsTransformer) visitExpressionStatement(node *ast.ExpressionStatement) *ast.Node {
+	expression := ast.SkipParentheses(node.Expression)
+	if expression.Kind == ast.KindPostfixUnaryExpression {
+		return tx.Factory().UpdateExpressionStatement(node, tx.visitPreOrPostfixUnaryExpression(expression, true /*resultIsDiscarded*/))
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Replaces `++receiver.#x` and `receiver.#x++` with a read and write of the private member. When the result of a
+// postfix expression is used, the original value is captured in a temporary variable:
+//
+//	(__classPrivateFieldSet(receiver, _C_x, (_a = __classPrivateFieldGet(receiver, _C_x, "f"), _b = _a++, _a), "f"), _b)
+func (tx *classFieldsTransformer) visitPreOrPostfixUnaryExpression(node *ast.Node, resultIsDiscarded bool) *ast.Node {
+	var operator ast.Kind
+	var operand *ast.Expression
+	isPrefix := ast.IsPrefixUnaryExpression(node)
+	if isPrefix {
+		operator = node.AsPrefixUnaryExpression().Operator
+		operand = node.AsPrefixUnaryExpression().Operand
+	} else {
+		operator = node.AsPostfixUnaryExpression().Operator
+		operand = node.AsPostfixUnaryExpression().Operand
+	}
+
+	if (operator == ast.KindPlusPlusToken || operator == ast.KindMinusMinusToken) && ast.IsPropertyAccessExpression(operand) {
+		if info := tx.lookupPrivateIdentifier(operand.Name()); info != nil {
+			receiver, receiverCopy := tx.visitReusableReceiver(operand.Expression())
+			value := tx.Factory().NewTempVariable()
+			tx.EmitContext().AddVariableDeclaration(value)
+			expressions := []*ast.Expression{tx.Factory().NewAssignmentExpression(value, tx.createPrivateIdentifierGet(info, receiverCopy))}
+
+			var result *ast.Expression
+			if isPrefix || resultIsDiscarded {
+				expressions = append(expressions, tx.Factory().NewPrefixUnaryExpression(operator, value.Clone(tx.Factory())))
+				result = tx.createPrivateIdentifierSet(info, receiver, tx.Factory().NewParenthesizedExpression(tx.Factory().InlineExpressions(expressions)))
+			} else {
+				original := tx.Factory().NewTempVariable()
+				tx.EmitContext().AddVariableDeclaration(original)
+				expressions = append(expressions,
+					tx.Factory().NewAssignmentExpression(original, tx.Factory().NewPostfixUnaryExpression(value.Clone(tx.Factory()), operator)),
+					value.Clone(tx.Factory()),
+				)
+				result = tx.Factory().NewParenthesizedExpression(tx.Factory().InlineExpressions([]*ast.Expression{
+					tx.createPrivateIdentifierSet(info, receiver, tx.Factory().NewParenthesizedExpression(tx.Factory().InlineExpressions(expressions))),
+					original.Clone(tx.Factory()),
+				}))
+			}
+			tx.EmitContext().SetOriginal(result, node)
+			result.Loc = node.Loc
+			return result
+		}
+	}
+	return tx.Visitor().VisitEachChild(node)
 }
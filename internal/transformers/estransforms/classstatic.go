@@ -2,7 +2,6 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
-	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
@@ -11,10 +10,68 @@ type classStaticBlockTransformer struct {
 }
 
 func (ch *classStaticBlockTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	if node.SubtreeFacts()&ast.SubtreeContainsClassFields == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindClassStaticBlockDeclaration:
+		return ch.visitClassStaticBlockDeclaration(node.AsClassStaticBlockDeclaration())
+	default:
+		return ch.Visitor().VisitEachChild(node)
+	}
 }
 
-func newClassStaticBlockTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+// Replaces a class static block with a static property whose initializer evaluates the block in an immediately
+// invoked arrow function. For example:
+//
+//	class C {
+//	  static {
+//	    foo();
+//	  }
+//	}
+//
+// produces:
+//
+//	class C {
+//	  static _ = (() => {
+//	    foo();
+//	  })();
+//	}
+//
+// The property retains the static block as its original node, which the class fields transform uses to emit only the
+// initializer in the same order as the other static initializers of the class.
+func (ch *classStaticBlockTransformer) visitClassStaticBlockDeclaration(node *ast.ClassStaticBlockDeclaration) *ast.Node {
+	ch.EmitContext().StartVariableEnvironment()
+	body := ch.EmitContext().VisitFunctionBody(node.Body, ch.Visitor())
+	arrow := ch.Factory().NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		ch.Factory().NewNodeList(nil),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		ch.Factory().NewToken(ast.KindEqualsGreaterThanToken),
+		body,
+	)
+	iife := ch.Factory().NewCallExpression(
+		ch.Factory().NewParenthesizedExpression(arrow),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		ch.Factory().NewNodeList(nil),
+		ast.NodeFlagsNone,
+	)
+	property := ch.Factory().NewPropertyDeclaration(
+		ch.Factory().NewModifierList([]*ast.Node{ch.Factory().NewModifier(ast.KindStaticKeyword)}),
+		ch.Factory().NewUniqueName("_"),
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		iife,
+	)
+	ch.EmitContext().SetOriginal(property, node.AsNode())
+	property.Loc = node.Loc
+	return property
+}
+
+func newClassStaticBlockTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
 	tx := &classStaticBlockTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	return tx.NewTransformer(tx.visit, opt.Context)
 }
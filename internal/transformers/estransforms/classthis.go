@@ -26,3 +26,94 @@ func isClassThisAssignmentBlock(emitContext *printer.EmitContext, node *ast.Node
 	}
 	return false
 }
+
+// Creates a class `static {}` block used to assign the static `this` to a `_classThis` (or similar) variable.
+//
+// The thisExpression parameter overrides the expression to use for the actual `this` reference. This can be used to
+// provide an expression that has already had its `EmitFlags` set or may have been tracked to prevent substitution.
+func createClassThisAssignmentBlock(emitContext *printer.EmitContext, classThis *ast.IdentifierNode, thisExpression *ast.Expression) *ast.Node {
+	// produces:
+	//
+	//  static { _classThis = this; }
+	//
+
+	factory := emitContext.Factory
+	if thisExpression == nil {
+		thisExpression = factory.NewThisExpression()
+	}
+	expression := factory.NewAssignmentExpression(classThis, thisExpression)
+	statement := factory.NewExpressionStatement(expression)
+	body := factory.NewBlock(factory.NewNodeList([]*ast.Statement{statement}), false /*multiLine*/)
+	block := factory.NewClassStaticBlockDeclaration(nil /*modifiers*/, body)
+
+	// We use `emitNode.classThis` to indicate this is a `_classThis` assignment helper block
+	// and to stash the variable used for `_classThis`.
+	emitContext.SetClassThis(block, classThis)
+	return block
+}
+
+// Gets whether a class has a `static {}` block containing only a single assignment of the static `this` to the
+// `_classThis` (or similar) variable stored in the `classthis` property of the class's `EmitNode`.
+func classHasClassThisAssignment(emitContext *printer.EmitContext, node *ast.ClassLikeDeclaration) bool {
+	if emitContext.ClassThis(node) == nil {
+		return false
+	}
+	for _, member := range node.Members() {
+		if isClassThisAssignmentBlock(emitContext, member) {
+			return true
+		}
+	}
+	return false
+}
+
+// Injects a class `static {}` block used to assign the static `this` to a `_classThis` (or similar) variable, if one
+// does not already exist.
+func injectClassThisAssignmentIfMissing(emitContext *printer.EmitContext, node *ast.ClassLikeDeclaration, classThis *ast.IdentifierNode, thisExpression *ast.Expression) *ast.ClassLikeDeclaration {
+	// given:
+	//
+	//  class C {
+	//  }
+	//
+	// produces:
+	//
+	//  class C {
+	//      static { _classThis = this; }
+	//  }
+
+	if classHasClassThisAssignment(emitContext, node) {
+		return node
+	}
+
+	factory := emitContext.Factory
+	staticBlock := createClassThisAssignmentBlock(emitContext, classThis, thisExpression)
+	if node.Name() != nil {
+		emitContext.SetSourceMapRange(staticBlock.Body().AsBlock().Statements.Nodes[0], node.Name().Loc)
+	}
+
+	members := append([]*ast.ClassElement{staticBlock}, node.Members()...)
+	membersList := factory.NewNodeList(members)
+	membersList.Loc = node.MemberList().Loc
+
+	if ast.IsClassDeclaration(node) {
+		node = factory.UpdateClassDeclaration(
+			node.AsClassDeclaration(),
+			node.Modifiers(),
+			node.Name(),
+			node.TypeParameterList(),
+			node.AsClassDeclaration().HeritageClauses,
+			membersList,
+		)
+	} else {
+		node = factory.UpdateClassExpression(
+			node.AsClassExpression(),
+			node.Modifiers(),
+			node.Name(),
+			node.TypeParameterList(),
+			node.AsClassExpression().HeritageClauses,
+			membersList,
+		)
+	}
+
+	emitContext.SetClassThis(node, classThis)
+	return node
+}
@@ -2,12 +2,13 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/core"
-	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
-// !!! TODO: This fixed layering scheme assumes you can't swap out the es decorator transform for the legacy one,
-// or the proper es class field transform for the legacy one
+// Each target layers the transforms for the syntax it lacks over those of the next newer target. Transforms whose
+// behavior depends on compiler options, rather than on the target, check those options themselves. For example, the
+// ES decorator transform leaves files alone under `experimentalDecorators`, since legacy decorators are lowered
+// earlier by the TypeScript transforms.
 var (
 	NewESNextTransformer = transformers.Chain(newESDecoratorTransformer, newUsingDeclarationTransformer)
 	// 2025: only module system syntax (import attributes, json modules), untransformed regex modifiers
@@ -22,25 +23,40 @@ var (
 	NewES2016Transformer = transformers.Chain(NewES2017Transformer, newExponentiationTransformer)
 )
 
-func GetESTransformer(options *core.CompilerOptions, emitContext *printer.EmitContext) *transformers.Transformer {
+// Class fields must still be moved into the constructor when `useDefineForClassFields` is disabled, even when
+// targeting a runtime that supports them natively.
+var newESNextClassFieldsTransformer = transformers.Chain(NewESNextTransformer, newClassFieldsTransformer)
+
+// Decorators must be lowered before class fields are moved into the constructor, since the decorator transform
+// rewrites field initializers to run the decorator initializers.
+var newESNextDecoratedClassFieldsTransformer = transformers.Chain(newESDecoratorTransformer, newClassFieldsTransformer)
+
+func GetESTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
+	options := opt.CompilerOptions
 	switch options.GetEmitScriptTarget() {
 	case core.ScriptTargetESNext:
+		if !options.GetEmitStandardClassFields() {
+			return newESNextDecoratedClassFieldsTransformer(opt)
+		}
 		return nil // no transforms needed
 	case /*core.ScriptTargetES2025,*/ core.ScriptTargetES2024, core.ScriptTargetES2023, core.ScriptTargetES2022:
-		return NewESNextTransformer(emitContext)
+		if !options.GetEmitStandardClassFields() {
+			return newESNextClassFieldsTransformer(opt)
+		}
+		return NewESNextTransformer(opt)
 	case core.ScriptTargetES2021:
-		return NewES2022Transformer(emitContext)
+		return NewES2022Transformer(opt)
 	case core.ScriptTargetES2020:
-		return NewES2021Transformer(emitContext)
+		return NewES2021Transformer(opt)
 	case core.ScriptTargetES2019:
-		return NewES2020Transformer(emitContext)
+		return NewES2020Transformer(opt)
 	case core.ScriptTargetES2018:
-		return NewES2019Transformer(emitContext)
+		return NewES2019Transformer(opt)
 	case core.ScriptTargetES2017:
-		return NewES2018Transformer(emitContext)
+		return NewES2018Transformer(opt)
 	case core.ScriptTargetES2016:
-		return NewES2017Transformer(emitContext)
+		return NewES2017Transformer(opt)
 	default: // other, older, option, transform maximally
-		return NewES2016Transformer(emitContext)
+		return NewES2016Transformer(opt)
 	}
 }
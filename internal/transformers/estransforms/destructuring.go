@@ -0,0 +1,423 @@
+package estransforms
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
+)
+
+// Flattens a destructuring pattern that contains an object rest element into a series of simpler bindings or
+// assignments. Elements that do not depend on an object rest are kept in (smaller) destructuring patterns, so that
+// the result only uses destructuring syntax supported by ES2015.
+type objectRestFlattener struct {
+	emitContext  *printer.EmitContext
+	factory      *printer.NodeFactory
+	visitor      *ast.NodeVisitor
+	isBinding    bool              // whether we are flattening a binding pattern rather than an assignment pattern
+	declarations []*ast.Node       // the variable declarations produced when flattening a binding pattern
+	expressions  []*ast.Expression // the assignments produced when flattening an assignment pattern
+}
+
+// Flattens the binding pattern of a variable or parameter declaration into a list of variable declarations. For
+// example:
+//
+//	const { a, [k]: b, ...rest } = obj;
+//
+// produces:
+//
+//	const _a = k, { a } = obj, b = obj[_a], rest = __rest(obj, ["a", typeof _a === "symbol" ? _a : _a + ""]);
+//
+// When `value` is provided, it is used in place of the initializer of the declaration.
+func flattenObjectRestBinding(emitContext *printer.EmitContext, visitor *ast.NodeVisitor, node *ast.Node, value *ast.Expression, skipInitializer bool) []*ast.Node {
+	fl := &objectRestFlattener{
+		emitContext: emitContext,
+		factory:     emitContext.Factory,
+		visitor:     visitor,
+		isBinding:   true,
+	}
+	if ast.IsVariableDeclaration(node) && value == nil && !skipInitializer {
+		// If the initializer is an identifier bound by the pattern itself, capture its value before any binding in
+		// the pattern can overwrite it.
+		if initializer := node.Initializer(); initializer != nil && ast.IsIdentifier(initializer) && fl.elementAssignsToName(node, initializer.Text()) {
+			value = fl.ensureIdentifier(fl.visitor.VisitNode(initializer), false /*reuseIdentifierExpressions*/, initializer)
+			skipInitializer = true
+		}
+	}
+	fl.flattenElement(node, value, node, skipInitializer)
+	return fl.declarations
+}
+
+// Flattens a destructuring assignment into a comma-delimited list of assignments. For example:
+//
+//	({ a, ...rest } = obj);
+//
+// produces:
+//
+//	({ a } = obj, rest = __rest(obj, ["a"]));
+//
+// When `needsValue` is true, the result evaluates to the right-hand side of the assignment.
+func flattenObjectRestAssignment(emitContext *printer.EmitContext, visitor *ast.NodeVisitor, node *ast.BinaryExpression, needsValue bool) *ast.Expression {
+	fl := &objectRestFlattener{
+		emitContext: emitContext,
+		factory:     emitContext.Factory,
+		visitor:     visitor,
+	}
+	value := fl.visitor.VisitNode(node.Right)
+	if ast.IsIdentifier(value) && fl.elementAssignsToName(node.AsNode(), value.Text()) {
+		value = fl.ensureIdentifier(value, false /*reuseIdentifierExpressions*/, node.AsNode())
+	} else if needsValue {
+		value = fl.ensureIdentifier(value, true /*reuseIdentifierExpressions*/, node.AsNode())
+	}
+	fl.flattenElement(node.AsNode(), value, node.AsNode(), true /*skipInitializer*/)
+	if needsValue {
+		fl.expressions = append(fl.expressions, fl.reuse(value))
+	}
+	return fl.factory.InlineExpressions(fl.expressions)
+}
+
+func (fl *objectRestFlattener) flattenElement(element *ast.Node, value *ast.Expression, location *ast.Node, skipInitializer bool) {
+	target := fl.getTarget(element)
+	if !skipInitializer {
+		if initializer := fl.visitor.VisitNode(fl.getInitializer(element)); initializer != nil {
+			if value != nil {
+				value = fl.createDefaultValueCheck(value, initializer, location)
+			} else {
+				value = initializer
+			}
+		} else if value == nil {
+			value = fl.factory.NewVoidZeroExpression()
+		}
+	}
+	switch {
+	case ast.IsObjectBindingPattern(target) || ast.IsObjectLiteralExpression(target):
+		fl.flattenObjectPattern(element, target, value, location)
+	case ast.IsArrayBindingPattern(target) || ast.IsArrayLiteralExpression(target):
+		fl.flattenArrayPattern(element, target, value, location)
+	default:
+		if !fl.isBinding {
+			target = fl.visitor.VisitNode(target)
+		}
+		fl.emit(target, value, location, element)
+	}
+}
+
+func (fl *objectRestFlattener) flattenObjectPattern(parent *ast.Node, pattern *ast.Node, value *ast.Expression, location *ast.Node) {
+	elements := fl.getElements(pattern)
+	if len(elements) != 1 {
+		// For anything other than a single-element destructuring we need to generate a temporary to ensure the value
+		// is only evaluated once.
+		reuseIdentifierExpressions := !isDeclarationBindingElement(parent) || len(elements) != 0
+		value = fl.ensureIdentifier(value, reuseIdentifierExpressions, location)
+	}
+
+	var pendingElements []*ast.Node
+	var propertyNames []*ast.Node
+	var computedTempVariables []*ast.Node
+	for i, element := range elements {
+		if !fl.isRestElement(element) {
+			propertyName := fl.getPropertyName(element)
+			propertyNames = append(propertyNames, propertyName)
+			if element.SubtreeFacts()&ast.SubtreeContainsESObjectRestOrSpread == 0 && !ast.IsComputedPropertyName(propertyName) {
+				pendingElements = append(pendingElements, fl.visitor.VisitNode(element))
+				continue
+			}
+			if len(pendingElements) > 0 {
+				fl.emitObjectPattern(pendingElements, fl.reuse(value), location, pattern)
+				pendingElements = nil
+			}
+			access := fl.createPropertyAccess(fl.reuse(value), propertyName)
+			if ast.IsComputedPropertyName(propertyName) {
+				computedTempVariables = append(computedTempVariables, access.AsElementAccessExpression().ArgumentExpression.Clone(fl.factory))
+			}
+			fl.flattenElement(element, access, element, false /*skipInitializer*/)
+		} else if i == len(elements)-1 {
+			if len(pendingElements) > 0 {
+				fl.emitObjectPattern(pendingElements, fl.reuse(value), location, pattern)
+				pendingElements = nil
+			}
+			rest := fl.factory.NewRestHelper(fl.reuse(value), propertyNames, computedTempVariables, pattern.Loc)
+			fl.flattenElement(element, rest, element, false /*skipInitializer*/)
+		}
+	}
+	if len(pendingElements) > 0 {
+		fl.emitObjectPattern(pendingElements, fl.reuse(value), location, pattern)
+	}
+}
+
+func (fl *objectRestFlattener) flattenArrayPattern(parent *ast.Node, pattern *ast.Node, value *ast.Expression, location *ast.Node) {
+	elements := fl.getElements(pattern)
+	if len(elements) == 0 || core.Every(elements, ast.IsOmittedExpression) {
+		reuseIdentifierExpressions := !isDeclarationBindingElement(parent) || len(elements) != 0
+		value = fl.ensureIdentifier(value, reuseIdentifierExpressions, location)
+	}
+
+	// Elements containing an object rest are bound to a temporary and flattened after the array pattern. Any
+	// later element with observable side effects is also deferred to preserve the order of evaluation.
+	type deferredElement struct {
+		temp    *ast.IdentifierNode
+		element *ast.Node
+	}
+	var pendingElements []*ast.Node
+	var deferredElements []deferredElement
+	for _, element := range elements {
+		if element.SubtreeFacts()&ast.SubtreeContainsESObjectRestOrSpread != 0 || len(deferredElements) > 0 && !fl.isSimpleElement(element) {
+			temp := fl.factory.NewTempVariable()
+			if !fl.isBinding {
+				fl.emitContext.AddVariableDeclaration(temp)
+			}
+			deferredElements = append(deferredElements, deferredElement{temp, element})
+			pendingElements = append(pendingElements, fl.createArrayElement(temp.Clone(fl.factory), fl.isRestElement(element)))
+		} else {
+			pendingElements = append(pendingElements, fl.visitor.VisitNode(element))
+		}
+	}
+	fl.emitArrayPattern(pendingElements, value, location, pattern)
+	for _, deferred := range deferredElements {
+		fl.flattenElement(deferred.element, deferred.temp.Clone(fl.factory), deferred.element, false /*skipInitializer*/)
+	}
+}
+
+// Creates an expression that evaluates to `initializer` when `value` is `undefined`, and to `value` otherwise.
+func (fl *objectRestFlattener) createDefaultValueCheck(value *ast.Expression, initializer *ast.Expression, location *ast.Node) *ast.Expression {
+	value = fl.ensureIdentifier(value, true /*reuseIdentifierExpressions*/, location)
+	return fl.factory.NewConditionalExpression(
+		fl.factory.NewStrictEqualityExpression(value, fl.factory.NewVoidZeroExpression()),
+		fl.factory.NewToken(ast.KindQuestionToken),
+		initializer,
+		fl.factory.NewToken(ast.KindColonToken),
+		value.Clone(fl.factory),
+	)
+}
+
+// Creates an access to the property of `value` named by `propertyName`. The expression of a computed property name
+// is captured in a temporary, so that it can also be excluded from the object rest.
+func (fl *objectRestFlattener) createPropertyAccess(value *ast.Expression, propertyName *ast.Node) *ast.Expression {
+	switch propertyName.Kind {
+	case ast.KindComputedPropertyName:
+		argument := fl.ensureIdentifier(fl.visitor.VisitNode(propertyName.Expression()), false /*reuseIdentifierExpressions*/, propertyName)
+		return fl.factory.NewElementAccessExpression(value, nil /*questionDotToken*/, argument, ast.NodeFlagsNone)
+	case ast.KindStringLiteral, ast.KindNumericLiteral, ast.KindBigIntLiteral:
+		return fl.factory.NewElementAccessExpression(value, nil /*questionDotToken*/, propertyName.Clone(fl.factory), ast.NodeFlagsNone)
+	default:
+		return fl.factory.NewPropertyAccessExpression(value, nil /*questionDotToken*/, fl.factory.NewIdentifier(propertyName.Text()), ast.NodeFlagsNone)
+	}
+}
+
+// Ensures that `value` is an identifier that can be referenced more than once, capturing it in a temporary if
+// necessary.
+func (fl *objectRestFlattener) ensureIdentifier(value *ast.Expression, reuseIdentifierExpressions bool, location *ast.Node) *ast.Expression {
+	if ast.IsIdentifier(value) && reuseIdentifierExpressions {
+		return value
+	}
+	temp := fl.factory.NewTempVariable()
+	if fl.isBinding {
+		fl.emit(temp, value, location, nil /*original*/)
+	} else {
+		fl.emitContext.AddVariableDeclaration(temp)
+		assignment := fl.factory.NewAssignmentExpression(temp, value)
+		assignment.Loc = location.Loc
+		fl.expressions = append(fl.expressions, assignment)
+	}
+	return temp.Clone(fl.factory)
+}
+
+func (fl *objectRestFlattener) reuse(value *ast.Expression) *ast.Expression {
+	if ast.IsIdentifier(value) {
+		return value.Clone(fl.factory)
+	}
+	return value
+}
+
+func (fl *objectRestFlattener) emit(target *ast.Node, value *ast.Expression, location *ast.Node, original *ast.Node) {
+	var node *ast.Node
+	if fl.isBinding {
+		node = fl.factory.NewVariableDeclaration(target, nil /*exclamationToken*/, nil /*typeNode*/, value)
+		fl.declarations = append(fl.declarations, node)
+	} else {
+		node = fl.factory.NewAssignmentExpression(target, value)
+		fl.expressions = append(fl.expressions, node)
+	}
+	node.Loc = location.Loc
+	if original != nil {
+		fl.emitContext.SetOriginal(node, original)
+	}
+}
+
+func (fl *objectRestFlattener) emitObjectPattern(elements []*ast.Node, value *ast.Expression, location *ast.Node, original *ast.Node) {
+	var pattern *ast.Node
+	if fl.isBinding {
+		pattern = fl.factory.NewBindingPattern(ast.KindObjectBindingPattern, fl.factory.NewNodeList(elements))
+	} else {
+		pattern = fl.factory.NewObjectLiteralExpression(fl.factory.NewNodeList(elements), false /*multiLine*/)
+	}
+	fl.emit(pattern, value, location, original)
+}
+
+func (fl *objectRestFlattener) emitArrayPattern(elements []*ast.Node, value *ast.Expression, location *ast.Node, original *ast.Node) {
+	var pattern *ast.Node
+	if fl.isBinding {
+		pattern = fl.factory.NewBindingPattern(ast.KindArrayBindingPattern, fl.factory.NewNodeList(elements))
+	} else {
+		pattern = fl.factory.NewArrayLiteralExpression(fl.factory.NewNodeList(elements), false /*multiLine*/)
+	}
+	fl.emit(pattern, value, location, original)
+}
+
+func (fl *objectRestFlattener) createArrayElement(name *ast.IdentifierNode, isRest bool) *ast.Node {
+	if fl.isBinding {
+		var dotDotDotToken *ast.TokenNode
+		if isRest {
+			dotDotDotToken = fl.factory.NewToken(ast.KindDotDotDotToken)
+		}
+		return fl.factory.NewBindingElement(dotDotDotToken, nil /*propertyName*/, name, nil /*initializer*/)
+	}
+	if isRest {
+		return fl.factory.NewSpreadElement(name)
+	}
+	return name
+}
+
+// Gets the name or expression that is bound or assigned by a destructuring element.
+func (fl *objectRestFlattener) getTarget(element *ast.Node) *ast.Node {
+	switch element.Kind {
+	case ast.KindVariableDeclaration, ast.KindParameter, ast.KindBindingElement, ast.KindShorthandPropertyAssignment:
+		return element.Name()
+	case ast.KindPropertyAssignment:
+		return fl.getTarget(element.Initializer())
+	case ast.KindSpreadAssignment, ast.KindSpreadElement:
+		return fl.getTarget(element.Expression())
+	case ast.KindBinaryExpression:
+		if ast.IsAssignmentExpression(element, true /*excludeCompoundAssignment*/) {
+			return fl.getTarget(element.AsBinaryExpression().Left)
+		}
+	}
+	return element
+}
+
+// Gets the default value of a destructuring element, if any.
+func (fl *objectRestFlattener) getInitializer(element *ast.Node) *ast.Expression {
+	switch element.Kind {
+	case ast.KindVariableDeclaration, ast.KindParameter, ast.KindBindingElement:
+		return element.Initializer()
+	case ast.KindPropertyAssignment:
+		return fl.getInitializer(element.Initializer())
+	case ast.KindShorthandPropertyAssignment:
+		return element.AsShorthandPropertyAssignment().ObjectAssignmentInitializer
+	case ast.KindBinaryExpression:
+		if ast.IsAssignmentExpression(element, true /*excludeCompoundAssignment*/) {
+			return element.AsBinaryExpression().Right
+		}
+	}
+	return nil
+}
+
+// Gets the property name of an element of an object destructuring pattern.
+func (fl *objectRestFlattener) getPropertyName(element *ast.Node) *ast.Node {
+	switch element.Kind {
+	case ast.KindBindingElement:
+		if propertyName := element.PropertyName(); propertyName != nil {
+			return propertyName
+		}
+		return element.Name()
+	case ast.KindPropertyAssignment, ast.KindShorthandPropertyAssignment:
+		return element.Name()
+	}
+	return nil
+}
+
+func (fl *objectRestFlattener) getElements(pattern *ast.Node) []*ast.Node {
+	switch pattern.Kind {
+	case ast.KindObjectBindingPattern, ast.KindArrayBindingPattern:
+		return pattern.AsBindingPattern().Elements.Nodes
+	case ast.KindObjectLiteralExpression:
+		return pattern.AsObjectLiteralExpression().Properties.Nodes
+	default:
+		return pattern.AsArrayLiteralExpression().Elements.Nodes
+	}
+}
+
+func (fl *objectRestFlattener) isRestElement(element *ast.Node) bool {
+	switch element.Kind {
+	case ast.KindBindingElement:
+		return element.AsBindingElement().DotDotDotToken != nil
+	case ast.KindSpreadAssignment, ast.KindSpreadElement:
+		return true
+	}
+	return false
+}
+
+// Determines whether evaluating a destructuring element has no observable side effects other than the binding or
+// assignment itself.
+func (fl *objectRestFlattener) isSimpleElement(element *ast.Node) bool {
+	target := fl.getTarget(element)
+	if target == nil || ast.IsOmittedExpression(target) {
+		return true
+	}
+	if initializer := fl.getInitializer(element); initializer != nil && !transformers.IsSimpleCopiableExpression(initializer) {
+		return false
+	}
+	return ast.IsIdentifier(target)
+}
+
+// Determines whether a destructuring element binds or assigns to the provided name.
+func (fl *objectRestFlattener) elementAssignsToName(element *ast.Node, name string) bool {
+	target := fl.getTarget(element)
+	switch {
+	case target == nil:
+		return false
+	case ast.IsIdentifier(target):
+		return target.Text() == name
+	case ast.IsBindingPattern(target) || ast.IsObjectLiteralExpression(target) || ast.IsArrayLiteralExpression(target):
+		for _, child := range fl.getElements(target) {
+			if fl.elementAssignsToName(child, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isDeclarationBindingElement(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindVariableDeclaration, ast.KindParameter, ast.KindBindingElement:
+		return true
+	}
+	return false
+}
+
+// Determines whether a destructuring target contains an object rest element.
+func containsObjectRestElement(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindObjectBindingPattern, ast.KindArrayBindingPattern:
+		return node.SubtreeFacts()&ast.SubtreeContainsESObjectRestOrSpread != 0
+	case ast.KindObjectLiteralExpression:
+		for _, property := range node.AsObjectLiteralExpression().Properties.Nodes {
+			switch property.Kind {
+			case ast.KindSpreadAssignment:
+				return true
+			case ast.KindPropertyAssignment:
+				if containsObjectRestElement(skipDefaultValue(property.Initializer())) {
+					return true
+				}
+			}
+		}
+	case ast.KindArrayLiteralExpression:
+		for _, element := range node.AsArrayLiteralExpression().Elements.Nodes {
+			if ast.IsSpreadElement(element) {
+				element = element.Expression()
+			}
+			if containsObjectRestElement(skipDefaultValue(element)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func skipDefaultValue(node *ast.Node) *ast.Node {
+	if ast.IsAssignmentExpression(node, true /*excludeCompoundAssignment*/) {
+		return node.AsBinaryExpression().Left
+	}
+	return node
+}
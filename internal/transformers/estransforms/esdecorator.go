@@ -1,20 +1,1440 @@
 package estransforms
 
 import (
+	"slices"
+
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
+// Lowers TC39 decorators into calls to the `__esDecorate` and `__runInitializers` helpers. For example:
+//
+//	@dec class C {
+//	    @dec m() {}
+//	    @dec x = 1;
+//	}
+//
+// produces:
+//
+//	let C = (() => {
+//	    let _classDecorators = [dec];
+//	    let _classDescriptor;
+//	    let _classExtraInitializers = [];
+//	    let _classThis;
+//	    let _instanceExtraInitializers = [];
+//	    let _m_decorators;
+//	    let _x_decorators;
+//	    let _x_initializers = [];
+//	    let _x_extraInitializers = [];
+//	    var C = class {
+//	        static { _classThis = this; }
+//	        static {
+//	            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+//	            _m_decorators = [dec];
+//	            _x_decorators = [dec];
+//	            __esDecorate(this, null, _m_decorators, { kind: "method", name: "m", ... }, null, _instanceExtraInitializers);
+//	            __esDecorate(null, null, _x_decorators, { kind: "field", name: "x", ... }, _x_initializers, _x_extraInitializers);
+//	            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", ... }, null, _classExtraInitializers);
+//	            C = _classThis = _classDescriptor.value;
+//	            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { ... });
+//	            __runInitializers(_classThis, _classExtraInitializers);
+//	        }
+//	        m() { }
+//	        x = (__runInitializers(this, _instanceExtraInitializers), __runInitializers(this, _x_initializers, 1));
+//	        constructor() {
+//	            __runInitializers(this, _x_extraInitializers);
+//	        }
+//	    };
+//	    return C = _classThis;
+//	})();
 type esDecoratorTransformer struct {
 	transformers.Transformer
+	compilerOptions *core.CompilerOptions
+
+	classInfo          *esDecoratorClassInfo // the decorated class whose members are being visited, if any
+	pendingExpressions []*ast.Expression     // member decorator evaluations not yet added to the class body
+}
+
+// Tracks the helper variables and statements needed to decorate a class and its members.
+type esDecoratorClassInfo struct {
+	class *ast.ClassLikeDeclaration
+
+	classDecoratorsName        *ast.IdentifierNode // `_classDecorators`, the evaluated class decorators
+	classDescriptorName        *ast.IdentifierNode // `_classDescriptor`, holds the class that may be replaced by a decorator
+	classExtraInitializersName *ast.IdentifierNode // `_classExtraInitializers`, added via `context.addInitializer`
+	classThis                  *ast.IdentifierNode // `_classThis`, the (possibly replaced) class constructor
+	classSuper                 *ast.IdentifierNode // `_classSuper`, the evaluated `extends` expression
+	metadataReference          *ast.IdentifierNode // `_metadata`, the `Symbol.metadata` object for the class
+
+	memberInfos []*esDecoratorMemberInfo
+
+	instanceMethodExtraInitializersName *ast.IdentifierNode // `_instanceExtraInitializers`
+	staticMethodExtraInitializersName   *ast.IdentifierNode // `_staticExtraInitializers`
+
+	staticNonFieldDecorationStatements    []*ast.Statement
+	nonStaticNonFieldDecorationStatements []*ast.Statement
+	staticFieldDecorationStatements       []*ast.Statement
+	nonStaticFieldDecorationStatements    []*ast.Statement
+
+	hasStaticInitializers       bool
+	pendingStaticInitializers   []*ast.Expression
+	pendingInstanceInitializers []*ast.Expression
+}
+
+// Tracks the helper variables for a single decorated class element.
+type esDecoratorMemberInfo struct {
+	member                *ast.Node
+	decoratorsName        *ast.IdentifierNode // `_x_decorators`
+	initializersName      *ast.IdentifierNode // `_x_initializers`, for fields and auto-accessors
+	extraInitializersName *ast.IdentifierNode // `_x_extraInitializers`, for fields and auto-accessors
+	descriptorName        *ast.IdentifierNode // `_private_x_descriptor`, for private methods and accessors
+}
+
+// The parts of a class element that have been transformed by partialTransformClassElement.
+type esDecoratorClassElementParts struct {
+	modifiers             *ast.ModifierList
+	name                  *ast.PropertyName
+	initializersName      *ast.IdentifierNode
+	extraInitializersName *ast.IdentifierNode
+	descriptorName        *ast.IdentifierNode
+	thisArg               *ast.IdentifierNode
+}
+
+func newESDecoratorTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
+	tx := &esDecoratorTransformer{compilerOptions: opt.CompilerOptions}
+	return tx.NewTransformer(tx.visit, opt.Context)
+}
+
+func (tx *esDecoratorTransformer) visit(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsDecorators == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindVariableDeclaration,
+		ast.KindPropertyAssignment,
+		ast.KindShorthandPropertyAssignment,
+		ast.KindParameter,
+		ast.KindBindingElement,
+		ast.KindBinaryExpression,
+		ast.KindExportAssignment:
+		return tx.visitNamedEvaluationSource(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *esDecoratorTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	// Legacy decorators are lowered by the TypeScript transforms instead.
+	if node.IsDeclarationFile || tx.compilerOptions.ExperimentalDecorators.IsTrue() {
+		return node.AsNode()
+	}
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Gives an anonymous decorated class an assigned name before it is transformed, since the class is moved into an
+// immediately invoked function where it would otherwise lose the name it would receive from its container.
+func (tx *esDecoratorTransformer) visitNamedEvaluationSource(node *ast.Node) *ast.Node {
+	if isNamedEvaluationAnd(tx.EmitContext(), node, tx.isAnonymousClassNeedingAssignedName) {
+		node = transformNamedEvaluation(tx.EmitContext(), node, canIgnoreEmptyStringLiteralInAssignedName(getNamedEvaluationExpression(node)), "" /*assignedName*/)
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *esDecoratorTransformer) isAnonymousClassNeedingAssignedName(node *ast.Node) bool {
+	return ast.IsClassExpression(node) && node.Name() == nil && isDecoratedClassLike(node)
+}
+
+func getNamedEvaluationExpression(node *ast.Node) *ast.Expression {
+	switch node.Kind {
+	case ast.KindShorthandPropertyAssignment:
+		return node.AsShorthandPropertyAssignment().ObjectAssignmentInitializer
+	case ast.KindBinaryExpression:
+		return node.AsBinaryExpression().Right
+	case ast.KindExportAssignment:
+		return node.AsExportAssignment().Expression
+	default:
+		return node.Initializer()
+	}
+}
+
+// An anonymous class that isn't itself decorated doesn't need to be given an empty assigned name.
+func canIgnoreEmptyStringLiteralInAssignedName(node *ast.Expression) bool {
+	innerExpression := ast.SkipOuterExpressions(node, ast.OEKAll)
+	return ast.IsClassExpression(innerExpression) && innerExpression.Name() == nil && !ast.HasDecorators(innerExpression)
+}
+
+// Gets whether a class or any of its members have decorators.
+func isDecoratedClassLike(node *ast.ClassLikeDeclaration) bool {
+	return ast.HasDecorators(node) || core.Some(node.Members(), ast.HasDecorators)
+}
+
+func (tx *esDecoratorTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !isDecoratedClassLike(node.AsNode()) {
+		return tx.visitUndecoratedClass(node.AsNode())
+	}
+
+	var statements []*ast.Statement
+	isExport := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport)
+	isDefault := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsDefault)
+	class := node.AsNode()
+	if class.Name() == nil {
+		class = injectClassNamedEvaluationHelperBlockIfMissing(tx.EmitContext(), class, tx.Factory().NewStringLiteral("default"), nil /*thisExpression*/)
+	}
+
+	if isExport && isDefault {
+		iife := tx.transformClassLike(class)
+		if class.Name() != nil {
+			// let C = (() => { ... })();
+			// export default C;
+			declaration := tx.Factory().NewVariableDeclaration(tx.Factory().GetLocalName(class), nil /*exclamationToken*/, nil /*typeNode*/, iife)
+			tx.EmitContext().SetOriginal(declaration, node.AsNode())
+			statement := tx.Factory().NewVariableStatement(nil /*modifiers*/, tx.Factory().NewVariableDeclarationList(ast.NodeFlagsLet, tx.Factory().NewNodeList([]*ast.Node{declaration})))
+			statements = append(statements, statement)
+			exportStatement := tx.Factory().NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, tx.Factory().GetDeclarationName(class))
+			tx.EmitContext().SetOriginal(exportStatement, node.AsNode())
+			tx.EmitContext().SetCommentRange(exportStatement, tx.EmitContext().CommentRange(node.AsNode()))
+			tx.EmitContext().SetSourceMapRange(exportStatement, moveRangePastDecorators(node.AsNode()))
+			statements = append(statements, exportStatement)
+		} else {
+			// export default (() => { ... })();
+			exportStatement := tx.Factory().NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, iife)
+			tx.EmitContext().SetOriginal(exportStatement, node.AsNode())
+			tx.EmitContext().SetCommentRange(exportStatement, tx.EmitContext().CommentRange(node.AsNode()))
+			tx.EmitContext().SetSourceMapRange(exportStatement, moveRangePastDecorators(node.AsNode()))
+			statements = append(statements, exportStatement)
+		}
+	} else {
+		// let C = (() => { ... })();
+		iife := tx.transformClassLike(class)
+		declarationName := tx.Factory().GetLocalNameEx(class, printer.AssignedNameOptions{AllowSourceMaps: true})
+		declaration := tx.Factory().NewVariableDeclaration(declarationName, nil /*exclamationToken*/, nil /*typeNode*/, iife)
+		tx.EmitContext().SetOriginal(declaration, node.AsNode())
+		statement := tx.Factory().NewVariableStatement(nil /*modifiers*/, tx.Factory().NewVariableDeclarationList(ast.NodeFlagsLet, tx.Factory().NewNodeList([]*ast.Node{declaration})))
+		tx.EmitContext().SetOriginal(statement, node.AsNode())
+		tx.EmitContext().SetCommentRange(statement, tx.EmitContext().CommentRange(node.AsNode()))
+		statements = append(statements, statement)
+
+		if isExport {
+			// export { C };
+			exportStatement := tx.Factory().NewExportDeclaration(
+				nil,   /*modifiers*/
+				false, /*isTypeOnly*/
+				tx.Factory().NewNamedExports(tx.Factory().NewNodeList([]*ast.Node{
+					tx.Factory().NewExportSpecifier(false /*isTypeOnly*/, nil /*propertyName*/, declarationName.Clone(tx.Factory())),
+				})),
+				nil, /*moduleSpecifier*/
+				nil, /*attributes*/
+			)
+			tx.EmitContext().SetOriginal(exportStatement, node.AsNode())
+			statements = append(statements, exportStatement)
+		}
+	}
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+func (tx *esDecoratorTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	if !isDecoratedClassLike(node.AsNode()) {
+		return tx.visitUndecoratedClass(node.AsNode())
+	}
+	iife := tx.transformClassLike(node.AsNode())
+	tx.EmitContext().SetOriginal(iife, node.AsNode())
+	return iife
+}
+
+// Visits a class without decorators of its own, which may still contain decorated classes in its members.
+func (tx *esDecoratorTransformer) visitUndecoratedClass(node *ast.Node) *ast.Node {
+	savedClassInfo, savedPendingExpressions := tx.classInfo, tx.pendingExpressions
+	tx.classInfo, tx.pendingExpressions = nil, nil
+	defer func() { tx.classInfo, tx.pendingExpressions = savedClassInfo, savedPendingExpressions }()
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *esDecoratorTransformer) createClassInfo(node *ast.ClassLikeDeclaration) *esDecoratorClassInfo {
+	info := &esDecoratorClassInfo{
+		class:             node,
+		metadataReference: tx.Factory().NewUniqueNameEx("_metadata", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel}),
+	}
+
+	if ast.HasDecorators(node) {
+		// We do not mark _classThis as FileLevel if it may be reused by class private fields, which requires the
+		// ability to access the captured `_classThis` of outer scopes.
+		needsUniqueClassThis := core.Some(node.Members(), func(member *ast.Node) bool {
+			return (ast.IsPrivateIdentifierClassElementDeclaration(member) || ast.IsAutoAccessorPropertyDeclaration(member)) && ast.HasStaticModifier(member)
+		})
+		var flags printer.GeneratedIdentifierFlags = printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel
+		if needsUniqueClassThis {
+			flags = printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes
+		}
+		info.classThis = tx.Factory().NewUniqueNameEx("_classThis", printer.AutoGenerateOptions{Flags: flags})
+	}
+
+	for _, member := range node.Members() {
+		if isMethodOrAccessor(member) && ast.HasDecorators(member) {
+			if ast.HasStaticModifier(member) {
+				if info.staticMethodExtraInitializersName == nil {
+					info.staticMethodExtraInitializersName = tx.Factory().NewUniqueNameEx("_staticExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+					initializer := tx.Factory().NewRunInitializersHelper(tx.classThisReference(info), info.staticMethodExtraInitializersName.Clone(tx.Factory()), nil /*value*/)
+					tx.EmitContext().SetSourceMapRange(initializer, classNameOrRangePastDecorators(node))
+					info.pendingStaticInitializers = append(info.pendingStaticInitializers, initializer)
+				}
+			} else {
+				if info.instanceMethodExtraInitializersName == nil {
+					info.instanceMethodExtraInitializersName = tx.Factory().NewUniqueNameEx("_instanceExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+					initializer := tx.Factory().NewRunInitializersHelper(tx.Factory().NewThisExpression(), info.instanceMethodExtraInitializersName.Clone(tx.Factory()), nil /*value*/)
+					tx.EmitContext().SetSourceMapRange(initializer, classNameOrRangePastDecorators(node))
+					info.pendingInstanceInitializers = append(info.pendingInstanceInitializers, initializer)
+				}
+			}
+		}
+
+		if ast.IsClassStaticBlockDeclaration(member) {
+			if !isClassNamedEvaluationHelperBlock(tx.EmitContext(), member) {
+				info.hasStaticInitializers = true
+			}
+		} else if ast.IsPropertyDeclaration(member) && ast.HasStaticModifier(member) {
+			info.hasStaticInitializers = info.hasStaticInitializers || member.Initializer() != nil || ast.HasDecorators(member)
+		}
+	}
+	return info
+}
+
+// Transforms a decorated class into an immediately invoked arrow function that evaluates the decorators and returns
+// the decorated class.
+func (tx *esDecoratorTransformer) transformClassLike(node *ast.ClassLikeDeclaration) *ast.Expression {
+	tx.EmitContext().StartVariableEnvironment()
+
+	// When a class has class decorators we end up transforming it into a statement that would otherwise give it an
+	// assigned name. If the class doesn't have an assigned name, we'll give it an assigned name of `""`.
+	if !classHasDeclaredOrExplicitlyAssignedName(tx.EmitContext(), node) && ast.HasDecorators(node) {
+		node = injectClassNamedEvaluationHelperBlockIfMissing(tx.EmitContext(), node, tx.Factory().NewStringLiteral(""), nil /*thisExpression*/)
+	}
+
+	classReference := tx.Factory().GetLocalNameEx(node, printer.AssignedNameOptions{IgnoreAssignedName: true})
+	info := tx.createClassInfo(node)
+	var classDefinitionStatements []*ast.Statement
+	var leadingBlockStatements []*ast.Statement
+	var trailingBlockStatements []*ast.Statement
+	var syntheticConstructor *ast.Node
+	var heritageClauses *ast.NodeList
+
+	// Class decorators are evaluated outside of the private name scope of the class. Since a class decorator can
+	// replace the class constructor or add extra initializers, we track both in variables.
+	classDecorators := tx.transformAllDecoratorsOfDeclaration(node)
+	if classDecorators != nil {
+		info.classDecoratorsName = tx.Factory().NewUniqueNameEx("_classDecorators", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+		info.classDescriptorName = tx.Factory().NewUniqueNameEx("_classDescriptor", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+		info.classExtraInitializersName = tx.Factory().NewUniqueNameEx("_classExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+		classDefinitionStatements = append(classDefinitionStatements,
+			tx.createLet(info.classDecoratorsName, tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(classDecorators), false /*multiLine*/)),
+			tx.createLet(info.classDescriptorName, nil /*initializer*/),
+			tx.createLet(info.classExtraInitializersName, tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/)),
+			tx.createLet(info.classThis, nil /*initializer*/),
+		)
+	}
+
+	// The `extends` expression is evaluated once, prior to the class body, so that its `Symbol.metadata` can be
+	// inherited.
+	if extendsClause := ast.GetHeritageClause(node, ast.KindExtendsKeyword); extendsClause != nil && len(extendsClause.AsHeritageClause().Types.Nodes) > 0 {
+		extendsElement := extendsClause.AsHeritageClause().Types.Nodes[0]
+		extendsExpression := tx.Visitor().VisitNode(extendsElement.Expression())
+		info.classSuper = tx.Factory().NewUniqueNameEx("_classSuper", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+
+		// Ensure we do not give the class or function an assigned name due to the variable by prefixing it with `0, `.
+		unwrapped := ast.SkipOuterExpressions(extendsExpression, ast.OEKAll)
+		safeExtendsExpression := extendsExpression
+		if ast.IsClassExpression(unwrapped) && unwrapped.Name() == nil ||
+			ast.IsFunctionExpression(unwrapped) && unwrapped.Name() == nil ||
+			ast.IsArrowFunction(unwrapped) {
+			safeExtendsExpression = tx.Factory().NewCommaExpression(tx.Factory().NewNumericLiteral("0"), extendsExpression)
+		}
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(info.classSuper, safeExtendsExpression))
+		updatedExtendsElement := tx.Factory().UpdateExpressionWithTypeArguments(extendsElement.AsExpressionWithTypeArguments(), info.classSuper.Clone(tx.Factory()), nil /*typeArguments*/)
+		updatedExtendsClause := tx.Factory().UpdateHeritageClause(extendsClause.AsHeritageClause(), tx.Factory().NewNodeList([]*ast.Node{updatedExtendsElement}))
+		heritageClauses = tx.Factory().NewNodeList([]*ast.Node{updatedExtendsClause})
+	}
+
+	savedClassInfo, savedPendingExpressions := tx.classInfo, tx.pendingExpressions
+	tx.classInfo, tx.pendingExpressions = info, nil
+
+	leadingBlockStatements = append(leadingBlockStatements, tx.createMetadata(info.metadataReference, info.classSuper))
+
+	// Visit the constructor last so that it can receive any extra initializers that were not consumed by a field.
+	var visitedMembers [][]*ast.Node
+	constructorIndex := -1
+	for i, member := range node.Members() {
+		if ast.IsConstructorDeclaration(member) {
+			constructorIndex = i
+			visitedMembers = append(visitedMembers, nil)
+			continue
+		}
+		visitedMembers = append(visitedMembers, tx.visitClassElement(member))
+	}
+	if constructorIndex >= 0 {
+		visitedMembers[constructorIndex] = []*ast.Node{tx.visitConstructorDeclaration(node.Members()[constructorIndex].AsConstructorDeclaration())}
+	}
+	members := slices.Concat(visitedMembers...)
+
+	if len(tx.pendingExpressions) > 0 {
+		var outerThis *ast.IdentifierNode
+		for _, expression := range tx.pendingExpressions {
+			// If a pending expression contains a lexical `this`, we capture the `this` of the container so that the
+			// expression still refers to it when it is moved into the class `static` block.
+			expression = tx.replaceLexicalThis(expression, func() *ast.IdentifierNode {
+				if outerThis == nil {
+					outerThis = tx.Factory().NewUniqueNameEx("_outerThis", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+					classDefinitionStatements = append([]*ast.Statement{tx.createLet(outerThis, tx.Factory().NewThisExpression())}, classDefinitionStatements...)
+				}
+				return outerThis
+			})
+			leadingBlockStatements = append(leadingBlockStatements, tx.Factory().NewExpressionStatement(expression))
+		}
+	}
+
+	tx.classInfo, tx.pendingExpressions = savedClassInfo, savedPendingExpressions
+
+	if len(info.pendingInstanceInitializers) > 0 && constructorIndex < 0 {
+		if initializerStatements := tx.prepareConstructor(info); initializerStatements != nil {
+			var statements []*ast.Statement
+			if extendsElement := ast.GetExtendsHeritageClauseElement(node); extendsElement != nil && ast.SkipOuterExpressions(extendsElement.Expression(), ast.OEKAll).Kind != ast.KindNullKeyword {
+				// super(...arguments);
+				superCall := tx.Factory().NewCallExpression(
+					tx.Factory().NewKeywordExpression(ast.KindSuperKeyword),
+					nil, /*questionDotToken*/
+					nil, /*typeArguments*/
+					tx.Factory().NewNodeList([]*ast.Expression{tx.Factory().NewSpreadElement(tx.Factory().NewIdentifier("arguments"))}),
+					ast.NodeFlagsNone,
+				)
+				statements = append(statements, tx.Factory().NewExpressionStatement(superCall))
+			}
+			statements = append(statements, initializerStatements...)
+			body := tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+			syntheticConstructor = tx.Factory().NewConstructorDeclaration(nil /*modifiers*/, nil /*typeParameters*/, tx.Factory().NewNodeList(nil), nil /*returnType*/, nil /*fullSignature*/, body)
+		}
+	}
+
+	if info.staticMethodExtraInitializersName != nil {
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(info.staticMethodExtraInitializersName, tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/)))
+	}
+	if info.instanceMethodExtraInitializersName != nil {
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(info.instanceMethodExtraInitializersName, tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/)))
+	}
+
+	// Static member variables are declared before instance member variables.
+	for _, isStatic := range []bool{true, false} {
+		for _, memberInfo := range info.memberInfos {
+			if ast.IsStatic(memberInfo.member) != isStatic {
+				continue
+			}
+			classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.decoratorsName, nil /*initializer*/))
+			if memberInfo.initializersName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.initializersName, tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/)))
+			}
+			if memberInfo.extraInitializersName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.extraInitializersName, tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/)))
+			}
+			if memberInfo.descriptorName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.descriptorName, nil /*initializer*/))
+			}
+		}
+	}
+
+	// Element decorators are applied in the order: static methods and accessors, instance methods and accessors,
+	// static fields, and instance fields.
+	leadingBlockStatements = append(leadingBlockStatements, info.staticNonFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, info.nonStaticNonFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, info.staticFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, info.nonStaticFieldDecorationStatements...)
+
+	// Class decorators are applied, and the class binding is initialized.
+	if info.classDecoratorsName != nil {
+		// __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+		valueProperty := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, tx.classThisReference(info))
+		classDescriptor := tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{valueProperty}), false /*multiLine*/)
+		classDescriptorAssignment := tx.Factory().NewAssignmentExpression(info.classDescriptorName.Clone(tx.Factory()), classDescriptor)
+		classNameReference := tx.Factory().NewPropertyAccessExpression(tx.classThisReference(info), nil /*questionDotToken*/, tx.Factory().NewIdentifier("name"), ast.NodeFlagsNone)
+		esDecorateHelper := tx.Factory().NewESDecorateHelper(
+			nil, /*ctor*/
+			classDescriptorAssignment,
+			info.classDecoratorsName.Clone(tx.Factory()),
+			tx.Factory().NewESDecorateClassContextObject(printer.ESDecorateClassContext{
+				Name:     classNameReference,
+				Metadata: info.metadataReference.Clone(tx.Factory()),
+			}),
+			nil, /*initializers*/
+			info.classExtraInitializersName.Clone(tx.Factory()),
+		)
+		esDecorateStatement := tx.Factory().NewExpressionStatement(esDecorateHelper)
+		tx.EmitContext().SetSourceMapRange(esDecorateStatement, moveRangePastDecorators(node))
+		leadingBlockStatements = append(leadingBlockStatements, esDecorateStatement)
+
+		// C = _classThis = _classDescriptor.value;
+		classDescriptorValueReference := tx.Factory().NewPropertyAccessExpression(info.classDescriptorName.Clone(tx.Factory()), nil /*questionDotToken*/, tx.Factory().NewIdentifier("value"), ast.NodeFlagsNone)
+		classThisAssignment := tx.Factory().NewAssignmentExpression(info.classThis.Clone(tx.Factory()), classDescriptorValueReference)
+		classReferenceAssignment := tx.Factory().NewAssignmentExpression(classReference.Clone(tx.Factory()), classThisAssignment)
+		leadingBlockStatements = append(leadingBlockStatements, tx.Factory().NewExpressionStatement(classReferenceAssignment))
+	}
+
+	// if (_metadata) Object.defineProperty(C, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+	leadingBlockStatements = append(leadingBlockStatements, tx.createSymbolMetadata(tx.classThisReference(info), info.metadataReference))
+
+	// Static extra initializers that were not consumed by a static field are evaluated.
+	for _, initializer := range info.pendingStaticInitializers {
+		statement := tx.Factory().NewExpressionStatement(initializer)
+		tx.EmitContext().SetSourceMapRange(statement, tx.EmitContext().SourceMapRange(initializer))
+		trailingBlockStatements = append(trailingBlockStatements, statement)
+	}
+	info.pendingStaticInitializers = nil
+
+	// Class extra initializers are evaluated.
+	if info.classExtraInitializersName != nil {
+		runClassInitializersHelper := tx.Factory().NewRunInitializersHelper(tx.classThisReference(info), info.classExtraInitializersName.Clone(tx.Factory()), nil /*value*/)
+		runClassInitializersStatement := tx.Factory().NewExpressionStatement(runClassInitializersHelper)
+		tx.EmitContext().SetSourceMapRange(runClassInitializersStatement, classNameOrRangePastDecorators(node))
+		trailingBlockStatements = append(trailingBlockStatements, runClassInitializersStatement)
+	}
+
+	// If there are no other static initializers to run, combine the leading and trailing block statements.
+	if len(trailingBlockStatements) > 0 && !info.hasStaticInitializers {
+		leadingBlockStatements = append(leadingBlockStatements, trailingBlockStatements...)
+		trailingBlockStatements = nil
+	}
+
+	leadingStaticBlock := tx.Factory().NewClassStaticBlockDeclaration(nil /*modifiers*/, tx.Factory().NewBlock(tx.Factory().NewNodeList(leadingBlockStatements), true /*multiLine*/))
+
+	// Add the leading `static {}` block after any existing NamedEvaluation helper block.
+	insertionIndex := slices.IndexFunc(members, func(member *ast.Node) bool {
+		return isClassNamedEvaluationHelperBlock(tx.EmitContext(), member)
+	}) + 1
+	newMembers := slices.Concat(members[:insertionIndex], []*ast.Node{leadingStaticBlock}, members[insertionIndex:])
+	if syntheticConstructor != nil {
+		newMembers = append(newMembers, syntheticConstructor)
+	}
+	if len(trailingBlockStatements) > 0 {
+		newMembers = append(newMembers, tx.Factory().NewClassStaticBlockDeclaration(nil /*modifiers*/, tx.Factory().NewBlock(tx.Factory().NewNodeList(trailingBlockStatements), true /*multiLine*/)))
+	}
+	memberList := tx.Factory().NewNodeList(newMembers)
+	memberList.Loc = node.MemberList().Loc
+
+	var classExpression *ast.Expression
+	if classDecorators != nil {
+		// We use `var` instead of `let` so we can leverage NamedEvaluation to define the class name and still be able
+		// to ensure it is initialized prior to any use in `static {}`.
+		//
+		//  var C = class {
+		//      static { _classThis = this; }
+		//      static {
+		//          ...
+		//          C = _classThis = _classDescriptor.value;
+		//      }
+		//  };
+		//  return C = _classThis;
+		classExpression = tx.Factory().NewClassExpression(nil /*modifiers*/, nil /*name*/, nil /*typeParameters*/, heritageClauses, memberList)
+		tx.EmitContext().SetOriginal(classExpression, node)
+		classExpression = injectClassThisAssignmentIfMissing(tx.EmitContext(), classExpression, info.classThis, nil /*thisExpression*/)
+		classReferenceDeclaration := tx.Factory().NewVariableDeclaration(classReference, nil /*exclamationToken*/, nil /*typeNode*/, classExpression)
+		classReferenceDeclarationList := tx.Factory().NewVariableDeclarationList(ast.NodeFlagsNone, tx.Factory().NewNodeList([]*ast.Node{classReferenceDeclaration}))
+		returnExpression := tx.Factory().NewAssignmentExpression(classReference.Clone(tx.Factory()), info.classThis.Clone(tx.Factory()))
+		classDefinitionStatements = append(classDefinitionStatements,
+			tx.Factory().NewVariableStatement(nil /*modifiers*/, classReferenceDeclarationList),
+			tx.Factory().NewReturnStatement(returnExpression),
+		)
+	} else {
+		// return class C { ... };
+		classExpression = tx.Factory().NewClassExpression(nil /*modifiers*/, node.Name(), nil /*typeParameters*/, heritageClauses, memberList)
+		tx.EmitContext().SetOriginal(classExpression, node)
+		classDefinitionStatements = append(classDefinitionStatements, tx.Factory().NewReturnStatement(classExpression))
+	}
+
+	classDefinitionStatements = tx.EmitContext().EndAndMergeVariableEnvironment(classDefinitionStatements)
+	return tx.Factory().NewImmediatelyInvokedArrowFunction(classDefinitionStatements)
+}
+
+// Gets a reference to the (possibly decorated) class from within its static initializers.
+func (tx *esDecoratorTransformer) classThisReference(info *esDecoratorClassInfo) *ast.Expression {
+	if info.classThis != nil {
+		return info.classThis.Clone(tx.Factory())
+	}
+	return tx.Factory().NewThisExpression()
+}
+
+// Replaces the lexical `this` in an expression moved into a class `static` block with a captured outer `this`.
+func (tx *esDecoratorTransformer) replaceLexicalThis(expression *ast.Expression, getOuterThis func() *ast.IdentifierNode) *ast.Expression {
+	var visitor *ast.NodeVisitor
+	visitor = tx.EmitContext().NewNodeVisitor(func(node *ast.Node) *ast.Node {
+		if node.SubtreeFacts()&ast.SubtreeContainsLexicalThis == 0 {
+			return node
+		}
+		switch node.Kind {
+		case ast.KindThisKeyword:
+			return getOuterThis().Clone(tx.Factory())
+		case ast.KindFunctionDeclaration, ast.KindFunctionExpression:
+			return node
+		default:
+			return visitor.VisitEachChild(node)
+		}
+	})
+	return visitor.VisitNode(expression)
+}
+
+func (tx *esDecoratorTransformer) createLet(name *ast.IdentifierNode, initializer *ast.Expression) *ast.Statement {
+	return tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsLet,
+			tx.Factory().NewNodeList([]*ast.Node{
+				tx.Factory().NewVariableDeclaration(name.Clone(tx.Factory()), nil /*exclamationToken*/, nil /*typeNode*/, initializer),
+			}),
+		),
+	)
+}
+
+// Creates the `Symbol.metadata` object for a class, inheriting from the metadata of its base class:
+//
+//	const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(_classSuper[Symbol.metadata] ?? null) : void 0;
+func (tx *esDecoratorTransformer) createMetadata(name *ast.IdentifierNode, classSuper *ast.IdentifierNode) *ast.Statement {
+	var parent *ast.Expression
+	if classSuper != nil {
+		parent = tx.Factory().NewBinaryExpression(
+			nil, /*modifiers*/
+			tx.Factory().NewElementAccessExpression(classSuper.Clone(tx.Factory()), nil /*questionDotToken*/, tx.newSymbolMetadataReference(), ast.NodeFlagsNone),
+			nil, /*typeNode*/
+			tx.Factory().NewToken(ast.KindQuestionQuestionToken),
+			tx.Factory().NewKeywordExpression(ast.KindNullKeyword),
+		)
+	} else {
+		parent = tx.Factory().NewKeywordExpression(ast.KindNullKeyword)
+	}
+	initializer := tx.Factory().NewConditionalExpression(
+		tx.Factory().NewBinaryExpression(
+			nil, /*modifiers*/
+			tx.Factory().NewTypeCheck(tx.Factory().NewIdentifier("Symbol"), "function"),
+			nil, /*typeNode*/
+			tx.Factory().NewToken(ast.KindAmpersandAmpersandToken),
+			tx.newSymbolMetadataReference(),
+		),
+		tx.Factory().NewToken(ast.KindQuestionToken),
+		tx.Factory().NewGlobalMethodCall("Object", "create", []*ast.Node{parent}),
+		tx.Factory().NewToken(ast.KindColonToken),
+		tx.Factory().NewVoidZeroExpression(),
+	)
+	return tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsConst,
+			tx.Factory().NewNodeList([]*ast.Node{
+				tx.Factory().NewVariableDeclaration(name.Clone(tx.Factory()), nil /*exclamationToken*/, nil /*typeNode*/, initializer),
+			}),
+		),
+	)
+}
+
+// Creates `if (_metadata) Object.defineProperty(target, Symbol.metadata, { ... value: _metadata });`.
+func (tx *esDecoratorTransformer) createSymbolMetadata(target *ast.Expression, value *ast.IdentifierNode) *ast.Statement {
+	newProperty := func(name string, initializer *ast.Expression) *ast.Node {
+		return tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, initializer)
+	}
+	descriptor := tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		newProperty("enumerable", tx.Factory().NewTrueExpression()),
+		newProperty("configurable", tx.Factory().NewTrueExpression()),
+		newProperty("writable", tx.Factory().NewTrueExpression()),
+		newProperty("value", value.Clone(tx.Factory())),
+	}), false /*multiLine*/)
+	defineProperty := tx.Factory().NewGlobalMethodCall("Object", "defineProperty", []*ast.Node{target, tx.newSymbolMetadataReference(), descriptor})
+	statement := tx.Factory().NewIfStatement(value.Clone(tx.Factory()), tx.Factory().NewExpressionStatement(defineProperty), nil /*elseStatement*/)
+	tx.EmitContext().AddEmitFlags(statement, printer.EFSingleLine)
+	return statement
+}
+
+func (tx *esDecoratorTransformer) newSymbolMetadataReference() *ast.Expression {
+	return tx.Factory().NewPropertyAccessExpression(tx.Factory().NewIdentifier("Symbol"), nil /*questionDotToken*/, tx.Factory().NewIdentifier("metadata"), ast.NodeFlagsNone)
+}
+
+// Evaluates the decorators of a class or class element, in order. Returns nil if there are no decorators.
+func (tx *esDecoratorTransformer) transformAllDecoratorsOfDeclaration(node *ast.Node) []*ast.Expression {
+	var decorators []*ast.Expression
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				decorators = append(decorators, tx.transformDecorator(modifier.AsDecorator()))
+			}
+		}
+	}
+	return decorators
+}
+
+func (tx *esDecoratorTransformer) transformDecorator(decorator *ast.Decorator) *ast.Expression {
+	expression := tx.Visitor().VisitNode(decorator.Expression)
+	tx.EmitContext().AddEmitFlags(expression, printer.EFNoComments)
+
+	// Preserve the `this` binding for an access expression, i.e. `@a.b` becomes `(_a = a).b.bind(_a)`.
+	innerExpression := ast.SkipOuterExpressions(expression, ast.OEKAll)
+	if ast.IsAccessExpression(innerExpression) {
+		target, thisArg := tx.createCallBinding(innerExpression)
+		return tx.Factory().RestoreOuterExpressions(expression, tx.Factory().NewFunctionBindCall(target, thisArg, nil /*argumentsList*/), ast.OEKAll)
+	}
+	return expression
+}
+
+// Splits an access expression into a target that can be called and the `this` argument with which to call it,
+// caching the object of the access in a temporary variable if it may have side effects.
+func (tx *esDecoratorTransformer) createCallBinding(callee *ast.Expression) (target *ast.Expression, thisArg *ast.Expression) {
+	object := callee.Expression()
+	if object.Kind == ast.KindSuperKeyword {
+		return callee, tx.Factory().NewThisExpression()
+	}
+	switch ast.SkipParentheses(object).Kind {
+	case ast.KindThisKeyword, ast.KindNumericLiteral, ast.KindBigIntLiteral, ast.KindStringLiteral:
+		return callee, object.Clone(tx.Factory())
+	}
+
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	assignment := tx.Factory().NewAssignmentExpression(temp, object)
+	assignment.Loc = object.Loc
+	receiver := tx.Factory().NewParenthesizedExpression(assignment)
+	if ast.IsPropertyAccessExpression(callee) {
+		target = tx.Factory().NewPropertyAccessExpression(receiver, nil /*questionDotToken*/, callee.Name(), ast.NodeFlagsNone)
+	} else {
+		target = tx.Factory().NewElementAccessExpression(receiver, nil /*questionDotToken*/, callee.AsElementAccessExpression().ArgumentExpression, ast.NodeFlagsNone)
+	}
+	target.Loc = callee.Loc
+	return target, temp.Clone(tx.Factory())
+}
+
+// Visits a member of a decorated class other than its constructor.
+func (tx *esDecoratorTransformer) visitClassElement(member *ast.Node) []*ast.Node {
+	switch member.Kind {
+	case ast.KindMethodDeclaration:
+		return []*ast.Node{tx.visitMethodDeclaration(member.AsMethodDeclaration())}
+	case ast.KindGetAccessor:
+		return []*ast.Node{tx.visitGetAccessorDeclaration(member.AsGetAccessorDeclaration())}
+	case ast.KindSetAccessor:
+		return []*ast.Node{tx.visitSetAccessorDeclaration(member.AsSetAccessorDeclaration())}
+	case ast.KindPropertyDeclaration:
+		return tx.visitPropertyDeclaration(member.AsPropertyDeclaration())
+	case ast.KindClassStaticBlockDeclaration:
+		if isClassNamedEvaluationHelperBlock(tx.EmitContext(), member) || isClassThisAssignmentBlock(tx.EmitContext(), member) {
+			return []*ast.Node{member}
+		}
+		return []*ast.Node{tx.Visitor().VisitEachChild(member)}
+	default:
+		if visited := tx.Visitor().VisitNode(member); visited != nil {
+			return []*ast.Node{visited}
+		}
+		return nil
+	}
+}
+
+func (tx *esDecoratorTransformer) createHelperVariable(member *ast.Node, suffix string) *ast.IdentifierNode {
+	return tx.Factory().NewUniqueNameEx(getHelperVariableName(member)+"_"+suffix, printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+}
+
+// Gets the prefix for the helper variables of a class element, such as `_static_private_get_x`.
+func getHelperVariableName(member *ast.Node) string {
+	name := member.Name()
+	var declarationName string
+	switch {
+	case name != nil && ast.IsIdentifier(name):
+		declarationName = name.Text()
+	case name != nil && ast.IsPrivateIdentifier(name):
+		declarationName = name.Text()[1:]
+	case name != nil && ast.IsStringLiteral(name) && scanner.IsIdentifierText(name.Text(), core.LanguageVariantStandard):
+		declarationName = name.Text()
+	default:
+		declarationName = "member"
+	}
+	if ast.IsGetAccessorDeclaration(member) {
+		declarationName = "get_" + declarationName
+	}
+	if ast.IsSetAccessorDeclaration(member) {
+		declarationName = "set_" + declarationName
+	}
+	if name != nil && ast.IsPrivateIdentifier(name) {
+		declarationName = "private_" + declarationName
+	}
+	if ast.IsStatic(member) {
+		declarationName = "static_" + declarationName
+	}
+	return "_" + declarationName
+}
+
+// Evaluates the decorators of a class element and queues the `__esDecorate` call that applies them, returning the
+// transformed modifiers and name of the element along with the helper variables it must reference.
+//
+// The createDescriptor callback creates the descriptor for a decorated private method, accessor, or auto-accessor,
+// whose implementation must be moved into the `__esDecorate` call so that decorators can replace it.
+func (tx *esDecoratorTransformer) partialTransformClassElement(member *ast.Node, createDescriptor func(member *ast.Node, modifiers *ast.ModifierList) *ast.Expression) esDecoratorClassElementParts {
+	info := tx.classInfo
+	var parts esDecoratorClassElementParts
+
+	// Member decorators require privileged access to private names. However, computed property evaluation occurs
+	// interspersed with decorator evaluation. This means that if we encounter a computed property name we must
+	// inline decorator evaluation.
+	memberDecorators := tx.transformAllDecoratorsOfDeclaration(member)
+	parts.modifiers = tx.filterModifiers(member.Modifiers(), func(modifier *ast.Node) bool { return !ast.IsDecorator(modifier) })
+
+	if memberDecorators != nil {
+		memberInfo := &esDecoratorMemberInfo{
+			member:         member,
+			decoratorsName: tx.createHelperVariable(member, "decorators"),
+		}
+		info.memberInfos = append(info.memberInfos, memberInfo)
+		tx.pendingExpressions = append(tx.pendingExpressions, tx.Factory().NewAssignmentExpression(
+			memberInfo.decoratorsName.Clone(tx.Factory()),
+			tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(memberDecorators), false /*multiLine*/),
+		))
+
+		isStatic := ast.IsStatic(member)
+		isAutoAccessor := ast.IsAutoAccessorPropertyDeclaration(member)
+		var statements *[]*ast.Statement
+		switch {
+		case (isMethodOrAccessor(member) || isAutoAccessor) && isStatic:
+			statements = &info.staticNonFieldDecorationStatements
+		case isMethodOrAccessor(member) || isAutoAccessor:
+			statements = &info.nonStaticNonFieldDecorationStatements
+		case isStatic:
+			statements = &info.staticFieldDecorationStatements
+		default:
+			statements = &info.nonStaticFieldDecorationStatements
+		}
+
+		var kind string
+		switch {
+		case ast.IsGetAccessorDeclaration(member):
+			kind = "getter"
+		case ast.IsSetAccessorDeclaration(member):
+			kind = "setter"
+		case ast.IsMethodDeclaration(member):
+			kind = "method"
+		case isAutoAccessor:
+			kind = "accessor"
+		default:
+			kind = "field"
+		}
+
+		var propertyName printer.ESDecorateName
+		name := member.Name()
+		switch {
+		case ast.IsIdentifier(name) || ast.IsPrivateIdentifier(name):
+			propertyName = printer.ESDecorateName{Computed: false, Name: name}
+		case ast.IsPropertyNameLiteral(name):
+			propertyName = printer.ESDecorateName{Computed: true, Name: tx.Factory().NewStringLiteralFromNode(name)}
+		case ast.IsPropertyNameLiteral(name.Expression()) && !ast.IsIdentifier(name.Expression()):
+			propertyName = printer.ESDecorateName{Computed: true, Name: tx.Factory().NewStringLiteralFromNode(name.Expression())}
+		default:
+			var referencedName *ast.Expression
+			referencedName, parts.name = tx.visitReferencedPropertyName(name)
+			propertyName = printer.ESDecorateName{Computed: true, Name: referencedName}
+		}
+
+		context := tx.Factory().NewESDecorateClassElementContextObject(printer.ESDecorateClassElementContext{
+			Kind:    kind,
+			Name:    propertyName,
+			Static:  isStatic,
+			Private: ast.IsPrivateIdentifier(name),
+			Access: printer.ESDecorateClassElementAccess{
+				Get: ast.IsPropertyDeclaration(member) || ast.IsGetAccessorDeclaration(member) || ast.IsMethodDeclaration(member),
+				Set: ast.IsPropertyDeclaration(member) || ast.IsSetAccessorDeclaration(member),
+			},
+			Metadata: info.metadataReference.Clone(tx.Factory()),
+		})
+
+		var esDecorateExpression *ast.Expression
+		if isMethodOrAccessor(member) {
+			extraInitializersName := info.instanceMethodExtraInitializersName
+			if isStatic {
+				extraInitializersName = info.staticMethodExtraInitializersName
+			}
+
+			var descriptor *ast.Expression
+			if ast.IsPrivateIdentifier(name) && createDescriptor != nil {
+				modifiers := tx.filterModifiers(parts.modifiers, func(modifier *ast.Node) bool { return modifier.Kind == ast.KindAsyncKeyword })
+				memberInfo.descriptorName = tx.createHelperVariable(member, "descriptor")
+				parts.descriptorName = memberInfo.descriptorName
+				descriptor = tx.Factory().NewAssignmentExpression(memberInfo.descriptorName.Clone(tx.Factory()), createDescriptor(member, modifiers))
+			}
+
+			esDecorateExpression = tx.Factory().NewESDecorateHelper(
+				tx.Factory().NewThisExpression(),
+				descriptor,
+				memberInfo.decoratorsName.Clone(tx.Factory()),
+				context,
+				nil, /*initializers*/
+				extraInitializersName.Clone(tx.Factory()),
+			)
+		} else {
+			memberInfo.initializersName = tx.createHelperVariable(member, "initializers")
+			memberInfo.extraInitializersName = tx.createHelperVariable(member, "extraInitializers")
+			parts.initializersName = memberInfo.initializersName
+			parts.extraInitializersName = memberInfo.extraInitializersName
+			if isStatic {
+				parts.thisArg = info.classThis
+			}
+
+			var descriptor *ast.Expression
+			if ast.IsPrivateIdentifier(name) && isAutoAccessor && createDescriptor != nil {
+				memberInfo.descriptorName = tx.createHelperVariable(member, "descriptor")
+				parts.descriptorName = memberInfo.descriptorName
+				descriptor = tx.Factory().NewAssignmentExpression(memberInfo.descriptorName.Clone(tx.Factory()), createDescriptor(member, nil /*modifiers*/))
+			}
+
+			var ctor *ast.Expression
+			if isAutoAccessor {
+				ctor = tx.Factory().NewThisExpression()
+			}
+			esDecorateExpression = tx.Factory().NewESDecorateHelper(
+				ctor,
+				descriptor,
+				memberInfo.decoratorsName.Clone(tx.Factory()),
+				context,
+				memberInfo.initializersName.Clone(tx.Factory()),
+				memberInfo.extraInitializersName.Clone(tx.Factory()),
+			)
+		}
+
+		esDecorateStatement := tx.Factory().NewExpressionStatement(esDecorateExpression)
+		tx.EmitContext().SetSourceMapRange(esDecorateStatement, moveRangePastDecorators(member))
+		*statements = append(*statements, esDecorateStatement)
+	}
+
+	if parts.name == nil {
+		parts.name = tx.visitPropertyName(member.Name())
+	}
+	return parts
+}
+
+// Visits the computed property name of a decorated class element, caching the property key so that it can be
+// referenced by the decorator context:
+//
+//	[(_a = __propKey(expr))]
+func (tx *esDecoratorTransformer) visitReferencedPropertyName(name *ast.PropertyName) (*ast.Expression, *ast.PropertyName) {
+	referencedName := tx.Factory().NewGeneratedNameForNode(name)
+	tx.EmitContext().AddVariableDeclaration(referencedName)
+	key := tx.Factory().NewPropKeyHelper(tx.Visitor().VisitNode(name.Expression()))
+	assignment := tx.Factory().NewAssignmentExpression(referencedName.Clone(tx.Factory()), key)
+	updated := tx.Factory().UpdateComputedPropertyName(name.AsComputedPropertyName(), tx.injectPendingExpressions(assignment))
+	return referencedName, updated
+}
+
+func (tx *esDecoratorTransformer) visitPropertyName(name *ast.PropertyName) *ast.PropertyName {
+	if ast.IsComputedPropertyName(name) {
+		expression := tx.Visitor().VisitNode(name.Expression())
+		if !isSimpleInlineableExpression(expression) {
+			expression = tx.injectPendingExpressions(expression)
+		}
+		return tx.Factory().UpdateComputedPropertyName(name.AsComputedPropertyName(), expression)
+	}
+	return tx.Visitor().VisitNode(name)
+}
+
+// Evaluates any pending member decorators before a computed property name, to preserve the order of evaluation.
+func (tx *esDecoratorTransformer) injectPendingExpressions(expression *ast.Expression) *ast.Expression {
+	if len(tx.pendingExpressions) > 0 {
+		if ast.IsParenthesizedExpression(expression) {
+			expressions := append(tx.pendingExpressions, expression.Expression())
+			expression = tx.Factory().UpdateParenthesizedExpression(expression.AsParenthesizedExpression(), tx.Factory().InlineExpressions(expressions))
+		} else {
+			expression = tx.Factory().InlineExpressions(append(tx.pendingExpressions, expression))
+		}
+		tx.pendingExpressions = nil
+	}
+	return expression
+}
+
+// Prepends any pending extra initializers to the initializer of a field.
+func (tx *esDecoratorTransformer) injectPendingInitializers(info *esDecoratorClassInfo, isStatic bool, expression *ast.Expression) *ast.Expression {
+	pendingInitializers := &info.pendingInstanceInitializers
+	if isStatic {
+		pendingInitializers = &info.pendingStaticInitializers
+	}
+	if len(*pendingInitializers) > 0 {
+		if expression != nil {
+			expression = tx.Factory().InlineExpressions(append(*pendingInitializers, expression))
+		} else {
+			expression = tx.Factory().InlineExpressions(*pendingInitializers)
+		}
+		*pendingInitializers = nil
+	}
+	return expression
+}
+
+// Decorated instance members can add extra initializers to the instance. If a class contains any instance fields,
+// the `__runInitializers()` call for these extra initializers is injected into the initializer of the first field.
+// Otherwise, the call must be added to the constructor instead.
+func (tx *esDecoratorTransformer) prepareConstructor(info *esDecoratorClassInfo) []*ast.Statement {
+	if len(info.pendingInstanceInitializers) == 0 {
+		return nil
+	}
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(info.pendingInstanceInitializers))
+	info.pendingInstanceInitializers = nil
+	return []*ast.Statement{statement}
+}
+
+func (tx *esDecoratorTransformer) visitConstructorDeclaration(node *ast.ConstructorDeclaration) *ast.Node {
+	modifiers := tx.filterModifiers(node.Modifiers(), func(modifier *ast.Node) bool { return !ast.IsDecorator(modifier) })
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	if node.Body == nil {
+		return tx.Factory().UpdateConstructorDeclaration(node, modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()))
+	}
+
+	initializerStatements := tx.prepareConstructor(tx.classInfo)
+	if initializerStatements == nil {
+		body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+		return tx.Factory().UpdateConstructorDeclaration(node, modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	}
+
+	// Extra initializers run immediately after `super()` returns, or at the start of the constructor of a base class.
+	prologue, rest := tx.Factory().SplitStandardPrologue(node.Body.AsBlock().Statements.Nodes)
+	rest, _ = tx.Visitor().VisitSlice(rest)
+	insertionIndex := 0
+	// !!! `super()` calls nested within other statements are not yet supported
+	for i, statement := range rest {
+		if ast.IsExpressionStatement(statement) && ast.IsSuperCall(statement.Expression()) {
+			insertionIndex = i + 1
+			break
+		}
+	}
+	statements := slices.Concat(prologue, rest[:insertionIndex], initializerStatements, rest[insertionIndex:])
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+	statementList := tx.Factory().NewNodeList(statements)
+	statementList.Loc = node.Body.AsBlock().Statements.Loc
+	body := tx.Factory().NewBlock(statementList, true /*multiLine*/)
+	tx.EmitContext().SetOriginal(body, node.Body)
+	body.Loc = node.Body.Loc
+	return tx.Factory().UpdateConstructorDeclaration(node, modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+}
+
+func (tx *esDecoratorTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	parts := tx.partialTransformClassElement(node.AsNode(), tx.createMethodDescriptorObject)
+	if parts.descriptorName != nil {
+		return tx.finishClassElement(tx.createMethodDescriptorForwarder(parts.modifiers, parts.name, parts.descriptorName), node.AsNode())
+	}
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	updated := tx.Factory().UpdateMethodDeclaration(node, parts.modifiers, node.AsteriskToken, parts.name, nil /*postfixToken*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	return tx.finishClassElement(updated, node.AsNode())
+}
+
+func (tx *esDecoratorTransformer) visitGetAccessorDeclaration(node *ast.GetAccessorDeclaration) *ast.Node {
+	parts := tx.partialTransformClassElement(node.AsNode(), tx.createGetAccessorDescriptorObject)
+	if parts.descriptorName != nil {
+		return tx.finishClassElement(tx.createGetAccessorDescriptorForwarder(parts.modifiers, parts.name, parts.descriptorName), node.AsNode())
+	}
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	updated := tx.Factory().UpdateGetAccessorDeclaration(node, parts.modifiers, parts.name, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	return tx.finishClassElement(updated, node.AsNode())
+}
+
+func (tx *esDecoratorTransformer) visitSetAccessorDeclaration(node *ast.SetAccessorDeclaration) *ast.Node {
+	parts := tx.partialTransformClassElement(node.AsNode(), tx.createSetAccessorDescriptorObject)
+	if parts.descriptorName != nil {
+		return tx.finishClassElement(tx.createSetAccessorDescriptorForwarder(parts.modifiers, parts.name, parts.descriptorName), node.AsNode())
+	}
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	updated := tx.Factory().UpdateSetAccessorDeclaration(node, parts.modifiers, parts.name, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	return tx.finishClassElement(updated, node.AsNode())
+}
+
+func (tx *esDecoratorTransformer) visitPropertyDeclaration(node *ast.PropertyDeclaration) []*ast.Node {
+	if isNamedEvaluationAnd(tx.EmitContext(), node.AsNode(), tx.isAnonymousClassNeedingAssignedName) {
+		node = transformNamedEvaluation(tx.EmitContext(), node.AsNode(), canIgnoreEmptyStringLiteralInAssignedName(node.Initializer), "" /*assignedName*/).AsPropertyDeclaration()
+	}
+
+	// The backing field of an auto-accessor is shared by its getter and setter and, for a decorated private
+	// auto-accessor, by the descriptor passed to its decorators.
+	var backingFieldName *ast.PrivateIdentifierNode
+	var createDescriptor func(member *ast.Node, modifiers *ast.ModifierList) *ast.Expression
+	if ast.HasAccessorModifier(node.AsNode()) {
+		backingFieldName = tx.Factory().NewGeneratedPrivateNameForNodeEx(node.Name(), printer.AutoGenerateOptions{Suffix: "_accessor_storage"})
+		createDescriptor = func(member *ast.Node, modifiers *ast.ModifierList) *ast.Expression {
+			return tx.createAccessorPropertyDescriptorObject(backingFieldName, modifiers)
+		}
+	}
+	parts := tx.partialTransformClassElement(node.AsNode(), createDescriptor)
+	info := tx.classInfo
+	isStatic := ast.IsStatic(node.AsNode())
+
+	tx.EmitContext().StartVariableEnvironment()
+	initializer := tx.Visitor().VisitNode(node.Initializer)
+	if parts.initializersName != nil {
+		if initializer == nil {
+			initializer = tx.Factory().NewVoidZeroExpression()
+		}
+		var thisArg *ast.Expression
+		if parts.thisArg != nil {
+			thisArg = parts.thisArg.Clone(tx.Factory())
+		} else {
+			thisArg = tx.Factory().NewThisExpression()
+		}
+		initializer = tx.Factory().NewRunInitializersHelper(thisArg, parts.initializersName.Clone(tx.Factory()), initializer)
+	}
+	if isStatic && initializer != nil {
+		info.hasStaticInitializers = true
+	}
+	if declarations := tx.EmitContext().EndVariableEnvironment(); len(declarations) > 0 {
+		initializer = tx.Factory().NewImmediatelyInvokedArrowFunction(append(declarations, tx.Factory().NewReturnStatement(initializer)))
+	}
+
+	initializer = tx.injectPendingInitializers(info, isStatic, initializer)
+	if parts.extraInitializersName != nil {
+		var thisArg *ast.Expression
+		if isStatic {
+			thisArg = tx.classThisReference(info)
+		} else {
+			thisArg = tx.Factory().NewThisExpression()
+		}
+		extraInitializer := tx.Factory().NewRunInitializersHelper(thisArg, parts.extraInitializersName.Clone(tx.Factory()), nil /*value*/)
+		if isStatic {
+			info.pendingStaticInitializers = append(info.pendingStaticInitializers, extraInitializer)
+		} else {
+			info.pendingInstanceInitializers = append(info.pendingInstanceInitializers, extraInitializer)
+		}
+	}
+
+	if ast.HasAccessorModifier(node.AsNode()) {
+		return tx.transformAutoAccessor(node, parts, backingFieldName, initializer)
+	}
+
+	updated := tx.Factory().UpdatePropertyDeclaration(node, parts.modifiers, parts.name, nil /*postfixToken*/, nil /*typeNode*/, initializer)
+	return []*ast.Node{tx.finishClassElement(updated, node.AsNode())}
+}
+
+// Lowers an auto-accessor into a private backing field and a getter and setter pair. For example:
+//
+//	accessor x = 1;
+//
+// produces:
+//
+//	#x_accessor_storage = 1;
+//	get x() { return this.#x_accessor_storage; }
+//	set x(value) { this.#x_accessor_storage = value; }
+//
+// A decorated private auto-accessor instead forwards its getter and setter to the descriptor passed to its
+// decorators, which reads and writes the backing field:
+//
+//	#x_accessor_storage = 1;
+//	get #x() { return _private_x_descriptor.get.call(this); }
+//	set #x(value) { return _private_x_descriptor.set.call(this, value); }
+func (tx *esDecoratorTransformer) transformAutoAccessor(node *ast.PropertyDeclaration, parts esDecoratorClassElementParts, backingFieldName *ast.PrivateIdentifierNode, initializer *ast.Expression) []*ast.Node {
+	// Since we're creating two declarations where there was previously one, cache the expression for any computed
+	// property names.
+	getterName := parts.name
+	setterName := parts.name
+	if ast.IsComputedPropertyName(parts.name) {
+		expression := parts.name.Expression()
+		if cacheAssignment := findComputedPropertyNameCacheAssignment(expression); cacheAssignment != nil {
+			setterName = tx.Factory().UpdateComputedPropertyName(parts.name.AsComputedPropertyName(), cacheAssignment.Left.Clone(tx.Factory()))
+		} else if isSimpleInlineableExpression(expression) {
+			setterName = tx.Factory().UpdateComputedPropertyName(parts.name.AsComputedPropertyName(), expression.Clone(tx.Factory()))
+		} else {
+			temp := tx.Factory().NewTempVariable()
+			tx.EmitContext().AddVariableDeclaration(temp)
+			getterName = tx.Factory().UpdateComputedPropertyName(parts.name.AsComputedPropertyName(), tx.Factory().NewAssignmentExpression(temp, expression))
+			setterName = tx.Factory().UpdateComputedPropertyName(parts.name.AsComputedPropertyName(), temp.Clone(tx.Factory()))
+		}
+	} else {
+		setterName = parts.name.Clone(tx.Factory())
+	}
+
+	modifiers := tx.filterModifiers(parts.modifiers, func(modifier *ast.Node) bool { return modifier.Kind != ast.KindAccessorKeyword })
+
+	backingField := tx.Factory().NewPropertyDeclaration(modifiers, backingFieldName, nil /*postfixToken*/, nil /*typeNode*/, initializer)
+	tx.EmitContext().SetOriginal(backingField, node.AsNode())
+	tx.EmitContext().SetEmitFlags(backingField, printer.EFNoComments)
+	tx.EmitContext().SetSourceMapRange(backingField, moveRangePastDecorators(node.AsNode()))
+	tx.EmitContext().SetSourceMapRange(backingFieldName, node.Name().Loc)
+
+	var getter, setter *ast.Node
+	if parts.descriptorName != nil {
+		getter = tx.createGetAccessorDescriptorForwarder(modifiers, getterName, parts.descriptorName)
+		setter = tx.createSetAccessorDescriptorForwarder(modifiers, setterName, parts.descriptorName)
+	} else {
+		getter = tx.Factory().NewGetAccessorDeclaration(
+			modifiers,
+			getterName,
+			nil, /*typeParameters*/
+			tx.Factory().NewNodeList(nil),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+				tx.Factory().NewReturnStatement(tx.newThisPropertyAccess(backingFieldName)),
+			}), false /*multiLine*/),
+		)
+		setter = tx.Factory().NewSetAccessorDeclaration(
+			tx.cloneModifiers(modifiers),
+			setterName,
+			nil, /*typeParameters*/
+			tx.Factory().NewNodeList([]*ast.Node{tx.newValueParameter()}),
+			nil, /*returnType*/
+			nil, /*fullSignature*/
+			tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+				tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(tx.newThisPropertyAccess(backingFieldName), tx.Factory().NewIdentifier("value"))),
+			}), false /*multiLine*/),
+		)
+	}
+	tx.EmitContext().SetOriginal(getter, node.AsNode())
+	tx.EmitContext().SetCommentRange(getter, tx.EmitContext().CommentRange(node.AsNode()))
+	tx.EmitContext().SetSourceMapRange(getter, moveRangePastDecorators(node.AsNode()))
+	tx.EmitContext().SetOriginal(setter, node.AsNode())
+	tx.EmitContext().SetEmitFlags(setter, printer.EFNoComments)
+	tx.EmitContext().SetSourceMapRange(setter, moveRangePastDecorators(node.AsNode()))
+	return []*ast.Node{backingField, getter, setter}
+}
+
+// Finds the `_a = expr` assignment that caches a computed property name, if any.
+func findComputedPropertyNameCacheAssignment(expression *ast.Expression) *ast.BinaryExpression {
+	expression = ast.SkipParentheses(expression)
+	for ast.IsBinaryExpression(expression) && expression.AsBinaryExpression().OperatorToken.Kind == ast.KindCommaToken {
+		expression = ast.SkipParentheses(expression.AsBinaryExpression().Right)
+	}
+	if ast.IsAssignmentExpression(expression, true /*excludeCompoundAssignment*/) && ast.IsIdentifier(expression.AsBinaryExpression().Left) {
+		return expression.AsBinaryExpression()
+	}
+	return nil
+}
+
+func (tx *esDecoratorTransformer) finishClassElement(updated *ast.Node, original *ast.Node) *ast.Node {
+	if updated != original {
+		tx.EmitContext().SetCommentRange(updated, original.Loc)
+		tx.EmitContext().SetSourceMapRange(updated, moveRangePastDecorators(original))
+	}
+	return updated
+}
+
+// Creates a function that implements a decorated private method or accessor, to be stored in a property of its
+// descriptor:
+//
+//	value: __setFunctionName(function () { ... }, "#m")
+func (tx *esDecoratorTransformer) createDescriptorMethod(original *ast.Node, name *ast.PrivateIdentifierNode, modifiers *ast.ModifierList, asteriskToken *ast.TokenNode, kind string, parameters *ast.ParameterList, body *ast.BlockNode) *ast.Node {
+	if body == nil {
+		body = tx.Factory().NewBlock(tx.Factory().NewNodeList(nil), false /*multiLine*/)
+	}
+	function := tx.Factory().NewFunctionExpression(tx.cloneModifiers(modifiers), asteriskToken, nil /*name*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	tx.EmitContext().SetOriginal(function, original)
+	tx.EmitContext().SetSourceMapRange(function, moveRangePastDecorators(original))
+	tx.EmitContext().SetEmitFlags(function, printer.EFNoComments)
+
+	prefix := ""
+	if kind == "get" || kind == "set" {
+		prefix = kind
+	}
+	namedFunction := tx.Factory().NewSetFunctionNameHelper(function, tx.Factory().NewStringLiteralFromNode(name), prefix)
+	method := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier(kind), nil /*postfixToken*/, nil /*typeNode*/, namedFunction)
+	tx.EmitContext().SetOriginal(method, original)
+	tx.EmitContext().SetSourceMapRange(method, moveRangePastDecorators(original))
+	tx.EmitContext().SetEmitFlags(method, printer.EFNoComments)
+	return method
+}
+
+// Creates `{ value: __setFunctionName(function () { ... }, "#m") }` for a decorated private method.
+func (tx *esDecoratorTransformer) createMethodDescriptorObject(member *ast.Node, modifiers *ast.ModifierList) *ast.Expression {
+	node := member.AsMethodDeclaration()
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	return tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		tx.createDescriptorMethod(member, node.Name(), modifiers, node.AsteriskToken, "value", parameters, body),
+	}), false /*multiLine*/)
+}
+
+// Creates `{ get: __setFunctionName(function () { ... }, "#x", "get") }` for a decorated private getter.
+func (tx *esDecoratorTransformer) createGetAccessorDescriptorObject(member *ast.Node, modifiers *ast.ModifierList) *ast.Expression {
+	node := member.AsGetAccessorDeclaration()
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	return tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		tx.createDescriptorMethod(member, node.Name(), modifiers, nil /*asteriskToken*/, "get", parameters, body),
+	}), false /*multiLine*/)
+}
+
+// Creates `{ set: __setFunctionName(function (value) { ... }, "#x", "set") }` for a decorated private setter.
+func (tx *esDecoratorTransformer) createSetAccessorDescriptorObject(member *ast.Node, modifiers *ast.ModifierList) *ast.Expression {
+	node := member.AsSetAccessorDeclaration()
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor())
+	return tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		tx.createDescriptorMethod(member, node.Name(), modifiers, nil /*asteriskToken*/, "set", parameters, body),
+	}), false /*multiLine*/)
+}
+
+// Creates `{ get() { return this.#x_accessor_storage; }, set(value) { this.#x_accessor_storage = value; } }` for a
+// decorated private auto-accessor.
+func (tx *esDecoratorTransformer) createAccessorPropertyDescriptorObject(backingFieldName *ast.PrivateIdentifierNode, modifiers *ast.ModifierList) *ast.Expression {
+	getter := tx.Factory().NewMethodDeclaration(
+		tx.cloneModifiers(modifiers),
+		nil, /*asteriskToken*/
+		tx.Factory().NewIdentifier("get"),
+		nil, /*postfixToken*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList(nil),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewReturnStatement(tx.newThisPropertyAccess(backingFieldName)),
+		}), false /*multiLine*/),
+	)
+	setter := tx.Factory().NewMethodDeclaration(
+		tx.cloneModifiers(modifiers),
+		nil, /*asteriskToken*/
+		tx.Factory().NewIdentifier("set"),
+		nil, /*postfixToken*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.Node{tx.newValueParameter()}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(tx.newThisPropertyAccess(backingFieldName), tx.Factory().NewIdentifier("value"))),
+		}), false /*multiLine*/),
+	)
+	return tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{getter, setter}), false /*multiLine*/)
+}
+
+// Creates `get #m() { return _private_m_descriptor.value; }` for a decorated private method.
+func (tx *esDecoratorTransformer) createMethodDescriptorForwarder(modifiers *ast.ModifierList, name *ast.PropertyName, descriptorName *ast.IdentifierNode) *ast.Node {
+	return tx.Factory().NewGetAccessorDeclaration(
+		tx.staticModifiersOnly(modifiers),
+		name,
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList(nil),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewReturnStatement(tx.Factory().NewPropertyAccessExpression(descriptorName.Clone(tx.Factory()), nil /*questionDotToken*/, tx.Factory().NewIdentifier("value"), ast.NodeFlagsNone)),
+		}), false /*multiLine*/),
+	)
+}
+
+// Creates `get #x() { return _private_x_descriptor.get.call(this); }` for a decorated private getter or
+// auto-accessor.
+func (tx *esDecoratorTransformer) createGetAccessorDescriptorForwarder(modifiers *ast.ModifierList, name *ast.PropertyName, descriptorName *ast.IdentifierNode) *ast.Node {
+	return tx.Factory().NewGetAccessorDeclaration(
+		tx.staticModifiersOnly(modifiers),
+		name,
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList(nil),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewReturnStatement(tx.Factory().NewFunctionCallCall(
+				tx.Factory().NewPropertyAccessExpression(descriptorName.Clone(tx.Factory()), nil /*questionDotToken*/, tx.Factory().NewIdentifier("get"), ast.NodeFlagsNone),
+				tx.Factory().NewThisExpression(),
+				nil, /*argumentsList*/
+			)),
+		}), false /*multiLine*/),
+	)
+}
+
+// Creates `set #x(value) { return _private_x_descriptor.set.call(this, value); }` for a decorated private setter or
+// auto-accessor.
+func (tx *esDecoratorTransformer) createSetAccessorDescriptorForwarder(modifiers *ast.ModifierList, name *ast.PropertyName, descriptorName *ast.IdentifierNode) *ast.Node {
+	return tx.Factory().NewSetAccessorDeclaration(
+		tx.staticModifiersOnly(modifiers),
+		name,
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.Node{tx.newValueParameter()}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewReturnStatement(tx.Factory().NewFunctionCallCall(
+				tx.Factory().NewPropertyAccessExpression(descriptorName.Clone(tx.Factory()), nil /*questionDotToken*/, tx.Factory().NewIdentifier("set"), ast.NodeFlagsNone),
+				tx.Factory().NewThisExpression(),
+				[]*ast.Node{tx.Factory().NewIdentifier("value")},
+			)),
+		}), false /*multiLine*/),
+	)
+}
+
+func (tx *esDecoratorTransformer) newThisPropertyAccess(name *ast.PrivateIdentifierNode) *ast.Expression {
+	return tx.Factory().NewPropertyAccessExpression(tx.Factory().NewThisExpression(), nil /*questionDotToken*/, name.Clone(tx.Factory()), ast.NodeFlagsNone)
+}
+
+func (tx *esDecoratorTransformer) newValueParameter() *ast.Node {
+	return tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.Factory().NewIdentifier("value"), nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+}
+
+// Returns the modifiers for which keep returns true, reusing the original list when nothing is removed.
+func (tx *esDecoratorTransformer) filterModifiers(modifiers *ast.ModifierList, keep func(modifier *ast.Node) bool) *ast.ModifierList {
+	if modifiers == nil {
+		return nil
+	}
+	var nodes []*ast.Node
+	for _, modifier := range modifiers.Nodes {
+		if keep(modifier) {
+			nodes = append(nodes, modifier)
+		}
+	}
+	if len(nodes) == len(modifiers.Nodes) {
+		return modifiers
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	list := tx.Factory().NewModifierList(nodes)
+	list.Loc = modifiers.Loc
+	return list
+}
+
+func (tx *esDecoratorTransformer) staticModifiersOnly(modifiers *ast.ModifierList) *ast.ModifierList {
+	return tx.cloneModifiers(tx.filterModifiers(modifiers, func(modifier *ast.Node) bool { return modifier.Kind == ast.KindStaticKeyword }))
+}
+
+// Copies a modifier list so that it can be used by more than one generated declaration.
+func (tx *esDecoratorTransformer) cloneModifiers(modifiers *ast.ModifierList) *ast.ModifierList {
+	if modifiers == nil {
+		return nil
+	}
+	nodes := make([]*ast.Node, 0, len(modifiers.Nodes))
+	for _, modifier := range modifiers.Nodes {
+		nodes = append(nodes, tx.Factory().NewModifier(modifier.Kind))
+	}
+	return tx.Factory().NewModifierList(nodes)
+}
+
+func isMethodOrAccessor(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	}
+	return false
+}
+
+// Gets the range of a declaration excluding its decorators, for use as a source map range.
+func moveRangePastDecorators(node *ast.Node) core.TextRange {
+	var lastDecorator *ast.Node
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				lastDecorator = modifier
+			}
+		}
+	}
+	if lastDecorator != nil && !ast.PositionIsSynthesized(lastDecorator.End()) {
+		return core.NewTextRange(lastDecorator.End(), node.End())
+	}
+	return node.Loc
 }
 
-func (ch *esDecoratorTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+func classNameOrRangePastDecorators(node *ast.ClassLikeDeclaration) core.TextRange {
+	if name := node.Name(); name != nil {
+		return name.Loc
+	}
+	return moveRangePastDecorators(node)
 }
 
-func newESDecoratorTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &esDecoratorTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+// A simple inlinable expression is an expression which can be copied into multiple locations without risk of
+// repeating any side effects and whose value could not possibly change between any such locations.
+func isSimpleInlineableExpression(expression *ast.Expression) bool {
+	return !ast.IsIdentifier(expression) && transformers.IsSimpleCopiableExpression(expression)
 }
@@ -2,7 +2,6 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
-	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
@@ -85,7 +84,7 @@ func (ch *exponentiationTransformer) visitExponentiationExpression(node *ast.Bin
 	return result
 }
 
-func newExponentiationTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+func newExponentiationTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
 	tx := &exponentiationTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	return tx.NewTransformer(tx.visit, opt.Context)
 }
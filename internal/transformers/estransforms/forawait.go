@@ -2,19 +2,522 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
 type forawaitTransformer struct {
 	transformers.Transformer
+	scope forawaitScope
 }
 
-func (ch *forawaitTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+// Tracks the lexical state of the function currently being visited.
+type forawaitScope struct {
+	inAsyncGeneratorBody bool              // whether we are in the body of an async generator that is being lowered
+	inIteration          bool              // whether we are in the body of an iteration statement of the current function
+	superAccess          *superAccessScope // tracks `super` property accesses for the async generator method being lowered
 }
 
-func newforawaitTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+func newforawaitTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
 	tx := &forawaitTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	return tx.NewTransformer(tx.visit, opt.Context)
+}
+
+func (tx *forawaitTransformer) shouldVisit(node *ast.Node) bool {
+	if tx.scope.inAsyncGeneratorBody {
+		return true
+	}
+	facts := node.SubtreeFacts()
+	if facts&ast.SubtreeContainsForAwaitOrAsyncGenerator != 0 {
+		return true
+	}
+	return tx.scope.superAccess != nil && facts&ast.SubtreeContainsLexicalSuper != 0
+}
+
+func (tx *forawaitTransformer) visit(node *ast.Node) *ast.Node {
+	if !tx.shouldVisit(node) {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindAwaitExpression:
+		return tx.visitAwaitExpression(node.AsAwaitExpression())
+	case ast.KindYieldExpression:
+		return tx.visitYieldExpression(node.AsYieldExpression())
+	case ast.KindReturnStatement:
+		return tx.visitReturnStatement(node.AsReturnStatement())
+	case ast.KindLabeledStatement:
+		return tx.visitLabeledStatement(node.AsLabeledStatement())
+	case ast.KindForOfStatement:
+		return tx.visitForOfStatement(node.AsForInOrOfStatement(), nil /*outermostLabeledStatement*/)
+	case ast.KindForStatement, ast.KindForInStatement, ast.KindDoStatement, ast.KindWhileStatement:
+		return tx.visitIterationStatement(node)
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration:
+		return tx.visitFunctionLikeDeclaration(node)
+	case ast.KindArrowFunction:
+		return tx.visitArrowFunction(node)
+	case ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor, ast.KindClassDeclaration, ast.KindClassExpression:
+		return tx.visitNewScope(node)
+	case ast.KindPropertyAccessExpression:
+		return tx.visitPropertyAccessExpression(node.AsPropertyAccessExpression())
+	case ast.KindElementAccessExpression:
+		return tx.visitElementAccessExpression(node.AsElementAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *forawaitTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+	tx.scope = forawaitScope{}
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Creates `yield __await(x)` within a lowered async generator, or `await x` otherwise.
+func (tx *forawaitTransformer) createDownlevelAwait(expression *ast.Expression) *ast.Expression {
+	if tx.scope.inAsyncGeneratorBody {
+		return tx.Factory().NewYieldExpression(nil /*asteriskToken*/, tx.Factory().NewAwaitHelper(expression))
+	}
+	return tx.Factory().NewAwaitExpression(expression)
+}
+
+func (tx *forawaitTransformer) visitAwaitExpression(node *ast.AwaitExpression) *ast.Node {
+	if !tx.scope.inAsyncGeneratorBody {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	result := tx.createDownlevelAwait(tx.Visitor().VisitNode(node.Expression))
+	tx.EmitContext().SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+// Lowers `yield` within an async generator. A value yielded by an async generator is awaited first, so `yield x`
+// becomes `yield yield __await(x)`, while `yield* x` delegates to the async iterator of `x`:
+//
+//	yield __await(yield* __asyncDelegator(__asyncValues(x)))
+func (tx *forawaitTransformer) visitYieldExpression(node *ast.YieldExpression) *ast.Node {
+	if !tx.scope.inAsyncGeneratorBody {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	var result *ast.Node
+	if node.AsteriskToken != nil {
+		expression := tx.Visitor().VisitNode(node.Expression)
+		values := tx.Factory().NewAsyncValuesHelper(expression)
+		values.Loc = expression.Loc
+		delegator := tx.Factory().NewAsyncDelegatorHelper(values)
+		delegator.Loc = expression.Loc
+		result = tx.Factory().NewYieldExpression(
+			nil, /*asteriskToken*/
+			tx.Factory().NewAwaitHelper(tx.Factory().UpdateYieldExpression(node, node.AsteriskToken, delegator)),
+		)
+	} else {
+		expression := tx.Visitor().VisitNode(node.Expression)
+		if expression == nil {
+			expression = tx.Factory().NewVoidZeroExpression()
+		}
+		result = tx.Factory().NewYieldExpression(nil /*asteriskToken*/, tx.createDownlevelAwait(expression))
+	}
+	tx.EmitContext().SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+// Awaits the value returned from an async generator.
+func (tx *forawaitTransformer) visitReturnStatement(node *ast.ReturnStatement) *ast.Node {
+	if !tx.scope.inAsyncGeneratorBody {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	expression := tx.Visitor().VisitNode(node.Expression)
+	if expression == nil {
+		expression = tx.Factory().NewVoidZeroExpression()
+	}
+	return tx.Factory().UpdateReturnStatement(node, tx.createDownlevelAwait(expression))
+}
+
+// Lowers a labeled `for await` statement, moving the labels onto the `for` statement that replaces it so that
+// `break` and `continue` continue to target the loop.
+func (tx *forawaitTransformer) visitLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	statement := node.Statement
+	for ast.IsLabeledStatement(statement) {
+		statement = statement.AsLabeledStatement().Statement
+	}
+	if ast.IsForOfStatement(statement) && statement.AsForInOrOfStatement().AwaitModifier != nil {
+		return tx.visitForOfStatement(statement.AsForInOrOfStatement(), node)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *forawaitTransformer) visitIterationStatement(node *ast.Node) *ast.Node {
+	savedInIteration := tx.scope.inIteration
+	tx.scope.inIteration = true
+	defer func() { tx.scope.inIteration = savedInIteration }()
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *forawaitTransformer) visitForOfStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	if node.AwaitModifier == nil {
+		result := tx.visitIterationStatement(node.AsNode())
+		if outermostLabeledStatement != nil {
+			return tx.restoreEnclosingLabels(result, outermostLabeledStatement)
+		}
+		return result
+	}
+	return tx.transformForAwaitOfStatement(node, outermostLabeledStatement)
+}
+
+// Lowers a `for await` statement into a loop over the async iterator returned by the `__asyncValues` helper. For
+// example:
+//
+//	for await (const x of xs) {
+//	  f(x);
+//	}
+//
+// produces:
+//
+//	var _a, e_1, _b, _c;
+//	try {
+//	  for (var _d = true, xs_1 = __asyncValues(xs), xs_1_1; xs_1_1 = await xs_1.next(), _a = xs_1_1.done, !_a; _d = true) {
+//	    _c = xs_1_1.value;
+//	    _d = false;
+//	    const x = _c;
+//	    f(x);
+//	  }
+//	}
+//	catch (e_1_1) { e_1 = { error: e_1_1 }; }
+//	finally {
+//	  try {
+//	    if (!_d && !_a && (_b = xs_1.return)) await _b.call(xs_1);
+//	  }
+//	  finally { if (e_1) throw e_1.error; }
+//	}
+func (tx *forawaitTransformer) transformForAwaitOfStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	factory := tx.Factory()
+	expression := tx.Visitor().VisitNode(node.Expression)
+	var iterator *ast.IdentifierNode
+	var result *ast.IdentifierNode
+	if ast.IsIdentifier(expression) {
+		iterator = factory.NewGeneratedNameForNode(expression)
+		result = factory.NewGeneratedNameForNode(iterator)
+	} else {
+		iterator = factory.NewTempVariable()
+		result = factory.NewTempVariable()
+	}
+	nonUserCode := factory.NewTempVariable()
+	done := factory.NewTempVariable()
+	errorRecord := factory.NewUniqueName("e")
+	catchVariable := factory.NewGeneratedNameForNode(errorRecord)
+	returnMethod := factory.NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(done)
+	tx.EmitContext().AddVariableDeclaration(errorRecord)
+	tx.EmitContext().AddVariableDeclaration(returnMethod)
+
+	var initializer *ast.Expression = factory.NewAsyncValuesHelper(expression)
+	initializer.Loc = node.Expression.Loc
+	if tx.scope.inIteration {
+		// If we are enclosed in an outer loop, ensure we reset the error record for each iteration.
+		initializer = factory.InlineExpressions([]*ast.Expression{
+			factory.NewAssignmentExpression(errorRecord.Clone(factory), factory.NewVoidZeroExpression()),
+			initializer,
+		})
+	}
+
+	iteratorDeclaration := factory.NewVariableDeclaration(iterator, nil /*exclamationToken*/, nil /*typeNode*/, initializer)
+	iteratorDeclaration.Loc = node.Expression.Loc
+	declarationList := factory.NewVariableDeclarationList(ast.NodeFlagsNone, factory.NewNodeList([]*ast.Node{
+		factory.NewVariableDeclaration(nonUserCode, nil /*exclamationToken*/, nil /*typeNode*/, factory.NewTrueExpression()),
+		iteratorDeclaration,
+		factory.NewVariableDeclaration(result, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/),
+	}))
+	declarationList.Loc = node.Expression.Loc
+	tx.EmitContext().AddEmitFlags(declarationList, printer.EFNoHoisting)
+
+	callNext := factory.NewCallExpression(
+		factory.NewPropertyAccessExpression(iterator.Clone(factory), nil /*questionDotToken*/, factory.NewIdentifier("next"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		factory.NewNodeList(nil),
+		ast.NodeFlagsNone,
+	)
+	condition := factory.InlineExpressions([]*ast.Expression{
+		factory.NewAssignmentExpression(result.Clone(factory), tx.createDownlevelAwait(callNext)),
+		factory.NewAssignmentExpression(done.Clone(factory), factory.NewPropertyAccessExpression(result.Clone(factory), nil /*questionDotToken*/, factory.NewIdentifier("done"), ast.NodeFlagsNone)),
+		factory.NewPrefixUnaryExpression(ast.KindExclamationToken, done.Clone(factory)),
+	})
+	incrementor := factory.NewAssignmentExpression(nonUserCode.Clone(factory), factory.NewTrueExpression())
+	value := factory.NewPropertyAccessExpression(result.Clone(factory), nil /*questionDotToken*/, factory.NewIdentifier("value"), ast.NodeFlagsNone)
+
+	forStatement := factory.NewForStatement(declarationList, condition, incrementor, tx.convertForOfStatementHead(node, value, nonUserCode))
+	tx.EmitContext().SetOriginal(forStatement, node.AsNode())
+	tx.EmitContext().AddEmitFlags(forStatement, printer.EFNoTokenTrailingSourceMaps)
+	forStatement.Loc = node.Loc
+
+	var loop *ast.Statement = forStatement
+	if outermostLabeledStatement != nil {
+		loop = tx.restoreEnclosingLabels(forStatement, outermostLabeledStatement)
+	}
+
+	// catch (e_1_1) { e_1 = { error: e_1_1 }; }
+	catchBlock := factory.NewBlock(factory.NewNodeList([]*ast.Statement{
+		factory.NewExpressionStatement(factory.NewAssignmentExpression(
+			errorRecord.Clone(factory),
+			factory.NewObjectLiteralExpression(factory.NewNodeList([]*ast.Node{
+				factory.NewPropertyAssignment(nil /*modifiers*/, factory.NewIdentifier("error"), nil /*postfixToken*/, nil /*typeNode*/, catchVariable.Clone(factory)),
+			}), false /*multiLine*/),
+		)),
+	}), false /*multiLine*/)
+	tx.EmitContext().AddEmitFlags(catchBlock, printer.EFSingleLine)
+	catchClause := factory.NewCatchClause(
+		factory.NewVariableDeclaration(catchVariable, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/),
+		catchBlock,
+	)
+
+	// if (!_d && !_a && (_b = xs_1.return)) await _b.call(xs_1);
+	returnIfStatement := factory.NewIfStatement(
+		factory.NewBinaryExpression(
+			nil, /*modifiers*/
+			factory.NewBinaryExpression(
+				nil, /*modifiers*/
+				factory.NewPrefixUnaryExpression(ast.KindExclamationToken, nonUserCode.Clone(factory)),
+				nil, /*typeNode*/
+				factory.NewToken(ast.KindAmpersandAmpersandToken),
+				factory.NewPrefixUnaryExpression(ast.KindExclamationToken, done.Clone(factory)),
+			),
+			nil, /*typeNode*/
+			factory.NewToken(ast.KindAmpersandAmpersandToken),
+			factory.NewAssignmentExpression(
+				returnMethod,
+				factory.NewPropertyAccessExpression(iterator.Clone(factory), nil /*questionDotToken*/, factory.NewIdentifier("return"), ast.NodeFlagsNone),
+			),
+		),
+		factory.NewExpressionStatement(tx.createDownlevelAwait(factory.NewFunctionCallCall(returnMethod.Clone(factory), iterator.Clone(factory), nil /*argumentsList*/))),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().AddEmitFlags(returnIfStatement, printer.EFSingleLine)
+
+	// finally { if (e_1) throw e_1.error; }
+	throwIfStatement := factory.NewIfStatement(
+		errorRecord.Clone(factory),
+		factory.NewThrowStatement(factory.NewPropertyAccessExpression(errorRecord.Clone(factory), nil /*questionDotToken*/, factory.NewIdentifier("error"), ast.NodeFlagsNone)),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().AddEmitFlags(throwIfStatement, printer.EFSingleLine)
+	innerFinallyBlock := factory.NewBlock(factory.NewNodeList([]*ast.Statement{throwIfStatement}), false /*multiLine*/)
+	tx.EmitContext().AddEmitFlags(innerFinallyBlock, printer.EFSingleLine)
+
+	finallyBlock := factory.NewBlock(factory.NewNodeList([]*ast.Statement{
+		factory.NewTryStatement(
+			factory.NewBlock(factory.NewNodeList([]*ast.Statement{returnIfStatement}), true /*multiLine*/),
+			nil, /*catchClause*/
+			innerFinallyBlock,
+		),
+	}), true /*multiLine*/)
+
+	return factory.NewTryStatement(
+		factory.NewBlock(factory.NewNodeList([]*ast.Statement{loop}), true /*multiLine*/),
+		catchClause,
+		finallyBlock,
+	)
+}
+
+// Creates the body of the loop that replaces a `for await` statement, which binds the iterated value to the
+// initializer of the original statement before evaluating its body.
+func (tx *forawaitTransformer) convertForOfStatementHead(node *ast.ForInOrOfStatement, boundValue *ast.Expression, nonUserCode *ast.IdentifierNode) *ast.Node {
+	factory := tx.Factory()
+	value := factory.NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(value)
+
+	iteratorValueStatement := factory.NewExpressionStatement(factory.NewAssignmentExpression(value, boundValue))
+	tx.EmitContext().SetSourceMapRange(iteratorValueStatement, node.Expression.Loc)
+	exitNonUserCodeStatement := factory.NewExpressionStatement(factory.NewAssignmentExpression(nonUserCode.Clone(factory), factory.NewFalseExpression()))
+	tx.EmitContext().SetSourceMapRange(exitNonUserCodeStatement, node.Expression.Loc)
+
+	var binding *ast.Statement
+	if ast.IsVariableDeclarationList(node.Initializer) {
+		declarationList := node.Initializer.AsVariableDeclarationList()
+		binding = factory.NewVariableStatement(nil /*modifiers*/, factory.UpdateVariableDeclarationList(declarationList, factory.NewNodeList([]*ast.Node{
+			factory.NewVariableDeclaration(declarationList.Declarations.Nodes[0].Name(), nil /*exclamationToken*/, nil /*typeNode*/, value.Clone(factory)),
+		})))
+	} else {
+		binding = factory.NewExpressionStatement(factory.NewAssignmentExpression(node.Initializer, value.Clone(factory)))
+	}
+	binding.Loc = node.Initializer.Loc
+
+	statements := []*ast.Statement{iteratorValueStatement, exitNonUserCodeStatement, tx.Visitor().VisitNode(binding)}
+
+	savedInIteration := tx.scope.inIteration
+	tx.scope.inIteration = true
+	statement := tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor())
+	tx.scope.inIteration = savedInIteration
+
+	if ast.IsBlock(statement) {
+		block := statement.AsBlock()
+		statements = append(statements, block.Statements.Nodes...)
+		statementList := factory.NewNodeList(statements)
+		statementList.Loc = block.Statements.Loc
+		body := factory.NewBlock(statementList, true /*multiLine*/)
+		body.Loc = statement.Loc
+		return body
+	}
+	statements = append(statements, statement)
+	return factory.NewBlock(factory.NewNodeList(statements), true /*multiLine*/)
+}
+
+// Re-applies the labels of `outermostLabeledStatement` to the statement that replaces its innermost statement.
+func (tx *forawaitTransformer) restoreEnclosingLabels(node *ast.Statement, outermostLabeledStatement *ast.LabeledStatement) *ast.Statement {
+	if outermostLabeledStatement == nil {
+		return node
+	}
+	statement := node
+	if inner := outermostLabeledStatement.Statement; ast.IsLabeledStatement(inner) {
+		statement = tx.restoreEnclosingLabels(node, inner.AsLabeledStatement())
+	}
+	return tx.Factory().UpdateLabeledStatement(outermostLabeledStatement, outermostLabeledStatement.Label, statement)
+}
+
+// Visits a function, method, or function expression, lowering it if it is an async generator.
+func (tx *forawaitTransformer) visitFunctionLikeDeclaration(node *ast.Node) *ast.Node {
+	saved := tx.scope
+	defer func() { tx.scope = saved }()
+	tx.scope = forawaitScope{}
+
+	if !isAsyncGeneratorFunction(node) {
+		return tx.Visitor().VisitEachChild(node)
+	}
+
+	modifiers := transformers.ExtractModifiers(tx.EmitContext(), tx.Visitor().VisitModifiers(node.Modifiers()), ^ast.ModifierFlagsAsync)
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	if ast.IsMethodDeclaration(node) {
+		if flags := getSuperAccessFlags(node, isAsyncGeneratorFunction); flags != superAccessFlagsNone {
+			tx.scope.superAccess = &superAccessScope{flags: flags}
+		}
+	}
+	body := tx.transformAsyncGeneratorFunctionBody(node)
+	if tx.scope.superAccess != nil {
+		body = tx.scope.superAccess.addDeclarations(tx.EmitContext(), body)
+	}
+
+	switch node.Kind {
+	case ast.KindFunctionDeclaration:
+		return tx.Factory().UpdateFunctionDeclaration(node.AsFunctionDeclaration(), modifiers, nil /*asteriskToken*/, node.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	case ast.KindFunctionExpression:
+		return tx.Factory().UpdateFunctionExpression(node.AsFunctionExpression(), modifiers, nil /*asteriskToken*/, node.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	default:
+		n := node.AsMethodDeclaration()
+		return tx.Factory().UpdateMethodDeclaration(n, modifiers, nil /*asteriskToken*/, tx.Visitor().VisitNode(n.Name()), nil /*postfixToken*/, nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, body)
+	}
+}
+
+// Lowers the body of an async generator into a generator function driven by the `__asyncGenerator` helper. For
+// example:
+//
+//	async function* g() {
+//	  yield await f();
+//	}
+//
+// produces:
+//
+//	function g() {
+//	  return __asyncGenerator(this, arguments, function* g_1() {
+//	    yield yield __await(yield __await(f()));
+//	  });
+//	}
+func (tx *forawaitTransformer) transformAsyncGeneratorFunctionBody(node *ast.Node) *ast.Node {
+	factory := tx.Factory()
+	body := node.Body()
+	prologue, rest := factory.SplitStandardPrologue(body.AsBlock().Statements.Nodes)
+
+	tx.scope.inAsyncGeneratorBody = true
+	tx.EmitContext().StartVariableEnvironment()
+	innerStatements := core.FirstResult(tx.Visitor().VisitSlice(rest))
+	innerStatements = tx.EmitContext().EndAndMergeVariableEnvironment(innerStatements)
+	tx.scope.inAsyncGeneratorBody = false
+
+	innerBody := factory.NewBlock(factory.NewNodeList(innerStatements), true /*multiLine*/)
+	innerBody.Loc = body.Loc
+
+	var name *ast.IdentifierNode
+	if node.Name() != nil && ast.IsIdentifier(node.Name()) {
+		name = factory.NewGeneratedNameForNode(node.Name())
+	}
+	generator := factory.NewFunctionExpression(
+		nil, /*modifiers*/
+		factory.NewToken(ast.KindAsteriskToken),
+		name,
+		nil, /*typeParameters*/
+		factory.NewNodeList(nil),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		innerBody,
+	)
+
+	statements := append([]*ast.Statement{}, prologue...)
+	statements = append(statements, factory.NewReturnStatement(factory.NewAsyncGeneratorHelper(generator, true /*hasLexicalThis*/)))
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+	outerBody := factory.NewBlock(factory.NewNodeList(statements), true /*multiLine*/)
+	outerBody.Loc = body.Loc
+	return outerBody
+}
+
+func (tx *forawaitTransformer) visitArrowFunction(node *ast.Node) *ast.Node {
+	// Arrow functions cannot be generators and have their own `await` and `return`, but inherit `super` from their
+	// containing function.
+	saved := tx.scope
+	defer func() { tx.scope = saved }()
+	tx.scope = forawaitScope{superAccess: saved.superAccess}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+// Visits a class or a function-like declaration that can never be an async generator, which introduces a new `this`
+// binding and a new `super` home object.
+func (tx *forawaitTransformer) visitNewScope(node *ast.Node) *ast.Node {
+	saved := tx.scope
+	defer func() { tx.scope = saved }()
+	tx.scope = forawaitScope{}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *forawaitTransformer) isSubstitutingSuper() bool {
+	return tx.scope.superAccess != nil
+}
+
+// Replaces `super.x` with `_super.x` in the body of a lowered async generator method, where `super` is not available.
+func (tx *forawaitTransformer) visitPropertyAccessExpression(node *ast.PropertyAccessExpression) *ast.Node {
+	if tx.isSubstitutingSuper() && node.Expression.Kind == ast.KindSuperKeyword {
+		return tx.scope.superAccess.createSuperPropertyAccess(tx.EmitContext(), node)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Replaces `super[x]` with `_superIndex(x)` in the body of a lowered async generator method, where `super` is not
+// available.
+func (tx *forawaitTransformer) visitElementAccessExpression(node *ast.ElementAccessExpression) *ast.Node {
+	if tx.isSubstitutingSuper() && node.Expression.Kind == ast.KindSuperKeyword {
+		return tx.scope.superAccess.createSuperElementAccess(tx.EmitContext(), node, tx.Visitor().VisitNode(node.ArgumentExpression))
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Replaces `super.x(...)` with `_super.x.call(this, ...)` in the body of a lowered async generator method.
+func (tx *forawaitTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if tx.isSubstitutingSuper() && isSuperProperty(node.Expression) && node.QuestionDotToken == nil {
+		target := tx.Visitor().VisitNode(node.Expression)
+		arguments := tx.Visitor().VisitNodes(node.Arguments)
+		result := tx.Factory().NewFunctionCallCall(target, tx.Factory().NewThisExpression(), arguments.Nodes)
+		tx.EmitContext().SetOriginal(result, node.AsNode())
+		result.Loc = node.Loc
+		return result
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func isAsyncGeneratorFunction(node *ast.Node) bool {
+	return ast.IsFunctionLike(node) && ast.HasSyntacticModifier(node, ast.ModifierFlagsAsync) && isGeneratorFunction(node)
 }
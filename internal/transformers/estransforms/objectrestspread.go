@@ -2,19 +2,230 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
-	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
 type objectRestSpreadTransformer struct {
 	transformers.Transformer
+	compilerOptions *core.CompilerOptions
 }
 
 func (ch *objectRestSpreadTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	if node.SubtreeFacts()&ast.SubtreeContainsESObjectRestOrSpread == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return ch.visitSourceFile(node.AsSourceFile())
+	case ast.KindObjectLiteralExpression:
+		return ch.visitObjectLiteralExpression(node.AsObjectLiteralExpression())
+	case ast.KindExpressionStatement:
+		return ch.visitExpressionStatement(node.AsExpressionStatement())
+	case ast.KindBinaryExpression:
+		return ch.visitBinaryExpression(node.AsBinaryExpression(), false /*expressionResultIsUnused*/)
+	case ast.KindVariableDeclarationList:
+		return ch.visitVariableDeclarationList(node.AsVariableDeclarationList())
+	case ast.KindForOfStatement:
+		return ch.visitForOfStatement(node.AsForInOrOfStatement())
+	case ast.KindCatchClause:
+		return ch.visitCatchClause(node.AsCatchClause())
+	case ast.KindParameter:
+		return ch.visitParameter(node.AsParameterDeclaration())
+	default:
+		return ch.Visitor().VisitEachChild(node)
+	}
 }
 
-func newObjectRestSpreadTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &objectRestSpreadTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+func (ch *objectRestSpreadTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+	visited := ch.Visitor().VisitEachChild(node.AsNode())
+	ch.EmitContext().AddEmitHelper(visited, ch.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Lowers an object literal containing spread assignments into calls to `Object.assign`. For example:
+//
+//	const o = { a, ...b, c };
+//
+// produces:
+//
+//	const o = Object.assign(Object.assign({ a }, b), { c });
+func (ch *objectRestSpreadTransformer) visitObjectLiteralExpression(node *ast.ObjectLiteralExpression) *ast.Node {
+	if !core.Some(node.Properties.Nodes, ast.IsSpreadAssignment) {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// Split the properties into runs of ordinary properties, each of which becomes its own object literal, separated
+	// by the expressions of the spread assignments.
+	var objects []*ast.Expression
+	var chunk []*ast.Node
+	for _, property := range node.Properties.Nodes {
+		if ast.IsSpreadAssignment(property) {
+			if len(chunk) > 0 {
+				objects = append(objects, ch.Factory().NewObjectLiteralExpression(ch.Factory().NewNodeList(chunk), node.MultiLine))
+				chunk = nil
+			}
+			objects = append(objects, ch.Visitor().VisitNode(property.Expression()))
+		} else {
+			chunk = append(chunk, ch.Visitor().VisitNode(property))
+		}
+	}
+	if len(chunk) > 0 {
+		objects = append(objects, ch.Factory().NewObjectLiteralExpression(ch.Factory().NewNodeList(chunk), node.MultiLine))
+	}
+
+	// The first argument to `Object.assign` is the object that receives the properties, so it must be a fresh object.
+	if !ast.IsObjectLiteralExpression(objects[0]) {
+		objects = append([]*ast.Expression{ch.Factory().NewObjectLiteralExpression(ch.Factory().NewNodeList(nil), false /*multiLine*/)}, objects...)
+	}
+
+	target := ch.compilerOptions.GetEmitScriptTarget()
+	expression := objects[0]
+	for _, object := range objects[1:] {
+		expression = ch.Factory().NewAssignHelper([]*ast.Expression{expression, object}, target)
+	}
+	ch.EmitContext().SetOriginal(expression, node.AsNode())
+	expression.Loc = node.Loc
+	return expression
+}
+
+func (ch *objectRestSpreadTransformer) visitExpressionStatement(node *ast.ExpressionStatement) *ast.Node {
+	expression := ast.SkipParentheses(node.Expression)
+	if ast.IsBinaryExpression(expression) {
+		return ch.Factory().UpdateExpressionStatement(node, ch.visitBinaryExpression(expression.AsBinaryExpression(), true /*expressionResultIsUnused*/))
+	}
+	return ch.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (ch *objectRestSpreadTransformer) visitBinaryExpression(node *ast.BinaryExpression, expressionResultIsUnused bool) *ast.Node {
+	if ast.IsDestructuringAssignment(node.AsNode()) && containsObjectRestElement(node.Left) {
+		return flattenObjectRestAssignment(ch.EmitContext(), ch.Visitor(), node, !expressionResultIsUnused)
+	}
+	return ch.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (ch *objectRestSpreadTransformer) visitVariableDeclarationList(node *ast.VariableDeclarationList) *ast.Node {
+	var declarations []*ast.Node
+	for _, declaration := range node.Declarations.Nodes {
+		if containsObjectRestElement(declaration.Name()) {
+			declarations = append(declarations, flattenObjectRestBinding(ch.EmitContext(), ch.Visitor(), declaration, nil /*value*/, false /*skipInitializer*/)...)
+		} else {
+			declarations = append(declarations, ch.Visitor().VisitNode(declaration))
+		}
+	}
+	declarationList := ch.Factory().NewNodeList(declarations)
+	declarationList.Loc = node.Declarations.Loc
+	return ch.Factory().UpdateVariableDeclarationList(node, declarationList)
+}
+
+// Moves a `for..of` binding or assignment pattern containing an object rest into the body of the loop, where it
+// can be flattened. For example:
+//
+//	for (const { a, ...rest } of items) { }
+//
+// produces:
+//
+//	for (let _a of items) {
+//	  const { a } = _a, rest = __rest(_a, ["a"]);
+//	}
+func (ch *objectRestSpreadTransformer) visitForOfStatement(node *ast.ForInOrOfStatement) *ast.Node {
+	initializer := ast.SkipParentheses(node.Initializer)
+	var binding *ast.Statement
+	temp := ch.Factory().NewTempVariable()
+	if ast.IsVariableDeclarationList(initializer) {
+		declaration := initializer.AsVariableDeclarationList().Declarations.Nodes[0]
+		if containsObjectRestElement(declaration.Name()) {
+			binding = ch.Factory().NewVariableStatement(nil /*modifiers*/, ch.Factory().NewVariableDeclarationList(
+				initializer.Flags&ast.NodeFlagsBlockScoped,
+				ch.Factory().NewNodeList([]*ast.Node{
+					ch.Factory().NewVariableDeclaration(declaration.Name(), nil /*exclamationToken*/, nil /*typeNode*/, temp.Clone(ch.Factory())),
+				}),
+			))
+			binding.Loc = initializer.Loc
+		}
+	} else if containsObjectRestElement(initializer) {
+		binding = ch.Factory().NewExpressionStatement(ch.Factory().NewAssignmentExpression(initializer, temp.Clone(ch.Factory())))
+		binding.Loc = initializer.Loc
+	}
+	if binding == nil {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	statements := []*ast.Statement{binding}
+	if ast.IsBlock(node.Statement) {
+		statements = append(statements, node.Statement.AsBlock().Statements.Nodes...)
+	} else {
+		statements = append(statements, node.Statement)
+	}
+	body := ch.Factory().NewBlock(ch.Factory().NewNodeList(statements), true /*multiLine*/)
+	body.Loc = node.Statement.Loc
+
+	declarationList := ch.Factory().NewVariableDeclarationList(
+		ast.NodeFlagsLet,
+		ch.Factory().NewNodeList([]*ast.Node{
+			ch.Factory().NewVariableDeclaration(temp, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/),
+		}),
+	)
+	declarationList.Loc = node.Initializer.Loc
+
+	updated := ch.Factory().UpdateForInOrOfStatement(node, node.AwaitModifier, declarationList, node.Expression, body)
+	return ch.Visitor().VisitEachChild(updated)
+}
+
+// Replaces a catch clause binding pattern containing an object rest with a generated name, which is destructured
+// at the start of the catch block.
+func (ch *objectRestSpreadTransformer) visitCatchClause(node *ast.CatchClause) *ast.Node {
+	if node.VariableDeclaration == nil || !containsObjectRestElement(node.VariableDeclaration.Name()) {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+	name := ch.Factory().NewGeneratedNameForNode(node.VariableDeclaration.Name())
+	declarations := flattenObjectRestBinding(ch.EmitContext(), ch.Visitor(), node.VariableDeclaration, name.Clone(ch.Factory()), true /*skipInitializer*/)
+	statement := ch.Factory().NewVariableStatement(nil /*modifiers*/, ch.Factory().NewVariableDeclarationList(ast.NodeFlagsLet, ch.Factory().NewNodeList(declarations)))
+
+	block := ch.Visitor().VisitNode(node.Block)
+	statements := append([]*ast.Statement{statement}, block.AsBlock().Statements.Nodes...)
+	statementList := ch.Factory().NewNodeList(statements)
+	statementList.Loc = block.AsBlock().Statements.Loc
+	block = ch.Factory().UpdateBlock(block.AsBlock(), statementList)
+
+	variableDeclaration := ch.Factory().UpdateVariableDeclaration(node.VariableDeclaration.AsVariableDeclaration(), name, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/)
+	return ch.Factory().UpdateCatchClause(node, variableDeclaration, block)
+}
+
+// Replaces a parameter binding pattern containing an object rest with a generated name, which is destructured at
+// the start of the function body. For example:
+//
+//	function f({ a, ...rest }) { }
+//
+// produces:
+//
+//	function f(_a) {
+//	  var { a } = _a, rest = __rest(_a, ["a"]);
+//	}
+func (ch *objectRestSpreadTransformer) visitParameter(node *ast.ParameterDeclaration) *ast.Node {
+	if !containsObjectRestElement(node.Name()) {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+	declarations := flattenObjectRestBinding(ch.EmitContext(), ch.Visitor(), node.AsNode(), ch.Factory().NewGeneratedNameForNode(node.AsNode()), true /*skipInitializer*/)
+	ch.EmitContext().AddInitializationStatement(ch.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		ch.Factory().NewVariableDeclarationList(ast.NodeFlagsNone, ch.Factory().NewNodeList(declarations)),
+	))
+	return ch.Factory().UpdateParameterDeclaration(
+		node,
+		ch.Visitor().VisitModifiers(node.Modifiers()),
+		node.DotDotDotToken,
+		ch.Factory().NewGeneratedNameForNode(node.AsNode()),
+		nil, /*questionToken*/
+		nil, /*typeNode*/
+		ch.Visitor().VisitNode(node.Initializer),
+	)
+}
+
+func newObjectRestSpreadTransformer(opt *transformers.TransformOptions) *transformers.Transformer {
+	tx := &objectRestSpreadTransformer{compilerOptions: opt.CompilerOptions}
+	return tx.NewTransformer(tx.visit, opt.Context)
 }
@@ -2,6 +2,7 @@ package estransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/transformers"
 )
@@ -47,3 +48,242 @@ func createNotNullCondition(emitContext *printer.EmitContext, left *ast.Node, ri
 		),
 	)
 }
+
+type superAccessFlags int
+
+const (
+	superAccessFlagsNone              superAccessFlags = 0
+	superAccessFlagsHasPropertyAccess superAccessFlags = 1 << 0 // `super.x` occurs in a lowered function
+	superAccessFlagsHasElementAccess  superAccessFlags = 1 << 1 // `super[x]` occurs in a lowered function
+	superAccessFlagsHasAssignment     superAccessFlags = 1 << 2 // `super.x` or `super[x]` is assigned in a lowered function
+)
+
+// Determines how `super` is accessed from within functions nested in a method, accessor, or constructor that will be
+// lowered into generator functions, where `super` is no longer available.
+func getSuperAccessFlags(container *ast.Node, isLoweredFunction func(node *ast.Node) bool) superAccessFlags {
+	flags := superAccessFlagsNone
+	var visit func(node *ast.Node, inLoweredFunction bool)
+	visit = func(node *ast.Node, inLoweredFunction bool) {
+		if node.SubtreeFacts()&ast.SubtreeContainsLexicalSuper == 0 && node.Kind != ast.KindSuperKeyword {
+			return
+		}
+		switch {
+		case ast.IsArrowFunction(node):
+			inLoweredFunction = inLoweredFunction || isLoweredFunction(node)
+		case ast.IsFunctionLike(node), ast.IsClassLike(node):
+			// `super` is rebound in nested functions and classes.
+			return
+		case inLoweredFunction && ast.IsPropertyAccessExpression(node) && node.Expression().Kind == ast.KindSuperKeyword:
+			flags |= superAccessFlagsHasPropertyAccess
+			if ast.IsAssignmentTarget(node) {
+				flags |= superAccessFlagsHasAssignment
+			}
+		case inLoweredFunction && ast.IsElementAccessExpression(node) && node.Expression().Kind == ast.KindSuperKeyword:
+			flags |= superAccessFlagsHasElementAccess
+			if ast.IsAssignmentTarget(node) {
+				flags |= superAccessFlagsHasAssignment
+			}
+		}
+		node.ForEachChild(func(child *ast.Node) bool {
+			visit(child, inLoweredFunction)
+			return false
+		})
+	}
+	inLoweredFunction := isLoweredFunction(container)
+	for _, parameter := range container.Parameters() {
+		visit(parameter, inLoweredFunction)
+	}
+	if body := container.Body(); body != nil {
+		visit(body, inLoweredFunction)
+	}
+	return flags
+}
+
+// Tracks the `super` property accesses that are replaced within a lowered function so that the `_super` accessor
+// object and `_superIndex` helper can be added to the body of the enclosing method, accessor, or constructor.
+type superAccessScope struct {
+	flags             superAccessFlags
+	properties        collections.OrderedSet[string]
+	superBinding      *ast.IdentifierNode
+	superIndexBinding *ast.IdentifierNode
+}
+
+func (s *superAccessScope) getSuperBinding(emitContext *printer.EmitContext) *ast.IdentifierNode {
+	if s.superBinding == nil {
+		s.superBinding = emitContext.Factory.NewUniqueNameEx("_super", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+	}
+	return s.superBinding
+}
+
+func (s *superAccessScope) getSuperIndexBinding(emitContext *printer.EmitContext) *ast.IdentifierNode {
+	if s.superIndexBinding == nil {
+		s.superIndexBinding = emitContext.Factory.NewUniqueNameEx("_superIndex", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+	}
+	return s.superIndexBinding
+}
+
+// Creates `_super.x` as a replacement for `super.x`.
+func (s *superAccessScope) createSuperPropertyAccess(emitContext *printer.EmitContext, node *ast.PropertyAccessExpression) *ast.Expression {
+	name := node.Name()
+	s.properties.Add(name.Text())
+	result := emitContext.Factory.NewPropertyAccessExpression(s.getSuperBinding(emitContext).Clone(emitContext.Factory), nil /*questionDotToken*/, name, ast.NodeFlagsNone)
+	emitContext.SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+// Creates `_superIndex(x)` (or `_superIndex(x).value` when `super` is assigned) as a replacement for `super[x]`.
+func (s *superAccessScope) createSuperElementAccess(emitContext *printer.EmitContext, node *ast.ElementAccessExpression, argumentExpression *ast.Expression) *ast.Expression {
+	var result *ast.Expression = emitContext.Factory.NewCallExpression(
+		s.getSuperIndexBinding(emitContext).Clone(emitContext.Factory),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		emitContext.Factory.NewNodeList([]*ast.Node{argumentExpression}),
+		ast.NodeFlagsNone,
+	)
+	if s.flags&superAccessFlagsHasAssignment != 0 {
+		result = emitContext.Factory.NewPropertyAccessExpression(result, nil /*questionDotToken*/, emitContext.Factory.NewIdentifier("value"), ast.NodeFlagsNone)
+	}
+	emitContext.SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+// Adds the declarations needed by any replaced `super` accesses to the body of a method, accessor, or constructor:
+//
+//	const _super = Object.create(null, {
+//	  x: { get: () => super.x, set: v => super.x = v }
+//	});
+func (s *superAccessScope) addDeclarations(emitContext *printer.EmitContext, body *ast.Node) *ast.Node {
+	factory := emitContext.Factory
+	if s.superIndexBinding != nil {
+		if s.flags&superAccessFlagsHasAssignment != 0 {
+			emitContext.AddEmitHelper(body, printer.AdvancedAsyncSuperHelper)
+		} else {
+			emitContext.AddEmitHelper(body, printer.AsyncSuperHelper)
+		}
+	}
+	if s.superBinding == nil || s.properties.Size() == 0 {
+		return body
+	}
+
+	var accessors []*ast.Node
+	for name := range s.properties.Values() {
+		var descriptorProperties []*ast.Node
+		descriptorProperties = append(descriptorProperties, factory.NewPropertyAssignment(
+			nil, /*modifiers*/
+			factory.NewIdentifier("get"),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			factory.NewArrowFunction(
+				nil, /*modifiers*/
+				nil, /*typeParameters*/
+				factory.NewNodeList(nil),
+				nil, /*returnType*/
+				nil, /*fullSignature*/
+				factory.NewToken(ast.KindEqualsGreaterThanToken),
+				factory.NewPropertyAccessExpression(factory.NewKeywordExpression(ast.KindSuperKeyword), nil /*questionDotToken*/, factory.NewIdentifier(name), ast.NodeFlagsNone),
+			),
+		))
+		if s.flags&superAccessFlagsHasAssignment != 0 {
+			descriptorProperties = append(descriptorProperties, factory.NewPropertyAssignment(
+				nil, /*modifiers*/
+				factory.NewIdentifier("set"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				factory.NewArrowFunction(
+					nil, /*modifiers*/
+					nil, /*typeParameters*/
+					factory.NewNodeList([]*ast.Node{
+						factory.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, factory.NewIdentifier("v"), nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+					}),
+					nil, /*returnType*/
+					nil, /*fullSignature*/
+					factory.NewToken(ast.KindEqualsGreaterThanToken),
+					factory.NewAssignmentExpression(
+						factory.NewPropertyAccessExpression(factory.NewKeywordExpression(ast.KindSuperKeyword), nil /*questionDotToken*/, factory.NewIdentifier(name), ast.NodeFlagsNone),
+						factory.NewIdentifier("v"),
+					),
+				),
+			))
+		}
+		accessors = append(accessors, factory.NewPropertyAssignment(
+			nil, /*modifiers*/
+			factory.NewIdentifier(name),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			factory.NewObjectLiteralExpression(factory.NewNodeList(descriptorProperties), false /*multiLine*/),
+		))
+	}
+
+	declaration := factory.NewVariableStatement(
+		nil, /*modifiers*/
+		factory.NewVariableDeclarationList(
+			ast.NodeFlagsConst,
+			factory.NewNodeList([]*ast.Node{
+				factory.NewVariableDeclaration(
+					s.superBinding,
+					nil, /*exclamationToken*/
+					nil, /*type*/
+					factory.NewGlobalMethodCall("Object", "create", []*ast.Node{
+						factory.NewKeywordExpression(ast.KindNullKeyword),
+						factory.NewObjectLiteralExpression(factory.NewNodeList(accessors), true /*multiLine*/),
+					}),
+				),
+			}),
+		),
+	)
+	emitContext.AddEmitFlags(declaration, printer.EFCustomPrologue)
+
+	block := body.AsBlock()
+	prologue, rest := factory.SplitStandardPrologue(block.Statements.Nodes)
+	statements := make([]*ast.Statement, 0, len(block.Statements.Nodes)+1)
+	statements = append(statements, prologue...)
+	statements = append(statements, declaration)
+	statements = append(statements, rest...)
+	statementList := factory.NewNodeList(statements)
+	statementList.Loc = block.Statements.Loc
+	return factory.UpdateBlock(block, statementList)
+}
+
+// Indicates whether a node is `super.x` or `super[x]`.
+func isSuperProperty(node *ast.Node) bool {
+	return (ast.IsPropertyAccessExpression(node) || ast.IsElementAccessExpression(node)) && node.Expression().Kind == ast.KindSuperKeyword
+}
+
+// Gets the binary operator corresponding to a compound assignment operator, such as `+` for `+=`.
+func getNonAssignmentOperatorForCompoundAssignment(kind ast.Kind) ast.Kind {
+	switch kind {
+	case ast.KindPlusEqualsToken:
+		return ast.KindPlusToken
+	case ast.KindMinusEqualsToken:
+		return ast.KindMinusToken
+	case ast.KindAsteriskEqualsToken:
+		return ast.KindAsteriskToken
+	case ast.KindAsteriskAsteriskEqualsToken:
+		return ast.KindAsteriskAsteriskToken
+	case ast.KindSlashEqualsToken:
+		return ast.KindSlashToken
+	case ast.KindPercentEqualsToken:
+		return ast.KindPercentToken
+	case ast.KindLessThanLessThanEqualsToken:
+		return ast.KindLessThanLessThanToken
+	case ast.KindGreaterThanGreaterThanEqualsToken:
+		return ast.KindGreaterThanGreaterThanToken
+	case ast.KindGreaterThanGreaterThanGreaterThanEqualsToken:
+		return ast.KindGreaterThanGreaterThanGreaterThanToken
+	case ast.KindAmpersandEqualsToken:
+		return ast.KindAmpersandToken
+	case ast.KindBarEqualsToken:
+		return ast.KindBarToken
+	case ast.KindCaretEqualsToken:
+		return ast.KindCaretToken
+	case ast.KindBarBarEqualsToken:
+		return ast.KindBarBarToken
+	case ast.KindAmpersandAmpersandEqualsToken:
+		return ast.KindAmpersandAmpersandToken
+	case ast.KindQuestionQuestionEqualsToken:
+		return ast.KindQuestionQuestionToken
+	}
+	panic("Unhandled compound assignment operator: " + kind.String())
+}
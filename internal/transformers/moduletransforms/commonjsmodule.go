@@ -14,19 +14,20 @@ import (
 
 type CommonJSModuleTransformer struct {
 	transformers.Transformer
-	topLevelVisitor           *ast.NodeVisitor // visits statements at top level of a module
-	topLevelNestedVisitor     *ast.NodeVisitor // visits nested statements at top level of a module
-	discardedValueVisitor     *ast.NodeVisitor // visits expressions whose values would be discarded at runtime
-	assignmentPatternVisitor  *ast.NodeVisitor // visits assignment patterns in a destructuring assignment
-	compilerOptions           *core.CompilerOptions
-	resolver                  binder.ReferenceResolver
-	getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind
-	moduleKind                core.ModuleKind
-	languageVersion           core.ScriptTarget
-	currentSourceFile         *ast.SourceFile
-	currentModuleInfo         *externalModuleInfo
-	parentNode                *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
-	currentNode               *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+	topLevelVisitor            *ast.NodeVisitor // visits statements at top level of a module
+	topLevelNestedVisitor      *ast.NodeVisitor // visits nested statements at top level of a module
+	discardedValueVisitor      *ast.NodeVisitor // visits expressions whose values would be discarded at runtime
+	assignmentPatternVisitor   *ast.NodeVisitor // visits assignment patterns in a destructuring assignment
+	compilerOptions            *core.CompilerOptions
+	resolver                   binder.ReferenceResolver
+	getEmitModuleFormatOfFile  func(file ast.HasFileName) core.ModuleKind
+	moduleKind                 core.ModuleKind
+	languageVersion            core.ScriptTarget
+	currentSourceFile          *ast.SourceFile
+	currentModuleInfo          *externalModuleInfo
+	needUMDDynamicImportHelper bool
+	parentNode                 *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+	currentNode                *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
 }
 
 func NewCommonJSModuleTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver binder.ReferenceResolver, getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind) *transformers.Transformer {
@@ -235,7 +236,18 @@ func (tx *CommonJSModuleTransformer) visitSourceFile(node *ast.SourceFile) *ast.
 
 	tx.currentSourceFile = node
 	tx.currentModuleInfo = collectExternalModuleInfo(node, tx.compilerOptions, tx.EmitContext(), tx.resolver)
-	updated := tx.transformCommonJSModule(node)
+	tx.needUMDDynamicImportHelper = false
+
+	var updated *ast.Node
+	switch tx.moduleKind {
+	case core.ModuleKindAMD:
+		updated = tx.transformAMDModule(node)
+	case core.ModuleKindUMD:
+		updated = tx.transformUMDModule(node)
+	default:
+		updated = tx.transformCommonJSModule(node)
+	}
+
 	tx.currentSourceFile = nil
 	tx.currentModuleInfo = nil
 	return updated
@@ -288,6 +300,269 @@ func (tx *CommonJSModuleTransformer) createUnderscoreUnderscoreESModule() *ast.S
 }
 
 func (tx *CommonJSModuleTransformer) transformCommonJSModule(node *ast.SourceFile) *ast.Node {
+	result, _ := tx.transformModuleBody(node, false /*emitAsReturn*/)
+	return result.AsNode()
+}
+
+// Transforms a SourceFile into an AMD module of the form:
+//
+//	define(["require", "exports", "mod1", "mod2"], function (require, exports, mod1_1, mod2_1) { ... });
+func (tx *CommonJSModuleTransformer) transformAMDModule(node *ast.SourceFile) *ast.Node {
+	body, externalHelpersImportDeclaration := tx.transformModuleBody(node, true /*emitAsReturn*/)
+	dependencies := tx.collectAsynchronousDependencies(node, externalHelpersImportDeclaration, true /*includeNonAmdDependencies*/)
+
+	// An AMD define function has the following shape:
+	//
+	//     define(id?, dependencies?, factory);
+	//
+	// The location of the alias in the parameter list in the factory function needs to match the position of the
+	// module name in the dependency list. To ensure this is true in cases of modules with no aliases (e.g.,
+	// `import "module"`), we add modules without alias names to the end of the dependencies list.
+	var arguments []*ast.Expression
+	if moduleName := tryGetModuleNameFromFile(tx.Factory(), node, nil /*host*/, tx.compilerOptions); moduleName != nil {
+		arguments = append(arguments, moduleName)
+	}
+	arguments = append(arguments,
+		tx.createDependencyArray(dependencies),
+		tx.createModuleFactoryFunction(dependencies, body),
+	)
+
+	defineCall := tx.Factory().NewExpressionStatement(
+		tx.Factory().NewCallExpression(
+			tx.Factory().NewIdentifier("define"),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList(arguments),
+			ast.NodeFlagsNone,
+		),
+	)
+
+	statementList := tx.Factory().NewNodeList([]*ast.Statement{defineCall})
+	statementList.Loc = node.Statements.Loc
+	return tx.Factory().UpdateSourceFile(body, statementList, node.EndOfFileToken)
+}
+
+// Transforms a SourceFile into a UMD module of the form:
+//
+//	(function (factory) {
+//	    if (typeof module === "object" && typeof module.exports === "object") {
+//	        var v = factory(require, exports);
+//	        if (v !== undefined) module.exports = v;
+//	    }
+//	    else if (typeof define === "function" && define.amd) {
+//	        define(["require", "exports", "mod1"], factory);
+//	    }
+//	})(function (require, exports) { ... });
+func (tx *CommonJSModuleTransformer) transformUMDModule(node *ast.SourceFile) *ast.Node {
+	body, externalHelpersImportDeclaration := tx.transformModuleBody(node, true /*emitAsReturn*/)
+	dependencies := tx.collectAsynchronousDependencies(node, externalHelpersImportDeclaration, false /*includeNonAmdDependencies*/)
+
+	f := tx.Factory()
+	var defineArguments []*ast.Expression
+	if moduleName := tryGetModuleNameFromFile(f, node, nil /*host*/, tx.compilerOptions); moduleName != nil {
+		defineArguments = append(defineArguments, moduleName)
+	}
+	defineArguments = append(defineArguments, tx.createDependencyArray(dependencies), f.NewIdentifier("factory"))
+
+	setModuleExports := f.NewIfStatement(
+		f.NewStrictInequalityExpression(f.NewIdentifier("v"), f.NewIdentifier("undefined")),
+		f.NewExpressionStatement(
+			f.NewAssignmentExpression(
+				f.NewPropertyAccessExpression(f.NewIdentifier("module"), nil /*questionDotToken*/, f.NewIdentifier("exports"), ast.NodeFlagsNone),
+				f.NewIdentifier("v"),
+			),
+		),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(setModuleExports, printer.EFSingleLine)
+
+	umdHeader := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.ParameterDeclarationNode{tx.createParameter(f.NewIdentifier("factory"))}),
+		nil, /*type*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewIfStatement(
+				f.NewLogicalANDExpression(
+					f.NewTypeCheck(f.NewIdentifier("module"), "object"),
+					f.NewTypeCheck(f.NewPropertyAccessExpression(f.NewIdentifier("module"), nil /*questionDotToken*/, f.NewIdentifier("exports"), ast.NodeFlagsNone), "object"),
+				),
+				f.NewBlock(f.NewNodeList([]*ast.Statement{
+					f.NewVariableStatement(
+						nil, /*modifiers*/
+						f.NewVariableDeclarationList(
+							ast.NodeFlagsNone,
+							f.NewNodeList([]*ast.VariableDeclarationNode{
+								f.NewVariableDeclaration(
+									f.NewIdentifier("v"),
+									nil, /*exclamationToken*/
+									nil, /*type*/
+									f.NewCallExpression(
+										f.NewIdentifier("factory"),
+										nil, /*questionDotToken*/
+										nil, /*typeArguments*/
+										f.NewNodeList([]*ast.Expression{f.NewIdentifier("require"), f.NewIdentifier("exports")}),
+										ast.NodeFlagsNone,
+									),
+								),
+							}),
+						),
+					),
+					setModuleExports,
+				}), true /*multiLine*/),
+				f.NewIfStatement(
+					f.NewLogicalANDExpression(
+						f.NewTypeCheck(f.NewIdentifier("define"), "function"),
+						f.NewPropertyAccessExpression(f.NewIdentifier("define"), nil /*questionDotToken*/, f.NewIdentifier("amd"), ast.NodeFlagsNone),
+					),
+					f.NewBlock(f.NewNodeList([]*ast.Statement{
+						f.NewExpressionStatement(
+							f.NewCallExpression(
+								f.NewIdentifier("define"),
+								nil, /*questionDotToken*/
+								nil, /*typeArguments*/
+								f.NewNodeList(defineArguments),
+								ast.NodeFlagsNone,
+							),
+						),
+					}), true /*multiLine*/),
+					nil, /*elseStatement*/
+				),
+			),
+		}), true /*multiLine*/),
+	)
+
+	umdCall := f.NewExpressionStatement(
+		f.NewCallExpression(
+			f.NewParenthesizedExpression(umdHeader),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList([]*ast.Expression{tx.createModuleFactoryFunction(dependencies, body)}),
+			ast.NodeFlagsNone,
+		),
+	)
+
+	statementList := f.NewNodeList([]*ast.Statement{umdCall})
+	statementList.Loc = node.Statements.Loc
+	return f.UpdateSourceFile(body, statementList, node.EndOfFileToken)
+}
+
+// The dependencies of an AMD or UMD module.
+type asynchronousDependencies struct {
+	aliasedModuleNames   []*ast.Expression               // names of modules with a corresponding parameter in the factory function
+	unaliasedModuleNames []*ast.Expression               // names of modules with no corresponding parameter in the factory function
+	importAliasNames     []*ast.ParameterDeclarationNode // parameters of the factory function, in the same order as `aliasedModuleNames`
+}
+
+func (tx *CommonJSModuleTransformer) collectAsynchronousDependencies(node *ast.SourceFile, externalHelpersImportDeclaration *ast.Node, includeNonAmdDependencies bool) *asynchronousDependencies {
+	result := &asynchronousDependencies{}
+
+	// !!! /// <amd-dependency path="..." name="..." /> directives are not yet recorded by the parser
+
+	externalImports := tx.currentModuleInfo.externalImports
+	if externalHelpersImportDeclaration != nil {
+		externalImports = append([]*ast.Node{externalHelpersImportDeclaration}, externalImports...)
+	}
+
+	for _, importNode := range externalImports {
+		// Find the name of the external module
+		externalModuleName := getExternalModuleNameLiteral(tx.Factory(), importNode, node, nil /*host*/, nil /*resolver*/, tx.compilerOptions)
+
+		// It is possible that externalModuleName is nil if it is not a string literal. This can happen in the
+		// invalid import syntax, e.g. `import * from alias from 'someLib';`
+		if externalModuleName == nil {
+			continue
+		}
+		externalModuleName = rewriteModuleSpecifier(tx.EmitContext(), externalModuleName, tx.compilerOptions)
+
+		// Find the name of the module alias, if there is one
+		importAliasName := getLocalNameForExternalImport(tx.EmitContext(), importNode)
+		if includeNonAmdDependencies && importAliasName != nil {
+			result.aliasedModuleNames = append(result.aliasedModuleNames, externalModuleName)
+			result.importAliasNames = append(result.importAliasNames, tx.createParameter(importAliasName))
+		} else {
+			result.unaliasedModuleNames = append(result.unaliasedModuleNames, externalModuleName)
+		}
+	}
+
+	return result
+}
+
+// Creates the dependency array argument of an AMD `define` call:
+//
+//	["require", "exports", "mod1", "mod2", ...]
+func (tx *CommonJSModuleTransformer) createDependencyArray(dependencies *asynchronousDependencies) *ast.Expression {
+	elements := []*ast.Expression{
+		tx.Factory().NewStringLiteral("require"),
+		tx.Factory().NewStringLiteral("exports"),
+	}
+	elements = append(elements, dependencies.aliasedModuleNames...)
+	elements = append(elements, dependencies.unaliasedModuleNames...)
+	return tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(elements), false /*multiLine*/)
+}
+
+// Creates the factory function of an AMD or UMD module:
+//
+//	function (require, exports, mod1_1, mod2_1) { ... }
+func (tx *CommonJSModuleTransformer) createModuleFactoryFunction(dependencies *asynchronousDependencies, body *ast.SourceFile) *ast.Expression {
+	parameters := []*ast.ParameterDeclarationNode{
+		tx.createParameter(tx.Factory().NewIdentifier("require")),
+		tx.createParameter(tx.Factory().NewIdentifier("exports")),
+	}
+	parameters = append(parameters, dependencies.importAliasNames...)
+
+	block := tx.Factory().NewBlock(tx.Factory().NewNodeList(body.Statements.Nodes), true /*multiLine*/)
+	if tx.needUMDDynamicImportHelper {
+		tx.EmitContext().AddEmitHelper(block, printer.DynamicImportUMDHelper)
+	}
+
+	return tx.Factory().NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList(parameters),
+		nil, /*type*/
+		nil, /*fullSignature*/
+		block,
+	)
+}
+
+func (tx *CommonJSModuleTransformer) createParameter(name *ast.IdentifierNode) *ast.ParameterDeclarationNode {
+	return tx.Factory().NewParameterDeclaration(
+		nil, /*modifiers*/
+		nil, /*dotDotDotToken*/
+		name,
+		nil, /*questionToken*/
+		nil, /*type*/
+		nil, /*initializer*/
+	)
+}
+
+// Gets the statement that applies an import helper to an AMD dependency, e.g.:
+//
+//	mod_1 = __importDefault(mod_1);
+func (tx *CommonJSModuleTransformer) getAMDImportExpressionForImport(node *ast.Node /*ImportDeclaration | ImportEqualsDeclaration | ExportDeclaration*/) *ast.Statement {
+	if !ast.IsImportDeclaration(node) || getExternalModuleNameLiteral(tx.Factory(), node, tx.currentSourceFile, nil /*host*/, nil /*resolver*/, tx.compilerOptions) == nil {
+		return nil
+	}
+	name := getLocalNameForExternalImport(tx.EmitContext(), node)
+	if name == nil {
+		return nil
+	}
+	expr := tx.getHelperExpressionForImport(node.AsImportDeclaration(), name)
+	if expr == name {
+		return nil
+	}
+	return tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(name, expr))
+}
+
+// Transforms the statements of a module. For AMD and UMD modules, the result is a SourceFile whose statements are the
+// body of the module factory function, along with the synthesized import of the external helpers module, if any.
+func (tx *CommonJSModuleTransformer) transformModuleBody(node *ast.SourceFile, emitAsReturn bool) (*ast.SourceFile, *ast.Node) {
 	tx.EmitContext().StartVariableEnvironment()
 
 	// emit standard prologue directives (e.g. "use strict")
@@ -350,12 +625,22 @@ func (tx *CommonJSModuleTransformer) transformCommonJSModule(node *ast.SourceFil
 		statements = tx.appendExportsOfClassOrFunctionDeclaration(statements, f.AsNode())
 	}
 
+	// apply import helpers to the dependencies of an AMD module, e.g.:
+	//  mod_1 = __importDefault(mod_1);
+	if tx.moduleKind == core.ModuleKindAMD {
+		for _, importNode := range tx.currentModuleInfo.externalImports {
+			if statement := tx.getAMDImportExpressionForImport(importNode); statement != nil {
+				statements = append(statements, statement)
+			}
+		}
+	}
+
 	// visit the remaining statements in the source file
 	rest, _ = tx.topLevelVisitor.VisitSlice(rest)
 	statements = append(statements, rest...)
 
-	// emit `module.exports = ...` if needd
-	statements = tx.appendExportEqualsIfNeeded(statements)
+	// emit `module.exports = ...` (or `return ...` for AMD and UMD) if needed
+	statements = tx.appendExportEqualsIfNeeded(statements, emitAsReturn)
 
 	// merge temp variables into the statement list
 	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
@@ -371,34 +656,42 @@ func (tx *CommonJSModuleTransformer) transformCommonJSModule(node *ast.SourceFil
 		custom, rest := tx.Factory().SplitCustomPrologue(rest)
 		statements := slices.Clone(prologue)
 		statements = append(statements, custom...)
-		statements = append(statements, tx.topLevelVisitor.VisitNode(externalHelpersImportDeclaration))
+		if statement := tx.topLevelVisitor.VisitNode(externalHelpersImportDeclaration); statement != nil {
+			statements = append(statements, statement)
+		}
 		statements = append(statements, rest...)
 		statementList := tx.Factory().NewNodeList(statements)
 		statementList.Loc = result.Statements.Loc
 		result = tx.Factory().UpdateSourceFile(result, statementList, node.EndOfFileToken).AsSourceFile()
 	}
 
-	return result.AsNode()
+	return result, externalHelpersImportDeclaration
 }
 
 // Adds the down-level representation of `export=` to the statement list if one exists in the source file.
 //
-// - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
-func (tx *CommonJSModuleTransformer) appendExportEqualsIfNeeded(statements []*ast.Statement) []*ast.Statement {
+//   - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
+//   - The `emitAsReturn` parameter indicates whether to emit a `return` statement from the module factory function.
+func (tx *CommonJSModuleTransformer) appendExportEqualsIfNeeded(statements []*ast.Statement, emitAsReturn bool) []*ast.Statement {
 	if tx.currentModuleInfo.exportEquals != nil {
 		expressionResult := tx.Visitor().VisitNode(tx.currentModuleInfo.exportEquals.Expression)
 		if expressionResult != nil {
-			statement := tx.Factory().NewExpressionStatement(
-				tx.Factory().NewAssignmentExpression(
-					tx.Factory().NewPropertyAccessExpression(
-						tx.Factory().NewIdentifier("module"),
-						nil, /*questionDotToken*/
-						tx.Factory().NewIdentifier("exports"),
-						ast.NodeFlagsNone,
+			var statement *ast.Statement
+			if emitAsReturn {
+				statement = tx.Factory().NewReturnStatement(expressionResult)
+			} else {
+				statement = tx.Factory().NewExpressionStatement(
+					tx.Factory().NewAssignmentExpression(
+						tx.Factory().NewPropertyAccessExpression(
+							tx.Factory().NewIdentifier("module"),
+							nil, /*questionDotToken*/
+							tx.Factory().NewIdentifier("exports"),
+							ast.NodeFlagsNone,
+						),
+						expressionResult,
 					),
-					expressionResult,
-				),
-			)
+				)
+			}
 
 			tx.EmitContext().AssignCommentAndSourceMapRanges(statement, tx.currentModuleInfo.exportEquals.AsNode())
 			tx.EmitContext().AddEmitFlags(statement, printer.EFNoComments)
@@ -710,6 +1003,11 @@ func (tx *CommonJSModuleTransformer) getHelperExpressionForImport(node *ast.Impo
 }
 
 func (tx *CommonJSModuleTransformer) visitTopLevelImportDeclaration(node *ast.ImportDeclaration) *ast.Node {
+	if tx.moduleKind == core.ModuleKindAMD {
+		// AMD dependencies are passed as parameters to the module factory function.
+		return tx.visitTopLevelAMDImportDeclaration(node)
+	}
+
 	if node.ImportClause == nil {
 		// import "mod";
 		statement := tx.Factory().NewExpressionStatement(tx.createRequireCall(node.AsNode()))
@@ -772,6 +1070,33 @@ func (tx *CommonJSModuleTransformer) visitTopLevelImportDeclaration(node *ast.Im
 	return transformers.SingleOrMany(statements, tx.Factory())
 }
 
+func (tx *CommonJSModuleTransformer) visitTopLevelAMDImportDeclaration(node *ast.ImportDeclaration) *ast.Node {
+	var statements []*ast.Statement
+	namespaceDeclaration := ast.GetNamespaceDeclarationNode(node.AsNode())
+	if namespaceDeclaration != nil && ast.IsDefaultImport(node.AsNode()) {
+		// import d, * as n from "mod";
+		varStatement := tx.Factory().NewVariableStatement(
+			nil, /*modifiers*/
+			tx.Factory().NewVariableDeclarationList(
+				ast.NodeFlagsConst,
+				tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+					tx.Factory().NewVariableDeclaration(
+						namespaceDeclaration.Name().Clone(tx.Factory()),
+						nil, /*exclamationToken*/
+						nil, /*type*/
+						tx.Factory().NewGeneratedNameForNode(node.AsNode()),
+					),
+				}),
+			),
+		)
+		tx.EmitContext().SetOriginal(varStatement, node.AsNode())
+		tx.EmitContext().AssignCommentAndSourceMapRanges(varStatement, node.AsNode())
+		statements = append(statements, varStatement)
+	}
+	statements = tx.appendExportsOfImportDeclaration(statements, node)
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
 func (tx *CommonJSModuleTransformer) visitTopLevelImportEqualsDeclaration(node *ast.ImportEqualsDeclaration) *ast.Node {
 	if !ast.IsExternalModuleImportEqualsDeclaration(node.AsNode()) {
 		// import m = n;
@@ -779,7 +1104,23 @@ func (tx *CommonJSModuleTransformer) visitTopLevelImportEqualsDeclaration(node *
 	}
 
 	var statements []*ast.Statement
-	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+	if tx.moduleKind == core.ModuleKindAMD {
+		// AMD dependencies are passed as parameters to the module factory function.
+		if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+			// export import m = require("mod");
+			statement := tx.Factory().NewExpressionStatement(
+				tx.createExportExpression(
+					tx.Factory().GetExportName(node.AsNode()),
+					tx.Factory().GetLocalName(node.AsNode()),
+					nil,   /*location*/
+					false, /*liveBinding*/
+				),
+			)
+			tx.EmitContext().SetOriginal(statement, node.AsNode())
+			tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
+			statements = append(statements, statement)
+		}
+	} else if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
 		// export import m = require("mod");
 		statement := tx.Factory().NewExpressionStatement(
 			tx.createExportExpression(
@@ -829,23 +1170,25 @@ func (tx *CommonJSModuleTransformer) visitTopLevelExportDeclaration(node *ast.Ex
 	if node.ExportClause != nil && ast.IsNamedExports(node.ExportClause) {
 		// export { x, y } from "mod";
 		var statements []*ast.Statement
-		varStatement := tx.Factory().NewVariableStatement(
-			nil, /*modifiers*/
-			tx.Factory().NewVariableDeclarationList(
-				ast.NodeFlagsConst,
-				tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
-					tx.Factory().NewVariableDeclaration(
-						generatedName,
-						nil, /*exclamationToken*/
-						nil, /*type*/
-						tx.createRequireCall(node.AsNode()),
-					),
-				}),
-			),
-		)
-		tx.EmitContext().SetOriginal(varStatement, node.AsNode())
-		tx.EmitContext().AssignCommentAndSourceMapRanges(varStatement, node.AsNode())
-		statements = append(statements, varStatement)
+		if tx.moduleKind != core.ModuleKindAMD {
+			varStatement := tx.Factory().NewVariableStatement(
+				nil, /*modifiers*/
+				tx.Factory().NewVariableDeclarationList(
+					ast.NodeFlagsConst,
+					tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+						tx.Factory().NewVariableDeclaration(
+							generatedName,
+							nil, /*exclamationToken*/
+							nil, /*type*/
+							tx.createRequireCall(node.AsNode()),
+						),
+					}),
+				),
+			)
+			tx.EmitContext().SetOriginal(varStatement, node.AsNode())
+			tx.EmitContext().AssignCommentAndSourceMapRanges(varStatement, node.AsNode())
+			statements = append(statements, varStatement)
+		}
 
 		for _, specifier := range node.ExportClause.AsNamedExports().Elements.Nodes {
 			specifierName := specifier.PropertyNameOrName()
@@ -898,13 +1241,19 @@ func (tx *CommonJSModuleTransformer) visitTopLevelExportDeclaration(node *ast.Ex
 		} else {
 			exportName = node.ExportClause.Name().Clone(tx.Factory())
 		}
+		var exportValue *ast.Expression
+		switch {
+		case tx.moduleKind != core.ModuleKindAMD:
+			exportValue = tx.getHelperExpressionForExport(node, tx.createRequireCall(node.AsNode()))
+		case ast.IsStringLiteral(node.ExportClause.Name()) || ast.ModuleExportNameIsDefault(node.ExportClause.Name()):
+			exportValue = generatedName
+		default:
+			exportValue = tx.Factory().NewIdentifier(node.ExportClause.Name().Text())
+		}
 		statement := tx.Factory().NewExpressionStatement(
 			tx.createExportExpression(
 				exportName,
-				tx.getHelperExpressionForExport(
-					node,
-					tx.createRequireCall(node.AsNode()),
-				),
+				exportValue,
 				nil,   /*location*/
 				false, /*liveBinding*/
 			),
@@ -915,8 +1264,14 @@ func (tx *CommonJSModuleTransformer) visitTopLevelExportDeclaration(node *ast.Ex
 	}
 
 	// export * from "mod";
+	var moduleExpression *ast.Expression
+	if tx.moduleKind != core.ModuleKindAMD {
+		moduleExpression = tx.createRequireCall(node.AsNode())
+	} else {
+		moduleExpression = generatedName
+	}
 	statement := tx.Factory().NewExpressionStatement(
-		tx.Visitor().VisitNode(tx.Factory().NewExportStarHelper(tx.createRequireCall(node.AsNode()), tx.Factory().NewIdentifier("exports"))),
+		tx.Visitor().VisitNode(tx.Factory().NewExportStarHelper(moduleExpression, tx.Factory().NewIdentifier("exports"))),
 	)
 	tx.EmitContext().SetOriginal(statement, node.AsNode())
 	tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
@@ -1710,10 +2065,129 @@ func (tx *CommonJSModuleTransformer) visitImportCallExpression(node *ast.CallExp
 	} else {
 		argument = firstArgument
 	}
-	return tx.createImportCallExpressionCommonJS(argument)
+
+	switch tx.moduleKind {
+	case core.ModuleKindAMD:
+		return tx.createImportCallExpressionAMD(argument)
+	case core.ModuleKindUMD:
+		if argument == nil {
+			argument = tx.Factory().NewVoidZeroExpression()
+		}
+		return tx.createImportCallExpressionUMD(argument)
+	default:
+		return tx.createImportCallExpressionCommonJS(argument, false /*isInlineable*/)
+	}
+}
+
+func (tx *CommonJSModuleTransformer) createImportCallExpressionUMD(arg *ast.Expression) *ast.Expression {
+	// import(x)
+	// emit as
+	// __syncRequire
+	//     ? Promise.resolve().then(() => require(x)) /*CommonJS Require*/
+	//     : new Promise((resolve_1, reject_1) => { require([x], resolve_1, reject_1); }); /*AMD Require*/
+	//
+	// where `__syncRequire` is declared at the top of the module factory function.
+	tx.needUMDDynamicImportHelper = true
+	if transformers.IsSimpleCopiableExpression(arg) {
+		var argClone *ast.Expression
+		switch {
+		case transformers.IsGeneratedIdentifier(tx.EmitContext(), arg):
+			argClone = arg
+		case ast.IsStringLiteral(arg):
+			argClone = tx.Factory().NewStringLiteralFromNode(arg)
+		default:
+			argClone = arg.Clone(tx.Factory())
+			argClone.Loc = arg.Loc
+			tx.EmitContext().SetEmitFlags(argClone, printer.EFNoComments)
+		}
+		return tx.Factory().NewConditionalExpression(
+			tx.Factory().NewIdentifier("__syncRequire"),
+			tx.Factory().NewToken(ast.KindQuestionToken),
+			tx.createImportCallExpressionCommonJS(arg, false /*isInlineable*/),
+			tx.Factory().NewToken(ast.KindColonToken),
+			tx.createImportCallExpressionAMD(argClone),
+		)
+	}
+
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return tx.Factory().NewCommaExpression(
+		tx.Factory().NewAssignmentExpression(temp, arg),
+		tx.Factory().NewConditionalExpression(
+			tx.Factory().NewIdentifier("__syncRequire"),
+			tx.Factory().NewToken(ast.KindQuestionToken),
+			tx.createImportCallExpressionCommonJS(temp, true /*isInlineable*/),
+			tx.Factory().NewToken(ast.KindColonToken),
+			tx.createImportCallExpressionAMD(temp),
+		),
+	)
+}
+
+func (tx *CommonJSModuleTransformer) createImportCallExpressionAMD(arg *ast.Expression) *ast.Expression {
+	// import(x)
+	// emit as
+	// new Promise((resolve_1, reject_1) => { require([x], resolve_1, reject_1); }); /*AMD Require*/
+	resolve := tx.Factory().NewUniqueName("resolve")
+	reject := tx.Factory().NewUniqueName("reject")
+	if arg == nil {
+		arg = tx.Factory().NewOmittedExpression()
+	}
+
+	body := tx.Factory().NewBlock(
+		tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewExpressionStatement(
+				tx.Factory().NewCallExpression(
+					tx.Factory().NewIdentifier("require"),
+					nil, /*questionDotToken*/
+					nil, /*typeArguments*/
+					tx.Factory().NewNodeList([]*ast.Expression{
+						tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList([]*ast.Expression{arg}), false /*multiLine*/),
+						resolve,
+						reject,
+					}),
+					ast.NodeFlagsNone,
+				),
+			),
+		}),
+		false, /*multiLine*/
+	)
+
+	function := tx.Factory().NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{
+			tx.createParameter(resolve),
+			tx.createParameter(reject),
+		}),
+		nil, /*type*/
+		nil, /*fullSignature*/
+		tx.Factory().NewToken(ast.KindEqualsGreaterThanToken), /*equalsGreaterThanToken*/
+		body,
+	)
+
+	promise := tx.Factory().NewNewExpression(
+		tx.Factory().NewIdentifier("Promise"),
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList([]*ast.Expression{function}),
+	)
+	if tx.compilerOptions.GetESModuleInterop() {
+		return tx.Factory().NewCallExpression(
+			tx.Factory().NewPropertyAccessExpression(
+				promise,
+				nil, /*questionDotToken*/
+				tx.Factory().NewIdentifier("then"),
+				ast.NodeFlagsNone,
+			),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList([]*ast.Expression{tx.Factory().NewImportStarCallbackHelper()}),
+			ast.NodeFlagsNone,
+		)
+	}
+	return promise
 }
 
-func (tx *CommonJSModuleTransformer) createImportCallExpressionCommonJS(arg *ast.Expression) *ast.Expression {
+func (tx *CommonJSModuleTransformer) createImportCallExpressionCommonJS(arg *ast.Expression, isInlineable bool) *ast.Expression {
 	// import(x)
 	// emit as
 	// Promise.resolve(`${x}`).then((s) => require(s)) /*CommonJS Require*/
@@ -1722,7 +2196,7 @@ func (tx *CommonJSModuleTransformer) createImportCallExpressionCommonJS(arg *ast
 	// If the arg is not inlineable, we have to evaluate and ToString() it in the current scope
 	// Otherwise, we inline it in require() so that it's statically analyzable
 
-	needSyncEval := arg != nil && !isSimpleInlineableExpression(arg)
+	needSyncEval := arg != nil && !isInlineable && !isSimpleInlineableExpression(arg)
 
 	var promiseResolveArguments []*ast.Expression
 	if needSyncEval {
@@ -1054,3 +1054,118 @@ exports.a = a;`,
 		})
 	}
 }
+
+func TestAMDAndUMDModuleTransformer(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title  string
+		input  string
+		output string
+		module core.ModuleKind
+	}{
+		// AMD
+		{
+			title:  "AMD#1 (side-effect import)",
+			input:  `import "other"`,
+			module: core.ModuleKindAMD,
+			output: `define(["require", "exports", "other"], function (require, exports) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+});`,
+		},
+		{
+			title: "AMD#2 (named import and export)",
+			input: `import { a } from "other";
+export const b = a;`,
+			module: core.ModuleKindAMD,
+			output: `define(["require", "exports", "other"], function (require, exports, other_1) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    exports.b = void 0;
+    exports.b = other_1.a;
+});`,
+		},
+		{
+			title: "AMD#3 (export =)",
+			input: `import * as a from "other";
+export = a;`,
+			module: core.ModuleKindAMD,
+			output: `define(["require", "exports", "other"], function (require, exports, a) {
+    "use strict";
+    return a;
+});`,
+		},
+		{
+			title:  "AMD#4 (dynamic import)",
+			input:  `export const p = import("other");`,
+			module: core.ModuleKindAMD,
+			output: `define(["require", "exports"], function (require, exports) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    exports.p = void 0;
+    exports.p = new Promise((resolve_1, reject_1) => { require(["other"], resolve_1, reject_1); });
+});`,
+		},
+
+		// UMD
+		{
+			title: "UMD#1 (named import and export)",
+			input: `import { a } from "other";
+export const b = a;`,
+			module: core.ModuleKindUMD,
+			output: `(function (factory) {
+    if (typeof module === "object" && typeof module.exports === "object") {
+        var v = factory(require, exports);
+        if (v !== undefined) module.exports = v;
+    }
+    else if (typeof define === "function" && define.amd) {
+        define(["require", "exports", "other"], factory);
+    }
+})(function (require, exports) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    exports.b = void 0;
+    const other_1 = require("other");
+    exports.b = other_1.a;
+});`,
+		},
+		{
+			title:  "UMD#2 (dynamic import)",
+			input:  `export const p = import("other");`,
+			module: core.ModuleKindUMD,
+			output: `(function (factory) {
+    if (typeof module === "object" && typeof module.exports === "object") {
+        var v = factory(require, exports);
+        if (v !== undefined) module.exports = v;
+    }
+    else if (typeof define === "function" && define.amd) {
+        define(["require", "exports"], factory);
+    }
+})(function (require, exports) {
+    "use strict";
+    var __syncRequire = typeof module === "object" && typeof module.exports === "object";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    exports.p = void 0;
+    exports.p = __syncRequire ? Promise.resolve().then(() => require("other")) : new Promise((resolve_1, reject_1) => { require(["other"], resolve_1, reject_1); });
+});`,
+		},
+	}
+	for _, rec := range data {
+		t.Run(rec.title, func(t *testing.T) {
+			t.Parallel()
+
+			compilerOptions := &core.CompilerOptions{Module: rec.module}
+
+			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
+			parsetestutil.CheckDiagnostics(t, file)
+			binder.BindSourceFile(file)
+
+			emitContext := printer.NewEmitContext()
+			resolver := binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+
+			file = tstransforms.NewRuntimeSyntaxTransformer(emitContext, compilerOptions, resolver).TransformSourceFile(file)
+			file = moduletransforms.NewCommonJSModuleTransformer(emitContext, compilerOptions, resolver, fakeGetEmitModuleFormatOfFile).TransformSourceFile(file)
+			emittestutil.CheckEmit(t, emitContext, file, rec.output)
+		})
+	}
+}
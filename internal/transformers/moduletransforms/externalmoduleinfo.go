@@ -249,9 +249,11 @@ const externalHelpersModuleNameText = "tslib"
 
 func createExternalHelpersImportDeclarationIfNeeded(emitContext *printer.EmitContext, sourceFile *ast.SourceFile, compilerOptions *core.CompilerOptions, fileModuleKind core.ModuleKind, hasExportStarsToExportValues bool, hasImportStar bool, hasImportDefault bool) *ast.Node /*ImportDeclaration | ImportEqualsDeclaration*/ {
 	if compilerOptions.ImportHelpers.IsTrue() && ast.IsEffectiveExternalModule(sourceFile, compilerOptions) {
-		moduleKind := compilerOptions.GetEmitModuleKind()
+		if fileModuleKind == core.ModuleKindNone {
+			fileModuleKind = compilerOptions.GetEmitModuleKind()
+		}
 		helpers := getImportedHelpers(emitContext, sourceFile)
-		if fileModuleKind == core.ModuleKindCommonJS || fileModuleKind == core.ModuleKindNone && moduleKind == core.ModuleKindCommonJS {
+		if fileModuleKind < core.ModuleKindES2015 {
 			// When we emit to a non-ES module, generate a synthetic `import tslib = require("tslib")` to be further transformed.
 			externalHelpersModuleName := getOrCreateExternalHelpersModuleNameIfNeeded(emitContext, sourceFile, compilerOptions, helpers, hasExportStarsToExportValues, hasImportStar || hasImportDefault, fileModuleKind)
 			if externalHelpersModuleName != nil {
@@ -0,0 +1,1497 @@
+package moduletransforms
+
+import (
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/binder"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
+)
+
+type SystemModuleTransformer struct {
+	transformers.Transformer
+	topLevelVisitor           *ast.NodeVisitor // visits statements at top level of a module
+	topLevelNestedVisitor     *ast.NodeVisitor // visits nested statements at top level of a module
+	discardedValueVisitor     *ast.NodeVisitor // visits expressions whose values would be discarded at runtime
+	assignmentPatternVisitor  *ast.NodeVisitor // visits assignment patterns in a destructuring assignment
+	compilerOptions           *core.CompilerOptions
+	resolver                  binder.ReferenceResolver
+	getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind
+	currentSourceFile         *ast.SourceFile
+	currentModuleInfo         *externalModuleInfo
+	exportFunction            *ast.IdentifierNode     // the `exports_1` parameter of the module body function
+	contextObject             *ast.IdentifierNode     // the `context_1` parameter of the module body function
+	hoistedStatements         []*ast.Statement        // function declarations and their exports, hoisted out of the `execute` function
+	hoistedNames              collections.Set[string] // names of variables already hoisted into the module body function
+	parentNode                *ast.Node               // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+	currentNode               *ast.Node               // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+}
+
+func NewSystemModuleTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver binder.ReferenceResolver, getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind) *transformers.Transformer {
+	if resolver == nil {
+		resolver = binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+	}
+	tx := &SystemModuleTransformer{compilerOptions: compilerOptions, resolver: resolver, getEmitModuleFormatOfFile: getEmitModuleFormatOfFile}
+	tx.topLevelVisitor = emitContext.NewNodeVisitor(tx.visitTopLevel)
+	tx.topLevelNestedVisitor = emitContext.NewNodeVisitor(tx.visitTopLevelNested)
+	tx.discardedValueVisitor = emitContext.NewNodeVisitor(tx.visitDiscardedValue)
+	tx.assignmentPatternVisitor = emitContext.NewNodeVisitor(tx.visitAssignmentPattern)
+	return tx.NewTransformer(tx.visit, emitContext)
+}
+
+// A group of imports and re-exports of the same external module, which share a single setter function.
+type dependencyGroup struct {
+	name            *ast.StringLiteralNode
+	externalImports []*ast.Node /*ImportDeclaration | ImportEqualsDeclaration | ExportDeclaration*/
+}
+
+// Pushes a new child node onto the ancestor tracking stack, returning the grandparent node to be restored later via `popNode`.
+func (tx *SystemModuleTransformer) pushNode(node *ast.Node) (grandparentNode *ast.Node) {
+	grandparentNode = tx.parentNode
+	tx.parentNode = tx.currentNode
+	tx.currentNode = node
+	return
+}
+
+// Pops the last child node off the ancestor tracking stack, restoring the grandparent node.
+func (tx *SystemModuleTransformer) popNode(grandparentNode *ast.Node) {
+	tx.currentNode = tx.parentNode
+	tx.parentNode = grandparentNode
+}
+
+// Visits a node at the top level of the source file.
+func (tx *SystemModuleTransformer) visitTopLevel(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	switch node.Kind {
+	case ast.KindImportDeclaration:
+		node = tx.visitTopLevelImportDeclaration(node.AsImportDeclaration())
+	case ast.KindImportEqualsDeclaration:
+		node = tx.visitTopLevelImportEqualsDeclaration(node.AsImportEqualsDeclaration())
+	case ast.KindExportDeclaration:
+		// Re-exports are handled by the setter functions of the module, and local exports are applied when the
+		// exported declaration is initialized or assigned.
+		node = nil
+	case ast.KindExportAssignment:
+		node = tx.visitTopLevelExportAssignment(node.AsExportAssignment())
+	case ast.KindFunctionDeclaration:
+		node = tx.visitTopLevelFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindClassDeclaration:
+		node = tx.visitTopLevelClassDeclaration(node.AsClassDeclaration())
+	case ast.KindVariableStatement:
+		node = tx.visitTopLevelVariableStatement(node.AsVariableStatement(), true /*isTopLevel*/)
+	default:
+		node = tx.visitTopLevelNestedNoStack(node)
+	}
+	return node
+}
+
+// Visits nested elements at the top-level of a module.
+func (tx *SystemModuleTransformer) visitTopLevelNested(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitTopLevelNestedNoStack(node)
+}
+
+// Visits nested elements at the top-level of a module without ancestor tracking.
+func (tx *SystemModuleTransformer) visitTopLevelNestedNoStack(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindVariableStatement:
+		node = tx.visitTopLevelVariableStatement(node.AsVariableStatement(), false /*isTopLevel*/)
+	case ast.KindForStatement:
+		node = tx.visitTopLevelNestedForStatement(node.AsForStatement())
+	case ast.KindForInStatement, ast.KindForOfStatement:
+		node = tx.visitTopLevelNestedForInOrOfStatement(node.AsForInOrOfStatement())
+	case ast.KindDoStatement:
+		n := node.AsDoStatement()
+		node = tx.Factory().UpdateDoStatement(n, tx.EmitContext().VisitIterationBody(n.Statement, tx.topLevelNestedVisitor), tx.Visitor().VisitNode(n.Expression))
+	case ast.KindWhileStatement:
+		n := node.AsWhileStatement()
+		node = tx.Factory().UpdateWhileStatement(n, tx.Visitor().VisitNode(n.Expression), tx.EmitContext().VisitIterationBody(n.Statement, tx.topLevelNestedVisitor))
+	case ast.KindLabeledStatement:
+		n := node.AsLabeledStatement()
+		node = tx.Factory().UpdateLabeledStatement(n, n.Label, tx.topLevelNestedVisitor.VisitEmbeddedStatement(n.Statement))
+	case ast.KindWithStatement:
+		n := node.AsWithStatement()
+		node = tx.Factory().UpdateWithStatement(n, tx.Visitor().VisitNode(n.Expression), tx.topLevelNestedVisitor.VisitEmbeddedStatement(n.Statement))
+	case ast.KindIfStatement:
+		n := node.AsIfStatement()
+		node = tx.Factory().UpdateIfStatement(n, tx.Visitor().VisitNode(n.Expression), tx.topLevelNestedVisitor.VisitEmbeddedStatement(n.ThenStatement), tx.topLevelNestedVisitor.VisitEmbeddedStatement(n.ElseStatement))
+	case ast.KindSwitchStatement:
+		n := node.AsSwitchStatement()
+		node = tx.Factory().UpdateSwitchStatement(n, tx.Visitor().VisitNode(n.Expression), tx.topLevelNestedVisitor.VisitNode(n.CaseBlock))
+	case ast.KindCaseClause, ast.KindDefaultClause:
+		n := node.AsCaseOrDefaultClause()
+		node = tx.Factory().UpdateCaseOrDefaultClause(n, tx.Visitor().VisitNode(n.Expression), tx.topLevelNestedVisitor.VisitNodes(n.Statements))
+	case ast.KindCatchClause:
+		n := node.AsCatchClause()
+		node = tx.Factory().UpdateCatchClause(n, n.VariableDeclaration, tx.topLevelNestedVisitor.VisitNode(n.Block))
+	case ast.KindCaseBlock, ast.KindTryStatement, ast.KindBlock:
+		node = tx.topLevelNestedVisitor.VisitEachChild(node)
+	default:
+		node = tx.visitNoStack(node, false /*resultIsDiscarded*/)
+	}
+	return node
+}
+
+// Visits source elements that are not top-level or top-level nested statements.
+func (tx *SystemModuleTransformer) visit(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitNoStack(node, false /*resultIsDiscarded*/)
+}
+
+// Visits source elements that are not top-level or top-level nested statements without ancestor tracking.
+func (tx *SystemModuleTransformer) visitNoStack(node *ast.Node, resultIsDiscarded bool) *ast.Node {
+	// This visitor does not need to descend into the tree if there are no dynamic imports or identifiers in the subtree
+	if !ast.IsSourceFile(node) && node.SubtreeFacts()&(ast.SubtreeContainsDynamicImport|ast.SubtreeContainsIdentifier) == 0 {
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		node = tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindForStatement:
+		n := node.AsForStatement()
+		node = tx.Factory().UpdateForStatement(
+			n,
+			tx.discardedValueVisitor.VisitNode(n.Initializer),
+			tx.Visitor().VisitNode(n.Condition),
+			tx.discardedValueVisitor.VisitNode(n.Incrementor),
+			tx.EmitContext().VisitIterationBody(n.Statement, tx.Visitor()),
+		)
+	case ast.KindExpressionStatement, ast.KindVoidExpression:
+		node = tx.discardedValueVisitor.VisitEachChild(node)
+	case ast.KindParenthesizedExpression, ast.KindPartiallyEmittedExpression:
+		node = core.IfElse(resultIsDiscarded, tx.discardedValueVisitor, tx.Visitor()).VisitEachChild(node)
+	case ast.KindCallExpression:
+		node = tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindMetaProperty:
+		node = tx.visitMetaProperty(node.AsMetaProperty())
+	case ast.KindBinaryExpression:
+		node = tx.visitBinaryExpression(node.AsBinaryExpression(), resultIsDiscarded)
+	case ast.KindPrefixUnaryExpression:
+		node = tx.visitPrefixUnaryExpression(node.AsPrefixUnaryExpression())
+	case ast.KindPostfixUnaryExpression:
+		node = tx.visitPostfixUnaryExpression(node.AsPostfixUnaryExpression(), resultIsDiscarded)
+	case ast.KindShorthandPropertyAssignment:
+		node = tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+	case ast.KindIdentifier:
+		node = tx.visitIdentifier(node)
+	default:
+		node = tx.Visitor().VisitEachChild(node)
+	}
+
+	return node
+}
+
+// Visits source elements whose value is discarded if they are expressions.
+func (tx *SystemModuleTransformer) visitDiscardedValue(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitNoStack(node, true /*resultIsDiscarded*/)
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentPattern(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitAssignmentPatternNoStack(node)
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentPatternNoStack(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	// AssignmentPattern
+	case ast.KindObjectLiteralExpression, ast.KindArrayLiteralExpression:
+		node = tx.assignmentPatternVisitor.VisitEachChild(node)
+
+	// AssignmentProperty
+	case ast.KindPropertyAssignment:
+		n := node.AsPropertyAssignment()
+		node = tx.Factory().UpdatePropertyAssignment(
+			n,
+			nil, /*modifiers*/
+			tx.Visitor().VisitNode(n.Name()),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.assignmentPatternVisitor.VisitNode(n.Initializer),
+		)
+	case ast.KindShorthandPropertyAssignment:
+		node = tx.visitShorthandAssignmentProperty(node.AsShorthandPropertyAssignment())
+
+	// AssignmentRestProperty
+	case ast.KindSpreadAssignment:
+		node = tx.Factory().UpdateSpreadAssignment(node.AsSpreadAssignment(), tx.visitDestructuringAssignmentTarget(node.Expression()))
+
+	// AssignmentRestElement
+	case ast.KindSpreadElement:
+		node = tx.Factory().UpdateSpreadElement(node.AsSpreadElement(), tx.visitDestructuringAssignmentTarget(node.Expression()))
+
+	// AssignmentElement
+	default:
+		if ast.IsExpression(node) {
+			if ast.IsBinaryExpression(node) && node.AsBinaryExpression().OperatorToken.Kind == ast.KindEqualsToken {
+				n := node.AsBinaryExpression()
+				node = tx.Factory().UpdateBinaryExpression(
+					n,
+					nil, /*modifiers*/
+					tx.visitDestructuringAssignmentTarget(n.Left),
+					nil, /*typeNode*/
+					n.OperatorToken,
+					tx.Visitor().VisitNode(n.Right),
+				)
+				break
+			}
+			node = tx.visitDestructuringAssignmentTargetNoStack(node)
+			break
+		}
+
+		node = tx.visitNoStack(node, false /*resultIsDiscarded*/)
+	}
+	return node
+}
+
+func (tx *SystemModuleTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile ||
+		!(ast.IsEffectiveExternalModule(node, tx.compilerOptions) ||
+			node.SubtreeFacts()&ast.SubtreeContainsDynamicImport != 0) {
+		return node.AsNode()
+	}
+
+	tx.currentSourceFile = node
+	tx.currentModuleInfo = collectExternalModuleInfo(node, tx.compilerOptions, tx.EmitContext(), tx.resolver)
+	tx.exportFunction = tx.Factory().NewUniqueName("exports")
+	tx.contextObject = tx.Factory().NewUniqueName("context")
+
+	updated := tx.transformSystemModule(node)
+
+	tx.currentSourceFile = nil
+	tx.currentModuleInfo = nil
+	tx.exportFunction = nil
+	tx.contextObject = nil
+	tx.hoistedStatements = nil
+	tx.hoistedNames.Clear()
+	return updated
+}
+
+// Transforms a SourceFile into a SystemJS module of the form:
+//
+//	System.register(["mod1", "mod2"], function (exports_1, context_1) {
+//	    "use strict";
+//	    var mod1_1, x;
+//	    var __moduleName = context_1 && context_1.id;
+//	    return {
+//	        setters: [
+//	            function (mod1_1_1) { mod1_1 = mod1_1_1; },
+//	            function (mod2_1_1) { exports_1({ "y": mod2_1_1["y"] }); }
+//	        ],
+//	        execute: function () {
+//	            exports_1("x", x = mod1_1.a);
+//	        }
+//	    };
+//	});
+//
+// Top-level declarations are hoisted into the module body function so that they can be read by the setter functions
+// and by hoisted function declarations before the `execute` function has run.
+func (tx *SystemModuleTransformer) transformSystemModule(node *ast.SourceFile) *ast.Node {
+	tx.EmitContext().StartVariableEnvironment()
+
+	// emit standard prologue directives (e.g. "use strict")
+	prologue, rest := tx.Factory().SplitStandardPrologue(node.Statements.Nodes)
+	statements := tx.Factory().EnsureUseStrict(slices.Clone(prologue))
+
+	// var __moduleName = context_1 && context_1.id;
+	statements = append(statements, tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsNone,
+			tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+				tx.Factory().NewVariableDeclaration(
+					tx.Factory().NewIdentifier("__moduleName"),
+					nil, /*exclamationToken*/
+					nil, /*type*/
+					tx.Factory().NewLogicalANDExpression(
+						tx.contextObject.Clone(tx.Factory()),
+						tx.Factory().NewPropertyAccessExpression(tx.contextObject.Clone(tx.Factory()), nil /*questionDotToken*/, tx.Factory().NewIdentifier("id"), ast.NodeFlagsNone),
+					),
+				),
+			}),
+		),
+	))
+
+	// emit custom prologues from other transformations
+	custom, rest := tx.Factory().SplitCustomPrologue(rest)
+	statements = append(statements, core.FirstResult(tx.topLevelVisitor.VisitSlice(custom))...)
+
+	// visit the remaining statements in the source file. These become the body of the `execute` function.
+	executeStatements, _ := tx.topLevelVisitor.VisitSlice(rest)
+
+	// add the synthesized import of the external helpers module to the dependencies of the module, if needed
+	dependencyGroups := tx.collectDependencyGroups(tx.currentModuleInfo.externalImports)
+	result := tx.Factory().UpdateSourceFile(node, tx.Factory().NewNodeList(executeStatements), node.EndOfFileToken).AsSourceFile()
+	tx.EmitContext().AddEmitHelper(result.AsNode(), tx.EmitContext().ReadEmitHelpers()...)
+	externalHelpersImportDeclaration := createExternalHelpersImportDeclarationIfNeeded(tx.EmitContext(), result, tx.compilerOptions, tx.getEmitModuleFormatOfFile(node), false /*hasExportStarsToExportValues*/, false /*hasImportStar*/, false /*hasImportDefault*/)
+	if externalHelpersImportDeclaration != nil {
+		tx.EmitContext().AddVariableDeclaration(getLocalNameForExternalImport(tx.EmitContext(), externalHelpersImportDeclaration))
+		dependencyGroups = append([]*dependencyGroup{{
+			name:            getExternalModuleNameLiteral(tx.Factory(), externalHelpersImportDeclaration, node, nil /*host*/, nil /*resolver*/, tx.compilerOptions),
+			externalImports: []*ast.Node{externalHelpersImportDeclaration},
+		}}, dependencyGroups...)
+	}
+
+	// emit hoisted functions and their exports, e.g.:
+	//  function f() {}
+	//  exports_1("f", f);
+	statements = append(statements, tx.hoistedStatements...)
+
+	// merge hoisted variables into the statement list
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+
+	exportStarFunction := tx.appendExportStarFunctionIfNeeded(&statements)
+
+	var executeModifiers *ast.ModifierList
+	if node.SubtreeFacts()&ast.SubtreeContainsAwait != 0 {
+		executeModifiers = tx.Factory().NewModifierList([]*ast.Node{tx.Factory().NewModifier(ast.KindAsyncKeyword)})
+	}
+
+	moduleObject := tx.Factory().NewObjectLiteralExpression(
+		tx.Factory().NewNodeList([]*ast.Node{
+			tx.Factory().NewPropertyAssignment(
+				nil, /*modifiers*/
+				tx.Factory().NewIdentifier("setters"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				tx.createSettersArray(exportStarFunction, dependencyGroups),
+			),
+			tx.Factory().NewPropertyAssignment(
+				nil, /*modifiers*/
+				tx.Factory().NewIdentifier("execute"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				tx.Factory().NewFunctionExpression(
+					executeModifiers,
+					nil, /*asteriskToken*/
+					nil, /*name*/
+					nil, /*typeParameters*/
+					tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{}),
+					nil, /*type*/
+					nil, /*fullSignature*/
+					tx.Factory().NewBlock(tx.Factory().NewNodeList(executeStatements), true /*multiLine*/),
+				),
+			),
+		}),
+		true, /*multiLine*/
+	)
+	statements = append(statements, tx.Factory().NewReturnStatement(moduleObject))
+
+	moduleBodyBlock := tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+	moduleBodyFunction := tx.Factory().NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{
+			tx.createParameter(tx.exportFunction),
+			tx.createParameter(tx.contextObject),
+		}),
+		nil, /*type*/
+		nil, /*fullSignature*/
+		moduleBodyBlock,
+	)
+
+	dependencies := core.Map(dependencyGroups, func(group *dependencyGroup) *ast.Expression { return group.name })
+	var arguments []*ast.Expression
+	if moduleName := tryGetModuleNameFromFile(tx.Factory(), node, nil /*host*/, tx.compilerOptions); moduleName != nil {
+		arguments = append(arguments, moduleName)
+	}
+	arguments = append(arguments,
+		tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(dependencies), false /*multiLine*/),
+		moduleBodyFunction,
+	)
+
+	registerCall := tx.Factory().NewExpressionStatement(
+		tx.Factory().NewCallExpression(
+			tx.Factory().NewPropertyAccessExpression(
+				tx.Factory().NewIdentifier("System"),
+				nil, /*questionDotToken*/
+				tx.Factory().NewIdentifier("register"),
+				ast.NodeFlagsNone,
+			),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList(arguments),
+			ast.NodeFlagsNone,
+		),
+	)
+
+	statementList := tx.Factory().NewNodeList([]*ast.Statement{registerCall})
+	statementList.Loc = node.Statements.Loc
+	updated := tx.Factory().UpdateSourceFile(result, statementList, node.EndOfFileToken)
+	tx.EmitContext().AddEmitFlags(updated, printer.EFNoTrailingComments)
+
+	// helpers are emitted in the module body function so that they are not added to the global scope
+	tx.EmitContext().MoveEmitHelpers(updated, moduleBodyBlock, func(helper *printer.EmitHelper) bool { return !helper.Scoped })
+	return updated
+}
+
+// Groups the external imports of the module by the name of the imported module.
+func (tx *SystemModuleTransformer) collectDependencyGroups(externalImports []*ast.Node) []*dependencyGroup {
+	groupIndices := make(map[string]int)
+	var dependencyGroups []*dependencyGroup
+	for _, externalImport := range externalImports {
+		externalModuleName := getExternalModuleNameLiteral(tx.Factory(), externalImport, tx.currentSourceFile, nil /*host*/, nil /*resolver*/, tx.compilerOptions)
+		if externalModuleName == nil {
+			continue
+		}
+		externalModuleName = rewriteModuleSpecifier(tx.EmitContext(), externalModuleName, tx.compilerOptions)
+		text := externalModuleName.Text()
+		if groupIndex, ok := groupIndices[text]; ok {
+			dependencyGroups[groupIndex].externalImports = append(dependencyGroups[groupIndex].externalImports, externalImport)
+		} else {
+			groupIndices[text] = len(dependencyGroups)
+			dependencyGroups = append(dependencyGroups, &dependencyGroup{name: externalModuleName, externalImports: []*ast.Node{externalImport}})
+		}
+	}
+	return dependencyGroups
+}
+
+// Appends the `exportStar` function used to re-export the members of other modules for `export *`, if needed. Returns
+// the name of the function, or nil if the module has no `export *` declarations.
+func (tx *SystemModuleTransformer) appendExportStarFunctionIfNeeded(statements *[]*ast.Statement) *ast.IdentifierNode {
+	if !tx.currentModuleInfo.hasExportStarsToExportValues {
+		return nil
+	}
+
+	// When resolving exports, local exported entries and indirect exported entries in the module should always win
+	// over entries with the same name that were added via `export *`. To support this, we store the names of local
+	// and indirect exported entries in an object that is used to filter the names brought in by `export *`.
+	if len(tx.currentModuleInfo.exportedNames) == 0 &&
+		tx.currentModuleInfo.exportedFunctions.Size() == 0 &&
+		tx.currentModuleInfo.exportSpecifiers.Len() == 0 &&
+		!core.Some(tx.currentModuleInfo.externalImports, func(node *ast.Node) bool {
+			return ast.IsExportDeclaration(node) && node.AsExportDeclaration().ExportClause != nil
+		}) {
+		exportStarFunction := tx.createExportStarFunction(nil /*localNames*/)
+		*statements = append(*statements, exportStarFunction)
+		return exportStarFunction.Name()
+	}
+
+	var exportedNames []*ast.Node
+	for _, exportedLocalName := range tx.currentModuleInfo.exportedNames {
+		if ast.ModuleExportNameIsDefault(exportedLocalName) {
+			continue
+		}
+		exportedNames = append(exportedNames, tx.Factory().NewPropertyAssignment(
+			nil, /*modifiers*/
+			tx.Factory().NewStringLiteralFromNode(exportedLocalName),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.Factory().NewTrueExpression(),
+		))
+	}
+	for f := range tx.currentModuleInfo.exportedFunctions.Values() {
+		if ast.HasSyntacticModifier(f.AsNode(), ast.ModifierFlagsDefault) || f.Name() == nil {
+			continue
+		}
+		exportedNames = append(exportedNames, tx.Factory().NewPropertyAssignment(
+			nil, /*modifiers*/
+			tx.Factory().NewStringLiteralFromNode(f.Name()),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.Factory().NewTrueExpression(),
+		))
+	}
+
+	exportedNamesStorageRef := tx.Factory().NewUniqueName("exportedNames")
+	*statements = append(*statements, tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsNone,
+			tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+				tx.Factory().NewVariableDeclaration(
+					exportedNamesStorageRef,
+					nil, /*exclamationToken*/
+					nil, /*type*/
+					tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList(exportedNames), true /*multiLine*/),
+				),
+			}),
+		),
+	))
+
+	exportStarFunction := tx.createExportStarFunction(exportedNamesStorageRef)
+	*statements = append(*statements, exportStarFunction)
+	return exportStarFunction.Name()
+}
+
+// Creates the `exportStar` function used to re-export the members of other modules:
+//
+//	function exportStar_1(m) {
+//	    var exports = {};
+//	    for (var n in m) {
+//	        if (n !== "default" && !exportedNames_1.hasOwnProperty(n)) exports[n] = m[n];
+//	    }
+//	    exports_1(exports);
+//	}
+func (tx *SystemModuleTransformer) createExportStarFunction(localNames *ast.IdentifierNode) *ast.Statement {
+	f := tx.Factory()
+	exportStarFunction := f.NewUniqueName("exportStar")
+	m := f.NewIdentifier("m")
+	n := f.NewIdentifier("n")
+	exports := f.NewIdentifier("exports")
+
+	condition := f.NewStrictInequalityExpression(n, f.NewStringLiteral("default"))
+	if localNames != nil {
+		condition = f.NewLogicalANDExpression(
+			condition,
+			f.NewPrefixUnaryExpression(
+				ast.KindExclamationToken,
+				f.NewCallExpression(
+					f.NewPropertyAccessExpression(localNames, nil /*questionDotToken*/, f.NewIdentifier("hasOwnProperty"), ast.NodeFlagsNone),
+					nil, /*questionDotToken*/
+					nil, /*typeArguments*/
+					f.NewNodeList([]*ast.Expression{n.Clone(f)}),
+					ast.NodeFlagsNone,
+				),
+			),
+		)
+	}
+
+	copyExport := f.NewIfStatement(
+		condition,
+		f.NewExpressionStatement(
+			f.NewAssignmentExpression(
+				f.NewElementAccessExpression(exports.Clone(f), nil /*questionDotToken*/, n.Clone(f), ast.NodeFlagsNone),
+				f.NewElementAccessExpression(m.Clone(f), nil /*questionDotToken*/, n.Clone(f), ast.NodeFlagsNone),
+			),
+		),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(copyExport, printer.EFSingleLine)
+
+	return f.NewFunctionDeclaration(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		exportStarFunction,
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.ParameterDeclarationNode{tx.createParameter(m)}),
+		nil, /*returnType*/
+		nil, /*fullSignature*/
+		f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewVariableStatement(
+				nil, /*modifiers*/
+				f.NewVariableDeclarationList(
+					ast.NodeFlagsNone,
+					f.NewNodeList([]*ast.VariableDeclarationNode{
+						f.NewVariableDeclaration(
+							exports,
+							nil, /*exclamationToken*/
+							nil, /*type*/
+							f.NewObjectLiteralExpression(f.NewNodeList(nil), false /*multiLine*/),
+						),
+					}),
+				),
+			),
+			f.NewForInOrOfStatement(
+				ast.KindForInStatement,
+				nil, /*awaitModifier*/
+				f.NewVariableDeclarationList(
+					ast.NodeFlagsNone,
+					f.NewNodeList([]*ast.VariableDeclarationNode{
+						f.NewVariableDeclaration(n.Clone(f), nil /*exclamationToken*/, nil /*type*/, nil /*initializer*/),
+					}),
+				),
+				m.Clone(f),
+				f.NewBlock(f.NewNodeList([]*ast.Statement{copyExport}), true /*multiLine*/),
+			),
+			f.NewExpressionStatement(
+				f.NewCallExpression(
+					tx.exportFunction.Clone(f),
+					nil, /*questionDotToken*/
+					nil, /*typeArguments*/
+					f.NewNodeList([]*ast.Expression{exports.Clone(f)}),
+					ast.NodeFlagsNone,
+				),
+			),
+		}), true /*multiLine*/),
+	)
+}
+
+// Creates the array of setter functions for each dependency group. SystemJS invokes a setter function with the module
+// namespace object of the dependency each time the dependency's exports change.
+func (tx *SystemModuleTransformer) createSettersArray(exportStarFunction *ast.IdentifierNode, dependencyGroups []*dependencyGroup) *ast.Expression {
+	f := tx.Factory()
+	setters := make([]*ast.Expression, 0, len(dependencyGroups))
+	for _, group := range dependencyGroups {
+		// derive a unique name for the parameter from the first named entry in the group
+		var parameterName *ast.IdentifierNode
+		for _, entry := range group.externalImports {
+			if localName := getLocalNameForExternalImport(tx.EmitContext(), entry); localName != nil {
+				parameterName = f.NewGeneratedNameForNode(localName)
+				break
+			}
+		}
+		if parameterName == nil {
+			parameterName = f.NewTempVariable()
+		}
+
+		var statements []*ast.Statement
+		for _, entry := range group.externalImports {
+			importVariableName := getLocalNameForExternalImport(tx.EmitContext(), entry)
+			switch entry.Kind {
+			case ast.KindImportDeclaration:
+				if entry.AsImportDeclaration().ImportClause == nil {
+					// `import "mod"` only evaluates the module
+					break
+				}
+				// save the import into the local, e.g.:
+				//  mod_1 = mod_1_1;
+				statements = append(statements, f.NewExpressionStatement(f.NewAssignmentExpression(importVariableName, parameterName.Clone(f))))
+
+				// `import d, * as ns from "mod"` binds the namespace to its own local
+				if namespaceDeclaration := ast.GetNamespaceDeclarationNode(entry); namespaceDeclaration != nil && ast.IsDefaultImport(entry) {
+					statements = append(statements, f.NewExpressionStatement(f.NewAssignmentExpression(
+						f.NewIdentifier(namespaceDeclaration.Name().Text()),
+						parameterName.Clone(f),
+					)))
+				}
+
+			case ast.KindImportEqualsDeclaration:
+				// save the import into the local, e.g.:
+				//  mod_1 = mod_1_1;
+				statements = append(statements, f.NewExpressionStatement(f.NewAssignmentExpression(importVariableName, parameterName.Clone(f))))
+				if ast.HasSyntacticModifier(entry, ast.ModifierFlagsExport) {
+					statements = append(statements, f.NewExpressionStatement(tx.createExportExpression(importVariableName, parameterName.Clone(f))))
+				}
+
+			case ast.KindExportDeclaration:
+				exportClause := entry.AsExportDeclaration().ExportClause
+				switch {
+				case exportClause == nil:
+					// export * from "mod"
+					// emits:
+					//  exportStar_1(mod_1_1);
+					statements = append(statements, f.NewExpressionStatement(f.NewCallExpression(
+						exportStarFunction.Clone(f),
+						nil, /*questionDotToken*/
+						nil, /*typeArguments*/
+						f.NewNodeList([]*ast.Expression{parameterName.Clone(f)}),
+						ast.NodeFlagsNone,
+					)))
+				case ast.IsNamedExports(exportClause):
+					// export { a, b as c } from "mod"
+					// emits:
+					//  exports_1({
+					//      "a": mod_1_1["a"],
+					//      "c": mod_1_1["b"]
+					//  });
+					var properties []*ast.Node
+					for _, element := range exportClause.AsNamedExports().Elements.Nodes {
+						properties = append(properties, f.NewPropertyAssignment(
+							nil, /*modifiers*/
+							f.NewStringLiteralFromNode(element.Name()),
+							nil, /*postfixToken*/
+							nil, /*typeNode*/
+							f.NewElementAccessExpression(
+								parameterName.Clone(f),
+								nil, /*questionDotToken*/
+								f.NewStringLiteralFromNode(element.PropertyNameOrName()),
+								ast.NodeFlagsNone,
+							),
+						))
+					}
+					statements = append(statements, f.NewExpressionStatement(f.NewCallExpression(
+						tx.exportFunction.Clone(f),
+						nil, /*questionDotToken*/
+						nil, /*typeArguments*/
+						f.NewNodeList([]*ast.Expression{f.NewObjectLiteralExpression(f.NewNodeList(properties), true /*multiLine*/)}),
+						ast.NodeFlagsNone,
+					)))
+				default:
+					// export * as ns from "mod"
+					// emits:
+					//  exports_1("ns", mod_1_1);
+					statements = append(statements, f.NewExpressionStatement(tx.createExportExpression(exportClause.Name(), parameterName.Clone(f))))
+				}
+			}
+		}
+
+		setters = append(setters, f.NewFunctionExpression(
+			nil, /*modifiers*/
+			nil, /*asteriskToken*/
+			nil, /*name*/
+			nil, /*typeParameters*/
+			f.NewNodeList([]*ast.ParameterDeclarationNode{tx.createParameter(parameterName)}),
+			nil, /*type*/
+			nil, /*fullSignature*/
+			f.NewBlock(f.NewNodeList(statements), true /*multiLine*/),
+		))
+	}
+	return f.NewArrayLiteralExpression(f.NewNodeList(setters), true /*multiLine*/)
+}
+
+func (tx *SystemModuleTransformer) createParameter(name *ast.IdentifierNode) *ast.ParameterDeclarationNode {
+	return tx.Factory().NewParameterDeclaration(
+		nil, /*modifiers*/
+		nil, /*dotDotDotToken*/
+		name,
+		nil, /*questionToken*/
+		nil, /*type*/
+		nil, /*initializer*/
+	)
+}
+
+// Creates a call to the export function of the module to export a value:
+//
+//	exports_1("name", value)
+func (tx *SystemModuleTransformer) createExportExpression(name *ast.ModuleExportName, value *ast.Expression) *ast.Expression {
+	return tx.Factory().NewCallExpression(
+		tx.exportFunction.Clone(tx.Factory()),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList([]*ast.Expression{tx.Factory().NewStringLiteralFromNode(name), value}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Creates a statement that calls the export function of the module to export a value.
+func (tx *SystemModuleTransformer) createExportStatement(name *ast.ModuleExportName, value *ast.Expression, allowComments bool) *ast.Statement {
+	statement := tx.Factory().NewExpressionStatement(tx.createExportExpression(name, value))
+	tx.EmitContext().AddEmitFlags(statement, printer.EFStartOnNewLine)
+	if !allowComments {
+		tx.EmitContext().AddEmitFlags(statement, printer.EFNoComments)
+	}
+	return statement
+}
+
+// Appends the down-level representation of an export to a statement list, returning the statement list.
+func (tx *SystemModuleTransformer) appendExportStatement(statements []*ast.Statement, seen *collections.Set[string], exportName *ast.ModuleExportName, expression *ast.Expression, allowComments bool) []*ast.Statement {
+	if exportName.Kind != ast.KindStringLiteral {
+		if seen.Has(exportName.Text()) {
+			return statements
+		}
+		seen.Add(exportName.Text())
+	}
+	return append(statements, tx.createExportStatement(exportName, expression, allowComments))
+}
+
+// Appends the exports of a declaration that are declared with `export {}` to a statement list, returning the statement
+// list.
+func (tx *SystemModuleTransformer) appendExportsOfDeclaration(statements []*ast.Statement, decl *ast.Declaration, seen *collections.Set[string]) []*ast.Statement {
+	if name := decl.Name(); tx.currentModuleInfo.exportSpecifiers.Len() > 0 && name != nil && ast.IsIdentifier(name) {
+		name = tx.Factory().GetDeclarationName(decl)
+		exportSpecifiers := tx.currentModuleInfo.exportSpecifiers.Get(name.Text())
+		if len(exportSpecifiers) > 0 {
+			exportValue := tx.visitExpressionIdentifier(name)
+			for _, exportSpecifier := range exportSpecifiers {
+				statements = tx.appendExportStatement(statements, seen, exportSpecifier.Name(), exportValue, false /*allowComments*/)
+			}
+		}
+	}
+	return statements
+}
+
+// Appends the exports of a hoisted ClassDeclaration or FunctionDeclaration to a statement list, returning the statement
+// list.
+func (tx *SystemModuleTransformer) appendExportsOfHoistedDeclaration(statements []*ast.Statement, decl *ast.Declaration) []*ast.Statement {
+	seen := &collections.Set[string]{}
+	if ast.HasSyntacticModifier(decl, ast.ModifierFlagsExport) {
+		var exportName *ast.IdentifierNode
+		if ast.HasSyntacticModifier(decl, ast.ModifierFlagsDefault) {
+			exportName = tx.Factory().NewIdentifier("default")
+		} else {
+			exportName = tx.Factory().GetDeclarationName(decl)
+		}
+		statements = tx.appendExportStatement(statements, seen, exportName, tx.Factory().GetLocalName(decl), false /*allowComments*/)
+	}
+	if decl.Name() != nil {
+		statements = tx.appendExportsOfDeclaration(statements, decl, seen)
+	}
+	return statements
+}
+
+// Appends the exports of an ImportDeclaration to a statement list, returning the statement list.
+func (tx *SystemModuleTransformer) appendExportsOfImportDeclaration(statements []*ast.Statement, decl *ast.ImportDeclaration) []*ast.Statement {
+	importClause := decl.ImportClause
+	if importClause == nil {
+		return statements
+	}
+
+	seen := &collections.Set[string]{}
+	if importClause.Name() != nil {
+		statements = tx.appendExportsOfDeclaration(statements, importClause, seen)
+	}
+
+	namedBindings := importClause.AsImportClause().NamedBindings
+	if namedBindings != nil {
+		switch namedBindings.Kind {
+		case ast.KindNamespaceImport:
+			statements = tx.appendExportsOfDeclaration(statements, namedBindings, seen)
+		case ast.KindNamedImports:
+			for _, importBinding := range namedBindings.AsNamedImports().Elements.Nodes {
+				statements = tx.appendExportsOfDeclaration(statements, importBinding, seen)
+			}
+		}
+	}
+	return statements
+}
+
+// Gets the export statements for the names declared by a `for..in` or `for..of` initializer, e.g.:
+//
+//	exports_1("x", x);
+func (tx *SystemModuleTransformer) getExportsOfBindingName(statements []*ast.Statement, name *ast.Node) []*ast.Statement {
+	if ast.IsBindingPattern(name) {
+		for _, element := range name.AsBindingPattern().Elements.Nodes {
+			if element.Name() != nil {
+				statements = tx.getExportsOfBindingName(statements, element.Name())
+			}
+		}
+		return statements
+	}
+	for _, exportName := range tx.getExports(name) {
+		statements = append(statements, tx.createExportStatement(exportName, name.Clone(tx.Factory()), false /*allowComments*/))
+	}
+	return statements
+}
+
+func (tx *SystemModuleTransformer) visitTopLevelImportDeclaration(node *ast.ImportDeclaration) *ast.Node {
+	if node.ImportClause != nil {
+		tx.EmitContext().AddVariableDeclaration(getLocalNameForExternalImport(tx.EmitContext(), node.AsNode()))
+		if namespaceDeclaration := ast.GetNamespaceDeclarationNode(node.AsNode()); namespaceDeclaration != nil && ast.IsDefaultImport(node.AsNode()) {
+			tx.EmitContext().AddVariableDeclaration(tx.Factory().NewIdentifier(namespaceDeclaration.Name().Text()))
+		}
+	}
+	return transformers.SingleOrMany(tx.appendExportsOfImportDeclaration(nil, node), tx.Factory())
+}
+
+func (tx *SystemModuleTransformer) visitTopLevelImportEqualsDeclaration(node *ast.ImportEqualsDeclaration) *ast.Node {
+	if !ast.IsExternalModuleImportEqualsDeclaration(node.AsNode()) {
+		// import m = n.x; is transformed by an earlier transformer
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	tx.EmitContext().AddVariableDeclaration(getLocalNameForExternalImport(tx.EmitContext(), node.AsNode()))
+	return transformers.SingleOrMany(tx.appendExportsOfDeclaration(nil, node.AsNode(), &collections.Set[string]{}), tx.Factory())
+}
+
+func (tx *SystemModuleTransformer) visitTopLevelExportAssignment(node *ast.ExportAssignment) *ast.Node {
+	if node.IsExportEquals {
+		// `export =` is not supported by SystemJS and is elided
+		return nil
+	}
+
+	statement := tx.createExportStatement(tx.Factory().NewIdentifier("default"), tx.Visitor().VisitNode(node.Expression), true /*allowComments*/)
+	tx.EmitContext().SetCommentRange(statement, node.Loc)
+	return statement
+}
+
+// Hoists a top-level function declaration, along with its exports, into the module body function.
+func (tx *SystemModuleTransformer) visitTopLevelFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+		tx.hoistedStatements = append(tx.hoistedStatements, tx.Factory().UpdateFunctionDeclaration(
+			node,
+			transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsExportDefault),
+			node.AsteriskToken,
+			tx.Factory().GetDeclarationName(node.AsNode()),
+			nil, /*typeParameters*/
+			tx.Visitor().VisitNodes(node.Parameters),
+			nil, /*type*/
+			nil, /*fullSignature*/
+			tx.Visitor().VisitNode(node.Body),
+		))
+	} else {
+		tx.hoistedStatements = append(tx.hoistedStatements, tx.Visitor().VisitEachChild(node.AsNode()))
+	}
+	tx.hoistedStatements = tx.appendExportsOfHoistedDeclaration(tx.hoistedStatements, node.AsNode())
+	return nil
+}
+
+// Rewrites a top-level class declaration into an assignment of a class expression to a hoisted variable:
+//
+//	C = class C { };
+//	exports_1("C", C);
+func (tx *SystemModuleTransformer) visitTopLevelClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	name := tx.Factory().GetLocalName(node.AsNode())
+	tx.EmitContext().AddVariableDeclaration(name)
+
+	classExpression := tx.Factory().NewClassExpression(
+		tx.Visitor().VisitModifiers(transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsExportDefault)),
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.HeritageClauses),
+		tx.Visitor().VisitNodes(node.Members),
+	)
+	tx.EmitContext().SetOriginal(classExpression, node.AsNode())
+	classExpression.Loc = node.Loc
+
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(name.Clone(tx.Factory()), classExpression))
+	tx.EmitContext().SetOriginal(statement, node.AsNode())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
+
+	statements := tx.appendExportsOfHoistedDeclaration([]*ast.Statement{statement}, node.AsNode())
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+// Determines whether the declarations of a variable declaration list should be hoisted into the module body function.
+// Top-level declarations are always hoisted, while nested declarations are hoisted only if they are not block scoped.
+func (tx *SystemModuleTransformer) shouldHoistVariableDeclarationList(node *ast.VariableDeclarationList, isTopLevel bool) bool {
+	if tx.EmitContext().EmitFlags(node.AsNode())&printer.EFNoHoisting != 0 {
+		return false
+	}
+	flags := tx.EmitContext().MostOriginal(node.AsNode()).Flags
+	if flags&ast.NodeFlagsUsing != 0 {
+		// `using` and `await using` declarations must remain in the `execute` function so that they are disposed
+		return false
+	}
+	return isTopLevel || flags&ast.NodeFlagsBlockScoped == 0
+}
+
+// Hoists the names declared by a variable declaration or binding element into the module body function.
+func (tx *SystemModuleTransformer) hoistBindingElement(node *ast.Node /*VariableDeclaration | BindingElement*/) {
+	name := node.Name()
+	if name == nil {
+		return
+	}
+	if ast.IsBindingPattern(name) {
+		for _, element := range name.AsBindingPattern().Elements.Nodes {
+			if !ast.IsOmittedExpression(element) {
+				tx.hoistBindingElement(element)
+			}
+		}
+		return
+	}
+	if !transformers.IsGeneratedIdentifier(tx.EmitContext(), name) {
+		if tx.hoistedNames.Has(name.Text()) {
+			return
+		}
+		tx.hoistedNames.Add(name.Text())
+	}
+	tx.EmitContext().AddVariableDeclaration(name.Clone(tx.Factory()))
+}
+
+// Visits a top-level or top-level nested variable statement, hoisting its declarations into the module body function
+// and converting its initializers into assignments, e.g.:
+//
+//	export var x = 1;
+//
+// emits:
+//
+//	exports_1("x", x = 1);
+func (tx *SystemModuleTransformer) visitTopLevelVariableStatement(node *ast.VariableStatement, isTopLevel bool) *ast.Node {
+	declarationList := node.DeclarationList.AsVariableDeclarationList()
+	if !tx.shouldHoistVariableDeclarationList(declarationList, isTopLevel) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	var expressions []*ast.Expression
+	for _, declaration := range declarationList.Declarations.Nodes {
+		tx.hoistBindingElement(declaration)
+		if expression := transformers.ConvertVariableDeclarationToAssignmentExpression(tx.EmitContext(), declaration.AsVariableDeclaration()); expression != nil {
+			// exports of the declaration are applied when visiting the assignment
+			expressions = append(expressions, tx.discardedValueVisitor.VisitNode(expression))
+		}
+	}
+
+	if len(expressions) == 0 {
+		return nil
+	}
+
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(expressions))
+	tx.EmitContext().SetOriginal(statement, node.AsNode())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
+	return statement
+}
+
+// Visits the initializer of a top-level nested `for` statement, hoisting its declarations if needed.
+func (tx *SystemModuleTransformer) visitForInitializer(node *ast.ForInitializer) *ast.ForInitializer {
+	if node == nil || !ast.IsVariableDeclarationList(node) || !tx.shouldHoistVariableDeclarationList(node.AsVariableDeclarationList(), false /*isTopLevel*/) {
+		return tx.discardedValueVisitor.VisitNode(node)
+	}
+
+	var expressions []*ast.Expression
+	for _, declaration := range node.AsVariableDeclarationList().Declarations.Nodes {
+		tx.hoistBindingElement(declaration)
+		if expression := transformers.ConvertVariableDeclarationToAssignmentExpression(tx.EmitContext(), declaration.AsVariableDeclaration()); expression != nil {
+			expressions = append(expressions, tx.discardedValueVisitor.VisitNode(expression))
+		}
+	}
+
+	if len(expressions) == 0 {
+		return nil
+	}
+	return tx.Factory().InlineExpressions(expressions)
+}
+
+// Visits a top-level nested `for` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedForStatement(node *ast.ForStatement) *ast.Node {
+	return tx.Factory().UpdateForStatement(
+		node,
+		tx.visitForInitializer(node.Initializer),
+		tx.Visitor().VisitNode(node.Condition),
+		tx.discardedValueVisitor.VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.topLevelNestedVisitor),
+	)
+}
+
+// Visits a top-level nested `for..in` or `for..of` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedForInOrOfStatement(node *ast.ForInOrOfStatement) *ast.Node {
+	initializer := node.Initializer
+	if !ast.IsVariableDeclarationList(initializer) || !tx.shouldHoistVariableDeclarationList(initializer.AsVariableDeclarationList(), false /*isTopLevel*/) {
+		return tx.Factory().UpdateForInOrOfStatement(
+			node,
+			node.AwaitModifier,
+			tx.discardedValueVisitor.VisitNode(initializer),
+			tx.Visitor().VisitNode(node.Expression),
+			tx.EmitContext().VisitIterationBody(node.Statement, tx.topLevelNestedVisitor),
+		)
+	}
+
+	// given:
+	//   export var x;
+	//   for (var x in y) { ... }
+	// emits:
+	//   for (x in y) {
+	//     exports_1("x", x);
+	//     ...
+	//   }
+	declaration := initializer.AsVariableDeclarationList().Declarations.Nodes[0]
+	tx.hoistBindingElement(declaration)
+	var target *ast.Expression
+	if ast.IsBindingPattern(declaration.Name()) {
+		target = transformers.ConvertBindingPatternToAssignmentPattern(tx.EmitContext(), declaration.Name().AsBindingPattern())
+		target = tx.assignmentPatternVisitor.VisitNode(target)
+	} else {
+		target = declaration.Name().Clone(tx.Factory())
+	}
+	target.Loc = initializer.Loc
+
+	exportStatements := tx.getExportsOfBindingName(nil, declaration.Name())
+	body := tx.EmitContext().VisitIterationBody(node.Statement, tx.topLevelNestedVisitor)
+	if len(exportStatements) > 0 {
+		if ast.IsBlock(body) {
+			block := body.AsBlock()
+			bodyStatementList := tx.Factory().NewNodeList(append(exportStatements, block.Statements.Nodes...))
+			bodyStatementList.Loc = block.Statements.Loc
+			body = tx.Factory().UpdateBlock(block, bodyStatementList)
+		} else {
+			body = tx.Factory().NewBlock(tx.Factory().NewNodeList(append(exportStatements, body)), true /*multiLine*/)
+		}
+	}
+
+	return tx.Factory().UpdateForInOrOfStatement(
+		node,
+		node.AwaitModifier,
+		target,
+		tx.Visitor().VisitNode(node.Expression),
+		body,
+	)
+}
+
+func (tx *SystemModuleTransformer) visitBinaryExpression(node *ast.BinaryExpression, resultIsDiscarded bool) *ast.Node {
+	if ast.IsAssignmentExpression(node.AsNode(), false /*excludeCompoundAssignment*/) {
+		return tx.visitAssignmentExpression(node)
+	}
+
+	if ast.IsCommaExpression(node.AsNode()) {
+		left := tx.discardedValueVisitor.VisitNode(node.Left)
+		right := core.IfElse(resultIsDiscarded, tx.discardedValueVisitor, tx.Visitor()).VisitNode(node.Right)
+		return tx.Factory().UpdateBinaryExpression(node, nil /*modifiers*/, left, nil /*typeNode*/, node.OperatorToken, right)
+	}
+
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Determines whether an identifier may be the target of an assignment to an exported binding.
+func (tx *SystemModuleTransformer) isExportableAssignmentTarget(node *ast.Node) bool {
+	return ast.IsIdentifier(node) &&
+		(!transformers.IsGeneratedIdentifier(tx.EmitContext(), node) || isFileLevelReservedGeneratedIdentifier(tx.EmitContext(), node)) &&
+		!transformers.IsLocalName(tx.EmitContext(), node)
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentExpression(node *ast.BinaryExpression) *ast.Node {
+	if ast.IsDestructuringAssignment(node.AsNode()) {
+		return tx.Factory().UpdateBinaryExpression(
+			node,
+			nil, /*modifiers*/
+			tx.assignmentPatternVisitor.VisitNode(node.Left),
+			nil, /*typeNode*/
+			node.OperatorToken,
+			tx.Visitor().VisitNode(node.Right),
+		)
+	}
+
+	// When we see an assignment expression whose left-hand side is an exported symbol, we should ensure all exports
+	// of that symbol are updated with the correct value.
+	if tx.isExportableAssignmentTarget(node.Left) {
+		exportedNames := tx.getExports(node.Left)
+		if len(exportedNames) > 0 {
+			expression := tx.Visitor().VisitEachChild(node.AsNode())
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+			return expression
+		}
+	}
+
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *SystemModuleTransformer) visitShorthandAssignmentProperty(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	target := tx.visitDestructuringAssignmentTargetNoStack(node.Name())
+	if ast.IsIdentifier(target) {
+		return tx.Factory().UpdateShorthandPropertyAssignment(
+			node,
+			nil, /*modifiers*/
+			target,
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			node.EqualsToken,
+			tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+		)
+	}
+	if node.ObjectAssignmentInitializer != nil {
+		equalsToken := node.EqualsToken
+		if equalsToken == nil {
+			equalsToken = tx.Factory().NewToken(ast.KindEqualsToken)
+		}
+		target = tx.Factory().NewBinaryExpression(
+			nil, /*modifiers*/
+			target,
+			nil, /*typeNode*/
+			equalsToken,
+			tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+		)
+	}
+	updated := tx.Factory().NewPropertyAssignment(
+		nil, /*modifiers*/
+		node.Name(),
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		target,
+	)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(updated, node.AsNode())
+	return updated
+}
+
+func (tx *SystemModuleTransformer) visitDestructuringAssignmentTarget(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	switch node.Kind {
+	case ast.KindObjectLiteralExpression, ast.KindArrayLiteralExpression:
+		node = tx.visitAssignmentPatternNoStack(node)
+	default:
+		node = tx.visitDestructuringAssignmentTargetNoStack(node)
+	}
+	return node
+}
+
+func (tx *SystemModuleTransformer) visitDestructuringAssignmentTargetNoStack(node *ast.Node) *ast.Node {
+	if tx.isExportableAssignmentTarget(node) {
+		exportedNames := tx.getExports(node)
+		if len(exportedNames) > 0 {
+			// transforms:
+			//  var x;
+			//  export { x }
+			//  { x: x } = y
+			// to:
+			//  { x: { set value(v) { exports_1("x", x = v); } }.value } = y
+
+			value := tx.Factory().NewUniqueNameEx("value", printer.AutoGenerateOptions{
+				Flags: printer.GeneratedIdentifierFlagsOptimistic,
+			})
+			expression := tx.Factory().NewAssignmentExpression(node.Clone(tx.Factory()), value)
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+			}
+
+			param := tx.createParameter(value)
+			valueSetter := tx.Factory().NewSetAccessorDeclaration(
+				nil, /*modifiers*/
+				tx.Factory().NewIdentifier("value"),
+				nil, /*typeParameters*/
+				tx.Factory().NewNodeList([]*ast.Node{param}),
+				nil, /*returnType*/
+				nil, /*fullSignature*/
+				tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Node{tx.Factory().NewExpressionStatement(expression)}), false /*multiLine*/),
+			)
+			object := tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{valueSetter}), false /*multiLine*/)
+			return tx.Factory().NewPropertyAccessExpression(object, nil /*questionDotToken*/, tx.Factory().NewIdentifier("value"), ast.NodeFlagsNone)
+		}
+		return node
+	}
+
+	return tx.visitNoStack(node, false /*resultIsDiscarded*/)
+}
+
+// Visits a prefix unary expression that might modify an exported identifier.
+func (tx *SystemModuleTransformer) visitPrefixUnaryExpression(node *ast.PrefixUnaryExpression) *ast.Node {
+	// given:
+	//   export var x = 0;
+	//   ++x;
+	// emits:
+	//   exports_1("x", x = 0);
+	//   exports_1("x", ++x);
+	if (node.Operator == ast.KindPlusPlusToken || node.Operator == ast.KindMinusMinusToken) &&
+		ast.IsIdentifier(node.Operand) &&
+		!transformers.IsGeneratedIdentifier(tx.EmitContext(), node.Operand) &&
+		!transformers.IsLocalName(tx.EmitContext(), node.Operand) {
+		exportedNames := tx.getExports(node.Operand)
+		if len(exportedNames) > 0 {
+			expression := tx.Factory().UpdatePrefixUnaryExpression(node, tx.Visitor().VisitNode(node.Operand))
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+			return expression
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Visits a postfix unary expression that might modify an exported identifier.
+func (tx *SystemModuleTransformer) visitPostfixUnaryExpression(node *ast.PostfixUnaryExpression, resultIsDiscarded bool) *ast.Node {
+	if (node.Operator == ast.KindPlusPlusToken || node.Operator == ast.KindMinusMinusToken) &&
+		ast.IsIdentifier(node.Operand) &&
+		!transformers.IsGeneratedIdentifier(tx.EmitContext(), node.Operand) &&
+		!transformers.IsLocalName(tx.EmitContext(), node.Operand) {
+		exportedNames := tx.getExports(node.Operand)
+		if len(exportedNames) > 0 {
+			// given (value is discarded):
+			//   export var x = 0;
+			//   x++;
+			// emits:
+			//   exports_1("x", (x++, x));
+			//
+			// given (value is not discarded):
+			//   export var x = 0;
+			//   y = x++;
+			// emits:
+			//   y = (exports_1("x", (_a = x++, x)), _a);
+
+			var temp *ast.IdentifierNode
+			expression := tx.Factory().UpdatePostfixUnaryExpression(node, tx.Visitor().VisitNode(node.Operand))
+			if !resultIsDiscarded {
+				temp = tx.Factory().NewTempVariable()
+				tx.EmitContext().AddVariableDeclaration(temp)
+				expression = tx.Factory().NewAssignmentExpression(temp, expression)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+
+			expression = tx.Factory().NewCommaExpression(expression, node.Operand.Clone(tx.Factory()))
+			tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+
+			if temp != nil {
+				expression = tx.Factory().NewCommaExpression(expression, temp.AsNode())
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+			return expression
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Visits a call expression that might be an `import()` call that must be rewritten to use the context object of the
+// module.
+func (tx *SystemModuleTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if ast.IsImportCall(node.AsNode()) && ast.ShouldTransformImportCall(tx.currentSourceFile.FileName(), tx.compilerOptions, tx.getEmitModuleFormatOfFile(tx.currentSourceFile)) {
+		return tx.visitImportCallExpression(node)
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Visits an `import()` call, rewriting it to a call to the `import` method of the context object of the module:
+//
+//	context_1.import("mod")
+func (tx *SystemModuleTransformer) visitImportCallExpression(node *ast.CallExpression) *ast.Node {
+	externalModuleName := getExternalModuleNameLiteral(tx.Factory(), node.AsNode(), tx.currentSourceFile, nil /*host*/, nil /*resolver*/, tx.compilerOptions)
+	firstArgument := tx.Visitor().VisitNode(core.FirstOrNil(node.Arguments.Nodes))
+
+	// Only use the external module name if it differs from the first argument. This allows us to preserve the quote style of the argument on output.
+	var argument *ast.Expression
+	if externalModuleName != nil && (firstArgument == nil || !ast.IsStringLiteral(firstArgument) || firstArgument.Text() != externalModuleName.Text()) {
+		argument = externalModuleName
+	} else if firstArgument != nil && tx.compilerOptions.RewriteRelativeImportExtensions.IsTrue() {
+		if ast.IsStringLiteral(firstArgument) {
+			argument = rewriteModuleSpecifier(tx.EmitContext(), firstArgument, tx.compilerOptions)
+		} else {
+			argument = tx.Factory().NewRewriteRelativeImportExtensionsHelper(firstArgument, tx.compilerOptions.Jsx == core.JsxEmitPreserve)
+		}
+	} else {
+		argument = firstArgument
+	}
+
+	var arguments []*ast.Expression
+	if argument != nil {
+		arguments = append(arguments, argument)
+	}
+	updated := tx.Factory().NewCallExpression(
+		tx.Factory().NewPropertyAccessExpression(tx.contextObject.Clone(tx.Factory()), nil /*questionDotToken*/, tx.Factory().NewIdentifier("import"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(updated, node.AsNode())
+	return updated
+}
+
+// Visits an `import.meta` meta-property, rewriting it to the `meta` property of the context object of the module.
+func (tx *SystemModuleTransformer) visitMetaProperty(node *ast.MetaProperty) *ast.Node {
+	if node.KeywordToken == ast.KindImportKeyword && tx.contextObject != nil {
+		reference := tx.Factory().NewPropertyAccessExpression(tx.contextObject.Clone(tx.Factory()), nil /*questionDotToken*/, tx.Factory().NewIdentifier("meta"), ast.NodeFlagsNone)
+		tx.EmitContext().AssignCommentAndSourceMapRanges(reference, node.AsNode())
+		reference.Loc = node.Loc
+		return reference
+	}
+	return node.AsNode()
+}
+
+// Visits a shorthand property assignment that might reference an imported symbol.
+func (tx *SystemModuleTransformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	name := node.Name()
+	importedName := tx.visitExpressionIdentifier(name)
+	if importedName != name {
+		expression := importedName
+		if node.ObjectAssignmentInitializer != nil {
+			expression = tx.Factory().NewAssignmentExpression(
+				expression,
+				tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+			)
+		}
+		assignment := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, name, nil /*postfixToken*/, nil /*typeNode*/, expression)
+		assignment.Loc = node.Loc
+		tx.EmitContext().AssignCommentAndSourceMapRanges(assignment, node.AsNode())
+		return assignment
+	}
+	return tx.Factory().UpdateShorthandPropertyAssignment(node,
+		nil, /*modifiers*/
+		importedName,
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		node.EqualsToken,
+		tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+	)
+}
+
+// Visits an identifier that, if it is in an expression position, might reference an imported symbol.
+func (tx *SystemModuleTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if transformers.IsIdentifierReference(node, tx.parentNode) {
+		return tx.visitExpressionIdentifier(node)
+	}
+	return node
+}
+
+// Visits an identifier in an expression position that might reference an imported symbol. Unlike CommonJS, exported
+// declarations of a SystemJS module are hoisted locals and need not be rewritten.
+func (tx *SystemModuleTransformer) visitExpressionIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if info := tx.EmitContext().GetAutoGenerateInfo(node); !(info != nil && !info.Flags.HasAllowNameSubstitution()) &&
+		!transformers.IsHelperName(tx.EmitContext(), node) &&
+		!transformers.IsLocalName(tx.EmitContext(), node) &&
+		!isDeclarationNameOfEnumOrNamespace(tx.EmitContext(), node) {
+		importDeclaration := tx.resolver.GetReferencedImportDeclaration(tx.EmitContext().MostOriginal(node))
+		if importDeclaration != nil {
+			if ast.IsImportClause(importDeclaration) {
+				reference := tx.Factory().NewPropertyAccessExpression(
+					tx.Factory().NewGeneratedNameForNode(importDeclaration.Parent),
+					nil, /*questionDotToken*/
+					tx.Factory().NewIdentifier("default"),
+					ast.NodeFlagsNone,
+				)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(reference, node)
+				reference.Loc = node.Loc
+				return reference
+			}
+			if ast.IsImportSpecifier(importDeclaration) {
+				name := importDeclaration.AsImportSpecifier().PropertyNameOrName()
+				decl := ast.FindAncestor(importDeclaration, ast.IsImportDeclaration)
+				target := tx.Factory().NewGeneratedNameForNode(core.Coalesce(decl, importDeclaration))
+				var reference *ast.Node
+				if ast.IsStringLiteral(name) {
+					reference = tx.Factory().NewElementAccessExpression(
+						target,
+						nil, /*questionDotToken*/
+						tx.Factory().NewStringLiteralFromNode(name),
+						ast.NodeFlagsNone,
+					)
+				} else {
+					referenceName := name.Clone(tx.Factory())
+					tx.EmitContext().AddEmitFlags(referenceName, printer.EFNoSourceMap|printer.EFNoComments)
+					reference = tx.Factory().NewPropertyAccessExpression(
+						target,
+						nil, /*questionDotToken*/
+						referenceName,
+						ast.NodeFlagsNone,
+					)
+				}
+				tx.EmitContext().AssignCommentAndSourceMapRanges(reference, node)
+				reference.Loc = node.Loc
+				return reference
+			}
+		}
+	}
+	return node
+}
+
+// Gets the exported names of an identifier, if it is exported.
+func (tx *SystemModuleTransformer) getExports(name *ast.IdentifierNode) []*ast.ModuleExportName {
+	if !transformers.IsGeneratedIdentifier(tx.EmitContext(), name) {
+		original := tx.EmitContext().MostOriginal(name)
+		importDeclaration := tx.resolver.GetReferencedImportDeclaration(original)
+		if importDeclaration != nil {
+			return tx.currentModuleInfo.exportedBindings.Get(importDeclaration)
+		}
+
+		var seen collections.Set[string]
+		var exportedNames []*ast.ModuleExportName
+		addExportedName := func(exportName *ast.ModuleExportName) {
+			if exportName.Kind == ast.KindStringLiteral || !seen.Has(exportName.Text()) {
+				if exportName.Kind != ast.KindStringLiteral {
+					seen.Add(exportName.Text())
+				}
+				exportedNames = append(exportedNames, exportName)
+			}
+		}
+
+		// A variable declared with `export var` is exported under its own name.
+		if exportContainer := tx.resolver.GetReferencedExportContainer(original, false /*prefixLocals*/); exportContainer != nil && ast.IsSourceFile(exportContainer) {
+			addExportedName(name)
+		}
+
+		// An exported namespace or enum may merge with an ambient declaration, which won't show up in .js emit, so
+		// we analyze all value exports of a symbol.
+		for _, declaration := range tx.resolver.GetReferencedValueDeclarations(original) {
+			if declaration.ModifierFlags()&ast.ModifierFlagsExportDefault == ast.ModifierFlagsExportDefault {
+				addExportedName(tx.Factory().NewIdentifier("default"))
+				continue
+			}
+			for _, exportName := range tx.currentModuleInfo.exportedBindings.Get(declaration) {
+				addExportedName(exportName)
+			}
+		}
+		return exportedNames
+	} else if isFileLevelReservedGeneratedIdentifier(tx.EmitContext(), name) {
+		exportSpecifiers := tx.currentModuleInfo.exportSpecifiers.Get(name.Text())
+		if exportSpecifiers != nil {
+			var exportedNames []*ast.ModuleExportName
+			for _, exportSpecifier := range exportSpecifiers {
+				exportedNames = append(exportedNames, exportSpecifier.Name())
+			}
+			return exportedNames
+		}
+	}
+	return nil
+}
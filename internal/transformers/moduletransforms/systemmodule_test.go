@@ -0,0 +1,408 @@
+package moduletransforms_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/binder"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/testutil/emittestutil"
+	"github.com/microsoft/typescript-go/internal/testutil/parsetestutil"
+	"github.com/microsoft/typescript-go/internal/transformers/moduletransforms"
+	"github.com/microsoft/typescript-go/internal/transformers/tstransforms"
+)
+
+func TestSystemModuleTransformer(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		input   string
+		output  string
+		options *core.CompilerOptions
+	}{
+		// ImportDeclaration
+		{
+			title: "ImportDeclaration#1",
+			input: `import "other"`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (_a) {
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportDeclaration#2",
+			input: `import * as a from "other";
+a;`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var a;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (a_1) {
+                a = a_1;
+            }
+        ],
+        execute: function () {
+            a;
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportDeclaration#3",
+			input: `import d, { a, b as c } from "other";
+d; a; c;`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var other_1;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (other_1_1) {
+                other_1 = other_1_1;
+            }
+        ],
+        execute: function () {
+            other_1.default;
+            other_1.a;
+            other_1.b;
+        }
+    };
+});`,
+		},
+
+		// ExportDeclaration
+		{
+			title: "ExportDeclaration#1",
+			input: `export * from "other";`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    function exportStar_1(m) {
+        var exports = {};
+        for (var n in m) {
+            if (n !== "default") exports[n] = m[n];
+        }
+        exports_1(exports);
+    }
+    return {
+        setters: [
+            function (other_1_1) {
+                exportStar_1(other_1_1);
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportDeclaration#2",
+			input: `export { a, b as c } from "other";`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (other_1_1) {
+                exports_1({
+                    "a": other_1_1["a"],
+                    "c": other_1_1["b"]
+                });
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportDeclaration#3",
+			input: `export * as ns from "other";`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (ns_1) {
+                exports_1("ns", ns_1);
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportDeclaration#4",
+			input: `import { a } from "other";
+export { a };`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var other_1;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (other_1_1) {
+                other_1 = other_1_1;
+            }
+        ],
+        execute: function () {
+            exports_1("a", other_1.a);
+        }
+    };
+});`,
+		},
+
+		// ExportAssignment
+		{
+			title: "ExportAssignment#1",
+			input: `export default 1;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("default", 1);
+        }
+    };
+});`,
+		},
+
+		// FunctionDeclaration
+		{
+			title: "FunctionDeclaration#1",
+			input: `export function f() {}
+export default function g() {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    function f() { }
+    exports_1("f", f);
+    function g() { }
+    exports_1("default", g);
+    return {
+        setters: [],
+        execute: function () {
+        }
+    };
+});`,
+		},
+
+		// ClassDeclaration
+		{
+			title: "ClassDeclaration#1",
+			input: `export class C {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var C;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            C = class C {
+            };
+            exports_1("C", C);
+        }
+    };
+});`,
+		},
+
+		// VariableStatement
+		{
+			title: "VariableStatement#1",
+			input: `export var x = 1, y;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x, y;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("x", x = 1);
+        }
+    };
+});`,
+		},
+		{
+			title: "VariableStatement#2",
+			input: `let x = 1;
+export { x as y };`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("y", x = 1);
+        }
+    };
+});`,
+		},
+		{
+			title: "VariableStatement#3 (nested)",
+			input: `if (true) {
+    var x = 1;
+    let y = 2;
+}
+export {};`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            if (true) {
+                x = 1;
+                let y = 2;
+            }
+        }
+    };
+});`,
+		},
+
+		// ForInStatement
+		{
+			title: "ForInStatement#1",
+			input: `export var k;
+for (var k in {}) {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var k;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            for (k in {}) {
+                exports_1("k", k);
+            }
+        }
+    };
+});`,
+		},
+
+		// Assignments
+		{
+			title: "AssignmentExpression#1",
+			input: `export let x = 0;
+x = 1;
+x++;
+++x;
+let y = x--;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x, y, _a;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("x", x = 0);
+            exports_1("x", x = 1);
+            exports_1("x", (x++, x));
+            exports_1("x", ++x);
+            y = (exports_1("x", (_a = x--, x)), _a);
+        }
+    };
+});`,
+		},
+		{
+			title: "AssignmentExpression#2 (destructuring)",
+			input: `export let x;
+({ x } = { x: 1 });`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            ({ x: { set value(value) { exports_1("x", x = value); } }.value } = { x: 1 });
+        }
+    };
+});`,
+		},
+
+		// Dynamic import and import.meta
+		{
+			title: "CallExpression#1 (dynamic import)",
+			input: `import("other");`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            context_1.import("other");
+        }
+    };
+});`,
+		},
+		{
+			title: "MetaProperty#1",
+			input: `export const url = import.meta.url;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var url;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("url", url = context_1.meta.url);
+        }
+    };
+});`,
+		},
+
+		// Top-level await
+		{
+			title: "AwaitExpression#1",
+			input: `await import("other");`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: async function () {
+            await context_1.import("other");
+        }
+    };
+});`,
+			options: &core.CompilerOptions{Target: core.ScriptTargetESNext},
+		},
+	}
+	for _, rec := range data {
+		t.Run(rec.title, func(t *testing.T) {
+			t.Parallel()
+
+			compilerOptions := rec.options
+			if compilerOptions == nil {
+				compilerOptions = &core.CompilerOptions{}
+			}
+
+			compilerOptions.Module = core.ModuleKindSystem
+
+			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
+			parsetestutil.CheckDiagnostics(t, file)
+			binder.BindSourceFile(file)
+
+			emitContext := printer.NewEmitContext()
+			resolver := binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+
+			file = tstransforms.NewRuntimeSyntaxTransformer(emitContext, compilerOptions, resolver).TransformSourceFile(file)
+			file = moduletransforms.NewSystemModuleTransformer(emitContext, compilerOptions, resolver, fakeGetEmitModuleFormatOfFile).TransformSourceFile(file)
+			emittestutil.CheckEmit(t, emitContext, file, rec.output)
+		})
+	}
+}
@@ -117,3 +117,24 @@ func isFileLevelReservedGeneratedIdentifier(emitContext *printer.EmitContext, na
 func isSimpleInlineableExpression(expression *ast.Expression) bool {
 	return !ast.IsIdentifier(expression) && transformers.IsSimpleCopiableExpression(expression)
 }
+
+// Gets the local name for an external import, if one exists. AMD and SystemJS modules use this name to bind the
+// dependency in the module factory or setter function.
+func getLocalNameForExternalImport(emitContext *printer.EmitContext, node *ast.Node /*ImportDeclaration | ImportEqualsDeclaration | ExportDeclaration*/) *ast.IdentifierNode {
+	namespaceDeclaration := ast.GetNamespaceDeclarationNode(node)
+	if namespaceDeclaration != nil && !ast.IsDefaultImport(node) && !ast.IsExportNamespaceAsDefaultDeclaration(node) {
+		name := namespaceDeclaration.Name()
+		if ast.IsStringLiteral(name) {
+			return emitContext.Factory.NewGeneratedNameForNode(node)
+		}
+		if transformers.IsGeneratedIdentifier(emitContext, name) {
+			return name
+		}
+		return emitContext.Factory.NewIdentifier(name.Text())
+	}
+	if ast.IsImportDeclaration(node) && node.AsImportDeclaration().ImportClause != nil ||
+		ast.IsExportDeclaration(node) && node.AsExportDeclaration().ModuleSpecifier != nil {
+		return emitContext.Factory.NewGeneratedNameForNode(node)
+	}
+	return nil
+}
@@ -2,9 +2,16 @@ package transformers
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/printer"
 )
 
+// TransformOptions holds the inputs shared by the transformers composed by a [TransformerFactory].
+type TransformOptions struct {
+	Context         *printer.EmitContext
+	CompilerOptions *core.CompilerOptions
+}
+
 type Transformer struct {
 	emitContext *printer.EmitContext
 	factory     *printer.NodeFactory
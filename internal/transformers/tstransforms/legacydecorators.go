@@ -0,0 +1,660 @@
+package tstransforms
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
+)
+
+// Transforms legacy decorators (`experimentalDecorators`) into calls to the `__decorate` and `__param` helpers, and
+// records design-time types with the `__metadata` helper when `emitDecoratorMetadata` is enabled.
+type LegacyDecoratorsTransformer struct {
+	transformers.Transformer
+	compilerOptions *core.CompilerOptions
+	resolver        printer.EmitResolver
+	serializer      *metadataSerializer
+	parentNode      *ast.Node
+	currentNode     *ast.Node
+
+	// Maps each decorated class declaration whose body is being visited to the alias used for references to the class
+	// from within its body, or to nil if no such reference has been seen yet.
+	classAliases map[*ast.Node]*ast.IdentifierNode
+}
+
+func NewLegacyDecoratorsTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver printer.EmitResolver) *transformers.Transformer {
+	tx := &LegacyDecoratorsTransformer{compilerOptions: compilerOptions, resolver: resolver}
+	if compilerOptions.EmitDecoratorMetadata.IsTrue() {
+		tx.serializer = newMetadataSerializer(emitContext, compilerOptions, resolver)
+	}
+	return tx.NewTransformer(tx.visit, emitContext)
+}
+
+// Pushes a new child node onto the ancestor tracking stack, returning the grandparent node to be restored later via `popNode`.
+func (tx *LegacyDecoratorsTransformer) pushNode(node *ast.Node) (grandparentNode *ast.Node) {
+	grandparentNode = tx.parentNode
+	tx.parentNode = tx.currentNode
+	tx.currentNode = node
+	return
+}
+
+// Pops the last child node off the ancestor tracking stack, restoring the grandparent node.
+func (tx *LegacyDecoratorsTransformer) popNode(grandparentNode *ast.Node) {
+	tx.currentNode = tx.parentNode
+	tx.parentNode = grandparentNode
+}
+
+func (tx *LegacyDecoratorsTransformer) visit(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsDecorators == 0 &&
+		(len(tx.classAliases) == 0 || node.SubtreeFacts()&ast.SubtreeContainsIdentifier == 0) {
+		return node
+	}
+
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindDecorator:
+		// Decorators are applied by the `__decorate` calls generated for the declarations they decorate.
+		return nil
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindConstructor, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindPropertyDeclaration:
+		return tx.visitClassElement(node)
+	case ast.KindParameter:
+		return tx.visitParameterDeclaration(node.AsParameterDeclaration())
+	case ast.KindIdentifier:
+		if len(tx.classAliases) > 0 && transformers.IsIdentifierReference(node, tx.parentNode) {
+			return tx.visitExpressionIdentifier(node)
+		}
+		return node
+	case ast.KindShorthandPropertyAssignment:
+		return tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *LegacyDecoratorsTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+func (tx *LegacyDecoratorsTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	var statements []*ast.Statement
+	if classOrConstructorParameterIsDecorated(node.AsNode()) {
+		statements = tx.transformClassDeclarationWithClassDecorators(node)
+	} else if childIsDecorated(node.AsNode()) {
+		statements = tx.transformClassDeclarationWithoutClassDecorators(node)
+	} else {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+// Transforms a non-decorated class declaration whose members are decorated.
+//
+// Transforms this:
+//
+//	class C {
+//	    @dec method() {}
+//	}
+//
+// Into this:
+//
+//	class C {
+//	    method() {}
+//	}
+//	__decorate([
+//	    dec
+//	], C.prototype, "method", null);
+func (tx *LegacyDecoratorsTransformer) transformClassDeclarationWithoutClassDecorators(node *ast.ClassDeclaration) []*ast.Statement {
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	members, decorationStatements := tx.transformClassMembers(node.AsNode())
+	updated := tx.Factory().UpdateClassDeclaration(node, modifiers, node.Name(), nil /*typeParameters*/, heritageClauses, members)
+	return append([]*ast.Statement{updated}, decorationStatements...)
+}
+
+// Transforms a decorated class declaration into a `let` declaration for a class expression, so that the decorated
+// class can be assigned to its binding.
+//
+// Transforms this:
+//
+//	@dec
+//	export class C {
+//	    static m() { return C; }
+//	}
+//
+// Into this:
+//
+//	var C_1;
+//	let C = C_1 = class C {
+//	    static m() { return C_1; }
+//	};
+//	C = C_1 = __decorate([
+//	    dec
+//	], C);
+//	export { C };
+func (tx *LegacyDecoratorsTransformer) transformClassDeclarationWithClassDecorators(node *ast.ClassDeclaration) []*ast.Statement {
+	isExport := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport)
+	isDefault := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsDefault)
+	modifiers := transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ast.ModifierFlagsModifier&^ast.ModifierFlagsExportDefault)
+	location := moveRangePastModifiers(node.AsNode())
+	declarationName := tx.Factory().GetLocalNameEx(node.AsNode(), printer.AssignedNameOptions{AllowSourceMaps: true})
+
+	// References to the class from within its body must see the decorated class, so they are redirected to an alias
+	// that is assigned both the undecorated and decorated classes.
+	parseNode := tx.EmitContext().ParseNode(node.AsNode())
+	trackClassAlias := parseNode != nil && parseNode.Name() != nil && tx.resolver != nil
+	if trackClassAlias {
+		if tx.classAliases == nil {
+			tx.classAliases = make(map[*ast.Node]*ast.IdentifierNode)
+		}
+		tx.classAliases[parseNode] = nil
+	}
+
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	members, decorationStatements := tx.transformClassMembers(node.AsNode())
+
+	var classAlias *ast.IdentifierNode
+	if trackClassAlias {
+		classAlias = tx.classAliases[parseNode]
+		delete(tx.classAliases, parseNode)
+		if classAlias != nil {
+			tx.EmitContext().AddVariableDeclaration(classAlias)
+		}
+	}
+
+	// If we're emitting to ES2022 or later then we need to reassign the class alias before static initializers are
+	// evaluated.
+	assignClassAliasInStaticBlock := tx.compilerOptions.GetEmitScriptTarget() >= core.ScriptTargetES2022 &&
+		classAlias != nil &&
+		core.Some(members.Nodes, func(member *ast.Node) bool {
+			return ast.IsPropertyDeclaration(member) && ast.HasStaticModifier(member) || ast.IsClassStaticBlockDeclaration(member)
+		})
+	if assignClassAliasInStaticBlock {
+		staticBlock := tx.Factory().NewClassStaticBlockDeclaration(nil /*modifiers*/, tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(classAlias.Clone(tx.Factory()), tx.Factory().NewThisExpression())),
+		}), false /*multiLine*/))
+		newMembers := tx.Factory().NewNodeList(append([]*ast.Node{staticBlock}, members.Nodes...))
+		newMembers.Loc = members.Loc
+		members = newMembers
+	}
+
+	var className *ast.IdentifierNode
+	if name := node.Name(); name != nil && !transformers.IsGeneratedIdentifier(tx.EmitContext(), name) {
+		className = name
+	}
+	classExpression := tx.Factory().NewClassExpression(modifiers, className, nil /*typeParameters*/, heritageClauses, members)
+	tx.EmitContext().SetOriginal(classExpression, node.AsNode())
+	classExpression.Loc = location
+
+	// let ${name} = ${classExpression} where name is either declaredName if the class doesn't contain self-reference
+	// or decoratedClassAlias if the class contain self-reference.
+	varInitializer := classExpression
+	if classAlias != nil && !assignClassAliasInStaticBlock {
+		varInitializer = tx.Factory().NewAssignmentExpression(classAlias.Clone(tx.Factory()), classExpression)
+	}
+	varDecl := tx.Factory().NewVariableDeclaration(declarationName, nil /*exclamationToken*/, nil /*typeNode*/, varInitializer)
+	tx.EmitContext().SetOriginal(varDecl, node.AsNode())
+
+	varDeclList := tx.Factory().NewVariableDeclarationList(ast.NodeFlagsLet, tx.Factory().NewNodeList([]*ast.Node{varDecl}))
+	varStatement := tx.Factory().NewVariableStatement(nil /*modifiers*/, varDeclList)
+	tx.EmitContext().SetOriginal(varStatement, node.AsNode())
+	varStatement.Loc = location
+	tx.EmitContext().SetCommentRange(varStatement, node.Loc)
+
+	statements := []*ast.Statement{varStatement}
+	statements = append(statements, decorationStatements...)
+	if expression := tx.generateConstructorDecorationExpression(node.AsNode(), classAlias); expression != nil {
+		statement := tx.Factory().NewExpressionStatement(expression)
+		tx.EmitContext().SetOriginal(statement, node.AsNode())
+		statements = append(statements, statement)
+	}
+
+	if isExport {
+		if isDefault {
+			// export default C;
+			statements = append(statements, tx.Factory().NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, declarationName.Clone(tx.Factory())))
+		} else {
+			// export { C };
+			statements = append(statements, tx.Factory().NewExportDeclaration(
+				nil,   /*modifiers*/
+				false, /*isTypeOnly*/
+				tx.Factory().NewNamedExports(tx.Factory().NewNodeList([]*ast.Node{
+					tx.Factory().NewExportSpecifier(false /*isTypeOnly*/, nil /*propertyName*/, tx.Factory().GetDeclarationName(node.AsNode())),
+				})),
+				nil, /*moduleSpecifier*/
+				nil, /*attributes*/
+			))
+		}
+	}
+	return statements
+}
+
+// Visits the members of a class with decorated members, returning the visited members and the statements that
+// decorate them.
+func (tx *LegacyDecoratorsTransformer) transformClassMembers(node *ast.ClassLikeDeclaration) (*ast.NodeList, []*ast.Statement) {
+	var members []*ast.ClassElement
+	for _, member := range node.Members() {
+		visited := tx.Visitor().VisitNode(member)
+		if visited == nil {
+			continue
+		}
+		// A computed property name of a decorated member is evaluated once, and then reused as the member name passed
+		// to `__decorate`.
+		if name := member.Name(); name != nil && ast.IsComputedPropertyName(name) && nodeOrChildIsDecorated(member, node) {
+			expression := visited.Name().Expression()
+			if !isSimpleInlineableExpression(ast.SkipPartiallyEmittedExpressions(expression)) {
+				generatedName := tx.Factory().NewGeneratedNameForNode(name)
+				tx.EmitContext().AddVariableDeclaration(generatedName)
+				updatedName := tx.Factory().UpdateComputedPropertyName(visited.Name().AsComputedPropertyName(), tx.Factory().NewAssignmentExpression(generatedName, expression))
+				visited = tx.updateClassElementName(visited, updatedName)
+			}
+		}
+		members = append(members, visited)
+	}
+
+	var decorationStatements []*ast.Statement
+	decorationStatements = tx.addClassElementDecorationStatements(decorationStatements, node, false /*isStatic*/)
+	decorationStatements = tx.addClassElementDecorationStatements(decorationStatements, node, true /*isStatic*/)
+
+	// Decorators that use a private name in an `in` expression must be evaluated within the class body.
+	if hasClassElementWithDecoratorContainingPrivateIdentifierInExpression(node) {
+		staticBlock := tx.Factory().NewClassStaticBlockDeclaration(nil /*modifiers*/, tx.Factory().NewBlock(tx.Factory().NewNodeList(decorationStatements), true /*multiLine*/))
+		members = append(members, staticBlock)
+		decorationStatements = nil
+	}
+
+	memberList := tx.Factory().NewNodeList(members)
+	memberList.Loc = node.MemberList().Loc
+	return memberList, decorationStatements
+}
+
+func (tx *LegacyDecoratorsTransformer) updateClassElementName(node *ast.ClassElement, name *ast.PropertyName) *ast.ClassElement {
+	switch node.Kind {
+	case ast.KindMethodDeclaration:
+		n := node.AsMethodDeclaration()
+		return tx.Factory().UpdateMethodDeclaration(n, n.Modifiers(), n.AsteriskToken, name, n.PostfixToken, n.TypeParameters, n.Parameters, n.Type, n.FullSignature, n.Body)
+	case ast.KindGetAccessor:
+		n := node.AsGetAccessorDeclaration()
+		return tx.Factory().UpdateGetAccessorDeclaration(n, n.Modifiers(), name, n.TypeParameters, n.Parameters, n.Type, n.FullSignature, n.Body)
+	case ast.KindSetAccessor:
+		n := node.AsSetAccessorDeclaration()
+		return tx.Factory().UpdateSetAccessorDeclaration(n, n.Modifiers(), name, n.TypeParameters, n.Parameters, n.Type, n.FullSignature, n.Body)
+	case ast.KindPropertyDeclaration:
+		n := node.AsPropertyDeclaration()
+		return tx.Factory().UpdatePropertyDeclaration(n, n.Modifiers(), name, n.PostfixToken, n.Type, n.Initializer)
+	default:
+		return node
+	}
+}
+
+func (tx *LegacyDecoratorsTransformer) visitClassElement(node *ast.ClassElement) *ast.Node {
+	updated := tx.Visitor().VisitEachChild(node)
+	if updated != node && updated != nil {
+		// While we emit the source map for the node after skipping decorators and modifiers, we need to emit the
+		// comments for the original range.
+		tx.EmitContext().SetCommentRange(updated, node.Loc)
+		tx.EmitContext().SetSourceMapRange(updated, moveRangePastModifiers(node))
+	}
+	return updated
+}
+
+func (tx *LegacyDecoratorsTransformer) visitParameterDeclaration(node *ast.ParameterDeclaration) *ast.Node {
+	updated := tx.Visitor().VisitEachChild(node.AsNode())
+	if updated != node.AsNode() && updated != nil {
+		tx.EmitContext().SetCommentRange(updated, node.Loc)
+		updated.Loc = moveRangePastModifiers(node.AsNode())
+		tx.EmitContext().SetSourceMapRange(updated, updated.Loc)
+		tx.EmitContext().AddEmitFlags(updated.Name(), printer.EFNoTrailingSourceMap)
+	}
+	return updated
+}
+
+// Visits an identifier in an expression position that might reference a decorated class from within its body.
+func (tx *LegacyDecoratorsTransformer) visitExpressionIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if alias := tx.getClassAliasForReference(node); alias != nil {
+		clone := alias.Clone(tx.Factory())
+		tx.EmitContext().SetSourceMapRange(clone, node.Loc)
+		tx.EmitContext().SetCommentRange(clone, node.Loc)
+		return clone
+	}
+	return node
+}
+
+func (tx *LegacyDecoratorsTransformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	if len(tx.classAliases) > 0 && node.ObjectAssignmentInitializer == nil {
+		if alias := tx.getClassAliasForReference(node.Name()); alias != nil {
+			// { C } -> { C: C_1 }
+			assignment := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, node.Name(), nil /*postfixToken*/, nil /*typeNode*/, alias.Clone(tx.Factory()))
+			assignment.Loc = node.Loc
+			tx.EmitContext().AssignCommentAndSourceMapRanges(assignment, node.AsNode())
+			return assignment
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Gets the alias for a reference to a decorated class from within the class body, creating the alias if needed.
+func (tx *LegacyDecoratorsTransformer) getClassAliasForReference(node *ast.IdentifierNode) *ast.IdentifierNode {
+	if transformers.IsGeneratedIdentifier(tx.EmitContext(), node) {
+		return nil
+	}
+	original := tx.EmitContext().MostOriginal(node)
+	if !ast.IsParseTreeNode(original) {
+		return nil
+	}
+	for class, alias := range tx.classAliases {
+		if class.Name().Text() != node.Text() {
+			continue
+		}
+		if tx.resolver.GetReferencedValueDeclaration(original) != class {
+			continue
+		}
+		if alias == nil {
+			alias = tx.Factory().NewUniqueName(class.Name().Text())
+			tx.classAliases[class] = alias
+		}
+		return alias
+	}
+	return nil
+}
+
+type allDecorators struct {
+	decorators []*ast.Node   // Decorator[]
+	parameters [][]*ast.Node // Decorator[][], indexed by parameter
+}
+
+// Gets the decorators of a class and its constructor parameters.
+func getAllDecoratorsOfClass(node *ast.ClassLikeDeclaration) *allDecorators {
+	decorators := getDecorators(node)
+	parameters := getDecoratorsFromParameters(getFirstConstructorWithBody(node))
+	if len(decorators) == 0 && len(parameters) == 0 {
+		return nil
+	}
+	return &allDecorators{decorators: decorators, parameters: parameters}
+}
+
+// Gets the decorators that apply to a class element and its parameters. The decorators of an accessor pair are
+// collected on the first accessor that has decorators.
+func getAllDecoratorsOfClassElement(member *ast.ClassElement, parent *ast.ClassLikeDeclaration) *allDecorators {
+	switch member.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		if member.Body() == nil {
+			return nil
+		}
+		accessors := getAllAccessorDeclarations(parent.Members(), member)
+		var firstAccessorWithDecorators *ast.Node
+		if ast.HasDecorators(accessors.firstAccessor) {
+			firstAccessorWithDecorators = accessors.firstAccessor
+		} else if accessors.secondAccessor != nil && ast.HasDecorators(accessors.secondAccessor) {
+			firstAccessorWithDecorators = accessors.secondAccessor
+		}
+		if firstAccessorWithDecorators == nil || member != firstAccessorWithDecorators {
+			return nil
+		}
+		decorators := getDecorators(firstAccessorWithDecorators)
+		parameters := getDecoratorsFromParameters(accessors.setAccessor)
+		if len(decorators) == 0 && len(parameters) == 0 {
+			return nil
+		}
+		return &allDecorators{decorators: decorators, parameters: parameters}
+	case ast.KindMethodDeclaration:
+		if member.Body() == nil {
+			return nil
+		}
+		decorators := getDecorators(member)
+		parameters := getDecoratorsFromParameters(member)
+		if len(decorators) == 0 && len(parameters) == 0 {
+			return nil
+		}
+		return &allDecorators{decorators: decorators, parameters: parameters}
+	case ast.KindPropertyDeclaration:
+		decorators := getDecorators(member)
+		if len(decorators) == 0 {
+			return nil
+		}
+		return &allDecorators{decorators: decorators}
+	default:
+		return nil
+	}
+}
+
+func getDecorators(node *ast.Node) []*ast.Node {
+	var decorators []*ast.Node
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				decorators = append(decorators, modifier)
+			}
+		}
+	}
+	return decorators
+}
+
+func getDecoratorsFromParameters(node *ast.Node) [][]*ast.Node {
+	var decorators [][]*ast.Node
+	if node != nil {
+		parameters := node.Parameters()
+		for i, parameter := range parameters {
+			if decorators != nil || ast.HasDecorators(parameter) {
+				if decorators == nil {
+					decorators = make([][]*ast.Node, len(parameters))
+				}
+				decorators[i] = getDecorators(parameter)
+			}
+		}
+	}
+	return decorators
+}
+
+// Transforms all of the decorators for a declaration into an array of expressions, with the type metadata last.
+func (tx *LegacyDecoratorsTransformer) transformAllDecoratorsOfDeclaration(allDecorators *allDecorators, metadata []*ast.Expression) []*ast.Expression {
+	var decoratorExpressions []*ast.Expression
+	for _, decorator := range allDecorators.decorators {
+		decoratorExpressions = append(decoratorExpressions, tx.transformDecorator(decorator))
+	}
+	for parameterOffset, decorators := range allDecorators.parameters {
+		for _, decorator := range decorators {
+			helper := tx.Factory().NewParamHelper(tx.transformDecorator(decorator), parameterOffset, decorator.Expression().Loc)
+			tx.EmitContext().AddEmitFlags(helper, printer.EFNoComments)
+			decoratorExpressions = append(decoratorExpressions, helper)
+		}
+	}
+	return append(decoratorExpressions, metadata...)
+}
+
+func (tx *LegacyDecoratorsTransformer) transformDecorator(decorator *ast.Node) *ast.Expression {
+	return tx.Visitor().VisitNode(decorator.Expression())
+}
+
+// Gets the `__metadata` decorator expressions for a declaration in a class, if decorator metadata is enabled.
+func (tx *LegacyDecoratorsTransformer) getTypeMetadata(node *ast.Node, container *ast.ClassLikeDeclaration) []*ast.Expression {
+	if tx.serializer == nil {
+		return nil
+	}
+	parseNode := tx.EmitContext().ParseNode(node)
+	parseContainer := tx.EmitContext().ParseNode(container)
+	if parseNode == nil || parseNode.Kind != node.Kind || parseContainer == nil || !ast.IsClassLike(parseContainer) {
+		return nil
+	}
+	return tx.serializer.getTypeMetadata(parseNode, parseContainer)
+}
+
+func (tx *LegacyDecoratorsTransformer) addClassElementDecorationStatements(statements []*ast.Statement, node *ast.ClassLikeDeclaration, isStatic bool) []*ast.Statement {
+	for _, member := range node.Members() {
+		if ast.IsStatic(member) != isStatic || !nodeOrChildIsDecorated(member, node) {
+			continue
+		}
+		if expression := tx.generateClassElementDecorationExpression(node, member); expression != nil {
+			statements = append(statements, tx.Factory().NewExpressionStatement(expression))
+		}
+	}
+	return statements
+}
+
+// Generates an expression used to evaluate class element decorators at runtime.
+//
+// The emit for a method is:
+//
+//	__decorate([
+//	    dec,
+//	    __param(0, dec2),
+//	    __metadata("design:type", Function),
+//	    __metadata("design:paramtypes", [Object]),
+//	    __metadata("design:returntype", void 0)
+//	], C.prototype, "method", null);
+//
+// The emit for an accessor is:
+//
+//	__decorate([
+//	    dec
+//	], C.prototype, "accessor", null);
+//
+// The emit for a property is:
+//
+//	__decorate([
+//	    dec
+//	], C.prototype, "prop", void 0);
+func (tx *LegacyDecoratorsTransformer) generateClassElementDecorationExpression(node *ast.ClassLikeDeclaration, member *ast.ClassElement) *ast.Expression {
+	allDecorators := getAllDecoratorsOfClassElement(member, node)
+	if allDecorators == nil {
+		return nil
+	}
+	decoratorExpressions := tx.transformAllDecoratorsOfDeclaration(allDecorators, tx.getTypeMetadata(member, node))
+
+	var prefix *ast.Expression
+	if ast.IsStatic(member) {
+		prefix = tx.Factory().GetDeclarationName(node)
+	} else {
+		prefix = tx.Factory().NewPropertyAccessExpression(tx.Factory().GetDeclarationName(node), nil /*questionDotToken*/, tx.Factory().NewIdentifier("prototype"), ast.NodeFlagsNone)
+	}
+	memberName := tx.getExpressionForPropertyName(member)
+
+	var descriptor *ast.Expression
+	if ast.IsPropertyDeclaration(member) && !ast.HasAccessorModifier(member) {
+		// We emit `void 0` here to indicate to `__decorate` that it can invoke `Object.defineProperty` directly, but
+		// that it should not invoke `Object.getOwnPropertyDescriptor`.
+		descriptor = tx.Factory().NewVoidZeroExpression()
+	} else {
+		// We emit `null` here to indicate to `__decorate` that it can invoke `Object.getOwnPropertyDescriptor`
+		// directly. We have this extra argument here so that we can inject an explicit property descriptor at a later
+		// date.
+		descriptor = tx.Factory().NewKeywordExpression(ast.KindNullKeyword)
+	}
+
+	helper := tx.Factory().NewDecorateHelper(decoratorExpressions, prefix, memberName, descriptor)
+	tx.EmitContext().AddEmitFlags(helper, printer.EFNoComments)
+	tx.EmitContext().SetSourceMapRange(helper, moveRangePastModifiers(member))
+	return helper
+}
+
+// Generates a `__decorate` call for the decorators of a class and its constructor parameters, assigning the
+// decorated class to the class binding (and its alias, if any).
+func (tx *LegacyDecoratorsTransformer) generateConstructorDecorationExpression(node *ast.ClassLikeDeclaration, classAlias *ast.IdentifierNode) *ast.Expression {
+	allDecorators := getAllDecoratorsOfClass(node)
+	if allDecorators == nil {
+		return nil
+	}
+	var metadata []*ast.Expression
+	if ast.HasDecorators(node) || len(allDecorators.parameters) > 0 {
+		metadata = tx.getTypeMetadata(node, node)
+	}
+	decoratorExpressions := tx.transformAllDecoratorsOfDeclaration(allDecorators, metadata)
+
+	decorate := tx.Factory().NewDecorateHelper(decoratorExpressions, tx.Factory().GetDeclarationName(node), nil /*memberName*/, nil /*descriptor*/)
+	if classAlias != nil {
+		decorate = tx.Factory().NewAssignmentExpression(classAlias.Clone(tx.Factory()), decorate)
+	}
+	expression := tx.Factory().NewAssignmentExpression(tx.Factory().GetDeclarationName(node), decorate)
+	tx.EmitContext().AddEmitFlags(expression, printer.EFNoComments)
+	tx.EmitContext().SetSourceMapRange(expression, moveRangePastModifiers(node))
+	return expression
+}
+
+// Gets an expression that represents the name of a decorated class element at runtime.
+func (tx *LegacyDecoratorsTransformer) getExpressionForPropertyName(member *ast.ClassElement) *ast.Expression {
+	name := member.Name()
+	switch {
+	case ast.IsPrivateIdentifier(name):
+		return tx.Factory().NewIdentifier("")
+	case ast.IsComputedPropertyName(name):
+		// A computed property name that isn't simple is hoisted by `transformClassMembers`.
+		expression := name.Expression()
+		if !isSimpleInlineableExpression(ast.SkipPartiallyEmittedExpressions(expression)) {
+			return tx.Factory().NewGeneratedNameForNode(name)
+		}
+		return tx.Visitor().VisitNode(expression)
+	case ast.IsIdentifier(name):
+		return tx.Factory().NewStringLiteral(name.Text())
+	default:
+		return name.Clone(tx.Factory())
+	}
+}
+
+// Gets whether a declaration or any of its parameters are decorated.
+func nodeOrChildIsDecorated(node *ast.Node, parent *ast.Node) bool {
+	if ast.HasDecorators(node) {
+		return true
+	}
+	switch node.Kind {
+	case ast.KindClassDeclaration:
+		return childIsDecorated(node)
+	case ast.KindMethodDeclaration, ast.KindSetAccessor, ast.KindConstructor:
+		return node.Body() != nil && core.Some(node.Parameters(), ast.HasDecorators)
+	}
+	return false
+}
+
+// Gets whether any of the members of a class, or their parameters, are decorated.
+func childIsDecorated(node *ast.ClassLikeDeclaration) bool {
+	return core.Some(node.Members(), func(member *ast.Node) bool {
+		return nodeOrChildIsDecorated(member, node)
+	})
+}
+
+// Gets whether a class or the parameters of its constructor are decorated.
+func classOrConstructorParameterIsDecorated(node *ast.ClassLikeDeclaration) bool {
+	if ast.HasDecorators(node) {
+		return true
+	}
+	constructor := getFirstConstructorWithBody(node)
+	return constructor != nil && nodeOrChildIsDecorated(constructor, node)
+}
+
+func hasClassElementWithDecoratorContainingPrivateIdentifierInExpression(node *ast.ClassLikeDeclaration) bool {
+	for _, member := range node.Members() {
+		allDecorators := getAllDecoratorsOfClassElement(member, node)
+		if allDecorators == nil {
+			continue
+		}
+		if core.Some(allDecorators.decorators, containsPrivateIdentifierInExpression) {
+			return true
+		}
+		for _, decorators := range allDecorators.parameters {
+			if core.Some(decorators, containsPrivateIdentifierInExpression) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Gets whether a node contains a `#x in obj` expression.
+func containsPrivateIdentifierInExpression(node *ast.Node) bool {
+	if ast.IsBinaryExpression(node) {
+		n := node.AsBinaryExpression()
+		if n.OperatorToken.Kind == ast.KindInKeyword && ast.IsPrivateIdentifier(n.Left) {
+			return true
+		}
+	}
+	return node.ForEachChild(containsPrivateIdentifierInExpression)
+}
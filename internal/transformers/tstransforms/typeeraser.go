@@ -209,6 +209,20 @@ func (tx *TypeEraserTransformer) visit(node *ast.Node) *ast.Node {
 		if ast.IsParameterPropertyDeclaration(node, tx.parentNode) {
 			modifiers = transformers.ExtractModifiers(tx.EmitContext(), n.Modifiers(), ast.ModifierFlagsParameterPropertyModifier)
 		}
+		// preserve legacy parameter decorators to be handled by the legacy decorator transformer
+		if tx.compilerOptions.ExperimentalDecorators.IsTrue() && ast.HasDecorators(node) {
+			var nodes []*ast.Node
+			for _, modifier := range n.Modifiers().Nodes {
+				if ast.IsDecorator(modifier) {
+					nodes = append(nodes, tx.Visitor().VisitNode(modifier))
+				}
+			}
+			if modifiers != nil {
+				nodes = append(nodes, modifiers.Nodes...)
+			}
+			modifiers = tx.Factory().NewModifierList(nodes)
+			modifiers.Loc = n.Modifiers().Loc
+		}
 		return tx.Factory().UpdateParameterDeclaration(n, modifiers, n.DotDotDotToken, tx.Visitor().VisitNode(n.Name()), nil, nil, tx.Visitor().VisitNode(n.Initializer))
 
 	case ast.KindCallExpression:
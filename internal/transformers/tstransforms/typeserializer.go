@@ -0,0 +1,424 @@
+package tstransforms
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/printer"
+)
+
+// Serializes type annotations into the runtime values recorded by `emitDecoratorMetadata`. Type annotations are read
+// from the parse tree, since they have already been erased from the nodes being transformed.
+type metadataSerializer struct {
+	emitContext      *printer.EmitContext
+	resolver         printer.EmitResolver
+	languageVersion  core.ScriptTarget
+	strictNullChecks bool
+	currentNameScope *ast.Node // ClassLikeDeclaration
+}
+
+func newMetadataSerializer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver printer.EmitResolver) *metadataSerializer {
+	return &metadataSerializer{
+		emitContext:      emitContext,
+		resolver:         resolver,
+		languageVersion:  compilerOptions.GetEmitScriptTarget(),
+		strictNullChecks: compilerOptions.StrictNullChecks.IsTrue() || compilerOptions.StrictNullChecks == core.TSUnknown && compilerOptions.Strict.IsTrue(),
+	}
+}
+
+func (s *metadataSerializer) factory() *printer.NodeFactory {
+	return s.emitContext.Factory
+}
+
+// Gets the `__metadata` decorator expressions for a parse tree declaration and its containing class.
+func (s *metadataSerializer) getTypeMetadata(node *ast.Node, container *ast.ClassLikeDeclaration) []*ast.Expression {
+	savedCurrentNameScope := s.currentNameScope
+	s.currentNameScope = container
+	defer func() { s.currentNameScope = savedCurrentNameScope }()
+
+	var metadata []*ast.Expression
+	if shouldAddTypeMetadata(node) {
+		metadata = append(metadata, s.factory().NewMetadataHelper("design:type", s.serializeTypeOfNode(node, container)))
+	}
+	if shouldAddParamTypesMetadata(node) {
+		metadata = append(metadata, s.factory().NewMetadataHelper("design:paramtypes", s.serializeParameterTypesOfNode(node, container)))
+	}
+	if shouldAddReturnTypeMetadata(node) {
+		metadata = append(metadata, s.factory().NewMetadataHelper("design:returntype", s.serializeReturnTypeOfNode(node)))
+	}
+	return metadata
+}
+
+func shouldAddTypeMetadata(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindPropertyDeclaration:
+		return true
+	}
+	return false
+}
+
+func shouldAddReturnTypeMetadata(node *ast.Node) bool {
+	return node.Kind == ast.KindMethodDeclaration
+}
+
+func shouldAddParamTypesMetadata(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		return getFirstConstructorWithBody(node) != nil
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	}
+	return false
+}
+
+// Serializes the type of a node for use with decorator type metadata.
+func (s *metadataSerializer) serializeTypeOfNode(node *ast.Node, container *ast.ClassLikeDeclaration) *ast.Expression {
+	switch node.Kind {
+	case ast.KindPropertyDeclaration, ast.KindParameter:
+		return s.serializeTypeNode(node.Type())
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		return s.serializeTypeNode(getAccessorTypeNode(node, container))
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindMethodDeclaration:
+		return s.factory().NewIdentifier("Function")
+	default:
+		return s.factory().NewVoidZeroExpression()
+	}
+}
+
+// Serializes the types of the parameters of a node for use with decorator type metadata.
+func (s *metadataSerializer) serializeParameterTypesOfNode(node *ast.Node, container *ast.ClassLikeDeclaration) *ast.Expression {
+	var valueDeclaration *ast.Node
+	if ast.IsClassLike(node) {
+		valueDeclaration = getFirstConstructorWithBody(node)
+	} else if ast.IsFunctionLike(node) && node.Body() != nil {
+		valueDeclaration = node
+	}
+
+	var expressions []*ast.Expression
+	if valueDeclaration != nil {
+		for i, parameter := range getParametersOfDecoratedDeclaration(valueDeclaration, container) {
+			if i == 0 && ast.IsThisParameter(parameter) {
+				continue
+			}
+			if parameter.AsParameterDeclaration().DotDotDotToken != nil {
+				expressions = append(expressions, s.serializeTypeNode(getRestParameterElementType(parameter.Type())))
+			} else {
+				expressions = append(expressions, s.serializeTypeOfNode(parameter, container))
+			}
+		}
+	}
+	return s.factory().NewArrayLiteralExpression(s.factory().NewNodeList(expressions), false /*multiLine*/)
+}
+
+func getParametersOfDecoratedDeclaration(node *ast.Node, container *ast.ClassLikeDeclaration) []*ast.ParameterDeclarationNode {
+	if container != nil && node.Kind == ast.KindGetAccessor {
+		if accessors := getAllAccessorDeclarations(container.Members(), node); accessors.setAccessor != nil {
+			return accessors.setAccessor.Parameters()
+		}
+	}
+	return node.Parameters()
+}
+
+func getRestParameterElementType(node *ast.TypeNode) *ast.TypeNode {
+	if node != nil {
+		switch node.Kind {
+		case ast.KindArrayType:
+			return node.AsArrayTypeNode().ElementType
+		case ast.KindTypeReference:
+			if typeArguments := node.AsTypeReferenceNode().TypeArguments; typeArguments != nil && len(typeArguments.Nodes) == 1 {
+				return typeArguments.Nodes[0]
+			}
+		}
+	}
+	return nil
+}
+
+// Serializes the return type of a node for use with decorator type metadata.
+func (s *metadataSerializer) serializeReturnTypeOfNode(node *ast.Node) *ast.Expression {
+	if ast.IsFunctionLike(node) && node.Type() != nil {
+		return s.serializeTypeNode(node.Type())
+	}
+	if ast.IsFunctionLike(node) && ast.HasSyntacticModifier(node, ast.ModifierFlagsAsync) {
+		return s.factory().NewIdentifier("Promise")
+	}
+	return s.factory().NewVoidZeroExpression()
+}
+
+// Serializes a type node for use with decorator type metadata.
+//
+// Types are serialized in the following fashion:
+//   - Void types point to "undefined" (e.g. "void 0")
+//   - Function and Constructor types point to the global "Function" constructor.
+//   - Interface types with a call or construct signature types point to the global "Function" constructor.
+//   - Array and Tuple types point to the global "Array" constructor.
+//   - Type predicates and booleans point to the global "Boolean" constructor.
+//   - String literal types and strings point to the global "String" constructor.
+//   - Enum and number types point to the global "Number" constructor.
+//   - Symbol types point to the global "Symbol" constructor.
+//   - Type references to classes (or class-like variables) point to the constructor for the class.
+//   - Anything else points to the global "Object" constructor.
+func (s *metadataSerializer) serializeTypeNode(node *ast.TypeNode) *ast.Expression {
+	if node == nil {
+		return s.factory().NewIdentifier("Object")
+	}
+
+	node = ast.SkipTypeParentheses(node)
+	switch node.Kind {
+	case ast.KindVoidKeyword, ast.KindUndefinedKeyword, ast.KindNeverKeyword:
+		return s.factory().NewVoidZeroExpression()
+	case ast.KindFunctionType, ast.KindConstructorType:
+		return s.factory().NewIdentifier("Function")
+	case ast.KindArrayType, ast.KindTupleType:
+		return s.factory().NewIdentifier("Array")
+	case ast.KindTypePredicate:
+		if node.AsTypePredicateNode().AssertsModifier != nil {
+			return s.factory().NewVoidZeroExpression()
+		}
+		return s.factory().NewIdentifier("Boolean")
+	case ast.KindBooleanKeyword:
+		return s.factory().NewIdentifier("Boolean")
+	case ast.KindTemplateLiteralType, ast.KindStringKeyword:
+		return s.factory().NewIdentifier("String")
+	case ast.KindObjectKeyword:
+		return s.factory().NewIdentifier("Object")
+	case ast.KindLiteralType:
+		return s.serializeLiteralOfLiteralTypeNode(node.AsLiteralTypeNode().Literal)
+	case ast.KindNumberKeyword:
+		return s.factory().NewIdentifier("Number")
+	case ast.KindBigIntKeyword:
+		return s.getGlobalConstructor("BigInt", core.ScriptTargetES2020)
+	case ast.KindSymbolKeyword:
+		return s.getGlobalConstructor("Symbol", core.ScriptTargetES2015)
+	case ast.KindTypeReference:
+		return s.serializeTypeReferenceNode(node)
+	case ast.KindIntersectionType:
+		return s.serializeUnionOrIntersectionConstituents(node.AsIntersectionTypeNode().Types.Nodes, true /*isIntersection*/)
+	case ast.KindUnionType:
+		return s.serializeUnionOrIntersectionConstituents(node.AsUnionTypeNode().Types.Nodes, false /*isIntersection*/)
+	case ast.KindConditionalType:
+		n := node.AsConditionalTypeNode()
+		return s.serializeUnionOrIntersectionConstituents([]*ast.TypeNode{n.TrueType, n.FalseType}, false /*isIntersection*/)
+	case ast.KindTypeOperator:
+		if n := node.AsTypeOperatorNode(); n.Operator == ast.KindReadonlyKeyword {
+			return s.serializeTypeNode(n.Type)
+		}
+	case ast.KindJSDocNullableType, ast.KindJSDocNonNullableType, ast.KindJSDocOptionalType:
+		// handle JSDoc types from an invalid parse
+		return s.serializeTypeNode(node.Type())
+	}
+	return s.factory().NewIdentifier("Object")
+}
+
+func (s *metadataSerializer) serializeLiteralOfLiteralTypeNode(node *ast.Node) *ast.Expression {
+	switch node.Kind {
+	case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral:
+		return s.factory().NewIdentifier("String")
+	case ast.KindPrefixUnaryExpression:
+		return s.serializeLiteralOfLiteralTypeNode(node.AsPrefixUnaryExpression().Operand)
+	case ast.KindNumericLiteral:
+		return s.factory().NewIdentifier("Number")
+	case ast.KindBigIntLiteral:
+		return s.getGlobalConstructor("BigInt", core.ScriptTargetES2020)
+	case ast.KindTrueKeyword, ast.KindFalseKeyword:
+		return s.factory().NewIdentifier("Boolean")
+	case ast.KindNullKeyword:
+		return s.factory().NewVoidZeroExpression()
+	}
+	return s.factory().NewIdentifier("Object")
+}
+
+func (s *metadataSerializer) serializeUnionOrIntersectionConstituents(types []*ast.TypeNode, isIntersection bool) *ast.Expression {
+	var serializedType *ast.Expression
+	for _, typeNode := range types {
+		typeNode = ast.SkipTypeParentheses(typeNode)
+		switch {
+		case typeNode.Kind == ast.KindNeverKeyword:
+			if isIntersection {
+				// Reduce to `never` in an intersection
+				return s.factory().NewVoidZeroExpression()
+			}
+			// Elide `never` in a union
+			continue
+		case typeNode.Kind == ast.KindUnknownKeyword:
+			if !isIntersection {
+				// Reduce to `unknown` in a union
+				return s.factory().NewIdentifier("Object")
+			}
+			// Elide `unknown` in an intersection
+			continue
+		case typeNode.Kind == ast.KindAnyKeyword:
+			// Reduce to `any` in a union or intersection
+			return s.factory().NewIdentifier("Object")
+		case !s.strictNullChecks && (ast.IsLiteralTypeNode(typeNode) && typeNode.AsLiteralTypeNode().Literal.Kind == ast.KindNullKeyword || typeNode.Kind == ast.KindUndefinedKeyword):
+			// Elide null and undefined from unions for metadata, just like what we did prior to the implementation of
+			// strict null checks
+			continue
+		}
+
+		serializedConstituent := s.serializeTypeNode(typeNode)
+		if ast.IsIdentifier(serializedConstituent) && serializedConstituent.Text() == "Object" {
+			// One of the individual is global object, return immediately
+			return serializedConstituent
+		}
+
+		// If there exists union that is not `void 0` expression, check if the the common type is identifier.
+		// anything more complex and we will just default to Object
+		if serializedType == nil {
+			serializedType = serializedConstituent
+		} else if !s.equateSerializedTypeNodes(serializedType, serializedConstituent) {
+			return s.factory().NewIdentifier("Object")
+		}
+	}
+
+	// If we were able to find common type, use it
+	if serializedType != nil {
+		return serializedType
+	}
+	// Fallback is only hit if all union constituents are null/undefined/never
+	return s.factory().NewVoidZeroExpression()
+}
+
+func (s *metadataSerializer) equateSerializedTypeNodes(left *ast.Expression, right *ast.Expression) bool {
+	switch {
+	case s.emitContext.HasAutoGenerateInfo(left):
+		// temp vars used in fallback
+		return s.emitContext.HasAutoGenerateInfo(right)
+	case ast.IsIdentifier(left):
+		// entity names
+		return ast.IsIdentifier(right) && !s.emitContext.HasAutoGenerateInfo(right) && left.Text() == right.Text()
+	case ast.IsPropertyAccessExpression(left):
+		return ast.IsPropertyAccessExpression(right) &&
+			s.equateSerializedTypeNodes(left.Expression(), right.Expression()) &&
+			s.equateSerializedTypeNodes(left.Name(), right.Name())
+	case ast.IsVoidExpression(left):
+		// `void 0`
+		return ast.IsVoidExpression(right) &&
+			ast.IsNumericLiteral(left.Expression()) && left.Expression().Text() == "0" &&
+			ast.IsNumericLiteral(right.Expression()) && right.Expression().Text() == "0"
+	case ast.IsStringLiteral(left):
+		// `"undefined"` or `"function"` in `typeof` checks
+		return ast.IsStringLiteral(right) && left.Text() == right.Text()
+	case ast.IsTypeOfExpression(left), ast.IsParenthesizedExpression(left):
+		// used in `typeof` checks for fallback, and parens in `typeof` checks with temps
+		return right.Kind == left.Kind && s.equateSerializedTypeNodes(left.Expression(), right.Expression())
+	case ast.IsConditionalExpression(left):
+		// conditionals used in fallback
+		l, r := left.AsConditionalExpression(), right.AsConditionalExpression()
+		return ast.IsConditionalExpression(right) &&
+			s.equateSerializedTypeNodes(l.Condition, r.Condition) &&
+			s.equateSerializedTypeNodes(l.WhenTrue, r.WhenTrue) &&
+			s.equateSerializedTypeNodes(l.WhenFalse, r.WhenFalse)
+	case ast.IsBinaryExpression(left):
+		// logical binary and assignments used in fallback
+		if !ast.IsBinaryExpression(right) {
+			return false
+		}
+		l, r := left.AsBinaryExpression(), right.AsBinaryExpression()
+		return l.OperatorToken.Kind == r.OperatorToken.Kind &&
+			s.equateSerializedTypeNodes(l.Left, r.Left) &&
+			s.equateSerializedTypeNodes(l.Right, r.Right)
+	}
+	return false
+}
+
+// Serializes a TypeReferenceNode to an appropriate JS constructor value for use with decorator type metadata.
+func (s *metadataSerializer) serializeTypeReferenceNode(node *ast.TypeNode) *ast.Expression {
+	typeName := node.AsTypeReferenceNode().TypeName
+	switch s.resolver.GetTypeReferenceSerializationKind(typeName, s.currentNameScope) {
+	case printer.TypeReferenceSerializationKindUnknown:
+		// From conditional type type reference that cannot be resolved is Similar to any or unknown
+		if ast.FindAncestor(node, func(n *ast.Node) bool {
+			return n.Parent != nil && ast.IsConditionalTypeNode(n.Parent) && (n.Parent.AsConditionalTypeNode().TrueType == n || n.Parent.AsConditionalTypeNode().FalseType == n)
+		}) != nil {
+			return s.factory().NewIdentifier("Object")
+		}
+
+		// typeof (_a = typeof A !== "undefined" && A) === "function" ? _a : Object
+		serialized := s.serializeEntityNameAsExpressionFallback(typeName)
+		temp := s.factory().NewTempVariable()
+		s.emitContext.AddVariableDeclaration(temp)
+		return s.factory().NewConditionalExpression(
+			s.factory().NewTypeCheck(s.factory().NewAssignmentExpression(temp, serialized), "function"),
+			s.factory().NewToken(ast.KindQuestionToken),
+			temp.Clone(s.factory()),
+			s.factory().NewToken(ast.KindColonToken),
+			s.factory().NewIdentifier("Object"),
+		)
+	case printer.TypeReferenceSerializationKindTypeWithConstructSignatureAndValue:
+		return s.serializeEntityNameAsExpression(typeName)
+	case printer.TypeReferenceSerializationKindVoidNullableOrNeverType:
+		return s.factory().NewVoidZeroExpression()
+	case printer.TypeReferenceSerializationKindBigIntLikeType:
+		return s.getGlobalConstructor("BigInt", core.ScriptTargetES2020)
+	case printer.TypeReferenceSerializationKindBooleanType:
+		return s.factory().NewIdentifier("Boolean")
+	case printer.TypeReferenceSerializationKindNumberLikeType:
+		return s.factory().NewIdentifier("Number")
+	case printer.TypeReferenceSerializationKindStringLikeType:
+		return s.factory().NewIdentifier("String")
+	case printer.TypeReferenceSerializationKindArrayLikeType:
+		return s.factory().NewIdentifier("Array")
+	case printer.TypeReferenceSerializationKindESSymbolType:
+		return s.getGlobalConstructor("Symbol", core.ScriptTargetES2015)
+	case printer.TypeReferenceSerializationKindTypeWithCallSignature:
+		return s.factory().NewIdentifier("Function")
+	case printer.TypeReferenceSerializationKindPromise:
+		return s.factory().NewIdentifier("Promise")
+	default: // printer.TypeReferenceSerializationKindObjectType
+		return s.factory().NewIdentifier("Object")
+	}
+}
+
+func (s *metadataSerializer) createCheckedValue(left *ast.Expression, right *ast.Expression) *ast.Expression {
+	return s.factory().NewLogicalANDExpression(
+		s.factory().NewStrictInequalityExpression(s.factory().NewTypeOfExpression(left), s.factory().NewStringLiteral("undefined")),
+		right,
+	)
+}
+
+// Serializes an entity name which may not exist at runtime, but whose access shouldn't throw.
+func (s *metadataSerializer) serializeEntityNameAsExpressionFallback(node *ast.EntityName) *ast.Expression {
+	if ast.IsIdentifier(node) {
+		// A -> typeof A !== "undefined" && A
+		return s.createCheckedValue(s.serializeEntityNameAsExpression(node), s.serializeEntityNameAsExpression(node))
+	}
+	n := node.AsQualifiedName()
+	if ast.IsIdentifier(n.Left) {
+		// A.B -> typeof A !== "undefined" && A.B
+		return s.createCheckedValue(s.serializeEntityNameAsExpression(n.Left), s.serializeEntityNameAsExpression(node))
+	}
+	// A.B.C -> typeof A !== "undefined" && (_a = A.B) !== void 0 && _a.C
+	left := s.serializeEntityNameAsExpressionFallback(n.Left).AsBinaryExpression()
+	temp := s.factory().NewTempVariable()
+	s.emitContext.AddVariableDeclaration(temp)
+	return s.factory().NewLogicalANDExpression(
+		s.factory().NewLogicalANDExpression(
+			left.Left,
+			s.factory().NewStrictInequalityExpression(s.factory().NewAssignmentExpression(temp, left.Right), s.factory().NewVoidZeroExpression()),
+		),
+		s.factory().NewPropertyAccessExpression(temp.Clone(s.factory()), nil /*questionDotToken*/, n.Right.Clone(s.factory()), ast.NodeFlagsNone),
+	)
+}
+
+// Serializes an entity name as an expression for decorator type metadata. The resulting identifiers keep their parse
+// tree names as their original nodes, so that later transforms can resolve them (e.g. to rewrite imported names).
+func (s *metadataSerializer) serializeEntityNameAsExpression(node *ast.EntityName) *ast.Expression {
+	return convertEntityNameToExpression(s.emitContext, node)
+}
+
+func (s *metadataSerializer) getGlobalConstructorWithFallback(name string) *ast.Expression {
+	// typeof Name === "function" ? Name : Object
+	return s.factory().NewConditionalExpression(
+		s.factory().NewTypeCheck(s.factory().NewIdentifier(name), "function"),
+		s.factory().NewToken(ast.KindQuestionToken),
+		s.factory().NewIdentifier(name),
+		s.factory().NewToken(ast.KindColonToken),
+		s.factory().NewIdentifier("Object"),
+	)
+}
+
+func (s *metadataSerializer) getGlobalConstructor(name string, minLanguageVersion core.ScriptTarget) *ast.Expression {
+	if s.languageVersion < minLanguageVersion {
+		return s.getGlobalConstructorWithFallback(name)
+	}
+	return s.factory().NewIdentifier(name)
+}
@@ -2,8 +2,10 @@ package tstransforms
 
 import (
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/jsnum"
 	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/transformers"
 )
 
 func convertEntityNameToExpression(emitContext *printer.EmitContext, name *ast.EntityName) *ast.Expression {
@@ -39,3 +41,107 @@ func isInstantiatedModule(node *ast.ModuleDeclarationNode, preserveConstEnums bo
 	return moduleState == ast.ModuleInstanceStateInstantiated ||
 		(preserveConstEnums && moduleState == ast.ModuleInstanceStateConstEnumOnly)
 }
+
+// A simple inlinable expression is an expression which can be copied into multiple locations without risk of
+// repeating any side effects and whose value could not possibly change between any such locations.
+func isSimpleInlineableExpression(expression *ast.Expression) bool {
+	return !ast.IsIdentifier(expression) && transformers.IsSimpleCopiableExpression(expression)
+}
+
+func getFirstConstructorWithBody(node *ast.ClassLikeDeclaration) *ast.Node {
+	for _, member := range node.Members() {
+		if ast.IsConstructorDeclaration(member) && member.Body() != nil {
+			return member
+		}
+	}
+	return nil
+}
+
+type allAccessorDeclarations struct {
+	firstAccessor  *ast.AccessorDeclaration
+	secondAccessor *ast.AccessorDeclaration
+	getAccessor    *ast.AccessorDeclaration
+	setAccessor    *ast.AccessorDeclaration
+}
+
+// Gets the get and set accessors among a list of class members that share the name and placement of an accessor.
+func getAllAccessorDeclarations(members []*ast.ClassElement, accessor *ast.AccessorDeclaration) allAccessorDeclarations {
+	var result allAccessorDeclarations
+	if ast.HasDynamicName(accessor) {
+		result.firstAccessor = accessor
+		if accessor.Kind == ast.KindGetAccessor {
+			result.getAccessor = accessor
+		} else {
+			result.setAccessor = accessor
+		}
+		return result
+	}
+
+	accessorName := ast.GetPropertyNameForPropertyNameNode(accessor.Name())
+	for _, member := range members {
+		if ast.IsAccessor(member) && ast.IsStatic(member) == ast.IsStatic(accessor) && ast.GetPropertyNameForPropertyNameNode(member.Name()) == accessorName {
+			if result.firstAccessor == nil {
+				result.firstAccessor = member
+			} else if result.secondAccessor == nil {
+				result.secondAccessor = member
+			}
+			if member.Kind == ast.KindGetAccessor && result.getAccessor == nil {
+				result.getAccessor = member
+			}
+			if member.Kind == ast.KindSetAccessor && result.setAccessor == nil {
+				result.setAccessor = member
+			}
+		}
+	}
+	return result
+}
+
+// Gets the type annotation of an accessor pair, preferring the type of the set accessor's parameter.
+func getAccessorTypeNode(accessor *ast.AccessorDeclaration, container *ast.ClassLikeDeclaration) *ast.TypeNode {
+	accessors := getAllAccessorDeclarations(container.Members(), accessor)
+	if accessors.setAccessor != nil {
+		for _, parameter := range accessors.setAccessor.Parameters() {
+			if !ast.IsThisParameter(parameter) {
+				if parameter.Type() != nil {
+					return parameter.Type()
+				}
+				break
+			}
+		}
+	}
+	if accessors.getAccessor != nil {
+		return accessors.getAccessor.Type()
+	}
+	return nil
+}
+
+// Gets the range of a declaration excluding its decorators.
+func moveRangePastDecorators(node *ast.Node) core.TextRange {
+	var lastDecorator *ast.Node
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				lastDecorator = modifier
+			}
+		}
+	}
+	if lastDecorator != nil && !ast.PositionIsSynthesized(lastDecorator.End()) {
+		return core.NewTextRange(lastDecorator.End(), node.End())
+	}
+	return node.Loc
+}
+
+// Gets the range of a declaration excluding its decorators and modifiers.
+func moveRangePastModifiers(node *ast.Node) core.TextRange {
+	if ast.IsPropertyDeclaration(node) || ast.IsMethodDeclaration(node) {
+		return core.NewTextRange(node.Name().Pos(), node.End())
+	}
+	var lastModifier *ast.Node
+	if modifiers := node.Modifiers(); modifiers != nil && len(modifiers.Nodes) > 0 {
+		lastModifier = modifiers.Nodes[len(modifiers.Nodes)-1]
+	}
+	if lastModifier != nil && !ast.PositionIsSynthesized(lastModifier.End()) {
+		return core.NewTextRange(lastModifier.End(), node.End())
+	}
+	return moveRangePastDecorators(node)
+}
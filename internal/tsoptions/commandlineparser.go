@@ -67,6 +67,54 @@ func ParseCommandLine(
 	}
 }
 
+func ParseBuildCommandLine(
+	commandLine []string,
+	host ParseConfigHost,
+) *ParsedBuildCommandLine {
+	if commandLine == nil {
+		commandLine = []string{}
+	}
+	parser := parseCommandLineWorker(buildOptionsDidYouMeanDiagnostics, commandLine, host.FS())
+	optionsWithAbsolutePaths := convertToOptionsWithAbsolutePaths(parser.options, CommandLineCompilerOptionsMap, host.GetCurrentDirectory())
+	buildOptions := convertMapToOptions(optionsWithAbsolutePaths, &buildOptionsParser{&core.BuildOptions{}}).BuildOptions
+	compilerOptions := convertMapToOptions(optionsWithAbsolutePaths, &compilerOptionsParser{&core.CompilerOptions{}}).CompilerOptions
+	watchOptions := convertMapToOptions(optionsWithAbsolutePaths, &watchOptionsParser{&core.WatchOptions{}}).WatchOptions
+
+	projects := parser.fileNames
+	if len(projects) == 0 {
+		// tsc -b invoked with no extra arguments; act as if invoked with "tsc -b ."
+		projects = []string{"."}
+	}
+
+	errors := parser.errors
+	// Nonsensical combinations
+	if buildOptions.Clean.IsTrue() && buildOptions.Force.IsTrue() {
+		errors = append(errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "clean", "force"))
+	}
+	if buildOptions.Clean.IsTrue() && buildOptions.Verbose.IsTrue() {
+		errors = append(errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "clean", "verbose"))
+	}
+	if buildOptions.Clean.IsTrue() && compilerOptions.Watch.IsTrue() {
+		errors = append(errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "clean", "watch"))
+	}
+	if compilerOptions.Watch.IsTrue() && buildOptions.Dry.IsTrue() {
+		errors = append(errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "watch", "dry"))
+	}
+
+	return &ParsedBuildCommandLine{
+		BuildOptions:    buildOptions,
+		CompilerOptions: compilerOptions,
+		WatchOptions:    watchOptions,
+		Projects:        projects,
+		Errors:          errors,
+
+		comparePathsOptions: tspath.ComparePathsOptions{
+			UseCaseSensitiveFileNames: host.FS().UseCaseSensitiveFileNames(),
+			CurrentDirectory:          host.GetCurrentDirectory(),
+		},
+	}
+}
+
 func parseCommandLineWorker(
 	parseCommandLineWithDiagnostics *ParseCommandLineWorkerDiagnostics,
 	commandLine []string,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-json-experiment/json"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
@@ -15,6 +16,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/testutil/baseline"
 	"github.com/microsoft/typescript-go/internal/testutil/filefixture"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tsoptions/tsoptionstest"
 	"github.com/microsoft/typescript-go/internal/vfs/osvfs"
 	"gotest.tools/v3/assert"
 )
@@ -318,3 +320,70 @@ func TestAffectsBuildInfo(t *testing.T) {
 		}
 	})
 }
+
+func TestParseBuildCommandLine(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		args     []string
+		projects []string
+		build    core.BuildOptions
+		errors   []string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "defaults to current directory",
+			args:     []string{},
+			projects: []string{"."},
+		},
+		{
+			name:     "parses build flags",
+			args:     []string{"--verbose", "--dry", "--force", "--stopBuildOnErrors", "src", "tests"},
+			projects: []string{"src", "tests"},
+			build:    core.BuildOptions{Verbose: core.TSTrue, Dry: core.TSTrue, Force: core.TSTrue, StopBuildOnErrors: core.TSTrue},
+		},
+		{
+			name:     "short names",
+			args:     []string{"-v", "-d", "-f"},
+			projects: []string{"."},
+			build:    core.BuildOptions{Verbose: core.TSTrue, Dry: core.TSTrue, Force: core.TSTrue},
+		},
+		{
+			name:     "clean cannot be combined with force or verbose",
+			args:     []string{"--clean", "--force", "--verbose"},
+			projects: []string{"."},
+			build:    core.BuildOptions{Clean: core.TSTrue, Force: core.TSTrue, Verbose: core.TSTrue},
+			errors: []string{
+				"Options 'clean' and 'force' cannot be combined.",
+				"Options 'clean' and 'verbose' cannot be combined.",
+			},
+		},
+		{
+			name:     "watch cannot be combined with dry",
+			args:     []string{"--watch", "--dry"},
+			projects: []string{"."},
+			build:    core.BuildOptions{Dry: core.TSTrue},
+			errors:   []string{"Options 'watch' and 'dry' cannot be combined."},
+		},
+		{
+			name:     "compiler only options are rejected",
+			args:     []string{"--strict"},
+			projects: []string{"."},
+			errors:   []string{"Compiler option '--strict' may not be used with '--build'."},
+		},
+	}
+
+	host := tsoptionstest.NewVFSParseConfigHost(map[string]string{}, "/home/src", true /*useCaseSensitiveFileNames*/)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			parsed := tsoptions.ParseBuildCommandLine(tc.args, host)
+			assert.DeepEqual(t, parsed.Projects, tc.projects)
+			assert.DeepEqual(t, *parsed.BuildOptions, tc.build)
+			errors := core.Map(parsed.Errors, func(d *ast.Diagnostic) string { return d.Message() })
+			assert.DeepEqual(t, errors, tc.errors, cmpopts.EquateEmpty())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package tsoptions
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+type ParsedBuildCommandLine struct {
+	BuildOptions    *core.BuildOptions    `json:"buildOptions"`
+	CompilerOptions *core.CompilerOptions `json:"compilerOptions"`
+	WatchOptions    *core.WatchOptions    `json:"watchOptions"`
+	Projects        []string              `json:"projects"`
+	Errors          []*ast.Diagnostic     `json:"errors"`
+
+	comparePathsOptions tspath.ComparePathsOptions
+}
+
+// ResolvedProjectPaths returns the absolute paths of the config files named by the
+// projects passed on the command line. A directory resolves to its tsconfig.json.
+func (p *ParsedBuildCommandLine) ResolvedProjectPaths() []string {
+	result := make([]string, 0, len(p.Projects))
+	for _, project := range p.Projects {
+		result = append(result, core.ResolveProjectReferencePath(&core.ProjectReference{
+			Path: tspath.GetNormalizedAbsolutePath(project, p.comparePathsOptions.CurrentDirectory),
+		}))
+	}
+	return result
+}
@@ -144,6 +144,18 @@ func (o *watchOptionsParser) UnknownOptionDiagnostic() *diagnostics.Message {
 	return extraKeyDiagnostics("watchOptions")
 }
 
+type buildOptionsParser struct {
+	*core.BuildOptions
+}
+
+func (o *buildOptionsParser) ParseOption(key string, value any) []*ast.Diagnostic {
+	return ParseBuildOptions(key, value, o.BuildOptions)
+}
+
+func (o *buildOptionsParser) UnknownOptionDiagnostic() *diagnostics.Message {
+	return extraKeyDiagnostics("buildOptions")
+}
+
 type typeAcquisitionParser struct {
 	*core.TypeAcquisition
 }
@@ -476,6 +488,25 @@ func ParseWatchOptions(key string, value any, allOptions *core.WatchOptions) []*
 	return nil
 }
 
+func ParseBuildOptions(key string, value any, allOptions *core.BuildOptions) []*ast.Diagnostic {
+	if allOptions == nil {
+		return nil
+	}
+	switch key {
+	case "dry":
+		allOptions.Dry = parseTristate(value)
+	case "force":
+		allOptions.Force = parseTristate(value)
+	case "verbose":
+		allOptions.Verbose = parseTristate(value)
+	case "clean":
+		allOptions.Clean = parseTristate(value)
+	case "stopBuildOnErrors":
+		allOptions.StopBuildOnErrors = parseTristate(value)
+	}
+	return nil
+}
+
 func ParseTypeAcquisition(key string, value any, allOptions *core.TypeAcquisition) []*ast.Diagnostic {
 	if value == nil {
 		return nil
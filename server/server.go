@@ -14,11 +14,7 @@ import (
 
 	"github.com/evanw/esbuild/pkg/api"
 	"github.com/microsoft/typescript-go/internal/ast"
-	"github.com/microsoft/typescript-go/internal/bundled"
-	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/compiler"
-	"github.com/microsoft/typescript-go/internal/core"
-	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 	"github.com/microsoft/typescript-go/internal/vfs"
 )
@@ -38,8 +34,26 @@ type ModuleStats struct {
 	LoadErrors      int
 }
 
+// CompileRequest holds the inputs shared by /typecheck and /build.
+type CompileRequest struct {
+	// Code is shorthand for a single file request; it is placed at /input.tsx.
+	Code string `json:"code,omitempty"`
+	// Files maps file names to their contents. Relative names are resolved from the root of the
+	// virtual file system, which also holds the loaded node_modules.
+	Files map[string]string `json:"files,omitempty"`
+	// EntryPoint is the file to build. It may be omitted when only one file is given.
+	EntryPoint string `json:"entryPoint,omitempty"`
+	// CompilerOptions has the shape of the compilerOptions of a tsconfig.json. When it is omitted,
+	// the CrayonDeveloper defaults are used.
+	CompilerOptions json.RawMessage `json:"compilerOptions,omitempty"`
+	// Dependencies maps package names to exact versions uploaded with PUT /packages. Those versions
+	// are mounted in node_modules in place of any loaded version of the same packages, and the
+	// other loaded node_modules stay available.
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
 type TypecheckRequest struct {
-	Code string `json:"code"`
+	CompileRequest
 }
 
 type TypecheckResponse struct {
@@ -48,7 +62,7 @@ type TypecheckResponse struct {
 }
 
 type BuildRequest struct {
-	Code string `json:"code"`
+	CompileRequest
 }
 
 type BuildResponse struct {
@@ -57,9 +71,12 @@ type BuildResponse struct {
 }
 
 type DiagnosticError struct {
-	Message string `json:"message"`
-	Line    int    `json:"line"`
-	Column  int    `json:"column"`
+	Message  string `json:"message"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	File     string `json:"file,omitempty"`
+	Code     int32  `json:"code,omitempty"`
+	Category string `json:"category,omitempty"`
 }
 
 type HealthResponse struct {
@@ -72,6 +89,13 @@ type HealthResponse struct {
 
 type memoryFS struct {
 	files map[string]string
+	// bases hold files shared between requests, such as the loaded node_modules or the packages a
+	// request depends on. They are never written to, so requests layer their own files over them
+	// instead of copying them.
+	bases []*memoryFS
+	// hidden lists directories, each ending in a slash, whose files in bases are not visible
+	// through this file system.
+	hidden []string
 }
 
 func newMemoryFS() *memoryFS {
@@ -117,12 +141,8 @@ func loadTypeDefinitions(memFS *memoryFS) {
 			return nil
 		}
 		
-		// Load .d.ts files, .js files, and package.json files
-		if strings.HasSuffix(path, ".d.ts") || 
-		   strings.HasSuffix(path, ".js") || 
-		   strings.HasSuffix(path, ".jsx") ||
-		   strings.HasSuffix(path, ".mjs") ||
-		   strings.HasSuffix(path, "package.json") {
+		// Load declaration files, JavaScript files, and package.json files
+		if isPackageFile(path) {
 			
 			// Skip TypeScript compiler files to save space
 			if strings.Contains(path, "/typescript/lib/") && strings.HasSuffix(path, ".js") {
@@ -150,12 +170,12 @@ func loadTypeDefinitions(memFS *memoryFS) {
 			memFS.files[virtualPath] = string(content)
 			stats.TotalFiles++
 			
-			if strings.HasSuffix(path, ".d.ts") {
+			if tspath.IsDeclarationFileName(path) {
 				stats.TypeDefinitions++
-			} else if strings.HasSuffix(path, ".js") || strings.HasSuffix(path, ".jsx") || strings.HasSuffix(path, ".mjs") {
-				stats.JavaScriptFiles++
-			} else if strings.HasSuffix(path, "package.json") {
+			} else if tspath.GetBaseFileName(path) == "package.json" {
 				stats.PackageFiles++
+			} else {
+				stats.JavaScriptFiles++
 			}
 			
 			// Log every 100 files to show progress
@@ -178,12 +198,38 @@ func loadTypeDefinitions(memFS *memoryFS) {
 
 func (m *memoryFS) UseCaseSensitiveFileNames() bool { return true }
 func (m *memoryFS) FileExists(path string) bool {
-	_, ok := m.files[path]
+	_, ok := m.ReadFile(path)
 	return ok
 }
 func (m *memoryFS) ReadFile(path string) (string, bool) {
-	content, ok := m.files[path]
-	return content, ok
+	if content, ok := m.files[path]; ok {
+		return content, true
+	}
+	if m.isHidden(path) {
+		return "", false
+	}
+	for _, base := range m.bases {
+		if content, ok := base.ReadFile(path); ok {
+			return content, true
+		}
+	}
+	return "", false
+}
+
+// isHidden reports whether path is in one of the directories hidden from the base file systems.
+func (m *memoryFS) isHidden(path string) bool {
+	for _, dir := range m.hidden {
+		if strings.HasPrefix(path, dir) || path+"/" == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// isOverlayFile reports whether path is one of the files layered over the base file systems.
+func (m *memoryFS) isOverlayFile(path string) bool {
+	_, ok := m.files[path]
+	return ok
 }
 func (m *memoryFS) WriteFile(path string, data string, _ bool) error {
 	m.files[path] = data
@@ -206,6 +252,15 @@ func (m *memoryFS) DirectoryExists(path string) bool {
 		}
 	}
 	
+	if m.isHidden(normalizedPath) {
+		return false
+	}
+	for _, base := range m.bases {
+		if base.DirectoryExists(path) {
+			return true
+		}
+	}
+	
 	return false
 }
 
@@ -224,7 +279,11 @@ func (m *memoryFS) GetAccessibleEntries(path string) vfs.Entries {
 	}
 	
 	seen := make(map[string]bool)
-	
+	m.addAccessibleEntries(searchPath, seen, &entries)
+	return entries
+}
+
+func (m *memoryFS) addAccessibleEntries(searchPath string, seen map[string]bool, entries *vfs.Entries) {
 	for filePath := range m.files {
 		if !strings.HasPrefix(filePath, searchPath) {
 			continue
@@ -253,7 +312,12 @@ func (m *memoryFS) GetAccessibleEntries(path string) vfs.Entries {
 		}
 	}
 	
-	return entries
+	if m.isHidden(searchPath) {
+		return
+	}
+	for _, base := range m.bases {
+		base.addAccessibleEntries(searchPath, seen, entries)
+	}
 }
 func (m *memoryFS) Stat(path string) vfs.FileInfo { return nil }
 func (m *memoryFS) WalkDir(root string, walkFn vfs.WalkDirFunc) error { return nil }
@@ -275,281 +339,119 @@ func calculateLineColumn(text string, pos int) (int, int) {
 	return line, col
 }
 
-func typecheckTypeScript(code string) TypecheckResponse {
+func newDiagnosticError(diag *ast.Diagnostic) DiagnosticError {
+	err := DiagnosticError{
+		Message:  diag.Message(),
+		Code:     diag.Code(),
+		Category: diag.Category().Name(),
+	}
+	if diag.File() != nil {
+		err.File = diag.File().FileName()
+		if diag.Loc().Pos() >= 0 {
+			line, col := calculateLineColumn(diag.File().Text(), diag.Loc().Pos())
+			err.Line = line + 1
+			err.Column = col + 1
+		}
+	}
+	return err
+}
+
+func newDiagnosticErrors(diagnostics []*ast.Diagnostic) []DiagnosticError {
+	errors := make([]DiagnosticError, 0, len(diagnostics))
+	for _, diag := range diagnostics {
+		errors = append(errors, newDiagnosticError(diag))
+	}
+	return errors
+}
+
+// typecheckTypeScript type checks the request files. When onDiagnostic is set, files are checked
+// one at a time and their diagnostics are passed to it as soon as each file is done; otherwise files
+// are checked in parallel. An error is only returned when ctx is done before checking finishes.
+func typecheckTypeScript(ctx context.Context, input *compileInput, onDiagnostic func(DiagnosticError)) (TypecheckResponse, error) {
 	// Track typecheck duration
 	typecheckStart := time.Now()
 	defer func() {
 		typecheckDuration.Observe(time.Since(typecheckStart).Seconds())
 	}()
-	// Clone the global memFS to avoid concurrent modification issues
-	memFS := &memoryFS{
-		files: make(map[string]string, len(globalMemFS.files)),
-	}
-	for k, v := range globalMemFS.files {
-		memFS.files[k] = v
-	}
-	
-	// Always use .tsx to support JSX
-	fileName := "/input.tsx"
-	
-	memFS.files[fileName] = code
-	
-	fs := bundled.WrapFS(memFS)
-	
-	// Create minimal compiler options (matching CrayonDeveloper settings)
-	jsxImportSource := "@crayonnow/core"
-	compilerOptions := &core.CompilerOptions{
-		AllowJs:                          core.TSTrue,
-		Declaration:                      core.TSTrue,
-		ESModuleInterop:                  core.TSTrue,
-		ForceConsistentCasingInFileNames: core.TSTrue,
-		IsolatedModules:                  core.TSTrue,
-		Jsx:                              core.JsxEmitReactJSX,
-		JsxImportSource:                  jsxImportSource,
-		Module:                           core.ModuleKindCommonJS,
-		ModuleResolution:                 core.ModuleResolutionKindBundler,
-		NoEmit:                           core.TSTrue,
-		ResolveJsonModule:                core.TSTrue,
-		SkipLibCheck:                     core.TSTrue,
-		Strict:                           core.TSTrue,
-		StrictNullChecks:                 core.TSTrue,
-		Target:                           core.ScriptTargetES2022,
-		Lib:                              []string{"ES2022"},
-	}
 	
-	// Create parsed options
-	parsedOptions := &core.ParsedOptions{
-		CompilerOptions: compilerOptions,
-		FileNames:       []string{fileName},
+	report := func(diagnostics []*ast.Diagnostic) []DiagnosticError {
+		errors := newDiagnosticErrors(diagnostics)
+		if onDiagnostic != nil {
+			for _, err := range errors {
+				onDiagnostic(err)
+			}
+		}
+		return errors
 	}
 	
-	// Create config
-	config := &tsoptions.ParsedCommandLine{
-		ParsedConfig: parsedOptions,
+	if diagnostics := input.optionsDiagnostics(); len(diagnostics) > 0 {
+		typecheckResults.WithLabelValues("error").Inc()
+		return TypecheckResponse{Errors: report(diagnostics)}, nil
 	}
 	
-	// Create cache
-	extendedConfigCache := &collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]{}
-	
-	// Create host
-	host := compiler.NewCachedFSCompilerHost("/", fs, bundled.LibPath(), extendedConfigCache)
-	
-	// Create program
+	// Create program. It is incomplete if ctx is done before all files are loaded, so it is only
+	// checked if ctx is still live afterwards
 	program := compiler.NewProgram(compiler.ProgramOptions{
-		Config:           config,
-		Host:             host,
+		Config:           input.config,
+		Host:             input.host,
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Context:          ctx,
 	})
-	
-	ctx := context.Background()
-	
-	// Get diagnostics
-	diagnostics := program.GetSyntacticDiagnostics(ctx, nil)
-	if len(diagnostics) == 0 {
-		diagnostics = append(diagnostics, program.GetSemanticDiagnostics(ctx, nil)...)
+	if err := ctx.Err(); err != nil {
+		typecheckResults.WithLabelValues("canceled").Inc()
+		return TypecheckResponse{}, err
 	}
 	
-	if len(diagnostics) > 0 {
-		errors := make([]DiagnosticError, 0, len(diagnostics))
-		for _, diag := range diagnostics {
-			err := DiagnosticError{
-				Message: diag.Message(),
-			}
-			if diag.File() != nil && diag.Loc().Pos() >= 0 {
-				line, col := calculateLineColumn(diag.File().Text(), diag.Loc().Pos())
-				err.Line = line + 1
-				err.Column = col + 1
+	// Get diagnostics
+	errors := report(program.GetSyntacticDiagnostics(ctx, nil))
+	if len(errors) == 0 {
+		if onDiagnostic == nil {
+			errors = report(program.GetSemanticDiagnostics(ctx, nil))
+		} else {
+			for _, file := range program.GetSourceFiles() {
+				if ctx.Err() != nil {
+					break
+				}
+				errors = append(errors, report(program.GetSemanticDiagnostics(ctx, file))...)
 			}
-			errors = append(errors, err)
 		}
+	}
+	
+	if err := ctx.Err(); err != nil {
+		typecheckResults.WithLabelValues("canceled").Inc()
+		return TypecheckResponse{}, err
+	}
+	
+	if len(errors) > 0 {
 		typecheckResults.WithLabelValues("error").Inc()
-		return TypecheckResponse{Errors: errors}
+		return TypecheckResponse{Errors: errors}, nil
 	}
 	
 	typecheckResults.WithLabelValues("success").Inc()
-	return TypecheckResponse{Pass: true}
+	return TypecheckResponse{Pass: true}, nil
 }
 
-func buildTypeScript(code string) BuildResponse {
+// buildTypeScript bundles the request with esbuild. The build is canceled when ctx is done, in
+// which case the context's error is returned.
+func buildTypeScript(ctx context.Context, input *compileInput) (BuildResponse, error) {
 	// Track compile duration
 	compileStart := time.Now()
 	defer func() {
 		compileDuration.Observe(time.Since(compileStart).Seconds())
 	}()
-	// Clone the global memFS to avoid concurrent modification issues
-	memFS := &memoryFS{
-		files: make(map[string]string, len(globalMemFS.files)),
-	}
-	for k, v := range globalMemFS.files {
-		memFS.files[k] = v
-	}
-	
-	// Always use .tsx to support JSX
-	fileName := "/input.tsx"
 	
-	memFS.files[fileName] = code
-	
-	// Create virtual file resolver for esbuild
-	resolver := func(path string) (api.OnLoadResult, error) {
-		log.Printf("Resolving: %s", path)
-		
-		// Track package resolutions
-		trackPackageResolution(path)
-		
-		// First try exact path
-		if content, exists := memFS.files[path]; exists {
-			loader := api.LoaderDefault
-			if strings.HasSuffix(path, ".tsx") || strings.HasSuffix(path, ".ts") {
-				loader = api.LoaderTSX
-			} else if strings.HasSuffix(path, ".jsx") {
-				loader = api.LoaderJSX
-			} else if strings.HasSuffix(path, ".json") {
-				loader = api.LoaderJSON
-			}
-			log.Printf("Found exact path: %s", path)
-			return api.OnLoadResult{
-				Contents: &content,
-				Loader:   loader,
-			}, nil
-		}
-		
-		// Handle absolute paths that may be missing extension
-		if strings.HasPrefix(path, "/") {
-			extensions := []string{".js", ".jsx", ".mjs", ".json", ".ts", ".tsx"}
-			for _, ext := range extensions {
-				testPath := path + ext
-				if content, exists := memFS.files[testPath]; exists {
-					loader := api.LoaderDefault
-					if ext == ".tsx" || ext == ".ts" {
-						loader = api.LoaderTSX
-					} else if ext == ".jsx" {
-						loader = api.LoaderJSX
-					} else if ext == ".json" {
-						loader = api.LoaderJSON
-					}
-					log.Printf("Found with extension: %s", testPath)
-					return api.OnLoadResult{
-						Contents: &content,
-						Loader:   loader,
-					}, nil
-				}
-			}
-		}
-		
-		// Try to resolve as node_modules
-		if !strings.HasPrefix(path, "/") {
-			// Split the path to handle scoped packages and subpaths
-			parts := strings.Split(path, "/")
-			packageName := parts[0]
-			if strings.HasPrefix(packageName, "@") && len(parts) > 1 {
-				// Scoped package like @crayonnow/core
-				packageName = parts[0] + "/" + parts[1]
-			}
-			
-			// Try various module resolution patterns
-			patterns := []string{}
-			
-			// Check package.json for main/exports field
-			pkgPath := "/node_modules/" + packageName + "/package.json"
-			if pkgContent, exists := memFS.files[pkgPath]; exists {
-				var pkg map[string]interface{}
-				if err := json.Unmarshal([]byte(pkgContent), &pkg); err == nil {
-					// Handle exports field
-					if exports, ok := pkg["exports"].(map[string]interface{}); ok {
-						// Look for the exact export path or default
-						subpath := "./" + strings.Join(parts[len(strings.Split(packageName, "/")):], "/")
-						if subpath == "./" {
-							subpath = "."
-						}
-						
-						if exportPath, ok := exports[subpath]; ok {
-							if str, ok := exportPath.(string); ok {
-								// Handle both string exports and object exports
-								patterns = append(patterns, "/node_modules/" + packageName + "/" + strings.TrimPrefix(str, "./"))
-							} else if obj, ok := exportPath.(map[string]interface{}); ok {
-								// Handle conditional exports like { "import": "./dist/index.js" }
-								if imp, ok := obj["import"].(string); ok {
-									patterns = append(patterns, "/node_modules/" + packageName + "/" + strings.TrimPrefix(imp, "./"))
-								}
-								if def, ok := obj["default"].(string); ok {
-									patterns = append(patterns, "/node_modules/" + packageName + "/" + strings.TrimPrefix(def, "./"))
-								}
-							}
-						}
-						
-						// Try default export
-						if def, ok := exports["."].(string); ok && subpath == "." {
-							patterns = append(patterns, "/node_modules/" + packageName + "/" + strings.TrimPrefix(def, "./"))
-						} else if defObj, ok := exports["."].(map[string]interface{}); ok && subpath == "." {
-							// Handle conditional exports for default
-							if imp, ok := defObj["import"].(string); ok {
-								patterns = append(patterns, "/node_modules/" + packageName + "/" + strings.TrimPrefix(imp, "./"))
-							}
-							if def, ok := defObj["default"].(string); ok {
-								patterns = append(patterns, "/node_modules/" + packageName + "/" + strings.TrimPrefix(def, "./"))
-							}
-						}
-					}
-					
-					// Handle main field
-					if main, ok := pkg["main"].(string); ok {
-						mainPath := "/node_modules/" + packageName + "/" + main
-						if len(parts) > len(strings.Split(packageName, "/")) {
-							// Subpath import
-							subpath := strings.Join(parts[len(strings.Split(packageName, "/")):], "/")
-							mainPath = "/node_modules/" + packageName + "/" + subpath
-						}
-						patterns = append(patterns, mainPath)
-					}
-				}
-			}
-			
-			// Add default patterns
-			if len(parts) > len(strings.Split(packageName, "/")) {
-				// Subpath import like @crayonnow/core/jsx-runtime
-				subpath := strings.Join(parts[len(strings.Split(packageName, "/")):], "/")
-				patterns = append(patterns,
-					"/node_modules/"+packageName+"/"+subpath,
-					"/node_modules/"+packageName+"/"+subpath+".js",
-					"/node_modules/"+packageName+"/"+subpath+".jsx",
-					"/node_modules/"+packageName+"/"+subpath+".mjs",
-					"/node_modules/"+packageName+"/"+subpath+"/index.js",
-				)
-			} else {
-				// Main package import
-				patterns = append(patterns,
-					"/node_modules/"+path,
-					"/node_modules/"+path+"/index.js",
-					"/node_modules/"+path+"/index.jsx",
-					"/node_modules/"+path+"/index.mjs",
-				)
-			}
-			
-			for _, pattern := range patterns {
-				if content, exists := memFS.files[pattern]; exists {
-					loader := api.LoaderDefault
-					if strings.HasSuffix(pattern, ".jsx") {
-						loader = api.LoaderJSX
-					} else if strings.HasSuffix(pattern, ".json") {
-						loader = api.LoaderJSON
-					}
-					log.Printf("Found at: %s", pattern)
-					return api.OnLoadResult{
-						Contents: &content,
-						Loader:   loader,
-					}, nil
-				}
-			}
-			
-			log.Printf("Tried patterns: %v", patterns)
-		}
-		
-		return api.OnLoadResult{}, fmt.Errorf("file not found: %s", path)
+	if diagnostics := input.optionsDiagnostics(); len(diagnostics) > 0 {
+		compileResults.WithLabelValues("error").Inc()
+		return BuildResponse{Errors: newDiagnosticErrors(diagnostics)}, nil
 	}
 	
+	// Resolve imports the way the type checker does, so the bundle is made of the files that were
+	// type checked
+	resolver := newBundleResolver(input)
+	
 	// Build with esbuild (matching Swift configuration)
-	result := api.Build(api.BuildOptions{
-		EntryPoints:        []string{fileName},
+	buildOptions := api.BuildOptions{
+		EntryPoints:        []string{input.entryPoint},
 		Bundle:             true,
 		Format:             api.FormatCommonJS,
 		JSXFactory:         "_CRAYONCORE_$REACT.createElement",
@@ -569,57 +471,35 @@ func buildTypeScript(code string) BuildResponse {
 					trackPackageResolution(args.Path)
 					
 					// Transform react imports to use global variable
-					if args.Path == "react" {
+					if input.defaultOptions && args.Path == "react" {
 						return api.OnResolveResult{
 							Path:      "react",
 							Namespace: "use-crayon-react-global",
 						}, nil
 					}
 					
-					// Handle absolute imports
-					if strings.HasPrefix(args.Path, "/") {
-						return api.OnResolveResult{Path: args.Path, Namespace: "virtual"}, nil
-					}
-					
-					// Handle relative imports
-					if strings.HasPrefix(args.Path, "./") || strings.HasPrefix(args.Path, "../") {
-						importerDir := filepath.Dir(args.Importer)
-						resolvedPath := filepath.Join(importerDir, args.Path)
-						resolvedPath = strings.ReplaceAll(resolvedPath, "\\", "/")
-						return api.OnResolveResult{Path: resolvedPath, Namespace: "virtual"}, nil
-					}
-					
-					// Handle bare imports (no relative path)
-					if !strings.Contains(args.Path, "/") || strings.HasPrefix(args.Path, "@") {
-						// This is a node_modules import
+					// Entry points are already absolute paths in the virtual file system
+					if args.Kind == api.ResolveEntryPoint {
 						return api.OnResolveResult{Path: args.Path, Namespace: "virtual"}, nil
 					}
 					
-					// Handle subpath imports that aren't relative (like "cjs/react.production.js")
-					// These are relative to the importer's package
-					if args.Importer != "" && strings.Contains(args.Importer, "/node_modules/") {
-						// Extract the package path from the importer
-						parts := strings.Split(args.Importer, "/node_modules/")
-						if len(parts) >= 2 {
-							// Find the package name
-							remainingPath := parts[1]
-							packageParts := strings.Split(remainingPath, "/")
-							packageName := packageParts[0]
-							if strings.HasPrefix(packageName, "@") && len(packageParts) > 1 {
-								packageName = packageParts[0] + "/" + packageParts[1]
-							}
-							// Resolve relative to package root
-							resolvedPath := "/node_modules/" + packageName + "/" + args.Path
-							return api.OnResolveResult{Path: resolvedPath, Namespace: "virtual"}, nil
-						}
+					resolved := resolver.ResolveModuleName(args.Path, args.Importer, resolutionModeForKind(args.Kind), nil)
+					if !resolved.IsResolved() {
+						log.Printf("Failed to resolve %s from %s", args.Path, args.Importer)
+						return api.OnResolveResult{}, fmt.Errorf("could not resolve %q", args.Path)
 					}
-					
-					// Default: treat as node_modules import
-					return api.OnResolveResult{Path: args.Path, Namespace: "virtual"}, nil
+					return api.OnResolveResult{Path: resolved.ResolvedFileName, Namespace: "virtual"}, nil
 				})
 				
 				pb.OnLoad(api.OnLoadOptions{Filter: ".*", Namespace: "virtual"}, func(args api.OnLoadArgs) (api.OnLoadResult, error) {
-					return resolver(args.Path)
+					content, ok := input.memFS.ReadFile(args.Path)
+					if !ok {
+						return api.OnLoadResult{}, fmt.Errorf("file not found: %s", args.Path)
+					}
+					return api.OnLoadResult{
+						Contents: &content,
+						Loader:   loaderForFile(args.Path),
+					}, nil
 				})
 				
 				// Handle react global transform
@@ -632,31 +512,60 @@ func buildTypeScript(code string) BuildResponse {
 				})
 			},
 		}},
-	})
+	}
+	
+	// Requests with their own compiler options decide the target, module format and JSX transform
+	if !input.defaultOptions {
+		applyCompilerOptions(input.config.CompilerOptions(), &buildOptions)
+	}
+	
+	buildContext, contextErr := api.Context(buildOptions)
+	if contextErr != nil {
+		compileResults.WithLabelValues("error").Inc()
+		return BuildResponse{Errors: newBuildErrors(contextErr.Errors)}, nil
+	}
+	defer buildContext.Dispose()
+	
+	// Cancel the build when the request is canceled or times out
+	stop := context.AfterFunc(ctx, buildContext.Cancel)
+	defer stop()
+	
+	result := buildContext.Rebuild()
+	
+	if err := ctx.Err(); err != nil {
+		compileResults.WithLabelValues("canceled").Inc()
+		return BuildResponse{}, err
+	}
 	
 	if len(result.Errors) > 0 {
-		errors := make([]DiagnosticError, 0, len(result.Errors))
-		for _, err := range result.Errors {
-			diagErr := DiagnosticError{
-				Message: err.Text,
-			}
-			if err.Location != nil {
-				diagErr.Line = err.Location.Line
-				diagErr.Column = err.Location.Column
-			}
-			errors = append(errors, diagErr)
-		}
 		compileResults.WithLabelValues("error").Inc()
-		return BuildResponse{Errors: errors}
+		return BuildResponse{Errors: newBuildErrors(result.Errors)}, nil
 	}
 	
 	if len(result.OutputFiles) == 0 {
 		compileResults.WithLabelValues("error").Inc()
-		return BuildResponse{Errors: []DiagnosticError{{Message: "No output generated"}}}
+		return BuildResponse{Errors: []DiagnosticError{{Message: "No output generated"}}}, nil
 	}
 	
 	compileResults.WithLabelValues("success").Inc()
-	return BuildResponse{Code: string(result.OutputFiles[0].Contents)}
+	return BuildResponse{Code: string(result.OutputFiles[0].Contents)}, nil
+}
+
+func newBuildErrors(messages []api.Message) []DiagnosticError {
+	errors := make([]DiagnosticError, 0, len(messages))
+	for _, err := range messages {
+		diagErr := DiagnosticError{
+			Message:  err.Text,
+			Category: "error",
+		}
+		if err.Location != nil {
+			diagErr.Line = err.Location.Line
+			diagErr.Column = err.Location.Column
+			diagErr.File = err.Location.File
+		}
+		errors = append(errors, diagErr)
+	}
+	return errors
 }
 
 // Middleware for request logging
@@ -689,6 +598,11 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streamed responses
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 func getPackageVersions() map[string]string {
 	versions := make(map[string]string)
 	
@@ -764,12 +678,24 @@ func typecheck(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if typecheckReq.Code == "" {
-		http.Error(w, "Code is required", http.StatusBadRequest)
+	input, err := newCompileInput(typecheckReq.CompileRequest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel, err := requestContext(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	defer cancel()
 
-	response := typecheckTypeScript(typecheckReq.Code)
+	response, err := typecheckTypeScript(ctx, input, nil)
+	if err != nil {
+		writeContextError(w, err)
+		return
+	}
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -787,17 +713,40 @@ func build(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if buildReq.Code == "" {
-		http.Error(w, "Code is required", http.StatusBadRequest)
+	input, err := newCompileInput(buildReq.CompileRequest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	ctx, cancel, err := requestContext(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer cancel()
+
 	// Check if type validation is requested
 	validateTypes := req.URL.Query().Get("validate_types") == "true"
 	
+	// Stream diagnostics and output as they become available if requested
+	switch format := req.URL.Query().Get("stream"); format {
+	case "":
+	case streamFormatNDJSON, streamFormatSSE:
+		streamBuild(ctx, newEventWriter(w, format), input, validateTypes)
+		return
+	default:
+		http.Error(w, fmt.Sprintf("Unsupported stream format %q", format), http.StatusBadRequest)
+		return
+	}
+	
 	if validateTypes {
 		// First run typecheck
-		typecheckResponse := typecheckTypeScript(buildReq.Code)
+		typecheckResponse, err := typecheckTypeScript(ctx, input, nil)
+		if err != nil {
+			writeContextError(w, err)
+			return
+		}
 		if len(typecheckResponse.Errors) > 0 {
 			// Return type errors as build errors
 			response := BuildResponse{
@@ -810,7 +759,11 @@ func build(w http.ResponseWriter, req *http.Request) {
 	}
 
 	// Proceed with build
-	response := buildTypeScript(buildReq.Code)
+	response, err := buildTypeScript(ctx, input)
+	if err != nil {
+		writeContextError(w, err)
+		return
+	}
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -819,21 +772,41 @@ func build(w http.ResponseWriter, req *http.Request) {
 func main() {
 	log.Printf("TypeScript Go Server v%s starting...", serverVersion)
 	
+	if err := loadRequestTimeout(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := loadSourceFileCacheSize(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	
 	// Initialize module loading before serving requests
 	log.Println("Initializing server...")
 	globalMemFS = newMemoryFS() // Load modules once at startup
 	
+	store, err := loadPackageStore()
+	if err != nil {
+		log.Fatalf("Failed to open package store: %v", err)
+	}
+	globalPackageStore = store
+	
 	// Set up routes with logging middleware
 	http.HandleFunc("/health", loggingMiddleware(health))
 	http.HandleFunc("/typecheck", loggingMiddleware(typecheck))
 	http.HandleFunc("/build", loggingMiddleware(build))
+	http.HandleFunc("/packages/", loggingMiddleware(uploadPackage))
+	http.HandleFunc("/hover", loggingMiddleware(languageServiceHandler("hover", hover)))
+	http.HandleFunc("/completions", loggingMiddleware(languageServiceHandler("completions", completions)))
+	http.HandleFunc("/definition", loggingMiddleware(languageServiceHandler("definition", definition)))
+	http.HandleFunc("/references", loggingMiddleware(languageServiceHandler("references", references)))
+	http.HandleFunc("/signatureHelp", loggingMiddleware(languageServiceHandler("signatureHelp", signatureHelp)))
+	http.HandleFunc("/format", loggingMiddleware(languageServiceHandler("format", formatDocument)))
 	http.HandleFunc("/", loggingMiddleware(hello))
 	
 	// Start Prometheus metrics server on port 9091
 	go startMetricsServer()
 	
 	log.Printf("Server ready! Listening on :8080...")
-	log.Printf("Endpoints: /, /health, /typecheck, /build")
+	log.Printf("Endpoints: /, /health, /typecheck, /build, /packages/{name}@{version}, /hover, /completions, /definition, /references, /signatureHelp, /format")
 	log.Printf("Metrics available at :9091/metrics")
 	
 	if err := http.ListenAndServe(":8080", nil); err != nil {
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/parser"
+	"github.com/zeebo/xxh3"
+)
+
+// sourceFileCacheKey identifies a parsed file by its parse options and a hash of its text.
+type sourceFileCacheKey struct {
+	opts       ast.SourceFileParseOptions
+	scriptKind core.ScriptKind
+	hash       xxh3.Uint128
+}
+
+// defaultSourceFileCacheSize is the number of parsed files sourceFileCache keeps unless the
+// SOURCE_FILE_CACHE_SIZE environment variable is set.
+const defaultSourceFileCacheSize = 10000
+
+// sourceFileCache holds the lib and node_modules files parsed by earlier requests. Source files
+// are not modified once parsed and bound, so programs of concurrent requests can share them. The
+// least recently used files are dropped when it is full; programs that still use them keep them
+// alive until they are done.
+var sourceFileCache = newSourceFileCache(defaultSourceFileCacheSize)
+
+func newSourceFileCache(size int) *lruCache[sourceFileCacheKey, *ast.SourceFile] {
+	cache := newLRUCache[sourceFileCacheKey, *ast.SourceFile](size)
+	cache.onEvict = func(sourceFileCacheKey, *ast.SourceFile) {
+		sourceFileCacheEvictions.Inc()
+	}
+	return cache
+}
+
+func loadSourceFileCacheSize() error {
+	size, err := loadPositiveInt("SOURCE_FILE_CACHE_SIZE", defaultSourceFileCacheSize)
+	if err != nil {
+		return err
+	}
+	sourceFileCache = newSourceFileCache(size)
+	return nil
+}
+
+// cachedCompilerHost parses the files of a request on every call, and reuses the parsed lib and
+// node_modules files from sourceFileCache.
+type cachedCompilerHost struct {
+	compiler.CompilerHost
+	memFS *memoryFS
+}
+
+func (h *cachedCompilerHost) GetSourceFile(opts ast.SourceFileParseOptions) *ast.SourceFile {
+	text, ok := h.FS().ReadFile(opts.FileName)
+	if !ok {
+		return nil
+	}
+
+	scriptKind := core.GetScriptKindFromFileName(opts.FileName)
+	if h.memFS.isOverlayFile(opts.FileName) {
+		return parser.ParseSourceFile(opts, text, scriptKind)
+	}
+
+	key := sourceFileCacheKey{
+		opts:       opts,
+		scriptKind: scriptKind,
+		hash:       xxh3.Hash128([]byte(text)),
+	}
+	if cached, ok := sourceFileCache.Load(key); ok {
+		sourceFileCacheResults.WithLabelValues("hit").Inc()
+		return cached
+	}
+
+	sourceFileCacheResults.WithLabelValues("miss").Inc()
+	sourceFile := parser.ParseSourceFile(opts, text, scriptKind)
+	result, _ := sourceFileCache.LoadOrStore(key, sourceFile)
+	sourceFileCacheSize.Set(float64(sourceFileCache.Len()))
+	return result
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// setupSourceFileCache replaces sourceFileCache with an empty cache of the given size.
+func setupSourceFileCache(t *testing.T, size int) {
+	t.Helper()
+	previous := sourceFileCache
+	sourceFileCache = newSourceFileCache(size)
+	t.Cleanup(func() { sourceFileCache = previous })
+}
+
+func TestSourceFileCache(t *testing.T) {
+	setupMemFS(t, map[string]string{
+		"/node_modules/pkg/package.json": `{"name": "pkg", "types": "index.d.ts"}`,
+		"/node_modules/pkg/index.d.ts":   "export declare const value: number;",
+	})
+	setupSourceFileCache(t, 100)
+	hits := sourceFileCacheResults.WithLabelValues("hit")
+	misses := sourceFileCacheResults.WithLabelValues("miss")
+
+	request := func(code string) *compileInput {
+		t.Helper()
+		input, err := newCompileInput(CompileRequest{Code: code})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return input
+	}
+	parse := func(input *compileInput, fileName string) *ast.SourceFile {
+		t.Helper()
+		return input.host.GetSourceFile(ast.SourceFileParseOptions{FileName: fileName, Path: tspath.Path(fileName)})
+	}
+
+	hitsBefore, missesBefore := testutil.ToFloat64(hits), testutil.ToFloat64(misses)
+	first := request(`import { value } from "pkg";`)
+	declaration := parse(first, "/node_modules/pkg/index.d.ts")
+	if testutil.ToFloat64(misses) != missesBefore+1 || sourceFileCache.Len() != 1 {
+		t.Errorf("expected the declaration file to be cached")
+	}
+
+	// The files of the request are parsed again for every request
+	if parse(first, "/input.tsx") == parse(request(`import { value } from "pkg";`), "/input.tsx") {
+		t.Error("request files were cached")
+	}
+
+	second := request(`import { value } from "pkg"; value;`)
+	if parse(second, "/node_modules/pkg/index.d.ts") != declaration {
+		t.Error("the declaration file was parsed again")
+	}
+	if testutil.ToFloat64(hits) != hitsBefore+1 {
+		t.Errorf("expected one cache hit, got %v", testutil.ToFloat64(hits)-hitsBefore)
+	}
+
+	response := decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", CompileRequest{
+		Code: `import { value } from "pkg"; const n: number = value;`,
+	}))
+	if !response.Pass {
+		t.Errorf("expected the request to pass, got %+v", response)
+	}
+	if size := testutil.ToFloat64(sourceFileCacheSize); int(size) != sourceFileCache.Len() || size < 2 {
+		t.Errorf("source_file_cache_entries is %v, the cache has %d entries", size, sourceFileCache.Len())
+	}
+}
+
+func TestSourceFileCacheEviction(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+	setupSourceFileCache(t, 2)
+	evictionsBefore := testutil.ToFloat64(sourceFileCacheEvictions)
+
+	// Each request checks at least the es2022 lib files, which do not fit in the cache
+	response := decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", CompileRequest{Code: "const x = 1;"}))
+	if !response.Pass {
+		t.Errorf("expected the request to pass, got %+v", response)
+	}
+	if sourceFileCache.Len() != 2 {
+		t.Errorf("the cache has %d entries, expected 2", sourceFileCache.Len())
+	}
+	if testutil.ToFloat64(sourceFileCacheEvictions) == evictionsBefore {
+		t.Error("no evictions were counted")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// requestTimeout is the longest a /typecheck or /build request may run. It can be set with the
+// REQUEST_TIMEOUT environment variable, e.g. REQUEST_TIMEOUT=10s.
+var requestTimeout = 30 * time.Second
+
+func loadRequestTimeout() error {
+	value := os.Getenv("REQUEST_TIMEOUT")
+	if value == "" {
+		return nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("invalid REQUEST_TIMEOUT %q", value)
+	}
+	requestTimeout = timeout
+	return nil
+}
+
+// requestContext returns the context a request is processed under. It is canceled when the client
+// disconnects, and times out after requestTimeout, or after the duration in the timeout query
+// parameter if that is shorter.
+func requestContext(req *http.Request) (context.Context, context.CancelFunc, error) {
+	timeout := requestTimeout
+	if value := req.URL.Query().Get("timeout"); value != "" {
+		requested, err := time.ParseDuration(value)
+		if err != nil || requested <= 0 {
+			return nil, nil, fmt.Errorf("invalid timeout %q", value)
+		}
+		timeout = min(timeout, requested)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	return ctx, cancel, nil
+}
+
+func contextErrorMessage(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "Request timed out"
+	}
+	return "Request canceled"
+}
+
+// writeContextError responds to a request that could not finish before its context was done.
+func writeContextError(w http.ResponseWriter, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		http.Error(w, contextErrorMessage(err), http.StatusGatewayTimeout)
+	}
+	// Otherwise the client disconnected, and there is no one to respond to
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+	request := CompileRequest{Code: "const x: number = 1;"}
+
+	for _, handler := range []struct {
+		target string
+		fn     http.HandlerFunc
+	}{{"/typecheck?timeout=1ns", typecheck}, {"/build?timeout=1ns", build}, {"/build?validate_types=true&timeout=1ns", build}} {
+		recorder := post(t, handler.fn, handler.target, request)
+		if recorder.Code != http.StatusGatewayTimeout || recorder.Body.String() != "Request timed out\n" {
+			t.Errorf("%s: got %d %q, expected %d", handler.target, recorder.Code, recorder.Body, http.StatusGatewayTimeout)
+		}
+	}
+
+	// A longer timeout than the server's does not extend it
+	previous := requestTimeout
+	requestTimeout = time.Nanosecond
+	t.Cleanup(func() { requestTimeout = previous })
+	if recorder := post(t, typecheck, "/typecheck?timeout=1h", request); recorder.Code != http.StatusGatewayTimeout {
+		t.Errorf("timeout=1h: got %d, expected %d", recorder.Code, http.StatusGatewayTimeout)
+	}
+}
+
+func TestRequestCanceled(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+	canceled := typecheckResults.WithLabelValues("canceled")
+	canceledBefore := testutil.ToFloat64(canceled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data, _ := json.Marshal(CompileRequest{Code: "const x: number = 1;"})
+	recorder := httptest.NewRecorder()
+	typecheck(recorder, httptest.NewRequestWithContext(ctx, http.MethodPost, "/typecheck", bytes.NewReader(data)))
+	// The client is gone, so nothing is written
+	if recorder.Body.Len() != 0 {
+		t.Errorf("wrote %q to a canceled request", recorder.Body)
+	}
+	if testutil.ToFloat64(canceled) != canceledBefore+1 {
+		t.Error("the canceled request was not counted")
+	}
+
+	// Program creation stops as soon as the context is done, and the incomplete program is not
+	// checked
+	input, err := newCompileInput(CompileRequest{Code: `import "./a"; const n: number = "one";`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := typecheckTypeScript(ctx, input, nil)
+	if !errors.Is(err, context.Canceled) || len(response.Errors) != 0 {
+		t.Errorf("got %+v, %v, expected context.Canceled", response, err)
+	}
+}
+
+func TestLoadRequestTimeout(t *testing.T) {
+	previous := requestTimeout
+	t.Cleanup(func() { requestTimeout = previous })
+
+	t.Setenv("REQUEST_TIMEOUT", "10s")
+	if err := loadRequestTimeout(); err != nil || requestTimeout != 10*time.Second {
+		t.Errorf("got %v, %v", requestTimeout, err)
+	}
+	for _, value := range []string{"10", "-1s", "0s"} {
+		t.Setenv("REQUEST_TIMEOUT", value)
+		if err := loadRequestTimeout(); err == nil {
+			t.Errorf("%s: expected an error", value)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// lruCache is a map that holds at most capacity entries, and evicts the least recently used
+// entry when one more is added. It is safe for concurrent use.
+type lruCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[K]*list.Element
+	// order holds the entries from the most to the least recently used.
+	order *list.List
+	// onEvict, if set, is called with the evicted entries while the cache is locked.
+	onEvict func(key K, value V)
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRUCache[K comparable, V any](capacity int) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Load returns the value for key and marks it as the most recently used.
+func (c *lruCache[K, V]) Load(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*lruEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// LoadOrStore returns the value for key if there is one. Otherwise it stores value, evicting the
+// least recently used entry if the cache is full, and returns it.
+func (c *lruCache[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*lruEntry[K, V]).value, true
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Remove(c.order.Back()).(*lruEntry[K, V])
+		delete(c.entries, oldest.key)
+		if c.onEvict != nil {
+			c.onEvict(oldest.key, oldest.value)
+		}
+	}
+	return value, false
+}
+
+// Len returns the number of entries in the cache.
+func (c *lruCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// loadPositiveInt returns the positive integer in the environment variable name, or defaultValue
+// if it is not set.
+func loadPositiveInt(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return size, nil
+}
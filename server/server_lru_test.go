@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newLRUCache[string, int](2)
+	var evicted []string
+	cache.onEvict = func(key string, value int) {
+		evicted = append(evicted, key)
+	}
+
+	cache.LoadOrStore("a", 1)
+	cache.LoadOrStore("b", 2)
+	if value, loaded := cache.LoadOrStore("a", 10); !loaded || value != 1 {
+		t.Errorf("LoadOrStore of an existing key returned %d, %v", value, loaded)
+	}
+	// a was used more recently than b, so b is evicted
+	cache.LoadOrStore("c", 3)
+	if _, ok := cache.Load("b"); ok {
+		t.Error("b was not evicted")
+	}
+	cache.Load("a")
+	cache.LoadOrStore("d", 4)
+	if _, ok := cache.Load("c"); ok {
+		t.Error("c was not evicted")
+	}
+	if value, ok := cache.Load("a"); !ok || value != 1 {
+		t.Errorf("Load(a) returned %d, %v", value, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len is %d, expected 2", cache.Len())
+	}
+	if !slices.Equal(evicted, []string{"b", "c"}) {
+		t.Errorf("evicted %v", evicted)
+	}
+}
+
+func TestLoadPositiveInt(t *testing.T) {
+	t.Setenv("TEST_CACHE_SIZE", "")
+	if size, err := loadPositiveInt("TEST_CACHE_SIZE", 5); err != nil || size != 5 {
+		t.Errorf("unset: got %d, %v", size, err)
+	}
+	t.Setenv("TEST_CACHE_SIZE", "12")
+	if size, err := loadPositiveInt("TEST_CACHE_SIZE", 5); err != nil || size != 12 {
+		t.Errorf("12: got %d, %v", size, err)
+	}
+	for _, value := range []string{"0", "-1", "many"} {
+		t.Setenv("TEST_CACHE_SIZE", value)
+		if _, err := loadPositiveInt("TEST_CACHE_SIZE", 5); err == nil {
+			t.Errorf("%s: expected an error", value)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	encjson "encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/ls/standalone"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// LanguageServiceRequest is the body of the language service endpoints: the files of a
+// CompileRequest, and the file and position the request is about.
+type LanguageServiceRequest struct {
+	CompileRequest
+	// File is the file the request is about. When it is omitted, the entry point is used.
+	File string `json:"file,omitempty"`
+	// Position is a zero-based line and UTF-16 character offset in File. /format ignores it.
+	Position lsproto.Position `json:"position"`
+	// TriggerCharacter is the character that triggered /completions or /signatureHelp, if any.
+	TriggerCharacter string `json:"triggerCharacter,omitempty"`
+	// IncludeDeclaration makes /references include the declaration of the symbol.
+	IncludeDeclaration bool `json:"includeDeclaration,omitempty"`
+	// Options are the /format settings. By default, four spaces are used for indentation.
+	Options *lsproto.FormattingOptions `json:"options,omitempty"`
+}
+
+// languageServiceParams is what a language service endpoint needs to answer a request.
+type languageServiceParams struct {
+	uri lsproto.DocumentUri
+	req *LanguageServiceRequest
+}
+
+// languageServiceHandler returns a handler that creates a language service for the request files
+// and answers the request with provide. The result is written as lsproto JSON.
+func languageServiceHandler[T any](method string, provide func(context.Context, *ls.LanguageService, languageServiceParams) (T, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var lsReq LanguageServiceRequest
+		if err := encjson.NewDecoder(req.Body).Decode(&lsReq); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		// The file a request is about is a natural entry point, so requests with several files
+		// do not need to name one separately
+		if lsReq.EntryPoint == "" {
+			if lsReq.File == "" && len(lsReq.Files) > 1 {
+				http.Error(w, "file is required when more than one file is specified", http.StatusBadRequest)
+				return
+			}
+			lsReq.EntryPoint = lsReq.File
+		}
+
+		input, err := newCompileInput(lsReq.CompileRequest)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel, err := requestContext(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer cancel()
+
+		start := time.Now()
+		defer func() {
+			languageServiceDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		}()
+
+		host := standalone.NewHost(compiler.NewProgram(compiler.ProgramOptions{
+			Config:  input.config,
+			Host:    input.host,
+			Context: ctx,
+		}))
+		if ctx.Err() != nil {
+			writeContextError(w, ctx.Err())
+			return
+		}
+
+		fileName := input.entryPoint
+		if lsReq.File != "" {
+			fileName = tspath.GetNormalizedAbsolutePath(lsReq.File, "/")
+		}
+		if host.GetProgram().GetSourceFile(fileName) == nil {
+			http.Error(w, fmt.Sprintf("file %q is not part of the program", fileName), http.StatusBadRequest)
+			return
+		}
+		// /format ignores the position
+		if lines := len(host.GetLineMap(fileName).LineStarts); method != "format" && int(lsReq.Position.Line) >= lines {
+			http.Error(w, fmt.Sprintf("line %d is past the end of %s, which has %d lines", lsReq.Position.Line, fileName, lines), http.StatusBadRequest)
+			return
+		}
+
+		result, err := callLanguageService(ctx, method, ls.NewLanguageService(host), languageServiceParams{
+			uri: ls.FileNameToDocumentURI(fileName),
+			req: &lsReq,
+		}, provide)
+		if ctx.Err() != nil {
+			writeContextError(w, ctx.Err())
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.MarshalWrite(w, &result)
+	}
+}
+
+// callLanguageService calls provide, turning a panic in the language service into an error so a
+// single unsupported request does not take the connection down with it.
+func callLanguageService[T any](ctx context.Context, method string, languageService *ls.LanguageService, params languageServiceParams, provide func(context.Context, *ls.LanguageService, languageServiceParams) (T, error)) (T, error) {
+	result, err := standalone.Call(func() (T, error) {
+		return provide(ctx, languageService, params)
+	})
+	var panicErr *standalone.PanicError
+	if errors.As(err, &panicErr) {
+		log.Printf("ERROR: %s panicked: %v", method, panicErr.Value)
+		err = fmt.Errorf("%s failed", method)
+	}
+	return result, err
+}
+
+func hover(ctx context.Context, languageService *ls.LanguageService, params languageServiceParams) (lsproto.HoverResponse, error) {
+	return languageService.ProvideHover(ctx, params.uri, params.req.Position)
+}
+
+func completions(ctx context.Context, languageService *ls.LanguageService, params languageServiceParams) (lsproto.CompletionResponse, error) {
+	return languageService.ProvideCompletion(ctx, params.uri, params.req.Position, standalone.CompletionContext(params.req.TriggerCharacter), nil, &ls.UserPreferences{})
+}
+
+func definition(ctx context.Context, languageService *ls.LanguageService, params languageServiceParams) (lsproto.DefinitionResponse, error) {
+	return languageService.ProvideDefinition(ctx, params.uri, params.req.Position)
+}
+
+func references(ctx context.Context, languageService *ls.LanguageService, params languageServiceParams) (lsproto.ReferencesResponse, error) {
+	return standalone.ProvideReferences(ctx, languageService, params.uri, params.req.Position, params.req.IncludeDeclaration)
+}
+
+func signatureHelp(ctx context.Context, languageService *ls.LanguageService, params languageServiceParams) (lsproto.SignatureHelpResponse, error) {
+	return languageService.ProvideSignatureHelp(ctx, params.uri, params.req.Position, standalone.SignatureHelpContext(params.req.TriggerCharacter), standalone.SignatureHelpCapabilities, &ls.UserPreferences{})
+}
+
+func formatDocument(ctx context.Context, languageService *ls.LanguageService, params languageServiceParams) (lsproto.DocumentFormattingResponse, error) {
+	options := params.req.Options
+	if options == nil {
+		options = &standalone.DefaultFormattingOptions
+	}
+	return languageService.ProvideFormatDocument(ctx, params.uri, options)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+// decodeLSP decodes the lsproto JSON body of a successful language service response.
+func decodeLSP[T any](t *testing.T, recorder *httptest.ResponseRecorder) T {
+	t.Helper()
+	var result T
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got %d %s", recorder.Code, recorder.Body)
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid response %s: %v", recorder.Body, err)
+	}
+	return result
+}
+
+var languageServiceFiles = map[string]string{
+	"index.ts": "import { greet } from \"./greet\";\ngreet(\"world\");\ngreet(\"again\");\n",
+	"greet.ts": "export function greet(name: string): string { return name; }\n",
+}
+
+func TestLanguageServiceEndpoints(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+	request := func(line, character uint32) LanguageServiceRequest {
+		return LanguageServiceRequest{
+			CompileRequest: CompileRequest{Files: languageServiceFiles},
+			File:           "index.ts",
+			Position:       lsproto.Position{Line: line, Character: character},
+		}
+	}
+
+	hoverResponse := decodeLSP[lsproto.HoverResponse](t, post(t, languageServiceHandler("hover", hover), "/hover", request(1, 1)))
+	if hoverResponse.Hover == nil || hoverResponse.Hover.Contents.MarkupContent == nil ||
+		!strings.Contains(hoverResponse.Hover.Contents.MarkupContent.Value, "greet(name: string): string") {
+		t.Errorf("unexpected hover %+v", hoverResponse.Hover)
+	}
+
+	definitionResponse := decodeLSP[lsproto.DefinitionResponse](t, post(t, languageServiceHandler("definition", definition), "/definition", request(1, 1)))
+	if definitionResponse.Locations == nil || len(*definitionResponse.Locations) != 1 || (*definitionResponse.Locations)[0].Uri != "file:///greet.ts" {
+		t.Errorf("expected the definition in greet.ts, got %+v", definitionResponse)
+	}
+
+	referencesRequest := request(1, 1)
+	referencesResponse := decodeLSP[lsproto.ReferencesResponse](t, post(t, languageServiceHandler("references", references), "/references", referencesRequest))
+	if referencesResponse.Locations == nil || len(*referencesResponse.Locations) != 3 || slices.ContainsFunc(*referencesResponse.Locations, isGreetTS) {
+		t.Errorf("expected the three references in index.ts, got %+v", referencesResponse.Locations)
+	}
+	referencesRequest.IncludeDeclaration = true
+	referencesResponse = decodeLSP[lsproto.ReferencesResponse](t, post(t, languageServiceHandler("references", references), "/references", referencesRequest))
+	if referencesResponse.Locations == nil || len(*referencesResponse.Locations) != 4 || !slices.ContainsFunc(*referencesResponse.Locations, isGreetTS) {
+		t.Errorf("expected includeDeclaration to add the declaration in greet.ts, got %+v", referencesResponse.Locations)
+	}
+
+	completionsRequest := request(1, 0)
+	completionsResponse := decodeLSP[lsproto.CompletionResponse](t, post(t, languageServiceHandler("completions", completions), "/completions", completionsRequest))
+	if completionsResponse.List == nil || !hasCompletion(completionsResponse.List.Items, "greet") {
+		t.Errorf("expected greet to be completed, got %+v", completionsResponse)
+	}
+
+	signatureHelpRequest := request(1, 6)
+	signatureHelpRequest.TriggerCharacter = "("
+	signatureHelpResponse := decodeLSP[lsproto.SignatureHelpResponse](t, post(t, languageServiceHandler("signatureHelp", signatureHelp), "/signatureHelp", signatureHelpRequest))
+	if signatureHelpResponse.SignatureHelp == nil || len(signatureHelpResponse.SignatureHelp.Signatures) != 1 ||
+		signatureHelpResponse.SignatureHelp.Signatures[0].Label != "greet(name: string): string" {
+		t.Errorf("unexpected signature help %+v", signatureHelpResponse.SignatureHelp)
+	}
+}
+
+func isGreetTS(location lsproto.Location) bool {
+	return location.Uri == "file:///greet.ts"
+}
+
+func hasCompletion(items []*lsproto.CompletionItem, label string) bool {
+	for _, item := range items {
+		if item.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFormat(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+	request := LanguageServiceRequest{CompileRequest: CompileRequest{Code: "function  f( ) {return 1}"}}
+
+	edits := decodeLSP[lsproto.DocumentFormattingResponse](t, post(t, languageServiceHandler("format", formatDocument), "/format", request))
+	if edits.TextEdits == nil || len(*edits.TextEdits) != 5 {
+		t.Fatalf("expected the spacing to be fixed, got %+v", edits.TextEdits)
+	}
+	if edit := (*edits.TextEdits)[0]; edit.NewText != " " || edit.Range.Start.Character != 8 || edit.Range.End.Character != 10 {
+		t.Errorf("unexpected first edit %+v", edit)
+	}
+
+	// The position is not needed, and explicit options are accepted
+	request.Position = lsproto.Position{Line: 5}
+	request.Options = &lsproto.FormattingOptions{TabSize: 2, InsertSpaces: true}
+	withOptions := decodeLSP[lsproto.DocumentFormattingResponse](t, post(t, languageServiceHandler("format", formatDocument), "/format", request))
+	if withOptions.TextEdits == nil || len(*withOptions.TextEdits) != 5 {
+		t.Errorf("expected the same edits with options, got %+v", withOptions.TextEdits)
+	}
+}
+
+func TestLanguageServiceRequestErrors(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+	handler := languageServiceHandler("hover", hover)
+
+	tests := []struct {
+		name    string
+		request LanguageServiceRequest
+		message string
+	}{
+		{
+			"no file",
+			LanguageServiceRequest{CompileRequest: CompileRequest{Files: languageServiceFiles}},
+			"file is required",
+		},
+		{
+			"file not in the program",
+			LanguageServiceRequest{CompileRequest: CompileRequest{Files: map[string]string{"index.ts": "1", "notes.txt": "2"}, EntryPoint: "index.ts"}, File: "notes.txt"},
+			`file "/notes.txt" is not part of the program`,
+		},
+		{
+			"line past the end",
+			LanguageServiceRequest{CompileRequest: CompileRequest{Code: "const x = 1;"}, Position: lsproto.Position{Line: 1}},
+			"line 1 is past the end of /input.tsx, which has 1 lines",
+		},
+		{
+			"no code",
+			LanguageServiceRequest{},
+			"code or files is required",
+		},
+	}
+	for _, test := range tests {
+		checkBadRequest(t, test.name, post(t, handler, "/hover", test.request), test.message)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/hover", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /hover: got %d, expected %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -70,6 +70,37 @@ var (
 		},
 		[]string{"result"},
 	)
+
+	languageServiceDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "language_service_duration_seconds",
+			Help:    "Duration of language service requests in seconds",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"method"},
+	)
+
+	sourceFileCacheResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "source_file_cache_lookups_total",
+			Help: "Total number of lib and node_modules source file lookups by result",
+		},
+		[]string{"result"},
+	)
+
+	sourceFileCacheSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "source_file_cache_entries",
+			Help: "Number of lib and node_modules source files in the parsed file cache",
+		},
+	)
+
+	sourceFileCacheEvictions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "source_file_cache_evictions_total",
+			Help: "Total number of source files evicted from the parsed file cache",
+		},
+	)
 )
 
 func init() {
@@ -81,6 +112,10 @@ func init() {
 	prometheus.MustRegister(requestCounter)
 	prometheus.MustRegister(typecheckResults)
 	prometheus.MustRegister(compileResults)
+	prometheus.MustRegister(languageServiceDuration)
+	prometheus.MustRegister(sourceFileCacheResults)
+	prometheus.MustRegister(sourceFileCacheSize)
+	prometheus.MustRegister(sourceFileCacheEvictions)
 }
 
 // trackPackageResolution extracts and tracks package name from import path
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// defaultFileName is where the code of a request that only sets `code` is placed.
+const defaultFileName = "/input.tsx"
+
+// configFileName is the virtual tsconfig.json that request compiler options are parsed as.
+const configFileName = "/tsconfig.json"
+
+// defaultCompilerOptions are used when a request does not specify compilerOptions. They match the
+// settings CrayonDeveloper projects are created with.
+const defaultCompilerOptions = `{
+	"allowJs": true,
+	"declaration": true,
+	"esModuleInterop": true,
+	"forceConsistentCasingInFileNames": true,
+	"isolatedModules": true,
+	"jsx": "react-jsx",
+	"jsxImportSource": "@crayonnow/core",
+	"module": "commonjs",
+	"moduleResolution": "bundler",
+	"noEmit": true,
+	"resolveJsonModule": true,
+	"skipLibCheck": true,
+	"strict": true,
+	"strictNullChecks": true,
+	"target": "es2022",
+	"lib": ["es2022"]
+}`
+
+// compileInput is a validated CompileRequest: the request files layered over its dependencies,
+// and the compiler options parsed as if they were the compilerOptions of a tsconfig.json.
+type compileInput struct {
+	memFS      *memoryFS
+	host       compiler.CompilerHost
+	entryPoint string
+	config     *tsoptions.ParsedCommandLine
+	// defaultOptions is set when the request did not specify compilerOptions. Builds then keep the
+	// esbuild settings the server has always used instead of deriving them from the config.
+	defaultOptions bool
+}
+
+// newCompileInput checks the shape of a request and parses its compiler options. Malformed requests
+// are reported as an error; invalid compiler options are not, and are instead available as
+// diagnostics from compileInput.optionsDiagnostics.
+func newCompileInput(req CompileRequest) (*compileInput, error) {
+	files := req.Files
+	entryPoint := req.EntryPoint
+	switch {
+	case req.Code != "" && len(files) != 0:
+		return nil, errors.New("only one of code and files may be specified")
+	case req.Code != "":
+		files = map[string]string{defaultFileName: req.Code}
+		if entryPoint == "" {
+			entryPoint = defaultFileName
+		}
+	case len(files) == 0:
+		return nil, errors.New("code or files is required")
+	}
+
+	// Layer the request files over the packages the request depends on and the global memFS. The
+	// versions of those packages in the global memFS are hidden so that their files do not mix with
+	// the uploaded ones. All of these are shared and must not be modified.
+	bases := []*memoryFS{globalMemFS}
+	if len(req.Dependencies) != 0 {
+		dependencies, err := globalPackageStore.loadDependencies(req.Dependencies)
+		if err != nil {
+			return nil, err
+		}
+		global := &memoryFS{bases: bases}
+		for name := range req.Dependencies {
+			global.hidden = append(global.hidden, "/node_modules/"+name+"/")
+		}
+		bases = append(dependencies, global)
+	}
+	memFS := &memoryFS{
+		files: make(map[string]string, len(files)),
+		bases: bases,
+	}
+
+	fileNames := make([]string, 0, len(files))
+	rootFileNames := make([]string, 0, len(files))
+	for name, content := range files {
+		if name == "" {
+			return nil, errors.New("file names must not be empty")
+		}
+		fileName := tspath.GetNormalizedAbsolutePath(name, "/")
+		memFS.files[fileName] = content
+		fileNames = append(fileNames, fileName)
+		if tspath.HasTSFileExtension(fileName) {
+			rootFileNames = append(rootFileNames, fileName)
+		}
+	}
+
+	switch {
+	case entryPoint != "":
+		entryPoint = tspath.GetNormalizedAbsolutePath(entryPoint, "/")
+		if !slices.Contains(fileNames, entryPoint) {
+			return nil, fmt.Errorf("entryPoint %q is not one of the request files", req.EntryPoint)
+		}
+	case len(fileNames) == 1:
+		entryPoint = fileNames[0]
+	default:
+		return nil, errors.New("entryPoint is required when more than one file is specified")
+	}
+	if !slices.Contains(rootFileNames, entryPoint) {
+		rootFileNames = append(rootFileNames, entryPoint)
+	}
+	slices.Sort(rootFileNames)
+
+	compilerOptions := req.CompilerOptions
+	if len(compilerOptions) == 0 {
+		compilerOptions = json.RawMessage(defaultCompilerOptions)
+	}
+	configText, err := json.Marshal(map[string]any{
+		"compilerOptions": compilerOptions,
+		"files":           rootFileNames,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid compilerOptions: %w", err)
+	}
+
+	extendedConfigCache := &collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]{}
+	host := &cachedCompilerHost{
+		CompilerHost: compiler.NewCachedFSCompilerHost("/", bundled.WrapFS(memFS), bundled.LibPath(), extendedConfigCache),
+		memFS:        memFS,
+	}
+
+	configJson, textErrors := tsoptions.ParseConfigFileTextToJson(configFileName, tspath.Path(configFileName), string(configText))
+	config := tsoptions.ParseJsonConfigFileContent(configJson, host, "/", nil, configFileName, nil, nil, extendedConfigCache)
+	config.Errors = append(textErrors, config.Errors...)
+
+	return &compileInput{
+		memFS:          memFS,
+		host:           host,
+		entryPoint:     entryPoint,
+		config:         config,
+		defaultOptions: len(req.CompilerOptions) == 0,
+	}, nil
+}
+
+// optionsDiagnostics returns the problems found while parsing the request's compiler options.
+func (input *compileInput) optionsDiagnostics() []*ast.Diagnostic {
+	return input.config.GetConfigFileParsingDiagnostics()
+}
+
+// applyCompilerOptions configures the parts of an esbuild build that the compiler options control:
+// the language target, the module format and the JSX transform.
+func applyCompilerOptions(options *core.CompilerOptions, buildOptions *api.BuildOptions) {
+	buildOptions.Target = esbuildTarget(options.GetEmitScriptTarget())
+
+	switch moduleKind := options.GetEmitModuleKind(); {
+	case moduleKind >= core.ModuleKindES2015 && moduleKind <= core.ModuleKindESNext, moduleKind == core.ModuleKindPreserve:
+		buildOptions.Format = api.FormatESModule
+	default:
+		buildOptions.Format = api.FormatCommonJS
+	}
+
+	switch options.Jsx {
+	case core.JsxEmitPreserve, core.JsxEmitReactNative:
+		buildOptions.JSX = api.JSXPreserve
+	case core.JsxEmitReact:
+		buildOptions.JSX = api.JSXTransform
+		buildOptions.JSXFactory = options.JsxFactory
+		buildOptions.JSXFragment = options.JsxFragmentFactory
+	case core.JsxEmitReactJSX, core.JsxEmitReactJSXDev:
+		buildOptions.JSX = api.JSXAutomatic
+		buildOptions.JSXImportSource = options.JsxImportSource
+		buildOptions.JSXDev = options.Jsx == core.JsxEmitReactJSXDev
+	}
+}
+
+func esbuildTarget(target core.ScriptTarget) api.Target {
+	switch target {
+	case core.ScriptTargetES5:
+		return api.ES5
+	case core.ScriptTargetES2015:
+		return api.ES2015
+	case core.ScriptTargetES2016:
+		return api.ES2016
+	case core.ScriptTargetES2017:
+		return api.ES2017
+	case core.ScriptTargetES2018:
+		return api.ES2018
+	case core.ScriptTargetES2019:
+		return api.ES2019
+	case core.ScriptTargetES2020:
+		return api.ES2020
+	case core.ScriptTargetES2021:
+		return api.ES2021
+	case core.ScriptTargetES2022:
+		return api.ES2022
+	case core.ScriptTargetES2023:
+		return api.ES2023
+	case core.ScriptTargetES2024:
+		return api.ES2024
+	default:
+		return api.ESNext
+	}
+}
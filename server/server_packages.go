@@ -0,0 +1,410 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/project"
+	"github.com/microsoft/typescript-go/internal/semver"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+const (
+	// defaultMaxPackageUploadSize limits the size of an uploaded tarball unless the
+	// PACKAGE_UPLOAD_MAX_SIZE environment variable is set.
+	defaultMaxPackageUploadSize = 64 << 20
+	// maxPackageContentSize limits the total size of the files kept from an uploaded tarball.
+	maxPackageContentSize = 256 << 20
+	// defaultPackageCacheSize is the number of package versions kept in memory unless the
+	// PACKAGE_CACHE_SIZE environment variable is set.
+	defaultPackageCacheSize = 64
+)
+
+var (
+	errPackageNotFound = errors.New("package not found")
+	errPackageExists   = errors.New("package version already exists with different contents")
+)
+
+// globalPackageStore holds the uploaded packages. Its directory is set with the PACKAGE_STORE
+// environment variable, the number of versions it keeps in memory with PACKAGE_CACHE_SIZE, the
+// token uploads must present with PACKAGE_UPLOAD_TOKEN and the largest tarball it accepts with
+// PACKAGE_UPLOAD_MAX_SIZE.
+var globalPackageStore *packageStore
+
+// packageStore is a content-addressed store of uploaded packages on disk. File contents are kept
+// once per distinct file in objects/<sha256>, and each package version has a manifest in
+// packages/<name>/<version>.json that maps its file paths to their hashes. Uploaded versions are
+// immutable.
+type packageStore struct {
+	dir string
+	// mu serializes manifest writes so concurrent uploads of one version cannot both succeed.
+	mu sync.Mutex
+	// loaded caches the file systems of the packages that requests have most recently depended on,
+	// keyed by name@version.
+	loaded *lruCache[string, *memoryFS]
+	// uploadToken is the bearer token uploads must be authorized with. Uploads are disabled when it
+	// is empty.
+	uploadToken string
+	// maxUploadSize is the largest tarball, in bytes, an upload may send.
+	maxUploadSize int64
+}
+
+// packageManifest lists the files of a package version, relative to the package root.
+type packageManifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// PackageUploadResponse describes a package stored by PUT /packages/{name}@{version}.
+type PackageUploadResponse struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Files   int    `json:"files"`
+}
+
+func newPackageStore(dir string, cacheSize int) (*packageStore, error) {
+	for _, sub := range []string{"objects", "packages"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &packageStore{
+		dir:           dir,
+		loaded:        newLRUCache[string, *memoryFS](cacheSize),
+		maxUploadSize: defaultMaxPackageUploadSize,
+	}, nil
+}
+
+func loadPackageStore() (*packageStore, error) {
+	dir := os.Getenv("PACKAGE_STORE")
+	if dir == "" {
+		dir = "packages"
+	}
+	cacheSize, err := loadPositiveInt("PACKAGE_CACHE_SIZE", defaultPackageCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	maxUploadSize, err := loadPositiveInt("PACKAGE_UPLOAD_MAX_SIZE", defaultMaxPackageUploadSize)
+	if err != nil {
+		return nil, err
+	}
+	store, err := newPackageStore(dir, cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	store.uploadToken = os.Getenv("PACKAGE_UPLOAD_TOKEN")
+	store.maxUploadSize = int64(maxUploadSize)
+	return store, nil
+}
+
+// authorizeUpload reports whether an upload request carries the store's upload token.
+func (s *packageStore) authorizeUpload(req *http.Request) bool {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return ok && s.uploadToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.uploadToken)) == 1
+}
+
+// isPackageFile reports whether a file of a package is needed to type check or bundle code that
+// imports it: its declaration files, JavaScript files and package.json files.
+func isPackageFile(fileName string) bool {
+	return tspath.IsDeclarationFileName(fileName) ||
+		tspath.HasJSFileExtension(fileName) ||
+		tspath.GetBaseFileName(fileName) == "package.json"
+}
+
+// validatePackage checks that name is a valid npm package name and that version is an exact
+// semver version.
+func validatePackage(name string, version string) error {
+	if result, _, _ := project.ValidatePackageName(name); result != project.NameOk {
+		return fmt.Errorf("invalid package name %q", name)
+	}
+	parsed, err := semver.TryParseVersion(version)
+	if err != nil || parsed.String() != version {
+		return fmt.Errorf("invalid version %q for package %s", version, name)
+	}
+	return nil
+}
+
+// parsePackageSpec splits name@version, where name may be scoped, as in @scope/name@1.0.0.
+func parsePackageSpec(spec string) (name string, version string, err error) {
+	index := strings.LastIndex(spec, "@")
+	if index <= 0 {
+		return "", "", fmt.Errorf("expected name@version, got %q", spec)
+	}
+	name, version = spec[:index], spec[index+1:]
+	if err := validatePackage(name, version); err != nil {
+		return "", "", err
+	}
+	return name, version, nil
+}
+
+// readPackageTarball reads the files of a gzipped npm package tarball. Entries are relative to the
+// package root, which npm places in a single top-level directory, usually named package.
+func readPackageTarball(r io.Reader) (map[string]string, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tarball: %w", err)
+	}
+	defer gzipReader.Close()
+
+	files := make(map[string]string)
+	var size int64
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tarball: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		_, name, found := strings.Cut(path.Clean(strings.TrimPrefix(header.Name, "./")), "/")
+		if !found || !fs.ValidPath(name) || !isPackageFile(name) {
+			continue
+		}
+
+		size += header.Size
+		if size > maxPackageContentSize {
+			return nil, errors.New("package contents are too large")
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tarball: %w", err)
+		}
+		files[name] = string(content)
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("tarball contains no declaration, JavaScript or package.json files")
+	}
+	return files, nil
+}
+
+// checkPackageJson verifies that the package.json of an upload, if it has one, is for the package
+// and version it is uploaded as.
+func checkPackageJson(files map[string]string, name string, version string) error {
+	content, ok := files["package.json"]
+	if !ok {
+		return nil
+	}
+	var packageJson struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(content), &packageJson); err != nil {
+		return fmt.Errorf("invalid package.json: %w", err)
+	}
+	if packageJson.Name != name || packageJson.Version != version {
+		return fmt.Errorf("package.json is for %s@%s, not %s@%s", packageJson.Name, packageJson.Version, name, version)
+	}
+	return nil
+}
+
+func (s *packageStore) manifestPath(name string, version string) string {
+	return filepath.Join(s.dir, "packages", filepath.FromSlash(name), version+".json")
+}
+
+func (s *packageStore) objectPath(hash string) string {
+	return filepath.Join(s.dir, "objects", hash)
+}
+
+// put stores the files of a package version. It reports whether the version is new; uploading
+// the same files again succeeds without changing anything, but different files are rejected with
+// errPackageExists.
+func (s *packageStore) put(name string, version string, files map[string]string) (bool, error) {
+	manifest := packageManifest{Files: make(map[string]string, len(files))}
+	for fileName, content := range files {
+		sum := sha256.Sum256([]byte(content))
+		hash := hex.EncodeToString(sum[:])
+		manifest.Files[fileName] = hash
+
+		objectPath := s.objectPath(hash)
+		if _, err := os.Stat(objectPath); err == nil {
+			continue
+		}
+		if err := writeFileAtomic(objectPath, []byte(content)); err != nil {
+			return false, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.readManifest(name, version)
+	switch {
+	case err == nil:
+		if maps.Equal(existing.Files, manifest.Files) {
+			return false, nil
+		}
+		return false, errPackageExists
+	case !errors.Is(err, errPackageNotFound):
+		return false, err
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return false, err
+	}
+	manifestPath := s.manifestPath(name, version)
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0o755); err != nil {
+		return false, err
+	}
+	return true, writeFileAtomic(manifestPath, data)
+}
+
+func (s *packageStore) readManifest(name string, version string) (*packageManifest, error) {
+	data, err := os.ReadFile(s.manifestPath(name, version))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errPackageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest packageManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("corrupt manifest for %s@%s: %w", name, version, err)
+	}
+	return &manifest, nil
+}
+
+// load returns a file system with the files of a package version in node_modules/<name>. It is
+// shared by every request that depends on that version and must not be modified.
+func (s *packageStore) load(name string, version string) (*memoryFS, error) {
+	key := name + "@" + version
+	if memFS, ok := s.loaded.Load(key); ok {
+		return memFS, nil
+	}
+
+	manifest, err := s.readManifest(name, version)
+	if err != nil {
+		return nil, err
+	}
+	memFS := &memoryFS{files: make(map[string]string, len(manifest.Files))}
+	root := "/node_modules/" + name + "/"
+	for fileName, hash := range manifest.Files {
+		content, err := os.ReadFile(s.objectPath(hash))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s from %s: %w", fileName, key, err)
+		}
+		memFS.files[root+fileName] = string(content)
+	}
+
+	memFS, _ = s.loaded.LoadOrStore(key, memFS)
+	return memFS, nil
+}
+
+// loadDependencies returns the file systems of the given package versions, ordered by package name.
+func (s *packageStore) loadDependencies(dependencies map[string]string) ([]*memoryFS, error) {
+	names := slices.Sorted(maps.Keys(dependencies))
+	bases := make([]*memoryFS, 0, len(names))
+	for _, name := range names {
+		version := dependencies[name]
+		if err := validatePackage(name, version); err != nil {
+			return nil, err
+		}
+		memFS, err := s.load(name, version)
+		if errors.Is(err, errPackageNotFound) {
+			return nil, fmt.Errorf("dependency %s@%s has not been uploaded", name, version)
+		}
+		if err != nil {
+			return nil, err
+		}
+		bases = append(bases, memFS)
+	}
+	return bases, nil
+}
+
+// writeFileAtomic writes a file through a temporary file in the same directory, so readers never
+// see it partially written.
+func writeFileAtomic(fileName string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(fileName), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), fileName)
+}
+
+func uploadPackage(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if globalPackageStore.uploadToken == "" {
+		http.Error(w, "Package uploads are disabled", http.StatusForbidden)
+		return
+	}
+	if !globalPackageStore.authorizeUpload(req) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	name, version, err := parsePackageSpec(strings.TrimPrefix(req.URL.Path, "/packages/"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	files, err := readPackageTarball(http.MaxBytesReader(w, req.Body, globalPackageStore.maxUploadSize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			http.Error(w, "Tarball is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkPackageJson(files, name, version); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	created, err := globalPackageStore.put(name, version, files)
+	if errors.Is(err, errPackageExists) {
+		http.Error(w, fmt.Sprintf("%s@%s was already uploaded with different contents", name, version), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to store %s@%s: %v", name, version, err)
+		http.Error(w, "Failed to store package", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(PackageUploadResponse{
+		Name:    name,
+		Version: version,
+		Files:   len(files),
+	})
+}
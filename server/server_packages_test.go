@@ -0,0 +1,205 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func makeTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: "package/" + name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// setupPackageStore replaces globalPackageStore with an empty store that accepts uploads with the
+// token "secret".
+func setupPackageStore(t *testing.T) {
+	t.Helper()
+	store, err := newPackageStore(t.TempDir(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.uploadToken = "secret"
+	previous := globalPackageStore
+	globalPackageStore = store
+	t.Cleanup(func() { globalPackageStore = previous })
+}
+
+func putPackage(t *testing.T, spec string, token string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPut, "/packages/"+spec, bytes.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	uploadPackage(recorder, req)
+	return recorder
+}
+
+func TestUploadPackage(t *testing.T) {
+	setupPackageStore(t)
+	tarball := makeTarball(t, map[string]string{
+		"package.json": `{"name": "@scope/lib", "version": "1.0.0"}`,
+		"index.d.ts":   "export declare const value: number;",
+		"README.md":    "not kept",
+	})
+
+	if status := putPackage(t, "@scope/lib@1.0.0", "", tarball).Code; status != http.StatusUnauthorized {
+		t.Errorf("upload without a token: got %d, expected %d", status, http.StatusUnauthorized)
+	}
+	if status := putPackage(t, "@scope/lib@1.0.0", "wrong", tarball).Code; status != http.StatusUnauthorized {
+		t.Errorf("upload with a wrong token: got %d, expected %d", status, http.StatusUnauthorized)
+	}
+
+	recorder := putPackage(t, "@scope/lib@1.0.0", "secret", tarball)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("upload: got %d %s, expected %d", recorder.Code, recorder.Body, http.StatusCreated)
+	}
+	if body := recorder.Body.String(); body != `{"name":"@scope/lib","version":"1.0.0","files":2}`+"\n" {
+		t.Errorf("unexpected response %s", body)
+	}
+	if status := putPackage(t, "@scope/lib@1.0.0", "secret", tarball).Code; status != http.StatusOK {
+		t.Errorf("repeated upload: got %d, expected %d", status, http.StatusOK)
+	}
+
+	changed := makeTarball(t, map[string]string{
+		"package.json": `{"name": "@scope/lib", "version": "1.0.0"}`,
+		"index.d.ts":   "export declare const value: string;",
+	})
+	if status := putPackage(t, "@scope/lib@1.0.0", "secret", changed).Code; status != http.StatusConflict {
+		t.Errorf("upload with different contents: got %d, expected %d", status, http.StatusConflict)
+	}
+	mismatched := makeTarball(t, map[string]string{"package.json": `{"name": "other", "version": "1.0.0"}`})
+	if status := putPackage(t, "@scope/lib@2.0.0", "secret", mismatched).Code; status != http.StatusBadRequest {
+		t.Errorf("upload with a mismatched package.json: got %d, expected %d", status, http.StatusBadRequest)
+	}
+	if status := putPackage(t, "lib@latest", "secret", tarball).Code; status != http.StatusBadRequest {
+		t.Errorf("upload with an inexact version: got %d, expected %d", status, http.StatusBadRequest)
+	}
+
+	globalPackageStore.maxUploadSize = 16
+	if status := putPackage(t, "@scope/lib@1.0.0", "secret", tarball).Code; status != http.StatusRequestEntityTooLarge {
+		t.Errorf("upload over the size limit: got %d, expected %d", status, http.StatusRequestEntityTooLarge)
+	}
+
+	globalPackageStore.uploadToken = ""
+	if status := putPackage(t, "@scope/lib@1.0.0", "", tarball).Code; status != http.StatusForbidden {
+		t.Errorf("upload without a configured token: got %d, expected %d", status, http.StatusForbidden)
+	}
+}
+
+func TestCompileInputDependencies(t *testing.T) {
+	setupPackageStore(t)
+	previous := globalMemFS
+	globalMemFS = &memoryFS{files: map[string]string{
+		"/node_modules/lib/package.json":    `{"name": "lib", "version": "0.1.0"}`,
+		"/node_modules/lib/old.d.ts":        "export {};",
+		"/node_modules/other/index.d.ts":    "export {};",
+		"/node_modules/@types/x/index.d.ts": "export {};",
+	}}
+	t.Cleanup(func() { globalMemFS = previous })
+
+	tarball := makeTarball(t, map[string]string{
+		"package.json": `{"name": "lib", "version": "1.0.0"}`,
+		"index.d.ts":   "export declare const value: number;",
+	})
+	if recorder := putPackage(t, "lib@1.0.0", "secret", tarball); recorder.Code != http.StatusCreated {
+		t.Fatalf("upload: got %d %s", recorder.Code, recorder.Body)
+	}
+
+	input, err := newCompileInput(CompileRequest{
+		Code:         `import { value } from "lib";`,
+		Dependencies: map[string]string{"lib": "1.0.0"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if content, _ := input.memFS.ReadFile("/node_modules/lib/package.json"); content != `{"name": "lib", "version": "1.0.0"}` {
+		t.Errorf("package.json of lib is %q, expected the uploaded version", content)
+	}
+	if input.memFS.FileExists("/node_modules/lib/old.d.ts") {
+		t.Error("a file of the loaded version of lib is visible")
+	}
+	if !input.memFS.FileExists("/node_modules/other/index.d.ts") || !input.memFS.DirectoryExists("/node_modules/@types/x") {
+		t.Error("the loaded packages that are not dependencies are not visible")
+	}
+	entries := input.memFS.GetAccessibleEntries("/node_modules/lib")
+	if len(entries.Files) != 2 || len(entries.Directories) != 0 {
+		t.Errorf("unexpected entries of lib %+v", entries)
+	}
+
+	if _, err := newCompileInput(CompileRequest{Code: "", Files: map[string]string{"a.ts": ""}, Dependencies: map[string]string{"lib": "2.0.0"}}); err == nil {
+		t.Error("expected an error for a dependency that was not uploaded")
+	}
+}
+
+func TestPackageStoreLoad(t *testing.T) {
+	dir := t.TempDir()
+	store, err := newPackageStore(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := "export const version = 1;"
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := store.put(name, "1.0.0", map[string]string{"index.d.ts": index}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Files with the same content are stored once
+	if objects, err := os.ReadDir(filepath.Join(dir, "objects")); err != nil || len(objects) != 1 {
+		t.Errorf("expected one object, got %v, %v", objects, err)
+	}
+
+	a, err := store.load("a", "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.files["/node_modules/a/index.d.ts"] != index {
+		t.Errorf("unexpected files %v", a.files)
+	}
+	if again, _ := store.load("a", "1.0.0"); again != a {
+		t.Error("a was loaded again while it was cached")
+	}
+	store.load("b", "1.0.0")
+	store.load("c", "1.0.0")
+	if again, _ := store.load("a", "1.0.0"); again == a || again.files["/node_modules/a/index.d.ts"] != index {
+		t.Error("a was not evicted and loaded again")
+	}
+
+	// Packages outlive the store
+	store, err = newPackageStore(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.load("c", "1.0.0"); err != nil {
+		t.Error(err)
+	}
+	if _, err := store.load("d", "1.0.0"); !errors.Is(err, errPackageNotFound) {
+		t.Errorf("got %v, expected errPackageNotFound", err)
+	}
+}
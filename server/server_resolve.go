@@ -0,0 +1,45 @@
+package main
+
+import (
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/module"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// newBundleResolver creates the module resolver used to bundle a request. It resolves specifiers
+// with the same algorithm, conditions and path mappings as the type checker, but to implementation
+// files instead of declaration files.
+func newBundleResolver(input *compileInput) *module.Resolver {
+	options := input.config.CompilerOptions().Clone()
+	options.NoDtsResolution = core.TSTrue
+	return module.NewResolver(input.host, options, "", "")
+}
+
+// resolutionModeForKind returns the resolution mode of an import, which decides whether the
+// "import" or "require" condition is used for package.json exports and imports.
+func resolutionModeForKind(kind api.ResolveKind) core.ResolutionMode {
+	switch kind {
+	case api.ResolveJSRequireCall, api.ResolveJSRequireResolve:
+		return core.ModuleKindCommonJS
+	default:
+		return core.ModuleKindESNext
+	}
+}
+
+// loaderForFile returns the esbuild loader for a file in the virtual file system. Files loaded by
+// a plugin do not get a loader from their extension, so it has to be set explicitly.
+func loaderForFile(fileName string) api.Loader {
+	switch {
+	case tspath.FileExtensionIs(fileName, tspath.ExtensionTsx):
+		return api.LoaderTSX
+	case tspath.FileExtensionIsOneOf(fileName, []string{tspath.ExtensionTs, tspath.ExtensionMts, tspath.ExtensionCts}):
+		return api.LoaderTS
+	case tspath.FileExtensionIs(fileName, tspath.ExtensionJsx):
+		return api.LoaderJSX
+	case tspath.FileExtensionIs(fileName, tspath.ExtensionJson):
+		return api.LoaderJSON
+	default:
+		return api.LoaderJS
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildResolvesLikeTheChecker(t *testing.T) {
+	setupMemFS(t, map[string]string{
+		"/node_modules/pkg/package.json": `{
+			"name": "pkg",
+			"exports": {
+				".": {
+					"types": "./index.d.ts",
+					"import": "./esm.js",
+					"require": "./cjs.js"
+				}
+			}
+		}`,
+		"/node_modules/pkg/index.d.ts": "export declare const where: string;",
+		"/node_modules/pkg/esm.js":     `export const where = "pkg esm";`,
+		"/node_modules/pkg/cjs.js":     `exports.where = "pkg cjs";`,
+	})
+	options := json.RawMessage(`{
+		"module": "esnext",
+		"moduleResolution": "bundler",
+		"target": "es2020",
+		"paths": { "@lib/*": ["./src/lib/*"] }
+	}`)
+
+	request := CompileRequest{
+		Files: map[string]string{
+			"src/index.ts":     `import { where } from "pkg"; import { local } from "@lib/local"; console.log(where, local);`,
+			"src/lib/local.ts": `export const local = "mapped local";`,
+		},
+		EntryPoint:      "src/index.ts",
+		CompilerOptions: options,
+	}
+	if response := decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", request)); !response.Pass {
+		t.Fatalf("expected the request to type check, got %+v", response)
+	}
+	response := decode[BuildResponse](t, post(t, build, "/build", request))
+	if len(response.Errors) != 0 {
+		t.Fatalf("unexpected errors %+v", response.Errors)
+	}
+	for _, expected := range []string{"pkg esm", "mapped local"} {
+		if !strings.Contains(response.Code, expected) {
+			t.Errorf("expected the bundle to contain %q, got %s", expected, response.Code)
+		}
+	}
+	if strings.Contains(response.Code, "pkg cjs") {
+		t.Errorf("expected the import condition to be used, got %s", response.Code)
+	}
+
+	request.Files["src/index.ts"] = `const { where } = require("pkg"); console.log(where);`
+	response = decode[BuildResponse](t, post(t, build, "/build", request))
+	if len(response.Errors) != 0 || !strings.Contains(response.Code, "pkg cjs") {
+		t.Errorf("expected require to use the require condition, got %+v", response)
+	}
+
+	request.Files["src/index.ts"] = `import { missing } from "./missing"; console.log(missing);`
+	response = decode[BuildResponse](t, post(t, build, "/build", request))
+	if len(response.Errors) != 1 || !strings.Contains(response.Errors[0].Message, `could not resolve "./missing"`) {
+		t.Errorf("expected an error for the missing import, got %+v", response)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// BuildEvent is one event of a streamed /build response. Diagnostics are sent as they are found,
+// and the stream ends with either an output or an error event.
+type BuildEvent struct {
+	// Type is "diagnostic", "output" or "error".
+	Type       string           `json:"type"`
+	Diagnostic *DiagnosticError `json:"diagnostic,omitempty"`
+	Code       string           `json:"code,omitempty"`
+	Message    string           `json:"message,omitempty"`
+}
+
+const (
+	streamFormatNDJSON = "ndjson"
+	streamFormatSSE    = "sse"
+)
+
+// eventWriter writes build events as newline-delimited JSON or as Server-Sent Events, flushing
+// after each one so clients see them immediately.
+type eventWriter struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+	sse        bool
+}
+
+func newEventWriter(w http.ResponseWriter, format string) *eventWriter {
+	if format == streamFormatSSE {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	return &eventWriter{
+		w:          w,
+		controller: http.NewResponseController(w),
+		sse:        format == streamFormatSSE,
+	}
+}
+
+func (e *eventWriter) write(event BuildEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if e.sse {
+		fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event.Type, data)
+	} else {
+		e.w.Write(append(data, '\n'))
+	}
+	// Write errors mean the client disconnected, which cancels the request context
+	e.controller.Flush()
+}
+
+func (e *eventWriter) writeDiagnostic(diagnostic DiagnosticError) {
+	e.write(BuildEvent{Type: "diagnostic", Diagnostic: &diagnostic})
+}
+
+func (e *eventWriter) writeError(message string) {
+	e.write(BuildEvent{Type: "error", Message: message})
+}
+
+// streamBuild runs a build, optionally preceded by type checking, and streams its progress.
+func streamBuild(ctx context.Context, events *eventWriter, input *compileInput, validateTypes bool) {
+	if validateTypes {
+		typecheckResponse, err := typecheckTypeScript(ctx, input, events.writeDiagnostic)
+		if err != nil {
+			events.writeError(contextErrorMessage(err))
+			return
+		}
+		if len(typecheckResponse.Errors) > 0 {
+			events.writeError("Type checking failed")
+			return
+		}
+	}
+
+	response, err := buildTypeScript(ctx, input)
+	if err != nil {
+		events.writeError(contextErrorMessage(err))
+		return
+	}
+	for _, diagnostic := range response.Errors {
+		events.writeDiagnostic(diagnostic)
+	}
+	if len(response.Errors) > 0 {
+		events.writeError("Build failed")
+		return
+	}
+	events.write(BuildEvent{Type: "output", Code: response.Code})
+}
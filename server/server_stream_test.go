@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// readEvents decodes the events of an ndjson build stream.
+func readEvents(t *testing.T, body string) []BuildEvent {
+	t.Helper()
+	var events []BuildEvent
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		var event BuildEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid event %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestStreamBuild(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+
+	recorder := post(t, build, "/build?stream=ndjson&validate_types=true", CompileRequest{
+		Files: map[string]string{
+			"a.ts": `import "./b"; const s: string = 1;`,
+			"b.ts": `const n: number = "one";`,
+		},
+		EntryPoint: "a.ts",
+	})
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/x-ndjson" {
+		t.Errorf("Content-Type is %q", contentType)
+	}
+	events := readEvents(t, recorder.Body.String())
+	if len(events) != 3 {
+		t.Fatalf("expected two diagnostics and an error, got %+v", events)
+	}
+	var files []string
+	for _, event := range events[:2] {
+		if event.Type != "diagnostic" || event.Diagnostic == nil || event.Diagnostic.Code != 2322 {
+			t.Errorf("unexpected event %+v", event)
+			continue
+		}
+		files = append(files, event.Diagnostic.File)
+	}
+	slices.Sort(files)
+	if strings.Join(files, ",") != "/a.ts,/b.ts" {
+		t.Errorf("diagnostics were reported for %v", files)
+	}
+	if events[2].Type != "error" || events[2].Message != "Type checking failed" {
+		t.Errorf("unexpected last event %+v", events[2])
+	}
+
+	events = readEvents(t, post(t, build, "/build?stream=ndjson&validate_types=true", CompileRequest{
+		Code: `export const answer: number = 42;`,
+	}).Body.String())
+	if len(events) != 1 || events[0].Type != "output" || !strings.Contains(events[0].Code, "42") {
+		t.Errorf("expected only the output, got %+v", events)
+	}
+
+	events = readEvents(t, post(t, build, "/build?stream=ndjson", CompileRequest{
+		Code: `import "./missing";`,
+	}).Body.String())
+	if len(events) != 2 || events[0].Type != "diagnostic" || events[1].Type != "error" || events[1].Message != "Build failed" {
+		t.Errorf("expected the build error and an error event, got %+v", events)
+	}
+}
+
+func TestStreamBuildSSE(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+
+	recorder := post(t, build, "/build?stream=sse", CompileRequest{Code: `export const answer = 42;`})
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("Content-Type is %q", contentType)
+	}
+	event, data, ok := strings.Cut(strings.TrimSuffix(recorder.Body.String(), "\n\n"), "\n")
+	if !ok || event != "event: output" || !strings.HasPrefix(data, `data: {"type":"output","code":`) {
+		t.Errorf("unexpected stream %q", recorder.Body)
+	}
+
+	recorder = post(t, build, "/build?stream=xml", CompileRequest{Code: `export const answer = 42;`})
+	checkBadRequest(t, "stream=xml", recorder, `Unsupported stream format "xml"`)
+}
+
+func TestStreamBuildTimeout(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+
+	events := readEvents(t, post(t, build, "/build?stream=ndjson&validate_types=true&timeout=1ns", CompileRequest{
+		Code: `export const answer = 42;`,
+	}).Body.String())
+	if len(events) != 1 || events[0].Type != "error" || events[0].Message != "Request timed out" {
+		t.Errorf("expected a timeout error, got %+v", events)
+	}
+
+	recorder := post(t, build, "/build?stream=ndjson&timeout=soon", CompileRequest{Code: `export const answer = 42;`})
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("invalid timeout: got %d, expected %d", recorder.Code, http.StatusBadRequest)
+	}
+}
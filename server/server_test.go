@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// setupMemFS replaces globalMemFS, which normally holds the node_modules loaded at startup, with
+// the given files.
+func setupMemFS(t *testing.T, files map[string]string) {
+	t.Helper()
+	previous := globalMemFS
+	globalMemFS = &memoryFS{files: files}
+	t.Cleanup(func() { globalMemFS = previous })
+}
+
+// post sends a JSON request body to a handler and returns the recorded response.
+func post(t *testing.T, handler http.HandlerFunc, target string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, target, bytes.NewReader(data)))
+	return recorder
+}
+
+// decode decodes the JSON body of a successful response.
+func decode[T any](t *testing.T, recorder *httptest.ResponseRecorder) T {
+	t.Helper()
+	var result T
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("got %d %s", recorder.Code, recorder.Body)
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid response %s: %v", recorder.Body, err)
+	}
+	return result
+}
+
+func TestTypecheck(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+
+	response := decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", CompileRequest{
+		Code: "const x: number = 1;",
+	}))
+	if !response.Pass || len(response.Errors) != 0 {
+		t.Errorf("expected code to pass, got %+v", response)
+	}
+
+	response = decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", CompileRequest{
+		Files: map[string]string{
+			"src/index.ts": `import { greet } from "./greet"; const n: number = greet();`,
+			"src/greet.ts": `export const greet = () => "hello";`,
+		},
+		EntryPoint: "src/index.ts",
+	}))
+	if response.Pass || len(response.Errors) != 1 {
+		t.Fatalf("expected one error, got %+v", response)
+	}
+	if err := response.Errors[0]; err.File != "/src/index.ts" || err.Code != 2322 || err.Line != 1 || err.Column != 40 {
+		t.Errorf("unexpected error %+v", err)
+	}
+}
+
+func TestTypecheckCompilerOptions(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+	code := "function f(x) { return x; }"
+
+	// The default options are strict
+	response := decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", CompileRequest{Code: code}))
+	if response.Pass || len(response.Errors) != 1 || response.Errors[0].Code != 7006 {
+		t.Errorf("expected an implicit any error with the default options, got %+v", response)
+	}
+
+	response = decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", CompileRequest{
+		Code:            code,
+		CompilerOptions: json.RawMessage(`{"strict": false, "lib": ["es2022"]}`),
+	}))
+	if !response.Pass {
+		t.Errorf("expected code to pass without strict, got %+v", response)
+	}
+
+	response = decode[TypecheckResponse](t, post(t, typecheck, "/typecheck", CompileRequest{
+		Code:            code,
+		CompilerOptions: json.RawMessage(`{"notAnOption": true}`),
+	}))
+	if response.Pass || len(response.Errors) != 1 || !strings.Contains(response.Errors[0].Message, "notAnOption") {
+		t.Errorf("expected an error for the unknown option, got %+v", response)
+	}
+}
+
+func TestCompileRequestErrors(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+
+	tests := []struct {
+		name    string
+		body    any
+		message string
+	}{
+		{"invalid JSON", "{", "Invalid JSON"},
+		{"no code or files", CompileRequest{}, "code or files is required"},
+		{"code and files", CompileRequest{Code: "1", Files: map[string]string{"a.ts": "1"}}, "only one of code and files"},
+		{"missing entry point", CompileRequest{Files: map[string]string{"a.ts": "1", "b.ts": "2"}}, "entryPoint is required"},
+		{"unknown entry point", CompileRequest{Files: map[string]string{"a.ts": "1"}, EntryPoint: "b.ts"}, "is not one of the request files"},
+	}
+	for _, test := range tests {
+		for _, handler := range []struct {
+			target string
+			fn     http.HandlerFunc
+		}{{"/typecheck", typecheck}, {"/build", build}} {
+			body := test.body
+			if text, ok := body.(string); ok {
+				recorder := httptest.NewRecorder()
+				handler.fn(recorder, httptest.NewRequest(http.MethodPost, handler.target, strings.NewReader(text)))
+				checkBadRequest(t, handler.target+" "+test.name, recorder, test.message)
+				continue
+			}
+			checkBadRequest(t, handler.target+" "+test.name, post(t, handler.fn, handler.target, body), test.message)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	typecheck(recorder, httptest.NewRequest(http.MethodGet, "/typecheck", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /typecheck: got %d, expected %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func checkBadRequest(t *testing.T, name string, recorder *httptest.ResponseRecorder, message string) {
+	t.Helper()
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), message) {
+		t.Errorf("%s: got %d %q, expected %d with %q", name, recorder.Code, recorder.Body, http.StatusBadRequest, message)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	setupMemFS(t, map[string]string{})
+
+	response := decode[BuildResponse](t, post(t, build, "/build", CompileRequest{
+		Files: map[string]string{
+			"src/index.ts": `import { greet } from "./greet"; console.log(greet());`,
+			"src/greet.ts": `export const greet = (): string => "hello from greet";`,
+		},
+		EntryPoint: "src/index.ts",
+	}))
+	if len(response.Errors) != 0 || !strings.Contains(response.Code, "hello from greet") {
+		t.Errorf("expected greet.ts to be bundled, got %+v", response)
+	}
+
+	response = decode[BuildResponse](t, post(t, build, "/build", CompileRequest{
+		Code:            "declare const h: any; export const element = <div />;",
+		CompilerOptions: json.RawMessage(`{"jsx": "react", "jsxFactory": "h", "module": "esnext", "target": "es2020"}`),
+	}))
+	if len(response.Errors) != 0 || !strings.Contains(response.Code, `h("div"`) || !strings.Contains(response.Code, "export") {
+		t.Errorf("expected an ES module using the JSX factory, got %+v", response)
+	}
+
+	response = decode[BuildResponse](t, post(t, build, "/build?validate_types=true", CompileRequest{
+		Code: `const n: number = "one";`,
+	}))
+	if len(response.Errors) != 1 || response.Errors[0].Code != 2322 || response.Code != "" {
+		t.Errorf("expected the type error instead of output, got %+v", response)
+	}
+}
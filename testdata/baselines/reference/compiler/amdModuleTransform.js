@@ -0,0 +1,73 @@
+//// [tests/cases/compiler/amdModuleTransform.ts] ////
+
+//// [dep.ts]
+export function helper(n: number) { return n * 10; }
+export default "dep";
+
+//// [main.ts]
+import name, { helper } from "./dep";
+import * as ns from "./dep";
+export const value = helper(2);
+export async function later() {
+    const m = await import("./dep");
+    return m.helper(5) + ns.helper(1) + name;
+}
+export { helper as reexported } from "./dep";
+
+
+//// [dep.js]
+define(["require", "exports"], function (require, exports) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    exports.helper = helper;
+    function helper(n) { return n * 10; }
+    exports.default = "dep";
+});
+//// [main.js]
+var __createBinding = (this && this.__createBinding) || (Object.create ? (function(o, m, k, k2) {
+    if (k2 === undefined) k2 = k;
+    var desc = Object.getOwnPropertyDescriptor(m, k);
+    if (!desc || ("get" in desc ? !m.__esModule : desc.writable || desc.configurable)) {
+      desc = { enumerable: true, get: function() { return m[k]; } };
+    }
+    Object.defineProperty(o, k2, desc);
+}) : (function(o, m, k, k2) {
+    if (k2 === undefined) k2 = k;
+    o[k2] = m[k];
+}));
+var __setModuleDefault = (this && this.__setModuleDefault) || (Object.create ? (function(o, v) {
+    Object.defineProperty(o, "default", { enumerable: true, value: v });
+}) : function(o, v) {
+    o["default"] = v;
+});
+var __importStar = (this && this.__importStar) || (function () {
+    var ownKeys = function(o) {
+        ownKeys = Object.getOwnPropertyNames || function (o) {
+            var ar = [];
+            for (var k in o) if (Object.prototype.hasOwnProperty.call(o, k)) ar[ar.length] = k;
+            return ar;
+        };
+        return ownKeys(o);
+    };
+    return function (mod) {
+        if (mod && mod.__esModule) return mod;
+        var result = {};
+        if (mod != null) for (var k = ownKeys(mod), i = 0; i < k.length; i++) if (k[i] !== "default") __createBinding(result, mod, k[i]);
+        __setModuleDefault(result, mod);
+        return result;
+    };
+})();
+define(["require", "exports", "./dep", "./dep", "./dep"], function (require, exports, dep_1, ns, dep_2) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    exports.reexported = exports.value = void 0;
+    exports.later = later;
+    dep_1 = __importStar(dep_1);
+    ns = __importStar(ns);
+    exports.value = (0, dep_1.helper)(2);
+    async function later() {
+        const m = await new Promise((resolve_1, reject_1) => { require(["./dep"], resolve_1, reject_1); }).then(__importStar);
+        return m.helper(5) + ns.helper(1) + dep_1.default;
+    }
+    Object.defineProperty(exports, "reexported", { enumerable: true, get: function () { return dep_2.helper; } });
+});
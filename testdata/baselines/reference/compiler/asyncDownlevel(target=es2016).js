@@ -0,0 +1,135 @@
+//// [tests/cases/compiler/asyncDownlevel.ts] ////
+
+//// [asyncDownlevel.ts]
+declare function f(): Promise<number>;
+
+const arrow = async () => await f();
+const arrowWithThis = {
+    value: 1,
+    m() {
+        return async () => this.value + await f();
+    }
+};
+
+class Base {
+    m() { return 1; }
+    static s() { return 2; }
+}
+
+class Derived extends Base {
+    async a() {
+        const x = await f();
+        return super.m() + x;
+    }
+    async b(name: "m") {
+        return super[name]() + super["m"]();
+    }
+    static async t() {
+        return super.s() + await f();
+    }
+}
+
+async function usesArguments() {
+    await f();
+    return arguments.length;
+}
+
+function outer() {
+    return async () => arguments[0];
+}
+
+async function awaitInDefault(x = await f()) {
+    return x;
+}
+
+async function nameCollision(x: number, _arguments: number) {
+    var x = await f();
+    var y = arguments[0] + _arguments;
+    for (var i = 0; i < x; i++) {
+        var z = await f();
+    }
+    return y + z;
+}
+
+
+//// [asyncDownlevel.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+const arrow = () => __awaiter(void 0, void 0, void 0, function* () {
+    return yield f();
+});
+const arrowWithThis = {
+    value: 1,
+    m() {
+        return () => __awaiter(this, void 0, void 0, function* () {
+            return this.value + (yield f());
+        });
+    }
+};
+class Base {
+    m() { return 1; }
+    static s() { return 2; }
+}
+class Derived extends Base {
+    a() {
+        const _super = Object.create(null, {
+            m: { get: () => super.m }
+        });
+        return __awaiter(this, void 0, void 0, function* () {
+            const x = yield f();
+            return _super.m.call(this) + x;
+        });
+    }
+    b(name) {
+        const _superIndex = name => super[name];
+        return __awaiter(this, void 0, void 0, function* () {
+            return _superIndex(name).call(this) + _superIndex("m").call(this);
+        });
+    }
+    static t() {
+        const _super = Object.create(null, {
+            s: { get: () => super.s }
+        });
+        return __awaiter(this, void 0, void 0, function* () {
+            return _super.s.call(this) + (yield f());
+        });
+    }
+}
+function usesArguments() {
+    const arguments_1 = arguments;
+    return __awaiter(this, void 0, void 0, function* () {
+        yield f();
+        return arguments_1.length;
+    });
+}
+function outer() {
+    return () => {
+        const arguments_2 = arguments;
+        return __awaiter(this, void 0, void 0, function* () {
+            return arguments_2[0];
+        });
+    };
+}
+function awaitInDefault() {
+    return __awaiter(this, arguments, void 0, function* (x = yield f()) {
+        return x;
+    });
+}
+function nameCollision(x, _arguments) {
+    const arguments_3 = arguments;
+    return __awaiter(this, void 0, void 0, function* () {
+        x = (yield f());
+        var y = arguments_3[0] + _arguments;
+        for (var i = 0; i < x; i++) {
+            var z = yield f();
+        }
+        return y + z;
+    });
+}
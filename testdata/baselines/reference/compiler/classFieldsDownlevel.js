@@ -0,0 +1,99 @@
+//// [tests/cases/compiler/classFieldsDownlevel.ts] ////
+
+//// [classFieldsDownlevel.ts]
+class Base {
+    constructor(public p: number) {}
+}
+
+class C extends Base {
+    #count = 0;
+    label = "c";
+    static instances = 0;
+    static #registry = new Set<C>();
+
+    static {
+        this.instances++;
+        C.#registry.clear();
+    }
+
+    constructor(p: number) {
+        super(p);
+        this.#count += p;
+        C.#registry.add(this);
+    }
+
+    #increment() {
+        return this.#count++;
+    }
+
+    get #value() {
+        return this.#count;
+    }
+
+    read(other: object) {
+        return #count in other ? this.#increment() + this.#value : 0;
+    }
+}
+
+const D = class {
+    static self = this;
+    #tag = "d";
+};
+
+
+//// [classFieldsDownlevel.js]
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};
+var __classPrivateFieldIn = (this && this.__classPrivateFieldIn) || function(state, receiver) {
+    if (receiver === null || (typeof receiver !== "object" && typeof receiver !== "function")) throw new TypeError("Cannot use 'in' operator on non-object");
+    return typeof state === "function" ? receiver === state : state.has(receiver);
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var _C_count, _C_registry, _C_increment, _C_instances, _C_value_get, _a, _tag;
+class Base {
+    constructor(p) {
+        this.p = p;
+    }
+}
+class C extends Base {
+    constructor(p) {
+        super(p);
+        _C_instances.add(this);
+        _C_count.set(this, 0);
+        this.label = "c";
+        __classPrivateFieldSet(this, _C_count, __classPrivateFieldGet(this, _C_count, "f") + p, "f");
+        __classPrivateFieldGet(C, C, "f", _C_registry).add(this);
+    }
+    read(other) {
+        return __classPrivateFieldIn(_C_count, other) ? __classPrivateFieldGet(this, _C_instances, "m", _C_increment).call(this) + __classPrivateFieldGet(this, _C_instances, "a", _C_value_get) : 0;
+    }
+}
+_C_instances = new WeakSet(), _C_count = new WeakMap(), _C_increment = function _C_increment() {
+    var _b, _c;
+    return (__classPrivateFieldSet(this, _C_count, (_b = __classPrivateFieldGet(this, _C_count, "f"), _c = _b++, _b), "f"), _c);
+}, _C_value_get = function _C_value_get() {
+    return __classPrivateFieldGet(this, _C_count, "f");
+};
+C.instances = 0;
+_C_registry = { value: new Set() };
+(() => {
+    C.instances++;
+    __classPrivateFieldGet(C, C, "f", _C_registry).clear();
+})();
+const D = (_a = class {
+    constructor() {
+        _tag.set(this, "d");
+    }
+}, _tag = new WeakMap(), __setFunctionName(_a, "D"), _a.self = _a, _a);
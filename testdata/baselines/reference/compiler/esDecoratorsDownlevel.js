@@ -0,0 +1,222 @@
+//// [tests/cases/compiler/esDecoratorsDownlevel.ts] ////
+
+//// [esDecoratorsDownlevel.ts]
+function logged<This, Value>(value: Value, context: DecoratorContext): Value | void {
+    context.addInitializer(function (this: unknown) {
+        console.log(`initialized ${String(context.name)}`);
+    });
+}
+
+function double(value: undefined, context: ClassFieldDecoratorContext) {
+    return (initial: number) => initial * 2;
+}
+
+function tracked<This, Value>(target: ClassAccessorDecoratorTarget<This, Value>, context: ClassAccessorDecoratorContext<This, Value>): ClassAccessorDecoratorResult<This, Value> {
+    return {
+        get(this: This) {
+            return target.get.call(this);
+        },
+        set(this: This, value: Value) {
+            target.set.call(this, value);
+        },
+    };
+}
+
+const decorators = { logged };
+const key = "computed";
+
+@logged
+export class C {
+    @logged method() {}
+    @double field = 1;
+    @tracked accessor count = 0;
+    @logged static staticMethod() {}
+    @decorators.logged static staticField = 2;
+    @logged #privateMethod() {}
+    @logged get #privateGetter() { return 1; }
+    @tracked static accessor #privateAccessor = 3;
+    @logged [key]() {}
+}
+
+export default class D extends C {
+    @double other = 3;
+
+    constructor() {
+        super();
+        console.log(this.other);
+    }
+}
+
+const E = @logged class {};
+
+
+//// [esDecoratorsDownlevel.js]
+var __runInitializers = (this && this.__runInitializers) || function (thisArg, initializers, value) {
+    var useValue = arguments.length > 2;
+    for (var i = 0; i < initializers.length; i++) {
+        value = useValue ? initializers[i].call(thisArg, value) : initializers[i].call(thisArg);
+    }
+    return useValue ? value : void 0;
+};
+var __esDecorate = (this && this.__esDecorate) || function (ctor, descriptorIn, decorators, contextIn, initializers, extraInitializers) {
+    function accept(f) { if (f !== void 0 && typeof f !== "function") throw new TypeError("Function expected"); return f; }
+    var kind = contextIn.kind, key = kind === "getter" ? "get" : kind === "setter" ? "set" : "value";
+    var target = !descriptorIn && ctor ? contextIn["static"] ? ctor : ctor.prototype : null;
+    var descriptor = descriptorIn || (target ? Object.getOwnPropertyDescriptor(target, contextIn.name) : {});
+    var _, done = false;
+    for (var i = decorators.length - 1; i >= 0; i--) {
+        var context = {};
+        for (var p in contextIn) context[p] = p === "access" ? {} : contextIn[p];
+        for (var p in contextIn.access) context.access[p] = contextIn.access[p];
+        context.addInitializer = function (f) { if (done) throw new TypeError("Cannot add initializers after decoration has completed"); extraInitializers.push(accept(f || null)); };
+        var result = (0, decorators[i])(kind === "accessor" ? { get: descriptor.get, set: descriptor.set } : descriptor[key], context);
+        if (kind === "accessor") {
+            if (result === void 0) continue;
+            if (result === null || typeof result !== "object") throw new TypeError("Object expected");
+            if (_ = accept(result.get)) descriptor.get = _;
+            if (_ = accept(result.set)) descriptor.set = _;
+            if (_ = accept(result.init)) initializers.unshift(_);
+        }
+        else if (_ = accept(result)) {
+            if (kind === "field") initializers.unshift(_);
+            else descriptor[key] = _;
+        }
+    }
+    if (target) Object.defineProperty(target, contextIn.name, descriptor);
+    done = true;
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var __propKey = (this && this.__propKey) || function (x) {
+    return typeof x === "symbol" ? x : "".concat(x);
+};
+function logged(value, context) {
+    context.addInitializer(function () {
+        console.log(`initialized ${String(context.name)}`);
+    });
+}
+function double(value, context) {
+    return (initial) => initial * 2;
+}
+function tracked(target, context) {
+    return {
+        get() {
+            return target.get.call(this);
+        },
+        set(value) {
+            target.set.call(this, value);
+        },
+    };
+}
+const decorators = { logged };
+const key = "computed";
+let C = (() => {
+    var _a, _b;
+    let _classDecorators = [logged];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    let _staticExtraInitializers = [];
+    let _instanceExtraInitializers = [];
+    let _static_staticMethod_decorators;
+    let _static_staticField_decorators;
+    let _static_staticField_initializers = [];
+    let _static_staticField_extraInitializers = [];
+    let _static_private_privateAccessor_decorators;
+    let _static_private_privateAccessor_initializers = [];
+    let _static_private_privateAccessor_extraInitializers = [];
+    let _static_private_privateAccessor_descriptor;
+    let _method_decorators;
+    let _field_decorators;
+    let _field_initializers = [];
+    let _field_extraInitializers = [];
+    let _count_decorators;
+    let _count_initializers = [];
+    let _count_extraInitializers = [];
+    let _private_privateMethod_decorators;
+    let _private_privateMethod_descriptor;
+    let _private_get_privateGetter_decorators;
+    let _private_get_privateGetter_descriptor;
+    let _member_decorators;
+    var C = class {
+        static { _classThis = this; }
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(this, null, _static_staticMethod_decorators, { kind: "method", name: "staticMethod", static: true, private: false, access: { has: obj => "staticMethod" in obj, get: obj => obj.staticMethod }, metadata: _metadata }, null, _staticExtraInitializers);
+            __esDecorate(this, _static_private_privateAccessor_descriptor = { get() { return this.#privateAccessor_accessor_storage; }, set(value) { this.#privateAccessor_accessor_storage = value; } }, _static_private_privateAccessor_decorators, { kind: "accessor", name: "#privateAccessor", static: true, private: true, access: { has: obj => #privateAccessor in obj, get: obj => obj.#privateAccessor, set: (obj, value) => { obj.#privateAccessor = value; } }, metadata: _metadata }, _static_private_privateAccessor_initializers, _static_private_privateAccessor_extraInitializers);
+            __esDecorate(this, null, _method_decorators, { kind: "method", name: "method", static: false, private: false, access: { has: obj => "method" in obj, get: obj => obj.method }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(this, null, _count_decorators, { kind: "accessor", name: "count", static: false, private: false, access: { has: obj => "count" in obj, get: obj => obj.count, set: (obj, value) => { obj.count = value; } }, metadata: _metadata }, _count_initializers, _count_extraInitializers);
+            __esDecorate(this, _private_privateMethod_descriptor = { value: __setFunctionName(function () { }, "#privateMethod") }, _private_privateMethod_decorators, { kind: "method", name: "#privateMethod", static: false, private: true, access: { has: obj => #privateMethod in obj, get: obj => obj.#privateMethod }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(this, _private_get_privateGetter_descriptor = { get: __setFunctionName(function () { return 1; }, "#privateGetter", "get") }, _private_get_privateGetter_decorators, { kind: "getter", name: "#privateGetter", static: false, private: true, access: { has: obj => #privateGetter in obj, get: obj => obj.#privateGetter }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(this, null, _member_decorators, { kind: "method", name: _b, static: false, private: false, access: { has: obj => _b in obj, get: obj => obj[_b] }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(null, null, _static_staticField_decorators, { kind: "field", name: "staticField", static: true, private: false, access: { has: obj => "staticField" in obj, get: obj => obj.staticField, set: (obj, value) => { obj.staticField = value; } }, metadata: _metadata }, _static_staticField_initializers, _static_staticField_extraInitializers);
+            __esDecorate(null, null, _field_decorators, { kind: "field", name: "field", static: false, private: false, access: { has: obj => "field" in obj, get: obj => obj.field, set: (obj, value) => { obj.field = value; } }, metadata: _metadata }, _field_initializers, _field_extraInitializers);
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            C = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        }
+        method() { }
+        field = (__runInitializers(this, _instanceExtraInitializers), __runInitializers(this, _field_initializers, 1));
+        #count_accessor_storage = (__runInitializers(this, _field_extraInitializers), __runInitializers(this, _count_initializers, 0));
+        get count() { return this.#count_accessor_storage; }
+        set count(value) { this.#count_accessor_storage = value; }
+        static staticMethod() { }
+        static staticField = (__runInitializers(_classThis, _staticExtraInitializers), __runInitializers(_classThis, _static_staticField_initializers, 2));
+        get #privateMethod() { return _private_privateMethod_descriptor.value; }
+        get #privateGetter() { return _private_get_privateGetter_descriptor.get.call(this); }
+        static #privateAccessor_accessor_storage = (__runInitializers(_classThis, _static_staticField_extraInitializers), __runInitializers(_classThis, _static_private_privateAccessor_initializers, 3));
+        static get #privateAccessor() { return _static_private_privateAccessor_descriptor.get.call(this); }
+        static set #privateAccessor(value) { return _static_private_privateAccessor_descriptor.set.call(this, value); }
+        [(_method_decorators = [logged], _field_decorators = [double], _count_decorators = [tracked], _static_staticMethod_decorators = [logged], _static_staticField_decorators = [(_a = decorators).logged.bind(_a)], _private_privateMethod_decorators = [logged], _private_get_privateGetter_decorators = [logged], _static_private_privateAccessor_decorators = [tracked], _member_decorators = [logged], _b = __propKey(key))]() { }
+        constructor() {
+            __runInitializers(this, _count_extraInitializers);
+        }
+        static {
+            __runInitializers(_classThis, _static_private_privateAccessor_extraInitializers);
+            __runInitializers(_classThis, _classExtraInitializers);
+        }
+    };
+    return C = _classThis;
+})();
+export { C };
+let D = (() => {
+    let _classSuper = C;
+    let _other_decorators;
+    let _other_initializers = [];
+    let _other_extraInitializers = [];
+    return class D extends _classSuper {
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(_classSuper[Symbol.metadata] ?? null) : void 0;
+            _other_decorators = [double];
+            __esDecorate(null, null, _other_decorators, { kind: "field", name: "other", static: false, private: false, access: { has: obj => "other" in obj, get: obj => obj.other, set: (obj, value) => { obj.other = value; } }, metadata: _metadata }, _other_initializers, _other_extraInitializers);
+            if (_metadata) Object.defineProperty(this, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        }
+        other = __runInitializers(this, _other_initializers, 3);
+        constructor() {
+            super();
+            __runInitializers(this, _other_extraInitializers);
+            console.log(this.other);
+        }
+    };
+})();
+export default D;
+const E = (() => {
+    let _classDecorators = [logged];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    var class_1 = class {
+        static { _classThis = this; }
+        static { __setFunctionName(this, "E"); }
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            class_1 = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+            __runInitializers(_classThis, _classExtraInitializers);
+        }
+    };
+    return class_1 = _classThis;
+})();
@@ -0,0 +1,130 @@
+//// [tests/cases/compiler/forAwaitDownlevel.ts] ////
+
+//// [forAwaitDownlevel.ts]
+declare const xs: AsyncIterable<number>;
+declare function f(): Promise<number>;
+
+async function loop() {
+    for await (const x of xs) {
+        x;
+    }
+    outer: for await (const y of [1, 2]) {
+        continue outer;
+    }
+}
+
+async function* gen() {
+    const v = await f();
+    yield v;
+    yield* [1, 2];
+    for await (const z of xs) yield z;
+    return 1;
+}
+
+class Base {
+    m() { return 1; }
+}
+
+class Derived extends Base {
+    async *n() {
+        yield super.m();
+    }
+}
+
+
+//// [forAwaitDownlevel.js]
+var __asyncValues = (this && this.__asyncValues) || function (o) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var m = o[Symbol.asyncIterator], i;
+    return m ? m.call(o) : (o = typeof __values === "function" ? __values(o) : o[Symbol.iterator](), i = {}, verb("next"), verb("throw"), verb("return"), i[Symbol.asyncIterator] = function () { return this; }, i);
+    function verb(n) { i[n] = o[n] && function (v) { return new Promise(function (resolve, reject) { v = o[n](v), settle(resolve, reject, v.done, v.value); }); }; }
+    function settle(resolve, reject, d, v) { Promise.resolve(v).then(function(v) { resolve({ value: v, done: d }); }, reject); }
+};
+var __await = (this && this.__await) || function (v) { return this instanceof __await ? (this.v = v, this) : new __await(v); }
+var __asyncDelegator = (this && this.__asyncDelegator) || function (o) {
+    var i, p;
+    return i = {}, verb("next"), verb("throw", function (e) { throw e; }), verb("return"), i[Symbol.iterator] = function () { return this; }, i;
+    function verb(n, f) { i[n] = o[n] ? function (v) { return (p = !p) ? { value: __await(o[n](v)), done: false } : f ? f(v) : v; } : f; }
+};
+var __asyncGenerator = (this && this.__asyncGenerator) || function (thisArg, _arguments, generator) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var g = generator.apply(thisArg, _arguments || []), i, q = [];
+    return i = Object.create((typeof AsyncIterator === "function" ? AsyncIterator : Object).prototype), verb("next"), verb("throw"), verb("return", awaitReturn), i[Symbol.asyncIterator] = function () { return this; }, i;
+    function awaitReturn(f) { return function (v) { return Promise.resolve(v).then(f, reject); }; }
+    function verb(n, f) { if (g[n]) { i[n] = function (v) { return new Promise(function (a, b) { q.push([n, v, a, b]) > 1 || resume(n, v); }); }; if (f) i[n] = f(i[n]); } }
+    function resume(n, v) { try { step(g[n](v)); } catch (e) { settle(q[0][3], e); } }
+    function step(r) { r.value instanceof __await ? Promise.resolve(r.value.v).then(fulfill, reject) : settle(q[0][2], r); }
+    function fulfill(value) { resume("next", value); }
+    function reject(value) { resume("throw", value); }
+    function settle(f, v) { if (f(v), q.shift(), q.length) resume(q[0][0], q[0][1]); }
+};
+async function loop() {
+    var _a, e_1, _b, _c, _d, e_2, _e, _f;
+    try {
+        for (var _g = true, xs_1 = __asyncValues(xs), xs_1_1; xs_1_1 = await xs_1.next(), _a = xs_1_1.done, !_a; _g = true) {
+            _c = xs_1_1.value;
+            _g = false;
+            const x = _c;
+            x;
+        }
+    }
+    catch (e_1_1) { e_1 = { error: e_1_1 }; }
+    finally {
+        try {
+            if (!_g && !_a && (_b = xs_1.return)) await _b.call(xs_1);
+        }
+        finally { if (e_1) throw e_1.error; }
+    }
+    try {
+        outer: for (var _h = true, _j = __asyncValues([1, 2]), _k; _k = await _j.next(), _d = _k.done, !_d; _h = true) {
+            _f = _k.value;
+            _h = false;
+            const y = _f;
+            continue outer;
+        }
+    }
+    catch (e_2_1) { e_2 = { error: e_2_1 }; }
+    finally {
+        try {
+            if (!_h && !_d && (_e = _j.return)) await _e.call(_j);
+        }
+        finally { if (e_2) throw e_2.error; }
+    }
+}
+function gen() {
+    return __asyncGenerator(this, arguments, function* gen_1() {
+        var _a, e_3, _b, _c;
+        const v = yield __await(f());
+        yield yield __await(v);
+        yield __await(yield* __asyncDelegator(__asyncValues([1, 2])));
+        try {
+            for (var _d = true, xs_2 = __asyncValues(xs), xs_2_1; xs_2_1 = (yield __await(xs_2.next())), _a = xs_2_1.done, !_a; _d = true) {
+                _c = xs_2_1.value;
+                _d = false;
+                const z = _c;
+                yield yield __await(z);
+            }
+        }
+        catch (e_3_1) { e_3 = { error: e_3_1 }; }
+        finally {
+            try {
+                if (!_d && !_a && (_b = xs_2.return)) yield __await(_b.call(xs_2));
+            }
+            finally { if (e_3) throw e_3.error; }
+        }
+        return yield __await(1);
+    });
+}
+class Base {
+    m() { return 1; }
+}
+class Derived extends Base {
+    n() {
+        const _super = Object.create(null, {
+            m: { get: () => super.m }
+        });
+        return __asyncGenerator(this, arguments, function* n_1() {
+            yield yield __await(_super.m.call(this));
+        });
+    }
+}
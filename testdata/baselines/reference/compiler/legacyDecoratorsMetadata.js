@@ -0,0 +1,166 @@
+//// [tests/cases/compiler/legacyDecoratorsMetadata.ts] ////
+
+//// [service.ts]
+export class Service {
+    hello() { return "hi"; }
+}
+export interface Options {
+    verbose: boolean;
+}
+
+//// [main.ts]
+import { Service, Options } from "./service";
+
+declare function Injectable(): ClassDecorator;
+declare function Inject(token: string): ParameterDecorator;
+declare const prop: PropertyDecorator;
+declare const meth: MethodDecorator;
+
+const key = "comp" + "uted";
+
+@Injectable()
+export class C {
+    static instances = 0;
+    @prop name: string = "n";
+    @prop svc!: Service;
+    @prop options!: Options;
+    @prop list!: string[];
+    @prop either!: string | number;
+
+    constructor(@Inject("svc") public service: Service, count: number) {
+        C.instances++;
+    }
+
+    @meth method(@Inject("a") a: string, b: Service, ...rest: number[]): boolean { return true; }
+    @meth async load(): Promise<void> {}
+    @meth get value(): number { return 1; }
+    set value(v: number) {}
+    @meth static create() { return new C(new Service(), 0); }
+    @meth [key]() {}
+}
+
+export default class D {
+    @meth m(@Inject("x") x: Date) {}
+}
+
+
+//// [service.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.Service = void 0;
+class Service {
+    hello() { return "hi"; }
+}
+exports.Service = Service;
+//// [main.js]
+"use strict";
+var __decorate = (this && this.__decorate) || function (decorators, target, key, desc) {
+    var c = arguments.length, r = c < 3 ? target : desc === null ? desc = Object.getOwnPropertyDescriptor(target, key) : desc, d;
+    if (typeof Reflect === "object" && typeof Reflect.decorate === "function") r = Reflect.decorate(decorators, target, key, desc);
+    else for (var i = decorators.length - 1; i >= 0; i--) if (d = decorators[i]) r = (c < 3 ? d(r) : c > 3 ? d(target, key, r) : d(target, key)) || r;
+    return c > 3 && r && Object.defineProperty(target, key, r), r;
+};
+var __metadata = (this && this.__metadata) || function (k, v) {
+    if (typeof Reflect === "object" && typeof Reflect.metadata === "function") return Reflect.metadata(k, v);
+};
+var __param = (this && this.__param) || function (paramIndex, decorator) {
+    return function (target, key) { decorator(target, key, paramIndex); }
+};
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+var _a;
+var _b, C_1;
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.C = void 0;
+const service_1 = require("./service");
+const key = "comp" + "uted";
+let C = C_1 = (_a = class C {
+    constructor(service, count) {
+        this.service = service;
+        this.name = "n";
+        C_1.instances++;
+    }
+    method(a, b, ...rest) { return true; }
+    load() {
+        return __awaiter(this, void 0, void 0, function* () {
+        });
+    }
+    get value() { return 1; }
+    set value(v) { }
+    static create() { return new C_1(new service_1.Service(), 0); }
+    [_b = key]() { }
+}, _a.instances = 0, _a);
+exports.C = C;
+__decorate([
+    prop,
+    __metadata("design:type", String)
+], C.prototype, "name", void 0);
+__decorate([
+    prop,
+    __metadata("design:type", service_1.Service)
+], C.prototype, "svc", void 0);
+__decorate([
+    prop,
+    __metadata("design:type", Object)
+], C.prototype, "options", void 0);
+__decorate([
+    prop,
+    __metadata("design:type", Array)
+], C.prototype, "list", void 0);
+__decorate([
+    prop,
+    __metadata("design:type", Object)
+], C.prototype, "either", void 0);
+__decorate([
+    meth,
+    __param(0, Inject("a")),
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", [String, service_1.Service, Number]),
+    __metadata("design:returntype", Boolean)
+], C.prototype, "method", null);
+__decorate([
+    meth,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", Promise)
+], C.prototype, "load", null);
+__decorate([
+    meth,
+    __metadata("design:type", Number),
+    __metadata("design:paramtypes", [Number])
+], C.prototype, "value", null);
+__decorate([
+    meth,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", void 0)
+], C.prototype, _b, null);
+__decorate([
+    meth,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", void 0)
+], C, "create", null);
+exports.C = C = C_1 = __decorate([
+    Injectable(),
+    __param(0, Inject("svc")),
+    __metadata("design:paramtypes", [service_1.Service, Number])
+], C);
+class D {
+    m(x) { }
+}
+exports.default = D;
+__decorate([
+    meth,
+    __param(0, Inject("x")),
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", [Date]),
+    __metadata("design:returntype", void 0)
+], D.prototype, "m", null);
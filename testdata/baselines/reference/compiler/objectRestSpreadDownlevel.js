@@ -0,0 +1,71 @@
+//// [tests/cases/compiler/objectRestSpreadDownlevel.ts] ////
+
+//// [objectRestSpreadDownlevel.ts]
+declare const o: { a: number; b: number; c: number; [key: string]: number };
+declare const k: string;
+declare const items: { p: number; q: number }[];
+
+const spread = { a: 1, ...o, b: 2 };
+const copy = { ...o };
+
+const { a, ...rest } = o;
+const { [k]: computed, b = 2, ...computedRest } = o;
+const [first, { p, ...others }] = items;
+
+let target: number, targetRest: {};
+({ a: target, ...targetRest } = o);
+const result = ({ a: target, ...targetRest } = o);
+
+function fn({ a, ...rest }: typeof o, z = 1) {
+    return rest;
+}
+
+const arrow = ({ a, ...rest }: typeof o = o) => rest;
+
+for (const { p, ...q } of items) {
+    q;
+}
+
+try {
+}
+catch ({ message, ...details }) {
+}
+
+
+//// [objectRestSpreadDownlevel.js]
+var __rest = (this && this.__rest) || function (s, e) {
+    var t = {};
+    for (var p in s) if (Object.prototype.hasOwnProperty.call(s, p) && e.indexOf(p) < 0)
+        t[p] = s[p];
+    if (s != null && typeof Object.getOwnPropertySymbols === "function")
+        for (var i = 0, p = Object.getOwnPropertySymbols(s); i < p.length; i++) {
+            if (e.indexOf(p[i]) < 0 && Object.prototype.propertyIsEnumerable.call(s, p[i]))
+                t[p[i]] = s[p[i]];
+        }
+    return t;
+};
+const spread = Object.assign(Object.assign({ a: 1 }, o), { b: 2 });
+const copy = Object.assign({}, o);
+const { a } = o, rest = __rest(o, ["a"]);
+const _a = k, computed = o[_a], { b = 2 } = o, computedRest = __rest(o, [typeof _a === "symbol" ? _a : _a + "", "b"]);
+const [first, _b] = items, { p } = _b, others = __rest(_b, ["p"]);
+let target, targetRest;
+({ a: target } = o, targetRest = __rest(o, ["a"]));
+const result = ({ a: target } = o, targetRest = __rest(o, ["a"]), o);
+function fn(_a, z = 1) {
+    var { a } = _a, rest = __rest(_a, ["a"]);
+    return rest;
+}
+const arrow = (_a = o) => {
+    var { a } = _a, rest = __rest(_a, ["a"]);
+    return rest;
+};
+for (let _c of items) {
+    const { p } = _c, q = __rest(_c, ["p"]);
+    q;
+}
+try {
+}
+catch (_d) {
+    let { message } = _d, details = __rest(_d, ["message"]);
+}
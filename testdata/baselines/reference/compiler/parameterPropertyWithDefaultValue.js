@@ -7,7 +7,6 @@ export class SomeClass {
 
 //// [parameterPropertyWithDefaultValue.js]
 export class SomeClass {
-    timestamp;
     constructor(timestamp = new Date()) {
         this.timestamp = timestamp;
     }
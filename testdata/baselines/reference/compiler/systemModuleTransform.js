@@ -0,0 +1,90 @@
+//// [tests/cases/compiler/systemModuleTransform.ts] ////
+
+//// [dep.ts]
+export function helper(n: number) { return n * 10; }
+export default "dep";
+
+//// [main.ts]
+import name, { helper } from "./dep";
+import * as ns from "./dep";
+export * from "./dep";
+export { helper as reexported } from "./dep";
+export var counter = 0;
+export function increment() { counter++; }
+export class Box { value = name; }
+for (var key in ns) { counter += helper(1); }
+export const meta = import.meta;
+export async function later() {
+    const m = await import("./dep");
+    return m.helper(5);
+}
+export default counter;
+
+
+//// [dep.js]
+System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    function helper(n) { return n * 10; }
+    exports_1("helper", helper);
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("default", "dep");
+        }
+    };
+});
+//// [main.js]
+System.register(["./dep"], function (exports_1, context_1) {
+    "use strict";
+    var dep_1, ns, counter, Box, key, meta;
+    var __moduleName = context_1 && context_1.id;
+    function increment() { exports_1("counter", (counter++, counter)); }
+    exports_1("increment", increment);
+    async function later() {
+        const m = await context_1.import("./dep");
+        return m.helper(5);
+    }
+    exports_1("later", later);
+    var exportedNames_1 = {
+        "reexported": true,
+        "counter": true,
+        "Box": true,
+        "meta": true,
+        "increment": true,
+        "later": true
+    };
+    function exportStar_1(m) {
+        var exports = {};
+        for (var n in m) {
+            if (n !== "default" && !exportedNames_1.hasOwnProperty(n)) exports[n] = m[n];
+        }
+        exports_1(exports);
+    }
+    return {
+        setters: [
+            function (dep_1_1) {
+                dep_1 = dep_1_1;
+                ns = dep_1_1;
+                exportStar_1(dep_1_1);
+                exports_1({
+                    "reexported": dep_1_1["helper"]
+                });
+            }
+        ],
+        execute: function () {
+            exports_1("counter", counter = 0);
+            Box = class Box {
+                constructor() {
+                    this.value = dep_1.default;
+                }
+            };
+            exports_1("Box", Box);
+            for (key in ns) {
+                exports_1("counter", counter += dep_1.helper(1));
+            }
+            exports_1("meta", meta = context_1.meta);
+            exports_1("default", counter);
+        }
+    };
+});
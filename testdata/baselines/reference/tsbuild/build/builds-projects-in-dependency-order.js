@@ -0,0 +1,784 @@
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/solution/core/index.ts] *new* 
+export const someString: string = "HELLO WORLD";
+export function leftPad(s: string, n: number) { return s + n; }
+export function multiply(a: number, b: number) { return a * b; }
+//// [/home/src/workspaces/solution/core/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+}
+//// [/home/src/workspaces/solution/logic/index.ts] *new* 
+import * as c from '../core/index';
+export function getSecondsInDay() {
+    return c.multiply(10, 15);
+}
+//// [/home/src/workspaces/solution/logic/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+    "references": [
+        { "path": "../core" },
+    ],
+}
+//// [/home/src/workspaces/solution/tests/index.ts] *new* 
+import * as c from '../core/index';
+import * as logic from '../logic/index';
+c.leftPad("", 10);
+logic.getSecondsInDay();
+//// [/home/src/workspaces/solution/tests/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+    "references": [
+        { "path": "../core" },
+        { "path": "../logic" },
+    ],
+}
+
+tsgo --b tests --verbose
+ExitStatus:: Success
+Output::
+Projects in this build: 
+    * core/tsconfig.json
+    * logic/tsconfig.json
+    * tests/tsconfig.json
+
+Project 'core/tsconfig.json' is out of date because output file 'core/tsconfig.tsbuildinfo' does not exist
+
+Building project 'core/tsconfig.json'...
+
+Project 'logic/tsconfig.json' is out of date because output file 'logic/tsconfig.tsbuildinfo' does not exist
+
+Building project 'logic/tsconfig.json'...
+
+Project 'tests/tsconfig.json' is out of date because output file 'tests/tsconfig.tsbuildinfo' does not exist
+
+Building project 'tests/tsconfig.json'...
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/solution/core/index.d.ts] *new* 
+export declare const someString: string;
+export declare function leftPad(s: string, n: number): string;
+export declare function multiply(a: number, b: number): number;
+
+//// [/home/src/workspaces/solution/core/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.someString = void 0;
+exports.leftPad = leftPad;
+exports.multiply = multiply;
+exports.someString = "HELLO WORLD";
+function leftPad(s, n) { return s + n; }
+function multiply(a, b) { return a * b; }
+
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] *new* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"2753a1085d587a7d57069e1105af24ec-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }","signature":"da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n","impliedNodeFormat":1}],"options":{"composite":true,"declaration":true},"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo.readable.baseline.txt] *new* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "2753a1085d587a7d57069e1105af24ec-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }",
+      "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "2753a1085d587a7d57069e1105af24ec-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }",
+        "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1428
+}
+//// [/home/src/workspaces/solution/logic/index.d.ts] *new* 
+export declare function getSecondsInDay(): number;
+
+//// [/home/src/workspaces/solution/logic/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.getSecondsInDay = getSecondsInDay;
+const c = require("../core/index");
+function getSecondsInDay() {
+    return c.multiply(10, 15);
+}
+
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] *new* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",{"version":"35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}","signature":"9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n","impliedNodeFormat":1}],"fileIdsList":[[2]],"options":{"composite":true,"declaration":true},"referencedMap":[[3,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo.readable.baseline.txt] *new* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}",
+      "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}",
+        "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1507
+}
+//// [/home/src/workspaces/solution/tests/index.d.ts] *new* 
+export {};
+
+//// [/home/src/workspaces/solution/tests/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+const c = require("../core/index");
+const logic = require("../logic/index");
+c.leftPad("", 10);
+logic.getSecondsInDay();
+
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] *new* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","../logic/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n","9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",{"version":"4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();","signature":"abe7d9981d6018efb6b2b794f40a1607-export {};\n","impliedNodeFormat":1}],"fileIdsList":[[2,3]],"options":{"composite":true,"declaration":true},"referencedMap":[[4,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo.readable.baseline.txt] *new* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "../logic/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "../logic/index.d.ts",
+      "version": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+      "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+        "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1597
+}
+
+
+
+Edit [0]:: no change
+
+tsgo --b tests --verbose
+ExitStatus:: Success
+Output::
+Projects in this build: 
+    * core/tsconfig.json
+    * logic/tsconfig.json
+    * tests/tsconfig.json
+
+Project 'core/tsconfig.json' is up to date because newest input '../../tslibs/TS/Lib/lib.d.ts' is older than output 'core/tsconfig.tsbuildinfo'
+
+Project 'logic/tsconfig.json' is up to date because newest input 'core/index.d.ts' is older than output 'logic/tsconfig.tsbuildinfo'
+
+Project 'tests/tsconfig.json' is up to date because newest input 'logic/index.d.ts' is older than output 'tests/tsconfig.tsbuildinfo'
+
+
+
+Diff:: The up to date status of projects is reported against the outputs of the previous build
+--- nonIncremental errors.txt
++++ incremental errors.txt
+@@ -2,9 +2,6 @@
+     * core/tsconfig.json
+     * logic/tsconfig.json
+     * tests/tsconfig.json
+-Project 'core/tsconfig.json' is out of date because output file 'core/tsconfig.tsbuildinfo' does not exist
+-Building project 'core/tsconfig.json'...
+-Project 'logic/tsconfig.json' is out of date because output file 'logic/tsconfig.tsbuildinfo' does not exist
+-Building project 'logic/tsconfig.json'...
+-Project 'tests/tsconfig.json' is out of date because output file 'tests/tsconfig.tsbuildinfo' does not exist
+-Building project 'tests/tsconfig.json'...
++Project 'core/tsconfig.json' is up to date because newest input '../../tslibs/TS/Lib/lib.d.ts' is older than output 'core/tsconfig.tsbuildinfo'
++Project 'logic/tsconfig.json' is up to date because newest input 'core/index.d.ts' is older than output 'logic/tsconfig.tsbuildinfo'
++Project 'tests/tsconfig.json' is up to date because newest input 'logic/index.d.ts' is older than output 'tests/tsconfig.tsbuildinfo'
+
+Edit [1]:: change core
+//// [/home/src/workspaces/solution/core/index.ts] *modified* 
+export const someString: string = "HELLO WORLD";
+export function leftPad(s: string, n: number) { return s + n; }
+export function multiply(a: number, b: number) { return a * b; }
+export class someClass { }
+
+tsgo --b tests --verbose
+ExitStatus:: Success
+Output::
+Projects in this build: 
+    * core/tsconfig.json
+    * logic/tsconfig.json
+    * tests/tsconfig.json
+
+Project 'core/tsconfig.json' is out of date because output 'core/tsconfig.tsbuildinfo' is older than input 'core/index.ts'
+
+Building project 'core/tsconfig.json'...
+
+Project 'logic/tsconfig.json' is out of date because output 'logic/tsconfig.tsbuildinfo' is older than input 'core/index.d.ts'
+
+Building project 'logic/tsconfig.json'...
+
+Project 'tests/tsconfig.json' is out of date because output 'tests/tsconfig.tsbuildinfo' is older than input 'core/index.d.ts'
+
+Building project 'tests/tsconfig.json'...
+//// [/home/src/workspaces/solution/core/index.d.ts] *modified* 
+export declare const someString: string;
+export declare function leftPad(s: string, n: number): string;
+export declare function multiply(a: number, b: number): number;
+export declare class someClass {
+}
+
+//// [/home/src/workspaces/solution/core/index.js] *modified* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.someClass = exports.someString = void 0;
+exports.leftPad = leftPad;
+exports.multiply = multiply;
+exports.someString = "HELLO WORLD";
+function leftPad(s, n) { return s + n; }
+function multiply(a, b) { return a * b; }
+class someClass {
+}
+exports.someClass = someClass;
+
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] *modified* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"ce98c7b232474711a6089b586825cf2a-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }\nexport class someClass { }","signature":"f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n","impliedNodeFormat":1}],"options":{"composite":true,"declaration":true},"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo.readable.baseline.txt] *modified* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "ce98c7b232474711a6089b586825cf2a-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }\nexport class someClass { }",
+      "signature": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "ce98c7b232474711a6089b586825cf2a-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }\nexport class someClass { }",
+        "signature": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1493
+}
+//// [/home/src/workspaces/solution/logic/index.js] *rewrite with same content*
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] *modified* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",{"version":"35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}","signature":"9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n","impliedNodeFormat":1}],"fileIdsList":[[2]],"options":{"composite":true,"declaration":true},"referencedMap":[[3,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo.readable.baseline.txt] *modified* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "signature": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}",
+      "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}",
+        "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1544
+}
+//// [/home/src/workspaces/solution/tests/index.js] *rewrite with same content*
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] *modified* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","../logic/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n","9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",{"version":"4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();","signature":"abe7d9981d6018efb6b2b794f40a1607-export {};\n","impliedNodeFormat":1}],"fileIdsList":[[2,3]],"options":{"composite":true,"declaration":true},"referencedMap":[[4,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo.readable.baseline.txt] *modified* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "../logic/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "signature": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "../logic/index.d.ts",
+      "version": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+      "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+        "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1634
+}
+
+
+
+Diff:: The up to date status of projects is reported against the outputs of the previous build
+--- nonIncremental errors.txt
++++ incremental errors.txt
+@@ -2,9 +2,9 @@
+     * core/tsconfig.json
+     * logic/tsconfig.json
+     * tests/tsconfig.json
+-Project 'core/tsconfig.json' is out of date because output file 'core/tsconfig.tsbuildinfo' does not exist
++Project 'core/tsconfig.json' is out of date because output 'core/tsconfig.tsbuildinfo' is older than input 'core/index.ts'
+ Building project 'core/tsconfig.json'...
+-Project 'logic/tsconfig.json' is out of date because output file 'logic/tsconfig.tsbuildinfo' does not exist
++Project 'logic/tsconfig.json' is out of date because output 'logic/tsconfig.tsbuildinfo' is older than input 'core/index.d.ts'
+ Building project 'logic/tsconfig.json'...
+-Project 'tests/tsconfig.json' is out of date because output file 'tests/tsconfig.tsbuildinfo' does not exist
++Project 'tests/tsconfig.json' is out of date because output 'tests/tsconfig.tsbuildinfo' is older than input 'core/index.d.ts'
+ Building project 'tests/tsconfig.json'...
+
+Edit [2]:: change logic
+//// [/home/src/workspaces/solution/logic/index.ts] *modified* 
+import * as c from '../core/index';
+export function getSecondsInDay() {
+    return c.multiply(10, 15);
+}
+export const x = 10;
+
+tsgo --b tests --verbose
+ExitStatus:: Success
+Output::
+Projects in this build: 
+    * core/tsconfig.json
+    * logic/tsconfig.json
+    * tests/tsconfig.json
+
+Project 'core/tsconfig.json' is up to date because newest input 'core/index.ts' is older than output 'core/tsconfig.tsbuildinfo'
+
+Project 'logic/tsconfig.json' is out of date because output 'logic/tsconfig.tsbuildinfo' is older than input 'logic/index.ts'
+
+Building project 'logic/tsconfig.json'...
+
+Project 'tests/tsconfig.json' is out of date because output 'tests/tsconfig.tsbuildinfo' is older than input 'logic/index.d.ts'
+
+Building project 'tests/tsconfig.json'...
+//// [/home/src/workspaces/solution/logic/index.d.ts] *modified* 
+export declare function getSecondsInDay(): number;
+export declare const x = 10;
+
+//// [/home/src/workspaces/solution/logic/index.js] *modified* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+exports.getSecondsInDay = getSecondsInDay;
+const c = require("../core/index");
+function getSecondsInDay() {
+    return c.multiply(10, 15);
+}
+exports.x = 10;
+
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] *modified* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",{"version":"e22e4d15fa8cbd330fb0756a7152d221-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}\nexport const x = 10;","signature":"1b10d9f5ceeac755893bfd55787f03a6-export declare function getSecondsInDay(): number;\nexport declare const x = 10;\n","impliedNodeFormat":1}],"fileIdsList":[[2]],"options":{"composite":true,"declaration":true},"referencedMap":[[3,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo.readable.baseline.txt] *modified* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "signature": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "e22e4d15fa8cbd330fb0756a7152d221-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}\nexport const x = 10;",
+      "signature": "1b10d9f5ceeac755893bfd55787f03a6-export declare function getSecondsInDay(): number;\nexport declare const x = 10;\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "e22e4d15fa8cbd330fb0756a7152d221-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}\nexport const x = 10;",
+        "signature": "1b10d9f5ceeac755893bfd55787f03a6-export declare function getSecondsInDay(): number;\nexport declare const x = 10;\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1596
+}
+//// [/home/src/workspaces/solution/tests/index.js] *rewrite with same content*
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] *modified* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","../logic/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n","1b10d9f5ceeac755893bfd55787f03a6-export declare function getSecondsInDay(): number;\nexport declare const x = 10;\n",{"version":"4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();","signature":"abe7d9981d6018efb6b2b794f40a1607-export {};\n","impliedNodeFormat":1}],"fileIdsList":[[2,3]],"options":{"composite":true,"declaration":true},"referencedMap":[[4,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo.readable.baseline.txt] *modified* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "../logic/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "signature": "f678e4b80b87bcfac584b8a641b31960-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\nexport declare class someClass {\n}\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "../logic/index.d.ts",
+      "version": "1b10d9f5ceeac755893bfd55787f03a6-export declare function getSecondsInDay(): number;\nexport declare const x = 10;\n",
+      "signature": "1b10d9f5ceeac755893bfd55787f03a6-export declare function getSecondsInDay(): number;\nexport declare const x = 10;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+      "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+        "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1664
+}
+
+
+
+Diff:: The up to date status of projects is reported against the outputs of the previous build
+--- nonIncremental errors.txt
++++ incremental errors.txt
+@@ -2,9 +2,8 @@
+     * core/tsconfig.json
+     * logic/tsconfig.json
+     * tests/tsconfig.json
+-Project 'core/tsconfig.json' is out of date because output file 'core/tsconfig.tsbuildinfo' does not exist
+-Building project 'core/tsconfig.json'...
+-Project 'logic/tsconfig.json' is out of date because output file 'logic/tsconfig.tsbuildinfo' does not exist
++Project 'core/tsconfig.json' is up to date because newest input 'core/index.ts' is older than output 'core/tsconfig.tsbuildinfo'
++Project 'logic/tsconfig.json' is out of date because output 'logic/tsconfig.tsbuildinfo' is older than input 'logic/index.ts'
+ Building project 'logic/tsconfig.json'...
+-Project 'tests/tsconfig.json' is out of date because output file 'tests/tsconfig.tsbuildinfo' does not exist
++Project 'tests/tsconfig.json' is out of date because output 'tests/tsconfig.tsbuildinfo' is older than input 'logic/index.d.ts'
+ Building project 'tests/tsconfig.json'...
+
+Edit [3]:: force
+
+tsgo --b tests --verbose --force
+ExitStatus:: Success
+Output::
+Projects in this build: 
+    * core/tsconfig.json
+    * logic/tsconfig.json
+    * tests/tsconfig.json
+
+Project 'core/tsconfig.json' is being forcibly rebuilt
+
+Building project 'core/tsconfig.json'...
+
+Project 'logic/tsconfig.json' is being forcibly rebuilt
+
+Building project 'logic/tsconfig.json'...
+
+Project 'tests/tsconfig.json' is being forcibly rebuilt
+
+Building project 'tests/tsconfig.json'...
+//// [/home/src/workspaces/solution/core/index.d.ts] *rewrite with same content*
+//// [/home/src/workspaces/solution/core/index.js] *rewrite with same content*
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] *rewrite with same content*
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo.readable.baseline.txt] *rewrite with same content*
+//// [/home/src/workspaces/solution/logic/index.d.ts] *rewrite with same content*
+//// [/home/src/workspaces/solution/logic/index.js] *rewrite with same content*
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] *rewrite with same content*
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo.readable.baseline.txt] *rewrite with same content*
+//// [/home/src/workspaces/solution/tests/index.d.ts] *rewrite with same content*
+//// [/home/src/workspaces/solution/tests/index.js] *rewrite with same content*
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] *rewrite with same content*
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo.readable.baseline.txt] *rewrite with same content*
+
@@ -0,0 +1,329 @@
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/solution/core/index.ts] *new* 
+export const someString: string = "HELLO WORLD";
+export function leftPad(s: string, n: number) { return s + n; }
+export function multiply(a: number, b: number) { return a * b; }
+//// [/home/src/workspaces/solution/core/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+}
+//// [/home/src/workspaces/solution/logic/index.ts] *new* 
+import * as c from '../core/index';
+export function getSecondsInDay() {
+    return c.multiply(10, 15);
+}
+//// [/home/src/workspaces/solution/logic/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+    "references": [
+        { "path": "../core" },
+    ],
+}
+//// [/home/src/workspaces/solution/tests/index.ts] *new* 
+import * as c from '../core/index';
+import * as logic from '../logic/index';
+c.leftPad("", 10);
+logic.getSecondsInDay();
+//// [/home/src/workspaces/solution/tests/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+    "references": [
+        { "path": "../core" },
+        { "path": "../logic" },
+    ],
+}
+
+tsgo --b tests
+ExitStatus:: Success
+Output::
+No output
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/solution/core/index.d.ts] *new* 
+export declare const someString: string;
+export declare function leftPad(s: string, n: number): string;
+export declare function multiply(a: number, b: number): number;
+
+//// [/home/src/workspaces/solution/core/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.someString = void 0;
+exports.leftPad = leftPad;
+exports.multiply = multiply;
+exports.someString = "HELLO WORLD";
+function leftPad(s, n) { return s + n; }
+function multiply(a, b) { return a * b; }
+
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] *new* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"2753a1085d587a7d57069e1105af24ec-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }","signature":"da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n","impliedNodeFormat":1}],"options":{"composite":true,"declaration":true},"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo.readable.baseline.txt] *new* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "2753a1085d587a7d57069e1105af24ec-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }",
+      "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "2753a1085d587a7d57069e1105af24ec-export const someString: string = \"HELLO WORLD\";\nexport function leftPad(s: string, n: number) { return s + n; }\nexport function multiply(a: number, b: number) { return a * b; }",
+        "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1428
+}
+//// [/home/src/workspaces/solution/logic/index.d.ts] *new* 
+export declare function getSecondsInDay(): number;
+
+//// [/home/src/workspaces/solution/logic/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.getSecondsInDay = getSecondsInDay;
+const c = require("../core/index");
+function getSecondsInDay() {
+    return c.multiply(10, 15);
+}
+
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] *new* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",{"version":"35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}","signature":"9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n","impliedNodeFormat":1}],"fileIdsList":[[2]],"options":{"composite":true,"declaration":true},"referencedMap":[[3,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo.readable.baseline.txt] *new* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}",
+      "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "35014215353afde4332f1f1506bc530e-import * as c from '../core/index';\nexport function getSecondsInDay() {\n    return c.multiply(10, 15);\n}",
+        "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1507
+}
+//// [/home/src/workspaces/solution/tests/index.d.ts] *new* 
+export {};
+
+//// [/home/src/workspaces/solution/tests/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+const c = require("../core/index");
+const logic = require("../logic/index");
+c.leftPad("", 10);
+logic.getSecondsInDay();
+
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] *new* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","../core/index.d.ts","../logic/index.d.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n","9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",{"version":"4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();","signature":"abe7d9981d6018efb6b2b794f40a1607-export {};\n","impliedNodeFormat":1}],"fileIdsList":[[2,3]],"options":{"composite":true,"declaration":true},"referencedMap":[[4,1]],"latestChangedDtsFile":"./index.d.ts"}
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo.readable.baseline.txt] *new* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "../core/index.d.ts",
+    "../logic/index.d.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "../core/index.d.ts",
+      "version": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "signature": "da642d80443e7ccd327091080a82a43c-export declare const someString: string;\nexport declare function leftPad(s: string, n: number): string;\nexport declare function multiply(a: number, b: number): number;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "../logic/index.d.ts",
+      "version": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "signature": "9494e0492bdbb92c8cb1da677326ef0f-export declare function getSecondsInDay(): number;\n",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+      "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "4768cd2c8d35059b9392b85c856e8952-import * as c from '../core/index';\nimport * as logic from '../logic/index';\nc.leftPad(\"\", 10);\nlogic.getSecondsInDay();",
+        "signature": "abe7d9981d6018efb6b2b794f40a1607-export {};\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  ],
+  "options": {
+    "composite": true,
+    "declaration": true
+  },
+  "referencedMap": {
+    "./index.ts": [
+      "../core/index.d.ts",
+      "../logic/index.d.ts"
+    ]
+  },
+  "latestChangedDtsFile": "./index.d.ts",
+  "size": 1597
+}
+
+
+
+Edit [0]:: clean dry
+
+tsgo --b tests --clean --dry
+ExitStatus:: Success
+Output::
+A non-dry build would delete the following files: 
+ * /home/src/workspaces/solution/core/index.js
+ * /home/src/workspaces/solution/core/index.d.ts
+ * /home/src/workspaces/solution/core/tsconfig.tsbuildinfo
+ * /home/src/workspaces/solution/logic/index.js
+ * /home/src/workspaces/solution/logic/index.d.ts
+ * /home/src/workspaces/solution/logic/tsconfig.tsbuildinfo
+ * /home/src/workspaces/solution/tests/index.js
+ * /home/src/workspaces/solution/tests/index.d.ts
+ * /home/src/workspaces/solution/tests/tsconfig.tsbuildinfo
+
+
+
+Diff:: Clean only lists the outputs that exist, and a clean build has not written any
+--- nonIncremental errors.txt
++++ incremental errors.txt
+@@ -0,0 +1,10 @@
++A non-dry build would delete the following files: 
++ * /home/src/workspaces/solution/core/index.js
++ * /home/src/workspaces/solution/core/index.d.ts
++ * /home/src/workspaces/solution/core/tsconfig.tsbuildinfo
++ * /home/src/workspaces/solution/logic/index.js
++ * /home/src/workspaces/solution/logic/index.d.ts
++ * /home/src/workspaces/solution/logic/tsconfig.tsbuildinfo
++ * /home/src/workspaces/solution/tests/index.js
++ * /home/src/workspaces/solution/tests/index.d.ts
++ * /home/src/workspaces/solution/tests/tsconfig.tsbuildinfo
+
+Edit [1]:: clean
+
+tsgo --b tests --clean
+ExitStatus:: Success
+Output::
+No output
+//// [/home/src/workspaces/solution/core/index.d.ts] *deleted*
+//// [/home/src/workspaces/solution/core/index.js] *deleted*
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] *deleted*
+//// [/home/src/workspaces/solution/logic/index.d.ts] *deleted*
+//// [/home/src/workspaces/solution/logic/index.js] *deleted*
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] *deleted*
+//// [/home/src/workspaces/solution/tests/index.d.ts] *deleted*
+//// [/home/src/workspaces/solution/tests/index.js] *deleted*
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] *deleted*
+
@@ -0,0 +1,202 @@
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/solution/core/helper.ts] *new* 
+export function helper() { return 1; }
+//// [/home/src/workspaces/solution/core/index.ts] *new* 
+export { helper } from "./helper";
+//// [/home/src/workspaces/solution/core/tsconfig.json] *new* 
+{
+    "compilerOptions": { "incremental": true },
+    "files": ["index.ts"],
+}
+
+tsgo --b core --verbose
+ExitStatus:: Success
+Output::
+Projects in this build: 
+    * core/tsconfig.json
+
+Project 'core/tsconfig.json' is out of date because output file 'core/tsconfig.tsbuildinfo' does not exist
+
+Building project 'core/tsconfig.json'...
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/solution/core/helper.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.helper = helper;
+function helper() { return 1; }
+
+//// [/home/src/workspaces/solution/core/index.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.helper = void 0;
+const helper_1 = require("./helper");
+Object.defineProperty(exports, "helper", { enumerable: true, get: function () { return helper_1.helper; } });
+
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] *new* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","./helper.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},"d3d746857c71ff6f7dd412ba3c9f7d07-export function helper() { return 1; }","e0c151a2e61c0a23b520e75da423fdfc-export { helper } from \"./helper\";"],"fileIdsList":[[2]],"referencedMap":[[3,1]]}
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo.readable.baseline.txt] *new* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "./helper.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "./helper.ts",
+      "version": "d3d746857c71ff6f7dd412ba3c9f7d07-export function helper() { return 1; }",
+      "signature": "d3d746857c71ff6f7dd412ba3c9f7d07-export function helper() { return 1; }",
+      "impliedNodeFormat": "CommonJS"
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "e0c151a2e61c0a23b520e75da423fdfc-export { helper } from \"./helper\";",
+      "signature": "e0c151a2e61c0a23b520e75da423fdfc-export { helper } from \"./helper\";",
+      "impliedNodeFormat": "CommonJS"
+    }
+  ],
+  "fileIdsList": [
+    [
+      "./helper.ts"
+    ]
+  ],
+  "referencedMap": {
+    "./index.ts": [
+      "./helper.ts"
+    ]
+  },
+  "size": 1076
+}
+
+
+
+Edit [0]:: change helper
+//// [/home/src/workspaces/solution/core/helper.ts] *modified* 
+export function helper() { return 1; }
+export const x = 10;
+
+tsgo --b core --verbose
+ExitStatus:: Success
+Output::
+Projects in this build: 
+    * core/tsconfig.json
+
+Project 'core/tsconfig.json' is out of date because output 'core/tsconfig.tsbuildinfo' is older than input 'core/helper.ts'
+
+Building project 'core/tsconfig.json'...
+//// [/home/src/workspaces/solution/core/helper.js] *modified* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+exports.helper = helper;
+function helper() { return 1; }
+exports.x = 10;
+
+//// [/home/src/workspaces/solution/core/index.js] *rewrite with same content*
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] *modified* 
+{"version":"FakeTSVersion","fileNames":["../../../tslibs/TS/Lib/lib.d.ts","./helper.ts","./index.ts"],"fileInfos":[{"version":"8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"f9322ac1313511a828b0dc3d8482b0c2-export function helper() { return 1; }\nexport const x = 10;","signature":"9fbd88444f073b54f66316d73232e448-export declare function helper(): number;\nexport declare const x = 10;\n","impliedNodeFormat":1},{"version":"e0c151a2e61c0a23b520e75da423fdfc-export { helper } from \"./helper\";","signature":"e31a35c8712c5a495fea1fe0ae019038-export { helper } from \"./helper\";\n","impliedNodeFormat":1}],"fileIdsList":[[2]],"referencedMap":[[3,1]]}
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo.readable.baseline.txt] *modified* 
+{
+  "version": "FakeTSVersion",
+  "fileNames": [
+    "../../../tslibs/TS/Lib/lib.d.ts",
+    "./helper.ts",
+    "./index.ts"
+  ],
+  "fileInfos": [
+    {
+      "fileName": "../../../tslibs/TS/Lib/lib.d.ts",
+      "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "signature": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+      "affectsGlobalScope": true,
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "8859c12c614ce56ba9a18e58384a198f-/// <reference no-default-lib=\"true\"/>\ninterface Boolean {}\ninterface Function {}\ninterface CallableFunction {}\ninterface NewableFunction {}\ninterface IArguments {}\ninterface Number { toExponential: any; }\ninterface Object {}\ninterface RegExp {}\ninterface String { charAt: any; }\ninterface Array<T> { length: number; [n: number]: T; }\ninterface ReadonlyArray<T> {}\ninterface SymbolConstructor {\n    (desc?: string | number): symbol;\n    for(name: string): symbol;\n    readonly toStringTag: symbol;\n}\ndeclare var Symbol: SymbolConstructor;\ninterface Symbol {\n    readonly [Symbol.toStringTag]: string;\n}\ndeclare const console: { log(msg: any): void; };",
+        "affectsGlobalScope": true,
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "./helper.ts",
+      "version": "f9322ac1313511a828b0dc3d8482b0c2-export function helper() { return 1; }\nexport const x = 10;",
+      "signature": "9fbd88444f073b54f66316d73232e448-export declare function helper(): number;\nexport declare const x = 10;\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "f9322ac1313511a828b0dc3d8482b0c2-export function helper() { return 1; }\nexport const x = 10;",
+        "signature": "9fbd88444f073b54f66316d73232e448-export declare function helper(): number;\nexport declare const x = 10;\n",
+        "impliedNodeFormat": 1
+      }
+    },
+    {
+      "fileName": "./index.ts",
+      "version": "e0c151a2e61c0a23b520e75da423fdfc-export { helper } from \"./helper\";",
+      "signature": "e31a35c8712c5a495fea1fe0ae019038-export { helper } from \"./helper\";\n",
+      "impliedNodeFormat": "CommonJS",
+      "original": {
+        "version": "e0c151a2e61c0a23b520e75da423fdfc-export { helper } from \"./helper\";",
+        "signature": "e31a35c8712c5a495fea1fe0ae019038-export { helper } from \"./helper\";\n",
+        "impliedNodeFormat": 1
+      }
+    }
+  ],
+  "fileIdsList": [
+    [
+      "./helper.ts"
+    ]
+  ],
+  "referencedMap": {
+    "./index.ts": [
+      "./helper.ts"
+    ]
+  },
+  "size": 1373
+}
+
+
+
+Diff:: The up to date status of projects is reported against the outputs of the previous build
+--- nonIncremental errors.txt
++++ incremental errors.txt
+@@ -1,4 +1,4 @@
+ Projects in this build: 
+     * core/tsconfig.json
+-Project 'core/tsconfig.json' is out of date because output file 'core/tsconfig.tsbuildinfo' does not exist
++Project 'core/tsconfig.json' is out of date because output 'core/tsconfig.tsbuildinfo' is older than input 'core/helper.ts'
+ Building project 'core/tsconfig.json'...
\ No newline at end of file
@@ -0,0 +1,24 @@
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/solution/a/index.ts] *new* 
+export const a = 1;
+//// [/home/src/workspaces/solution/a/tsconfig.json] *new* 
+{
+    "compilerOptions": { "composite": true },
+    "references": [{ "path": "../b" }],
+}
+//// [/home/src/workspaces/solution/b/index.ts] *new* 
+export const b = 1;
+//// [/home/src/workspaces/solution/b/tsconfig.json] *new* 
+{
+    "compilerOptions": { "composite": true },
+    "references": [{ "path": "../a" }],
+}
+
+tsgo --b a
+ExitStatus:: ProjectReferenceCycle_OutputsSkipped
+Output::
+[91merror[0m[90m TS6202: [0mProject references may not form a circular graph. Cycle detected: a/tsconfig.json
+b/tsconfig.json
+a/tsconfig.json
@@ -0,0 +1,50 @@
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/solution/core/index.ts] *new* 
+export const someString: string = "HELLO WORLD";
+export function leftPad(s: string, n: number) { return s + n; }
+export function multiply(a: number, b: number) { return a * b; }
+//// [/home/src/workspaces/solution/core/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+}
+//// [/home/src/workspaces/solution/logic/index.ts] *new* 
+import * as c from '../core/index';
+export function getSecondsInDay() {
+    return c.multiply(10, 15);
+}
+//// [/home/src/workspaces/solution/logic/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+    "references": [
+        { "path": "../core" },
+    ],
+}
+//// [/home/src/workspaces/solution/tests/index.ts] *new* 
+import * as c from '../core/index';
+import * as logic from '../logic/index';
+c.leftPad("", 10);
+logic.getSecondsInDay();
+//// [/home/src/workspaces/solution/tests/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true,
+        "declaration": true,
+    },
+    "references": [
+        { "path": "../core" },
+        { "path": "../logic" },
+    ],
+}
+
+tsgo --b tests --clean --force
+ExitStatus:: DiagnosticsPresent_OutputsSkipped
+Output::
+[91merror[0m[90m TS6370: [0mOptions 'clean' and 'force' cannot be combined.
@@ -63,7 +63,9 @@ export default MessageablePerson;
 //// [/home/src/workspaces/project/MessageablePerson.js] *new* 
 const Messageable = () => {
     return class MessageableClass {
-        message = 'hello';
+        constructor() {
+            this.message = 'hello';
+        }
     };
 };
 const wrapper = () => Messageable();
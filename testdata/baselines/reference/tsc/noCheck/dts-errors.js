@@ -62,7 +62,9 @@ export declare const a: {
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 exports.a = a;
 
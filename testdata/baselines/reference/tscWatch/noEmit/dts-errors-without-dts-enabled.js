@@ -117,7 +117,9 @@ Edit [4]:: emit when error
 Output::
 //// [/home/src/workspaces/project/a.js] *modified* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 
@@ -164,7 +164,9 @@ declare const a: {
 
 //// [/home/src/workspaces/project/a.js] *modified* 
 const a = class {
-    p = 10;
+    constructor() {
+        this.p = 10;
+    }
 };
 
 
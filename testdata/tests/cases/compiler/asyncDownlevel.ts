@@ -0,0 +1,52 @@
+// @target: es2015, es2016
+// @lib: esnext
+
+declare function f(): Promise<number>;
+
+const arrow = async () => await f();
+const arrowWithThis = {
+    value: 1,
+    m() {
+        return async () => this.value + await f();
+    }
+};
+
+class Base {
+    m() { return 1; }
+    static s() { return 2; }
+}
+
+class Derived extends Base {
+    async a() {
+        const x = await f();
+        return super.m() + x;
+    }
+    async b(name: "m") {
+        return super[name]() + super["m"]();
+    }
+    static async t() {
+        return super.s() + await f();
+    }
+}
+
+async function usesArguments() {
+    await f();
+    return arguments.length;
+}
+
+function outer() {
+    return async () => arguments[0];
+}
+
+async function awaitInDefault(x = await f()) {
+    return x;
+}
+
+async function nameCollision(x: number, _arguments: number) {
+    var x = await f();
+    var y = arguments[0] + _arguments;
+    for (var i = 0; i < x; i++) {
+        var z = await f();
+    }
+    return y + z;
+}
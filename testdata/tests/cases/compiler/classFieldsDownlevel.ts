@@ -0,0 +1,40 @@
+// @target: es2021
+
+class Base {
+    constructor(public p: number) {}
+}
+
+class C extends Base {
+    #count = 0;
+    label = "c";
+    static instances = 0;
+    static #registry = new Set<C>();
+
+    static {
+        this.instances++;
+        C.#registry.clear();
+    }
+
+    constructor(p: number) {
+        super(p);
+        this.#count += p;
+        C.#registry.add(this);
+    }
+
+    #increment() {
+        return this.#count++;
+    }
+
+    get #value() {
+        return this.#count;
+    }
+
+    read(other: object) {
+        return #count in other ? this.#increment() + this.#value : 0;
+    }
+}
+
+const D = class {
+    static self = this;
+    #tag = "d";
+};
@@ -0,0 +1,50 @@
+// @target: es2022
+// @lib: esnext, dom
+
+function logged<This, Value>(value: Value, context: DecoratorContext): Value | void {
+    context.addInitializer(function (this: unknown) {
+        console.log(`initialized ${String(context.name)}`);
+    });
+}
+
+function double(value: undefined, context: ClassFieldDecoratorContext) {
+    return (initial: number) => initial * 2;
+}
+
+function tracked<This, Value>(target: ClassAccessorDecoratorTarget<This, Value>, context: ClassAccessorDecoratorContext<This, Value>): ClassAccessorDecoratorResult<This, Value> {
+    return {
+        get(this: This) {
+            return target.get.call(this);
+        },
+        set(this: This, value: Value) {
+            target.set.call(this, value);
+        },
+    };
+}
+
+const decorators = { logged };
+const key = "computed";
+
+@logged
+export class C {
+    @logged method() {}
+    @double field = 1;
+    @tracked accessor count = 0;
+    @logged static staticMethod() {}
+    @decorators.logged static staticField = 2;
+    @logged #privateMethod() {}
+    @logged get #privateGetter() { return 1; }
+    @tracked static accessor #privateAccessor = 3;
+    @logged [key]() {}
+}
+
+export default class D extends C {
+    @double other = 3;
+
+    constructor() {
+        super();
+        console.log(this.other);
+    }
+}
+
+const E = @logged class {};
@@ -0,0 +1,32 @@
+// @target: es2017
+// @lib: esnext
+
+declare const xs: AsyncIterable<number>;
+declare function f(): Promise<number>;
+
+async function loop() {
+    for await (const x of xs) {
+        x;
+    }
+    outer: for await (const y of [1, 2]) {
+        continue outer;
+    }
+}
+
+async function* gen() {
+    const v = await f();
+    yield v;
+    yield* [1, 2];
+    for await (const z of xs) yield z;
+    return 1;
+}
+
+class Base {
+    m() { return 1; }
+}
+
+class Derived extends Base {
+    async *n() {
+        yield super.m();
+    }
+}
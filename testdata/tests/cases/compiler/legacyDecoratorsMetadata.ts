@@ -0,0 +1,48 @@
+// @target: es2015
+// @module: commonjs
+// @experimentalDecorators: true
+// @emitDecoratorMetadata: true
+// @strict: true
+
+// @filename: service.ts
+export class Service {
+    hello() { return "hi"; }
+}
+export interface Options {
+    verbose: boolean;
+}
+
+// @filename: main.ts
+import { Service, Options } from "./service";
+
+declare function Injectable(): ClassDecorator;
+declare function Inject(token: string): ParameterDecorator;
+declare const prop: PropertyDecorator;
+declare const meth: MethodDecorator;
+
+const key = "comp" + "uted";
+
+@Injectable()
+export class C {
+    static instances = 0;
+    @prop name: string = "n";
+    @prop svc!: Service;
+    @prop options!: Options;
+    @prop list!: string[];
+    @prop either!: string | number;
+
+    constructor(@Inject("svc") public service: Service, count: number) {
+        C.instances++;
+    }
+
+    @meth method(@Inject("a") a: string, b: Service, ...rest: number[]): boolean { return true; }
+    @meth async load(): Promise<void> {}
+    @meth get value(): number { return 1; }
+    set value(v: number) {}
+    @meth static create() { return new C(new Service(), 0); }
+    @meth [key]() {}
+}
+
+export default class D {
+    @meth m(@Inject("x") x: Date) {}
+}
@@ -0,0 +1,31 @@
+// @target: es2017
+
+declare const o: { a: number; b: number; c: number; [key: string]: number };
+declare const k: string;
+declare const items: { p: number; q: number }[];
+
+const spread = { a: 1, ...o, b: 2 };
+const copy = { ...o };
+
+const { a, ...rest } = o;
+const { [k]: computed, b = 2, ...computedRest } = o;
+const [first, { p, ...others }] = items;
+
+let target: number, targetRest: {};
+({ a: target, ...targetRest } = o);
+const result = ({ a: target, ...targetRest } = o);
+
+function fn({ a, ...rest }: typeof o, z = 1) {
+    return rest;
+}
+
+const arrow = ({ a, ...rest }: typeof o = o) => rest;
+
+for (const { p, ...q } of items) {
+    q;
+}
+
+try {
+}
+catch ({ message, ...details }) {
+}
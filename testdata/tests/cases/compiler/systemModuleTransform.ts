@@ -0,0 +1,21 @@
+// @module: system
+// @target: es2017
+// @filename: dep.ts
+export function helper(n: number) { return n * 10; }
+export default "dep";
+
+// @filename: main.ts
+import name, { helper } from "./dep";
+import * as ns from "./dep";
+export * from "./dep";
+export { helper as reexported } from "./dep";
+export var counter = 0;
+export function increment() { counter++; }
+export class Box { value = name; }
+for (var key in ns) { counter += helper(1); }
+export const meta = import.meta;
+export async function later() {
+    const m = await import("./dep");
+    return m.helper(5);
+}
+export default counter;
@@ -0,0 +1,16 @@
+// @module: umd
+// @target: es2017
+// @esModuleInterop: true
+// @filename: dep.ts
+export function helper(n: number) { return n * 10; }
+export default "dep";
+
+// @filename: main.ts
+import name, { helper } from "./dep";
+import * as ns from "./dep";
+export const value = helper(2);
+export async function later() {
+    const m = await import("./dep");
+    return m.helper(5) + ns.helper(1) + name;
+}
+export { helper as reexported } from "./dep";